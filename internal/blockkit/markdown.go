@@ -0,0 +1,289 @@
+// Package blockkit converts Markdown into Slack Block Kit blocks.
+//
+// The conversion covers the Markdown agents commonly write: ATX headings,
+// paragraphs, bullet and numbered lists (including nesting), block quotes,
+// fenced code blocks, horizontal rules, links, and inline emphasis and code.
+// Headings become header blocks, rules become dividers, and everything else
+// becomes mrkdwn section blocks. Other Markdown (tables, images, HTML) is
+// passed through as text.
+package blockkit
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/slack-go/slack"
+)
+
+// Block Kit limits enforced by Slack on chat.postMessage.
+const (
+	// MaxBlocks is the maximum number of blocks in a message.
+	MaxBlocks = 50
+	// maxSectionTextLength is the maximum length of a section block's text.
+	maxSectionTextLength = 3000
+	// maxHeaderTextLength is the maximum length of a header block's text.
+	maxHeaderTextLength = 150
+)
+
+// ErrEmpty is returned when the Markdown contains no content.
+var ErrEmpty = errors.New("markdown is empty")
+
+var (
+	headingPattern    = regexp.MustCompile(`^#{1,6}\s+(.*?)\s*#*\s*$`)
+	rulePattern       = regexp.MustCompile(`^\s{0,3}([-*_])(\s*([-*_]))+\s*$`)
+	fencePattern      = regexp.MustCompile("^\\s{0,3}(```|~~~)")
+	bulletPattern     = regexp.MustCompile(`^(\s*)[-*+]\s+(.*)$`)
+	orderedPattern    = regexp.MustCompile(`^(\s*)(\d+)[.)]\s+(.*)$`)
+	quotePattern      = regexp.MustCompile(`^\s{0,3}>\s?(.*)$`)
+	codeSpanPattern   = regexp.MustCompile("`[^`\n]+`")
+	linkPattern       = regexp.MustCompile(`\[([^\]\n]+)\]\(([^)\s]+)\)`)
+	boldPattern       = regexp.MustCompile(`\*\*([^*\n]+?)\*\*|__([^_\n]+?)__`)
+	italicPattern     = regexp.MustCompile(`\*([^*\s][^*\n]*?)\*`)
+	strikePattern     = regexp.MustCompile(`~~([^~\n]+?)~~`)
+	inlineMarkPattern = regexp.MustCompile("\\*\\*|__|~~|`")
+)
+
+// boldMarker temporarily stands in for converted bold markers, so that the
+// italic pattern does not match them.
+const boldMarker = "\x00"
+
+// Result is a converted message.
+type Result struct {
+	// Blocks are the Block Kit blocks, ready to send as chat.postMessage's blocks argument.
+	Blocks []slack.Block
+	// Text is the message as mrkdwn, for chat.postMessage's text argument. Slack uses
+	// it for notifications and for clients that cannot render blocks.
+	Text string
+}
+
+// FromMarkdown converts Markdown into Block Kit blocks.
+//
+// Returns ErrEmpty if md has no content, or an error if the result exceeds
+// MaxBlocks blocks.
+func FromMarkdown(md string) (*Result, error) {
+	c := &converter{}
+	lines := strings.Split(strings.ReplaceAll(md, "\r\n", "\n"), "\n")
+
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+
+		if m := fencePattern.FindStringSubmatch(line); m != nil {
+			// Collect the fenced code block up to the closing fence (or the end)
+			var code []string
+			for i++; i < len(lines); i++ {
+				if strings.HasPrefix(strings.TrimSpace(lines[i]), m[1]) {
+					break
+				}
+				code = append(code, lines[i])
+			}
+			c.addCode(strings.Join(code, "\n"))
+			continue
+		}
+
+		if m := headingPattern.FindStringSubmatch(line); m != nil {
+			c.addHeading(m[1])
+			continue
+		}
+
+		if rulePattern.MatchString(line) {
+			c.flush()
+			c.blocks = append(c.blocks, slack.NewDividerBlock())
+			c.text = append(c.text, "---")
+			continue
+		}
+
+		c.addLine(line)
+	}
+	c.flush()
+
+	if len(c.blocks) == 0 {
+		return nil, ErrEmpty
+	}
+	if len(c.blocks) > MaxBlocks {
+		return nil, fmt.Errorf("markdown converts to %d blocks, more than the %d Slack allows in one message", len(c.blocks), MaxBlocks)
+	}
+
+	return &Result{
+		Blocks: c.blocks,
+		Text:   strings.Join(c.text, "\n\n"),
+	}, nil
+}
+
+// converter accumulates blocks while Markdown is read line by line.
+type converter struct {
+	blocks []slack.Block
+	// text holds the mrkdwn of each block, for Result.Text.
+	text []string
+	// section holds the mrkdwn lines of the section block being built.
+	section []string
+}
+
+// addLine adds a paragraph, list, or quote line to the current section.
+func (c *converter) addLine(line string) {
+	if m := bulletPattern.FindStringSubmatch(line); m != nil {
+		c.section = append(c.section, listIndent(m[1])+bulletFor(m[1])+" "+inline(m[2]))
+		return
+	}
+	if m := orderedPattern.FindStringSubmatch(line); m != nil {
+		c.section = append(c.section, listIndent(m[1])+m[2]+". "+inline(m[3]))
+		return
+	}
+	if m := quotePattern.FindStringSubmatch(line); m != nil {
+		c.section = append(c.section, ">"+inline(m[1]))
+		return
+	}
+
+	trimmed := strings.TrimSpace(line)
+	if trimmed == "" {
+		// Keep a single blank line between paragraphs, none at the start
+		if len(c.section) > 0 && c.section[len(c.section)-1] != "" {
+			c.section = append(c.section, "")
+		}
+		return
+	}
+	c.section = append(c.section, inline(trimmed))
+}
+
+// addHeading adds a header block. Headings longer than Slack allows in a
+// header block are added as a bold section line instead.
+func (c *converter) addHeading(heading string) {
+	plain := inlineMarkPattern.ReplaceAllString(heading, "")
+	if plain == "" {
+		return
+	}
+	if utf8.RuneCountInString(plain) > maxHeaderTextLength {
+		c.addLine("**" + heading + "**")
+		return
+	}
+
+	c.flush()
+	c.blocks = append(c.blocks, slack.NewHeaderBlock(slack.NewTextBlockObject(slack.PlainTextType, plain, true, false)))
+	c.text = append(c.text, "*"+escape(plain)+"*")
+}
+
+// addCode adds a fenced code block, split across sections if it is too long for one.
+func (c *converter) addCode(code string) {
+	c.flush()
+
+	// Each chunk is wrapped in a fence, so leave room for it
+	const fence = "```"
+	limit := maxSectionTextLength - 2*len(fence) - 2
+	for _, chunk := range splitLines(escape(code), limit) {
+		c.addSection(fence + "\n" + chunk + "\n" + fence)
+	}
+}
+
+// flush ends the current section, adding it as one or more section blocks.
+func (c *converter) flush() {
+	// Drop the trailing blank line, if any
+	for len(c.section) > 0 && c.section[len(c.section)-1] == "" {
+		c.section = c.section[:len(c.section)-1]
+	}
+	if len(c.section) == 0 {
+		return
+	}
+
+	for _, chunk := range splitLines(strings.Join(c.section, "\n"), maxSectionTextLength) {
+		c.addSection(chunk)
+	}
+	c.section = nil
+}
+
+// addSection adds a mrkdwn section block with the given text.
+func (c *converter) addSection(text string) {
+	c.blocks = append(c.blocks, slack.NewSectionBlock(slack.NewTextBlockObject(slack.MarkdownType, text, false, false), nil, nil))
+	c.text = append(c.text, text)
+}
+
+// inline converts inline Markdown (links, emphasis, code spans) to Slack mrkdwn
+// and escapes the characters Slack treats as control characters.
+// Code spans are escaped but otherwise left as-is.
+func inline(text string) string {
+	var b strings.Builder
+	last := 0
+	for _, loc := range codeSpanPattern.FindAllStringIndex(text, -1) {
+		b.WriteString(inlineText(text[last:loc[0]]))
+		b.WriteString(escape(text[loc[0]:loc[1]]))
+		last = loc[1]
+	}
+	b.WriteString(inlineText(text[last:]))
+	return b.String()
+}
+
+// inlineText converts inline Markdown outside code spans.
+func inlineText(text string) string {
+	text = escape(text)
+	text = linkPattern.ReplaceAllString(text, "<$2|$1>")
+	text = boldPattern.ReplaceAllString(text, boldMarker+"$1$2"+boldMarker)
+	text = italicPattern.ReplaceAllString(text, "_${1}_")
+	text = strikePattern.ReplaceAllString(text, "~$1~")
+	return strings.ReplaceAll(text, boldMarker, "*")
+}
+
+// escape escapes &, <, and > as Slack requires in message text.
+func escape(text string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;").Replace(text)
+}
+
+// listIndent returns the mrkdwn indentation for a list item, one level per
+// two spaces (or tab) of Markdown indentation.
+func listIndent(indent string) string {
+	return strings.Repeat("    ", listLevel(indent))
+}
+
+// bulletFor returns the bullet character for a list item's nesting level.
+func bulletFor(indent string) string {
+	if listLevel(indent)%2 == 1 {
+		return "◦"
+	}
+	return "•"
+}
+
+// listLevel returns the nesting level of a list item from its indentation.
+func listLevel(indent string) int {
+	return len(strings.ReplaceAll(indent, "\t", "  ")) / 2
+}
+
+// splitLines splits text into chunks of at most limit characters, breaking
+// between lines where possible and inside a line only if the line itself is too long.
+func splitLines(text string, limit int) []string {
+	if utf8.RuneCountInString(text) <= limit {
+		return []string{text}
+	}
+
+	var chunks []string
+	var current strings.Builder
+	currentLen := 0
+	for _, line := range strings.Split(text, "\n") {
+		lineLen := utf8.RuneCountInString(line)
+
+		// Start a new chunk if this line does not fit in the current one
+		if currentLen > 0 && currentLen+1+lineLen > limit {
+			chunks = append(chunks, current.String())
+			current.Reset()
+			currentLen = 0
+		}
+
+		// Split lines that do not fit in any chunk
+		for lineLen > limit {
+			runes := []rune(line)
+			chunks = append(chunks, string(runes[:limit]))
+			line = string(runes[limit:])
+			lineLen -= limit
+		}
+
+		if currentLen > 0 {
+			current.WriteString("\n")
+			currentLen++
+		}
+		current.WriteString(line)
+		currentLen += lineLen
+	}
+	if currentLen > 0 {
+		chunks = append(chunks, current.String())
+	}
+	return chunks
+}
@@ -0,0 +1,101 @@
+// Package blockkit converts Markdown into Slack Block Kit blocks.
+package blockkit
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestFromMarkdown(t *testing.T) {
+	tests := []struct {
+		name     string
+		markdown string
+		want     string
+	}{
+		{
+			name:     "heading and paragraph",
+			markdown: "# Incident update\n\nThe **deploy** is _rolled back_.",
+			want: `[{"type":"header","text":{"type":"plain_text","text":"Incident update","emoji":true}},` +
+				`{"type":"section","text":{"type":"mrkdwn","text":"The *deploy* is _rolled back_."}}]`,
+		},
+		{
+			name:     "links, italics and escaping",
+			markdown: "See [the runbook](https://example.com/a?b=1&c=2) for *details* & <notes>",
+			want: `[{"type":"section","text":{"type":"mrkdwn","text":` +
+				`"See \u003chttps://example.com/a?b=1\u0026amp;c=2|the runbook\u003e for _details_ \u0026amp; \u0026lt;notes\u0026gt;"}}]`,
+		},
+		{
+			name:     "nested lists and quotes",
+			markdown: "- one\n  - nested\n1. first\n> quoted ~~text~~",
+			want: `[{"type":"section","text":{"type":"mrkdwn","text":` +
+				`"• one\n    ◦ nested\n1. first\n\u003equoted ~text~"}}]`,
+		},
+		{
+			name:     "code span and fenced code",
+			markdown: "Run `make **all**`:\n\n```go\nif a < b {}\n```",
+			want: `[{"type":"section","text":{"type":"mrkdwn","text":"Run ` + "`make **all**`" + `:"}},` +
+				`{"type":"section","text":{"type":"mrkdwn","text":"` + "```\\nif a \\u0026lt; b {}\\n```" + `"}}]`,
+		},
+		{
+			name:     "divider",
+			markdown: "above\n\n---\n\nbelow",
+			want: `[{"type":"section","text":{"type":"mrkdwn","text":"above"}},{"type":"divider"},` +
+				`{"type":"section","text":{"type":"mrkdwn","text":"below"}}]`,
+		},
+	}
+
+	// json.Marshal escapes <, > and & as \u003c, \u003e and \u0026
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := FromMarkdown(tt.markdown)
+			if err != nil {
+				t.Fatalf("FromMarkdown() error = %v", err)
+			}
+			got, err := json.Marshal(result.Blocks)
+			if err != nil {
+				t.Fatalf("failed to marshal blocks: %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("blocks =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFromMarkdown_Text(t *testing.T) {
+	result, err := FromMarkdown("## Status\n\nAll **green**")
+	if err != nil {
+		t.Fatalf("FromMarkdown() error = %v", err)
+	}
+	if want := "*Status*\n\nAll *green*"; result.Text != want {
+		t.Errorf("Text = %q, want %q", result.Text, want)
+	}
+}
+
+func TestFromMarkdown_Limits(t *testing.T) {
+	t.Run("long section is split", func(t *testing.T) {
+		line := strings.Repeat("a", 2000)
+		result, err := FromMarkdown(line + "\n" + line)
+		if err != nil {
+			t.Fatalf("FromMarkdown() error = %v", err)
+		}
+		if len(result.Blocks) != 2 {
+			t.Errorf("got %d blocks, want 2", len(result.Blocks))
+		}
+	})
+
+	t.Run("too many blocks", func(t *testing.T) {
+		_, err := FromMarkdown(strings.Repeat("# heading\n", MaxBlocks+1))
+		if err == nil || !strings.Contains(err.Error(), "more than the 50") {
+			t.Errorf("error = %v, want block limit error", err)
+		}
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		if _, err := FromMarkdown(" \n\n "); !errors.Is(err, ErrEmpty) {
+			t.Errorf("error = %v, want ErrEmpty", err)
+		}
+	})
+}
@@ -0,0 +1,55 @@
+// Package blockkit provides parsing of Block Kit JSON to post.
+package blockkit
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/slack-go/slack"
+)
+
+// messageBlockTypes lists the block types that can be posted in a message.
+var messageBlockTypes = map[string]bool{
+	"actions":   true,
+	"context":   true,
+	"divider":   true,
+	"file":      true,
+	"header":    true,
+	"image":     true,
+	"markdown":  true,
+	"rich_text": true,
+	"section":   true,
+	"video":     true,
+}
+
+// Parse parses a Block Kit JSON array, such as the blocks returned by the
+// compose_blocks tool, into blocks to post with chat.postMessage.
+//
+// Returns an error if data is not a JSON array of blocks, is empty, has more than
+// MaxBlocks blocks, or has a block of a type that cannot be posted in a message.
+func Parse(data []byte) ([]slack.Block, error) {
+	var raw []struct {
+		Type string `json:"type"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, errors.New("blocks must be a JSON array of Block Kit blocks")
+	}
+	if len(raw) == 0 {
+		return nil, errors.New("blocks is empty")
+	}
+	if len(raw) > MaxBlocks {
+		return nil, fmt.Errorf("%d blocks is more than the %d Slack allows in one message", len(raw), MaxBlocks)
+	}
+	for i, block := range raw {
+		if !messageBlockTypes[block.Type] {
+			return nil, fmt.Errorf("block %d has type %q, which cannot be posted in a message", i, block.Type)
+		}
+	}
+
+	var blocks slack.Blocks
+	if err := json.Unmarshal(data, &blocks); err != nil {
+		return nil, fmt.Errorf("invalid blocks: %w", err)
+	}
+	return blocks.BlockSet, nil
+}
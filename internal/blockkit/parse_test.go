@@ -0,0 +1,38 @@
+// Package blockkit provides tests for parsing Block Kit JSON.
+package blockkit
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	// Blocks composed from Markdown parse back to the same JSON
+	composed, err := FromMarkdown("# Status\n\nAll *green*.\n\n---")
+	if err != nil {
+		t.Fatalf("FromMarkdown failed: %v", err)
+	}
+	data, _ := json.Marshal(composed.Blocks)
+	blocks, err := Parse(data)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if got, _ := json.Marshal(blocks); string(got) != string(data) {
+		t.Errorf("Parse round trip = %s, want %s", got, data)
+	}
+
+	tooMany := "[" + strings.Repeat(`{"type":"divider"},`, MaxBlocks) + `{"type":"divider"}]`
+	for input, wantErr := range map[string]string{
+		`{"type":"divider"}`:                    "JSON array",
+		`not json`:                              "JSON array",
+		`[]`:                                    "empty",
+		tooMany:                                 "more than the 50",
+		`[{"type":"divider"},{"type":"input"}]`: `block 1 has type "input"`,
+		`[{"text":"no type"}]`:                  `block 0 has type ""`,
+	} {
+		if _, err := Parse([]byte(input)); err == nil || !strings.Contains(err.Error(), wantErr) {
+			t.Errorf("Parse(%.40s) error = %v, want it to contain %q", input, err, wantErr)
+		}
+	}
+}
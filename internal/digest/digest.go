@@ -0,0 +1,181 @@
+// Package digest runs scheduled channel digests: on a cron-like schedule, each
+// job reads recent activity in a set of channels, formats a compact report, and
+// posts it to a target channel. Digests run inside the server process, so teams
+// get summaries even when no agent session is open.
+//
+// Jobs are defined in a config file. Because digests post messages, they only
+// run when write tools are enabled.
+package digest
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// defaultWindow is the activity window a job covers when it does not set one.
+	defaultWindow = 24 * time.Hour
+	// maxWindow caps a job's window so a digest cannot page through months of history.
+	maxWindow = 31 * 24 * time.Hour
+)
+
+// namePattern restricts job names to identifiers that are easy to read in logs.
+var namePattern = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// channelIDPattern matches a public or private channel ID. Digests do not read or post to DMs.
+var channelIDPattern = regexp.MustCompile(`^[CG][A-Z0-9]{8,}$`)
+
+// Job is a named digest job.
+type Job struct {
+	// Name identifies the job in logs.
+	Name string `json:"-"`
+	// Title heads the posted digest. Defaults to "Digest: <name>".
+	Title string `json:"title,omitempty"`
+	// Schedule is a five-field cron expression (see ParseSchedule), e.g. "0 9 * * 1-5".
+	Schedule string `json:"schedule"`
+	// Timezone is the IANA time zone the schedule is evaluated in. Defaults to UTC.
+	Timezone string `json:"timezone,omitempty"`
+	// Channels are the IDs of the channels to summarize.
+	Channels []string `json:"channels"`
+	// TargetChannel is the ID of the channel the digest is posted to.
+	TargetChannel string `json:"target_channel"`
+	// Window is how far back each run reads, as a Go duration (e.g., "24h"). Defaults to 24h.
+	Window string `json:"window,omitempty"`
+
+	// schedule is the parsed Schedule.
+	schedule *Schedule
+	// location is the parsed Timezone.
+	location *time.Location
+	// window is the parsed Window.
+	window time.Duration
+}
+
+// Next returns the job's first scheduled run after t.
+// Returns the zero time if the schedule never fires.
+func (j *Job) Next(t time.Time) time.Time {
+	return j.schedule.Next(t.In(j.location))
+}
+
+// Config is a set of digest jobs loaded from a config file.
+type Config struct {
+	jobs map[string]*Job
+}
+
+// file is the JSON layout of a digests config file:
+//
+//	{
+//	  "jobs": {
+//	    "eng-daily": {
+//	      "title": "Engineering daily digest",
+//	      "schedule": "0 9 * * 1-5",
+//	      "timezone": "America/New_York",
+//	      "channels": ["C01234567", "C07654321"],
+//	      "target_channel": "C0DIGEST1",
+//	      "window": "24h"
+//	    }
+//	  }
+//	}
+type file struct {
+	Jobs map[string]*Job `json:"jobs"`
+}
+
+// Load reads a digests config file.
+//
+// Returns an error if the file cannot be read or parsed, or if a job has an
+// invalid name, schedule, time zone, window, or channel.
+func Load(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading digests file: %w", err)
+	}
+	return Parse(data)
+}
+
+// Parse parses the contents of a digests config file. See Load.
+func Parse(data []byte) (*Config, error) {
+	var f file
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("parsing digests file: %w", err)
+	}
+	if len(f.Jobs) == 0 {
+		return nil, fmt.Errorf("digests file defines no jobs")
+	}
+
+	cfg := &Config{jobs: make(map[string]*Job, len(f.Jobs))}
+	for name, job := range f.Jobs {
+		if !namePattern.MatchString(name) {
+			return nil, fmt.Errorf("invalid digest job name %q: use letters, digits, '-' and '_'", name)
+		}
+		if job == nil {
+			return nil, fmt.Errorf("digest job %q is empty", name)
+		}
+		job.Name = name
+		if err := job.validate(); err != nil {
+			return nil, fmt.Errorf("digest job %q: %w", name, err)
+		}
+		cfg.jobs[name] = job
+	}
+	return cfg, nil
+}
+
+// validate checks a job's fields and fills in its parsed schedule, location, and window.
+func (j *Job) validate() error {
+	schedule, err := ParseSchedule(j.Schedule)
+	if err != nil {
+		return err
+	}
+	j.schedule = schedule
+
+	j.location = time.UTC
+	if j.Timezone != "" {
+		loc, err := time.LoadLocation(j.Timezone)
+		if err != nil {
+			return fmt.Errorf("invalid timezone %q: %w", j.Timezone, err)
+		}
+		j.location = loc
+	}
+
+	j.window = defaultWindow
+	if j.Window != "" {
+		window, err := time.ParseDuration(j.Window)
+		if err != nil || window <= 0 || window > maxWindow {
+			return fmt.Errorf("invalid window %q: must be a positive duration up to %s", j.Window, maxWindow)
+		}
+		j.window = window
+	}
+
+	if len(j.Channels) == 0 {
+		return fmt.Errorf("no channels to summarize")
+	}
+	for i, channel := range j.Channels {
+		channel = strings.TrimSpace(channel)
+		if !channelIDPattern.MatchString(channel) {
+			return fmt.Errorf("invalid channel %q: must be a Slack channel ID (e.g., C01234567)", channel)
+		}
+		j.Channels[i] = channel
+	}
+	j.TargetChannel = strings.TrimSpace(j.TargetChannel)
+	if !channelIDPattern.MatchString(j.TargetChannel) {
+		return fmt.Errorf("invalid target_channel %q: must be a Slack channel ID (e.g., C01234567)", j.TargetChannel)
+	}
+
+	if strings.TrimSpace(j.Title) == "" {
+		j.Title = "Digest: " + j.Name
+	}
+	return nil
+}
+
+// Jobs returns all jobs, sorted by name.
+func (c *Config) Jobs() []*Job {
+	all := make([]*Job, 0, len(c.jobs))
+	for _, job := range c.jobs {
+		all = append(all, job)
+	}
+	sort.Slice(all, func(i, j int) bool { return all[i].Name < all[j].Name })
+	return all
+}
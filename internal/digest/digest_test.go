@@ -0,0 +1,253 @@
+// Package digest provides tests for digest config, schedules, and reports.
+package digest
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	slackclient "github.com/Bitovi/slack-mcp-server/internal/slack"
+	"github.com/Bitovi/slack-mcp-server/pkg/types"
+)
+
+const testDigests = `{
+  "jobs": {
+    "eng-daily": {
+      "title": "Engineering <daily>",
+      "schedule": "0 9 * * 1-5",
+      "timezone": "America/New_York",
+      "channels": ["C01234567", "C07654321"],
+      "target_channel": "C0DIGEST1"
+    },
+    "weekly": {
+      "schedule": "30 8 * * 1",
+      "channels": ["C01234567"],
+      "target_channel": "C0DIGEST1",
+      "window": "168h"
+    }
+  }
+}`
+
+func TestLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "digests.json")
+	if err := os.WriteFile(path, []byte(testDigests), 0o600); err != nil {
+		t.Fatalf("failed to write digests file: %v", err)
+	}
+
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	jobs := cfg.Jobs()
+	if len(jobs) != 2 || jobs[0].Name != "eng-daily" || jobs[1].Name != "weekly" {
+		t.Fatalf("Jobs() = %v, want eng-daily and weekly", jobs)
+	}
+	if jobs[0].window != defaultWindow || jobs[1].window != 7*24*time.Hour {
+		t.Errorf("windows = %s, %s, want 24h and 168h", jobs[0].window, jobs[1].window)
+	}
+	if jobs[1].Title != "Digest: weekly" {
+		t.Errorf("default Title = %q, want %q", jobs[1].Title, "Digest: weekly")
+	}
+}
+
+func TestParse_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		wantErr string
+	}{
+		{name: "not JSON", data: `jobs:`, wantErr: "parsing digests file"},
+		{name: "no jobs", data: `{"jobs":{}}`, wantErr: "defines no jobs"},
+		{name: "bad name", data: `{"jobs":{"eng daily":{}}}`, wantErr: "invalid digest job name"},
+		{name: "bad schedule", data: `{"jobs":{"a":{"schedule":"0 25 * * *","channels":["C01234567"],"target_channel":"C01234567"}}}`, wantErr: "hour field"},
+		{name: "bad timezone", data: `{"jobs":{"a":{"schedule":"0 9 * * *","timezone":"Mars/Olympus","channels":["C01234567"],"target_channel":"C01234567"}}}`, wantErr: "invalid timezone"},
+		{name: "bad window", data: `{"jobs":{"a":{"schedule":"0 9 * * *","window":"-1h","channels":["C01234567"],"target_channel":"C01234567"}}}`, wantErr: "invalid window"},
+		{name: "no channels", data: `{"jobs":{"a":{"schedule":"0 9 * * *","target_channel":"C01234567"}}}`, wantErr: "no channels"},
+		{name: "DM channel", data: `{"jobs":{"a":{"schedule":"0 9 * * *","channels":["D01234567"],"target_channel":"C01234567"}}}`, wantErr: "invalid channel"},
+		{name: "no target", data: `{"jobs":{"a":{"schedule":"0 9 * * *","channels":["C01234567"]}}}`, wantErr: "invalid target_channel"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse([]byte(tt.data))
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Parse() error = %v, want error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestParseSchedule_Invalid(t *testing.T) {
+	for _, expr := range []string{"", "0 9 * *", "60 * * * *", "* * 0 * *", "* * * 13 *", "*/0 * * * *", "5-1 * * * *", "a * * * *"} {
+		if _, err := ParseSchedule(expr); err == nil {
+			t.Errorf("ParseSchedule(%q) succeeded, want error", expr)
+		}
+	}
+}
+
+func TestSchedule_Next(t *testing.T) {
+	// Wednesday, January 10, 2024
+	from := time.Date(2024, 1, 10, 9, 30, 15, 0, time.UTC)
+	tests := []struct {
+		expr string
+		want time.Time
+	}{
+		{"* * * * *", time.Date(2024, 1, 10, 9, 31, 0, 0, time.UTC)},
+		{"*/15 * * * *", time.Date(2024, 1, 10, 9, 45, 0, 0, time.UTC)},
+		{"0 9 * * 1-5", time.Date(2024, 1, 11, 9, 0, 0, 0, time.UTC)},
+		{"0 9 * * 0", time.Date(2024, 1, 14, 9, 0, 0, 0, time.UTC)},
+		{"0 9 * * 7", time.Date(2024, 1, 14, 9, 0, 0, 0, time.UTC)},
+		{"0 8-18/2 * * *", time.Date(2024, 1, 10, 10, 0, 0, 0, time.UTC)},
+		{"0 0 1,15 * *", time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)},
+		{"0 0 29 2 *", time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)},
+		// Day of month or day of week when both are restricted
+		{"0 0 20 * 5", time.Date(2024, 1, 12, 0, 0, 0, 0, time.UTC)},
+		{"0 0 31 2 *", time.Time{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			s, err := ParseSchedule(tt.expr)
+			if err != nil {
+				t.Fatalf("ParseSchedule() error = %v", err)
+			}
+			if got := s.Next(from); !got.Equal(tt.want) {
+				t.Errorf("Next() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestJob_Next_Timezone(t *testing.T) {
+	cfg, err := Parse([]byte(testDigests))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	job := cfg.Jobs()[0]
+
+	// 09:00 in New York is 14:00 UTC in January
+	got := job.Next(time.Date(2024, 1, 10, 12, 0, 0, 0, time.UTC))
+	if want := time.Date(2024, 1, 10, 14, 0, 0, 0, time.UTC); !got.Equal(want) {
+		t.Errorf("Next() = %s, want %s", got.UTC(), want)
+	}
+}
+
+// fakeClient serves channel history and records posts. Other ClientInterface
+// methods are not used by digests and panic if called.
+type fakeClient struct {
+	slackclient.ClientInterface
+	history map[string][]types.Message
+	posted  []string
+}
+
+func (f *fakeClient) GetChannelHistory(ctx context.Context, channelID string, opts slackclient.HistoryOptions) ([]types.Message, bool, error) {
+	messages, ok := f.history[channelID]
+	if !ok {
+		return nil, false, errors.New("not_in_channel")
+	}
+	return messages, false, nil
+}
+
+func (f *fakeClient) PostMessage(ctx context.Context, channelID, text string, opts slackclient.PostMessageOptions) (string, error) {
+	if opts.UnfurlLinks == nil || *opts.UnfurlLinks {
+		return "", errors.New("expected link unfurls to be disabled")
+	}
+	f.posted = append(f.posted, channelID+": "+text)
+	return "1700000000.000100", nil
+}
+
+func TestRunner_RunJob(t *testing.T) {
+	cfg, err := Parse([]byte(testDigests))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	client := &fakeClient{history: map[string][]types.Message{
+		"C01234567": {
+			{User: "U01111111", Text: "hey <!channel>, deploy of <https://example.com/pr/1|PR 1> is done", ReplyCount: 4},
+			{User: "U02222222", Text: "ping <@U01111111> about <stuff> & more", ReplyCount: 7},
+			{User: "U01111111", Text: "thanks"},
+		},
+	}}
+	var logs bytes.Buffer
+	runner := NewRunner(client, cfg, log.New(&logs, "", 0))
+	runner.now = func() time.Time { return time.Date(2024, 1, 10, 14, 0, 0, 0, time.UTC) }
+
+	if err := runner.RunJob(context.Background(), cfg.Jobs()[0]); err != nil {
+		t.Fatalf("RunJob() error = %v", err)
+	}
+	if len(client.posted) != 1 {
+		t.Fatalf("posted %d messages, want 1", len(client.posted))
+	}
+
+	post := client.posted[0]
+	for _, want := range []string{
+		"C0DIGEST1: *Engineering &lt;daily&gt;*",
+		"Jan 9 09:00 to Jan 10 09:00 EST",
+		"<#C01234567>: 3 messages from 2 people, 2 threads",
+		"7 replies: “ping @U01111111 about &lt;stuff&gt; &amp; more”",
+		"4 replies: “hey @channel, deploy of PR 1 is done”",
+		"<#C07654321>: could not be read (not_in_channel)",
+	} {
+		if !strings.Contains(post, want) {
+			t.Errorf("digest does not contain %q:\n%s", want, post)
+		}
+	}
+	if strings.Contains(post, "<!channel>") || strings.Contains(post, "<@U01111111>") {
+		t.Errorf("digest re-posts mentions:\n%s", post)
+	}
+	if strings.Index(post, "7 replies") > strings.Index(post, "4 replies") {
+		t.Errorf("expected threads ordered by replies:\n%s", post)
+	}
+	if !strings.Contains(logs.String(), "digest eng-daily: posted to C0DIGEST1") {
+		t.Errorf("log = %q, want a posted entry", logs.String())
+	}
+}
+
+func TestRunner_RunJob_NoReadableChannels(t *testing.T) {
+	cfg, err := Parse([]byte(testDigests))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	client := &fakeClient{}
+	var logs bytes.Buffer
+	runner := NewRunner(client, cfg, log.New(&logs, "", 0))
+
+	if err := runner.RunJob(context.Background(), cfg.Jobs()[1]); err == nil {
+		t.Fatal("expected an error when no channel can be read")
+	}
+	if len(client.posted) != 0 {
+		t.Errorf("expected nothing posted, got %v", client.posted)
+	}
+	if !strings.Contains(logs.String(), "digest weekly: failed to build digest") {
+		t.Errorf("log = %q, want a failure entry", logs.String())
+	}
+}
+
+func TestRunner_Run_StopsOnCancel(t *testing.T) {
+	cfg, err := Parse([]byte(testDigests))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	runner := NewRunner(&fakeClient{}, cfg, log.New(&bytes.Buffer{}, "", 0))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		runner.Run(ctx)
+		close(done)
+	}()
+	cancel()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Run did not return after cancel")
+	}
+}
@@ -0,0 +1,159 @@
+// Package digest provides the report built by each digest run.
+package digest
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	slackclient "github.com/Bitovi/slack-mcp-server/internal/slack"
+	"github.com/Bitovi/slack-mcp-server/pkg/types"
+)
+
+const (
+	// maxChannelMessages caps the messages read per channel in one run.
+	// Busier channels are reported as having at least this many messages.
+	maxChannelMessages = 1000
+	// maxTopThreads is the number of most-replied threads listed per channel.
+	maxTopThreads = 3
+	// maxExcerptLength caps the characters of a thread's first message quoted in the digest.
+	maxExcerptLength = 80
+)
+
+// entityPattern matches Slack's angle-bracket entities: mentions, channel links,
+// special mentions such as <!channel>, and URLs, with an optional |label.
+var entityPattern = regexp.MustCompile(`<([^<>|]*)(?:\|([^<>]*))?>`)
+
+// Report builds the digest text for a job covering the window that ends at now.
+//
+// Each channel gets a line with its message count, distinct posters, and thread
+// count, followed by its most-replied threads. A channel that cannot be read is
+// noted in the report rather than failing the whole digest.
+//
+// Returns an error only if none of the job's channels could be read.
+func Report(ctx context.Context, client slackclient.ClientInterface, job *Job, now time.Time) (string, error) {
+	start := now.Add(-job.window)
+	oldest := strconv.FormatInt(start.Unix(), 10)
+	latest := strconv.FormatInt(now.Unix(), 10)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "*%s*\n", escape(job.Title))
+	fmt.Fprintf(&b, "_Activity from %s to %s_\n",
+		start.In(job.location).Format("Jan 2 15:04"), now.In(job.location).Format("Jan 2 15:04 MST"))
+
+	var lastErr error
+	failed := 0
+	for _, channelID := range job.Channels {
+		messages, hasMore, err := client.GetChannelHistory(ctx, channelID, slackclient.HistoryOptions{
+			Limit:  maxChannelMessages,
+			Oldest: oldest,
+			Latest: latest,
+		})
+		if err != nil {
+			failed++
+			lastErr = err
+			fmt.Fprintf(&b, "\n• <#%s>: could not be read (%s)\n", channelID, escape(err.Error()))
+			continue
+		}
+		writeChannelSummary(&b, channelID, messages, hasMore)
+	}
+
+	if failed == len(job.Channels) {
+		return "", fmt.Errorf("no channels could be read: %w", lastErr)
+	}
+	return b.String(), nil
+}
+
+// writeChannelSummary writes one channel's section of the digest.
+func writeChannelSummary(b *strings.Builder, channelID string, messages []types.Message, hasMore bool) {
+	if len(messages) == 0 {
+		fmt.Fprintf(b, "\n• <#%s>: no activity\n", channelID)
+		return
+	}
+
+	posters := make(map[string]bool)
+	var threads []types.Message
+	for _, message := range messages {
+		if message.User != "" {
+			posters[message.User] = true
+		} else if message.BotID != "" {
+			posters[message.BotID] = true
+		}
+		if message.ReplyCount > 0 {
+			threads = append(threads, message)
+		}
+	}
+
+	count := strconv.Itoa(len(messages))
+	if hasMore {
+		count += "+"
+	}
+	fmt.Fprintf(b, "\n• <#%s>: %s %s from %d %s, %d %s\n", channelID,
+		count, plural(len(messages), "message", "messages"),
+		len(posters), plural(len(posters), "person", "people"),
+		len(threads), plural(len(threads), "thread", "threads"))
+
+	sort.SliceStable(threads, func(i, j int) bool { return threads[i].ReplyCount > threads[j].ReplyCount })
+	for _, thread := range threads[:min(len(threads), maxTopThreads)] {
+		fmt.Fprintf(b, "    ◦ %d %s: %s\n", thread.ReplyCount,
+			plural(thread.ReplyCount, "reply", "replies"), excerpt(thread.Text))
+	}
+}
+
+// excerpt shortens message text to a single quoted line. Slack entities are replaced
+// with their plain-text form, so a digest never re-notifies the people, groups, or
+// channels the original message mentioned.
+func excerpt(text string) string {
+	text = entityPattern.ReplaceAllStringFunc(text, func(entity string) string {
+		m := entityPattern.FindStringSubmatch(entity)
+		target, label := m[1], m[2]
+		switch {
+		case strings.HasPrefix(target, "@"), strings.HasPrefix(target, "#"):
+			if label != "" {
+				return target[:1] + label
+			}
+			return target
+		case strings.HasPrefix(target, "!"):
+			if label != "" {
+				return label
+			}
+			// <!subteam^S123> and <!date^...> have no readable form without a label
+			name, _, _ := strings.Cut(target[1:], "^")
+			return "@" + name
+		case strings.Contains(target, ":"):
+			if label != "" {
+				return label
+			}
+			return target
+		default:
+			// Message text is unescaped, so this is literal text such as "<stuff>"
+			return entity
+		}
+	})
+
+	text = strings.Join(strings.Fields(text), " ")
+	if runes := []rune(text); len(runes) > maxExcerptLength {
+		text = strings.TrimSpace(string(runes[:maxExcerptLength])) + "…"
+	}
+	if text == "" {
+		return "_(no text)_"
+	}
+	return "“" + escape(text) + "”"
+}
+
+// plural returns singular if n is 1, otherwise plural.
+func plural(n int, singular, plural string) string {
+	if n == 1 {
+		return singular
+	}
+	return plural
+}
+
+// escape escapes &, <, and > as Slack requires in message text.
+func escape(text string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;").Replace(text)
+}
@@ -0,0 +1,98 @@
+// Package digest provides the runner that executes digest jobs on their schedules.
+package digest
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	slackclient "github.com/Bitovi/slack-mcp-server/internal/slack"
+)
+
+// runTimeout bounds one digest run, so a hung Slack call cannot delay the job's later runs.
+const runTimeout = 5 * time.Minute
+
+// Runner executes digest jobs on their schedules, posting each digest with the
+// Slack client. Results and failures are written to the logger; a failed run is
+// not retried, and the job runs again at its next scheduled time.
+type Runner struct {
+	client slackclient.ClientInterface
+	jobs   []*Job
+	logger *log.Logger
+	// now returns the current time. Tests replace it.
+	now func() time.Time
+}
+
+// NewRunner creates a Runner for the jobs in cfg.
+func NewRunner(client slackclient.ClientInterface, cfg *Config, logger *log.Logger) *Runner {
+	return &Runner{
+		client: client,
+		jobs:   cfg.Jobs(),
+		logger: logger,
+		now:    time.Now,
+	}
+}
+
+// Run runs every job on its schedule until ctx is cancelled, then waits for
+// in-progress runs to finish.
+func (r *Runner) Run(ctx context.Context) {
+	var wg sync.WaitGroup
+	for _, job := range r.jobs {
+		wg.Add(1)
+		go func(job *Job) {
+			defer wg.Done()
+			r.schedule(ctx, job)
+		}(job)
+	}
+	wg.Wait()
+}
+
+// schedule sleeps until each of the job's scheduled times and runs it, until ctx is cancelled.
+func (r *Runner) schedule(ctx context.Context, job *Job) {
+	for {
+		next := job.Next(r.now())
+		if next.IsZero() {
+			r.logger.Printf("digest %s: schedule %q never fires; job disabled", job.Name, job.Schedule)
+			return
+		}
+
+		timer := time.NewTimer(time.Until(next))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		runCtx, cancel := context.WithTimeout(ctx, runTimeout)
+		_ = r.RunJob(runCtx, job)
+		cancel()
+	}
+}
+
+// RunJob builds the job's digest for the window ending now and posts it to the
+// job's target channel, logging the outcome.
+//
+// Returns the error that stopped the run, if any.
+func (r *Runner) RunJob(ctx context.Context, job *Job) error {
+	text, err := Report(ctx, r.client, job, r.now())
+	if err != nil {
+		r.logger.Printf("digest %s: failed to build digest: %v", job.Name, err)
+		return err
+	}
+
+	// Links quoted in excerpts would otherwise unfurl into large previews
+	unfurl := false
+	ts, err := r.client.PostMessage(ctx, job.TargetChannel, text, slackclient.PostMessageOptions{
+		UnfurlLinks: &unfurl,
+		UnfurlMedia: &unfurl,
+	})
+	if err != nil {
+		r.logger.Printf("digest %s: failed to post to %s: %v", job.Name, job.TargetChannel, err)
+		return err
+	}
+
+	r.logger.Printf("digest %s: posted to %s (ts=%s, %d channels)", job.Name, job.TargetChannel, ts, len(job.Channels))
+	return nil
+}
@@ -0,0 +1,161 @@
+// Package digest provides five-field cron schedules for digest jobs.
+package digest
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxScheduleSearch bounds the search for a schedule's next run. Every valid
+// schedule fires at least once in four years (e.g., on February 29).
+const maxScheduleSearch = 4 * 366 * 24 * time.Hour
+
+// Schedule is a parsed five-field cron expression: minute, hour, day of month,
+// month, and day of week. Fields accept *, numbers, ranges (1-5), lists (1,15),
+// and steps (*/15, 8-18/2). Days of week run from 0 (Sunday) to 6; 7 is also Sunday.
+//
+// As in cron, when both day of month and day of week are restricted, a day
+// matches if either does.
+type Schedule struct {
+	minutes, hours, daysOfMonth, months, daysOfWeek uint64
+	// anyDayOfMonth and anyDayOfWeek record which day fields were *.
+	anyDayOfMonth, anyDayOfWeek bool
+}
+
+// scheduleField describes the range of values one cron field accepts.
+type scheduleField struct {
+	name     string
+	min, max int
+}
+
+// scheduleFields are the cron fields in order.
+var scheduleFields = []scheduleField{
+	{"minute", 0, 59},
+	{"hour", 0, 23},
+	{"day of month", 1, 31},
+	{"month", 1, 12},
+	{"day of week", 0, 7},
+}
+
+// ParseSchedule parses a five-field cron expression such as "0 9 * * 1-5"
+// (09:00 on weekdays). Times are evaluated in the location passed to Next.
+//
+// Returns an error naming the invalid field if the expression cannot be parsed.
+func ParseSchedule(expr string) (*Schedule, error) {
+	parts := strings.Fields(expr)
+	if len(parts) != len(scheduleFields) {
+		return nil, fmt.Errorf("schedule %q must have 5 fields (minute hour day-of-month month day-of-week), got %d",
+			expr, len(parts))
+	}
+
+	var bits [5]uint64
+	for i, field := range scheduleFields {
+		b, err := parseScheduleField(parts[i], field)
+		if err != nil {
+			return nil, fmt.Errorf("schedule %q: %w", expr, err)
+		}
+		bits[i] = b
+	}
+
+	// 7 is an alias for Sunday
+	if bits[4]&(1<<7) != 0 {
+		bits[4] = bits[4]&^(1<<7) | 1
+	}
+
+	return &Schedule{
+		minutes:       bits[0],
+		hours:         bits[1],
+		daysOfMonth:   bits[2],
+		months:        bits[3],
+		daysOfWeek:    bits[4],
+		anyDayOfMonth: parts[2] == "*",
+		anyDayOfWeek:  parts[4] == "*",
+	}, nil
+}
+
+// parseScheduleField parses one comma-separated cron field into a bit set of its values.
+func parseScheduleField(text string, field scheduleField) (uint64, error) {
+	var bits uint64
+	for _, item := range strings.Split(text, ",") {
+		rangeText, stepText, hasStep := strings.Cut(item, "/")
+		step := 1
+		if hasStep {
+			n, err := strconv.Atoi(stepText)
+			if err != nil || n < 1 {
+				return 0, fmt.Errorf("invalid step %q in %s field", stepText, field.name)
+			}
+			step = n
+		}
+
+		low, high := field.min, field.max
+		if rangeText != "*" {
+			lowText, highText, isRange := strings.Cut(rangeText, "-")
+			var err error
+			if low, err = parseScheduleValue(lowText, field); err != nil {
+				return 0, err
+			}
+			high = low
+			if isRange {
+				if high, err = parseScheduleValue(highText, field); err != nil {
+					return 0, err
+				}
+			} else if hasStep {
+				// "5/15" means every 15 starting at 5
+				high = field.max
+			}
+			if low > high {
+				return 0, fmt.Errorf("invalid range %q in %s field", rangeText, field.name)
+			}
+		}
+
+		for v := low; v <= high; v += step {
+			bits |= 1 << v
+		}
+	}
+	return bits, nil
+}
+
+// parseScheduleValue parses a single number within a cron field's range.
+func parseScheduleValue(text string, field scheduleField) (int, error) {
+	n, err := strconv.Atoi(text)
+	if err != nil || n < field.min || n > field.max {
+		return 0, fmt.Errorf("invalid value %q in %s field: must be %d-%d", text, field.name, field.min, field.max)
+	}
+	return n, nil
+}
+
+// Next returns the first time after t, truncated to the minute, that matches the
+// schedule, in t's location. Returns the zero time if there is none within four years,
+// which only happens for impossible dates such as "0 0 31 2 *".
+func (s *Schedule) Next(t time.Time) time.Time {
+	next := t.Truncate(time.Minute).Add(time.Minute)
+	limit := next.Add(maxScheduleSearch)
+	for next.Before(limit) {
+		switch {
+		case s.months&(1<<uint(next.Month())) == 0:
+			// Skip to the first day of the next month
+			next = time.Date(next.Year(), next.Month()+1, 1, 0, 0, 0, 0, next.Location())
+		case !s.matchesDay(next):
+			next = time.Date(next.Year(), next.Month(), next.Day()+1, 0, 0, 0, 0, next.Location())
+		case s.hours&(1<<uint(next.Hour())) == 0:
+			next = next.Truncate(time.Hour).Add(time.Hour)
+		case s.minutes&(1<<uint(next.Minute())) == 0:
+			next = next.Add(time.Minute)
+		default:
+			return next
+		}
+	}
+	return time.Time{}
+}
+
+// matchesDay reports whether t's day matches the day-of-month and day-of-week fields.
+func (s *Schedule) matchesDay(t time.Time) bool {
+	dom := s.daysOfMonth&(1<<uint(t.Day())) != 0
+	dow := s.daysOfWeek&(1<<uint(t.Weekday())) != 0
+	if s.anyDayOfMonth || s.anyDayOfWeek {
+		return dom && dow
+	}
+	return dom || dow
+}
@@ -0,0 +1,149 @@
+// Package langdetect provides lightweight language detection for Slack message text.
+//
+// Detection is heuristic: non-Latin scripts are identified by their Unicode ranges,
+// and Latin-script languages are identified by counting common stopwords. It is
+// intended for routing and filtering, not for linguistic accuracy.
+package langdetect
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// slackMarkupPattern matches Slack markup that carries no language signal:
+// mentions, channel links, URLs (<...>) and emoji shortcodes (:name:).
+var slackMarkupPattern = regexp.MustCompile(`<[^>]*>|:[a-z0-9_+\-]+:`)
+
+// minLatinMatches is the minimum number of stopword hits required to
+// report a Latin-script language.
+const minLatinMatches = 2
+
+// scriptLanguages maps Unicode scripts to the language reported for them.
+// Scripts are checked in order, so Japanese kana is detected before Han.
+var scriptLanguages = []struct {
+	table *unicode.RangeTable
+	lang  string
+}{
+	{unicode.Hangul, "ko"},
+	{unicode.Hiragana, "ja"},
+	{unicode.Katakana, "ja"},
+	{unicode.Han, "zh"},
+	{unicode.Cyrillic, "ru"},
+	{unicode.Arabic, "ar"},
+	{unicode.Hebrew, "he"},
+	{unicode.Greek, "el"},
+	{unicode.Thai, "th"},
+	{unicode.Devanagari, "hi"},
+}
+
+// stopwords maps Latin-script language codes to words that are frequent in that
+// language and rare in the others.
+var stopwords = map[string][]string{
+	"en": {"the", "and", "is", "are", "was", "this", "that", "with", "for", "you", "have", "not", "it's", "what", "will"},
+	"es": {"el", "los", "las", "que", "es", "por", "para", "con", "una", "pero", "está", "como", "muy", "también", "gracias"},
+	"fr": {"le", "les", "est", "et", "des", "une", "pour", "pas", "avec", "dans", "que", "nous", "vous", "c'est", "merci"},
+	"de": {"der", "die", "das", "und", "ist", "nicht", "mit", "ein", "eine", "auch", "ich", "wir", "sie", "für", "danke"},
+	"pt": {"o", "os", "não", "que", "é", "com", "uma", "para", "mas", "você", "está", "obrigado", "também", "isso", "muito"},
+	"it": {"il", "che", "è", "non", "per", "una", "sono", "con", "anche", "della", "questo", "grazie", "molto", "gli", "ci"},
+	"nl": {"de", "het", "een", "en", "is", "niet", "van", "dat", "met", "voor", "ook", "maar", "wij", "jullie", "bedankt"},
+}
+
+// stopwordIndex maps each stopword to the languages it belongs to.
+var stopwordIndex = buildStopwordIndex()
+
+// buildStopwordIndex inverts the stopwords table for constant-time lookups.
+func buildStopwordIndex() map[string][]string {
+	index := make(map[string][]string)
+	for lang, words := range stopwords {
+		for _, word := range words {
+			index[word] = append(index[word], lang)
+		}
+	}
+	return index
+}
+
+// Detect returns the ISO 639-1 code of the most likely language of text,
+// or an empty string if the language cannot be determined (e.g., the text is
+// too short, contains only links and emoji, or is ambiguous).
+func Detect(text string) string {
+	text = slackMarkupPattern.ReplaceAllString(text, " ")
+
+	if lang := detectScript(text); lang != "" {
+		return lang
+	}
+
+	return detectLatin(text)
+}
+
+// detectScript returns the language of the dominant non-Latin script in text,
+// or an empty string if letters are mostly Latin.
+func detectScript(text string) string {
+	counts := make(map[string]int)
+	letters := 0
+
+	for _, r := range text {
+		if !unicode.IsLetter(r) {
+			continue
+		}
+		letters++
+		for _, script := range scriptLanguages {
+			if unicode.Is(script.table, r) {
+				counts[script.lang]++
+				break
+			}
+		}
+	}
+
+	if letters == 0 {
+		return ""
+	}
+
+	// Japanese text mixes kana with Han characters; any kana means Japanese
+	if counts["ja"] > 0 {
+		return "ja"
+	}
+
+	best, bestCount := "", 0
+	for _, script := range scriptLanguages {
+		if counts[script.lang] > bestCount {
+			best, bestCount = script.lang, counts[script.lang]
+		}
+	}
+
+	// Require the script to account for at least half of the letters
+	if bestCount*2 < letters {
+		return ""
+	}
+	return best
+}
+
+// detectLatin scores text against the stopword lists and returns the best
+// scoring language, or an empty string if there is no clear winner.
+func detectLatin(text string) string {
+	scores := make(map[string]int)
+
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && r != '\''
+	})
+	for _, word := range words {
+		for _, lang := range stopwordIndex[word] {
+			scores[lang]++
+		}
+	}
+
+	best, bestScore, tied := "", 0, false
+	for lang, score := range scores {
+		switch {
+		case score > bestScore:
+			best, bestScore, tied = lang, score, false
+		case score == bestScore:
+			tied = true
+		}
+	}
+
+	if bestScore < minLatinMatches || tied {
+		return ""
+	}
+	return best
+}
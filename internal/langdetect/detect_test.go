@@ -0,0 +1,81 @@
+// Package langdetect provides lightweight language detection for Slack message text.
+package langdetect
+
+import "testing"
+
+func TestDetect(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{
+			name: "english",
+			text: "The deploy is done and this should fix the login issue for you",
+			want: "en",
+		},
+		{
+			name: "spanish",
+			text: "Gracias por la ayuda, el despliegue está listo para mañana",
+			want: "es",
+		},
+		{
+			name: "french",
+			text: "Merci, le déploiement est terminé et nous avons corrigé les erreurs",
+			want: "fr",
+		},
+		{
+			name: "german",
+			text: "Danke, der Fehler ist behoben und die Seite funktioniert wieder",
+			want: "de",
+		},
+		{
+			name: "japanese",
+			text: "デプロイが完了しました。確認してください",
+			want: "ja",
+		},
+		{
+			name: "chinese",
+			text: "部署已经完成，请检查",
+			want: "zh",
+		},
+		{
+			name: "korean",
+			text: "배포가 완료되었습니다",
+			want: "ko",
+		},
+		{
+			name: "russian",
+			text: "Развертывание завершено, проверьте пожалуйста",
+			want: "ru",
+		},
+		{
+			name: "mentions and emoji are ignored",
+			text: "<@U12345678> la reunión es para mañana con el equipo :tada:",
+			want: "es",
+		},
+		{
+			name: "too short",
+			text: "ok",
+			want: "",
+		},
+		{
+			name: "only markup",
+			text: "<https://example.com|link> :thumbsup:",
+			want: "",
+		},
+		{
+			name: "empty",
+			text: "",
+			want: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Detect(tt.text); got != tt.want {
+				t.Errorf("Detect(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,109 @@
+// Package references extracts issue, pull request, and ticket references from
+// Slack message text, so triage agents can connect conversations to trackers
+// such as Jira and GitHub without re-parsing text themselves.
+//
+// Each tracker is a named regular expression. The reference ID is the first
+// non-empty capturing group of a match, or the whole match if the pattern has
+// none, which lets one pattern accept several spellings of a reference.
+package references
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/Bitovi/slack-mcp-server/pkg/types"
+)
+
+// Tracker is a named reference pattern.
+type Tracker struct {
+	// Name labels the references the pattern finds (e.g., "jira").
+	Name string
+	// Pattern matches references in message text.
+	Pattern *regexp.Regexp
+}
+
+// namePattern restricts tracker names to identifiers that are easy to filter on.
+var namePattern = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// DefaultTrackers returns the trackers used when none are configured: Jira issue
+// keys (e.g., "OPS-123") and GitHub issues and pull requests, either as
+// "owner/repo#123" or as github.com links.
+//
+// The Jira pattern matches any uppercase key followed by a number, which also
+// catches strings such as "UTF-8"; configure a pattern naming your project keys
+// to avoid that.
+func DefaultTrackers() []Tracker {
+	return []Tracker{
+		{Name: "jira", Pattern: regexp.MustCompile(`\b[A-Z][A-Z0-9]{1,9}-[1-9][0-9]*\b`)},
+		{Name: "github", Pattern: regexp.MustCompile(
+			`github\.com/([\w.-]+/[\w.-]+/(?:issues|pull)/[0-9]+)|\b([\w.-]+/[\w.-]+#[0-9]+)\b`)},
+	}
+}
+
+// ParseTrackers parses a tracker list of the form "name=regex;name=regex", as in
+// "jira=\b(?:OPS|ENG)-[0-9]+\b;zendesk=#ZD-([0-9]+)". Patterns use Go regular
+// expression syntax and cannot contain ';'.
+//
+// Returns an error naming the tracker if an entry has no name or its pattern does not compile.
+func ParseTrackers(spec string) ([]Tracker, error) {
+	var trackers []Tracker
+	seen := make(map[string]bool)
+	for _, entry := range strings.Split(spec, ";") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		name, expr, ok := strings.Cut(entry, "=")
+		name = strings.TrimSpace(name)
+		if !ok || !namePattern.MatchString(name) {
+			return nil, fmt.Errorf("invalid tracker %q: expected name=regex with a name of letters, digits, '-' and '_'", entry)
+		}
+		if seen[name] {
+			return nil, fmt.Errorf("tracker %q is defined more than once", name)
+		}
+		seen[name] = true
+
+		pattern, err := regexp.Compile(strings.TrimSpace(expr))
+		if err != nil {
+			return nil, fmt.Errorf("invalid pattern for tracker %q: %w", name, err)
+		}
+		if pattern.MatchString("") {
+			return nil, fmt.Errorf("invalid pattern for tracker %q: matches empty text", name)
+		}
+		trackers = append(trackers, Tracker{Name: name, Pattern: pattern})
+	}
+
+	if len(trackers) == 0 {
+		return nil, fmt.Errorf("no trackers defined")
+	}
+	return trackers, nil
+}
+
+// Extract returns the references found in text by each tracker, in tracker order
+// and then in order of appearance, without duplicates. Returns nil if there are none.
+func Extract(text string, trackers []Tracker) []types.Reference {
+	var refs []types.Reference
+	seen := make(map[types.Reference]bool)
+	for _, tracker := range trackers {
+		for _, match := range tracker.Pattern.FindAllStringSubmatch(text, -1) {
+			ref := types.Reference{Tracker: tracker.Name, ID: referenceID(match)}
+			if ref.ID != "" && !seen[ref] {
+				seen[ref] = true
+				refs = append(refs, ref)
+			}
+		}
+	}
+	return refs
+}
+
+// referenceID returns the first non-empty capturing group of a match, or the whole match.
+func referenceID(match []string) string {
+	for _, group := range match[1:] {
+		if group != "" {
+			return group
+		}
+	}
+	return match[0]
+}
@@ -0,0 +1,89 @@
+// Package references provides tests for reference extraction.
+package references
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/Bitovi/slack-mcp-server/pkg/types"
+)
+
+func TestExtract_DefaultTrackers(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want []types.Reference
+	}{
+		{
+			name: "jira keys",
+			text: "OPS-123 and ENG2-7 block the release, see OPS-123",
+			want: []types.Reference{{Tracker: "jira", ID: "OPS-123"}, {Tracker: "jira", ID: "ENG2-7"}},
+		},
+		{
+			name: "github shorthand",
+			text: "fixed in acme/api#42",
+			want: []types.Reference{{Tracker: "github", ID: "acme/api#42"}},
+		},
+		{
+			name: "github links",
+			text: "<https://github.com/acme/api/pull/42|the PR> closes <https://github.com/acme/web/issues/9>",
+			want: []types.Reference{
+				{Tracker: "github", ID: "acme/api/pull/42"},
+				{Tracker: "github", ID: "acme/web/issues/9"},
+			},
+		},
+		{
+			name: "no references",
+			text: "Deploy looks good, <@U01234567> thanks!",
+			want: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Extract(tt.text, DefaultTrackers()); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Extract() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseTrackers(t *testing.T) {
+	trackers, err := ParseTrackers(` jira=\b(?:OPS|ENG)-[0-9]+\b ; zendesk=#ZD-([0-9]+);`)
+	if err != nil {
+		t.Fatalf("ParseTrackers() error = %v", err)
+	}
+	if len(trackers) != 2 || trackers[0].Name != "jira" || trackers[1].Name != "zendesk" {
+		t.Fatalf("ParseTrackers() = %+v, want jira and zendesk", trackers)
+	}
+
+	got := Extract("UTF-8 bug OPS-9 reported in #ZD-5521", trackers)
+	want := []types.Reference{{Tracker: "jira", ID: "OPS-9"}, {Tracker: "zendesk", ID: "5521"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Extract() = %+v, want %+v", got, want)
+	}
+}
+
+func TestParseTrackers_Invalid(t *testing.T) {
+	tests := []struct {
+		spec    string
+		wantErr string
+	}{
+		{spec: "", wantErr: "no trackers"},
+		{spec: "jira", wantErr: "expected name=regex"},
+		{spec: "my tracker=X-[0-9]+", wantErr: "expected name=regex"},
+		{spec: "jira=OPS-[0-9]+;jira=ENG-[0-9]+", wantErr: "more than once"},
+		{spec: "jira=OPS-[0-9+", wantErr: "invalid pattern"},
+		{spec: "jira=[0-9]*", wantErr: "matches empty text"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			_, err := ParseTrackers(tt.spec)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("ParseTrackers(%q) error = %v, want error containing %q", tt.spec, err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,33 @@
+// Package requestid generates and propagates per-tool-call request IDs so that
+// failures reported by agent users can be correlated with server logs.
+package requestid
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+)
+
+// contextKey is the context key under which the request ID is stored.
+type contextKey struct{}
+
+// New returns a new random request ID (16 hex characters).
+func New() string {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		// crypto/rand does not fail on supported platforms; fall back to a fixed marker
+		return "unknown"
+	}
+	return hex.EncodeToString(b)
+}
+
+// WithID returns a copy of ctx carrying the given request ID.
+func WithID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, contextKey{}, id)
+}
+
+// FromContext returns the request ID stored in ctx, or an empty string if none is present.
+func FromContext(ctx context.Context) string {
+	id, _ := ctx.Value(contextKey{}).(string)
+	return id
+}
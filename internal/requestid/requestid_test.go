@@ -0,0 +1,30 @@
+// Package requestid generates and propagates per-tool-call request IDs so that
+// failures reported by agent users can be correlated with server logs.
+package requestid
+
+import (
+	"context"
+	"testing"
+)
+
+func TestNew(t *testing.T) {
+	id := New()
+	if len(id) != 16 {
+		t.Errorf("expected 16 character request ID, got %q", id)
+	}
+
+	if other := New(); other == id {
+		t.Errorf("expected unique request IDs, got %q twice", id)
+	}
+}
+
+func TestFromContext(t *testing.T) {
+	if id := FromContext(context.Background()); id != "" {
+		t.Errorf("expected empty request ID for bare context, got %q", id)
+	}
+
+	ctx := WithID(context.Background(), "abc123")
+	if id := FromContext(ctx); id != "abc123" {
+		t.Errorf("expected request ID 'abc123', got %q", id)
+	}
+}
@@ -0,0 +1,217 @@
+// Package secrets fetches Slack tokens from external secret stores (HashiCorp Vault,
+// AWS Secrets Manager) for deployments that forbid long-lived secrets in the
+// process environment.
+package secrets
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+// AWSCredentials are the AWS access keys used to sign Secrets Manager requests.
+type AWSCredentials struct {
+	// AccessKeyID is the AWS access key ID.
+	AccessKeyID string
+	// SecretAccessKey is the AWS secret access key.
+	SecretAccessKey string
+	// SessionToken is the session token for temporary credentials, or empty.
+	SessionToken string
+}
+
+// AWSSecretsManagerProvider reads a secret from AWS Secrets Manager using the
+// GetSecretValue API, signed with AWS Signature Version 4.
+type AWSSecretsManagerProvider struct {
+	// Region is the AWS region of the secret (e.g., "us-east-1").
+	Region string
+	// SecretID is the secret name or ARN.
+	SecretID string
+	// Field is the key to read when the secret string is a JSON object
+	// (e.g., "bot_token"). If empty, the whole secret string is the value.
+	Field string
+	// Credentials sign the request.
+	Credentials AWSCredentials
+	// Endpoint overrides the Secrets Manager endpoint (e.g., a VPC endpoint).
+	// Optional; defaults to https://secretsmanager.{Region}.amazonaws.com.
+	Endpoint string
+	// HTTPClient is the client used for requests. Optional; a client with a 10s timeout is used if nil.
+	HTTPClient *http.Client
+
+	// now returns the signing time; it is overridden in tests.
+	now func() time.Time
+}
+
+// awsSecretsManagerService is the service name used in the SigV4 credential scope.
+const awsSecretsManagerService = "secretsmanager"
+
+// Name implements Provider.
+func (p *AWSSecretsManagerProvider) Name() string {
+	return "aws-secrets-manager " + p.SecretID
+}
+
+// Fetch implements Provider. It reads the secret's current version and returns
+// either the whole secret string or its Field.
+func (p *AWSSecretsManagerProvider) Fetch(ctx context.Context) (string, error) {
+	endpoint := p.Endpoint
+	if endpoint == "" {
+		endpoint = fmt.Sprintf("https://secretsmanager.%s.amazonaws.com", p.Region)
+	}
+
+	payload, err := json.Marshal(map[string]string{"SecretId": p.SecretID})
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", p.Name(), err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, strings.TrimRight(endpoint, "/")+"/", bytes.NewReader(payload))
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", p.Name(), err)
+	}
+	req.Header.Set("Content-Type", "application/x-amz-json-1.1")
+	req.Header.Set("X-Amz-Target", "secretsmanager.GetSecretValue")
+
+	now := time.Now
+	if p.now != nil {
+		now = p.now
+	}
+	signAWSRequest(req, payload, p.Credentials, p.Region, awsSecretsManagerService, now().UTC())
+
+	resp, err := httpClientOrDefault(p.HTTPClient).Do(req)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", p.Name(), err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("%s: reading response: %w", p.Name(), err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		// Error bodies carry only a type and message, e.g., {"__type":"ResourceNotFoundException",...}
+		var apiErr struct {
+			Type    string `json:"__type"`
+			Message string `json:"message"`
+		}
+		_ = json.Unmarshal(body, &apiErr)
+		return "", fmt.Errorf("%s: AWS returned HTTP %d: %s %s", p.Name(), resp.StatusCode, apiErr.Type, apiErr.Message)
+	}
+
+	var secret struct {
+		SecretString *string `json:"SecretString"`
+	}
+	if err := json.Unmarshal(body, &secret); err != nil {
+		return "", fmt.Errorf("%s: decoding response: %w", p.Name(), err)
+	}
+	if secret.SecretString == nil || *secret.SecretString == "" {
+		return "", fmt.Errorf("%s: secret has no SecretString (binary secrets are not supported)", p.Name())
+	}
+
+	if p.Field == "" {
+		return *secret.SecretString, nil
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(*secret.SecretString), &fields); err != nil {
+		return "", fmt.Errorf("%s: secret is not a JSON object, cannot read field %q", p.Name(), p.Field)
+	}
+	value, err := stringField(fields, p.Field)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", p.Name(), err)
+	}
+	return value, nil
+}
+
+// signAWSRequest adds AWS Signature Version 4 headers to req.
+// See https://docs.aws.amazon.com/IAM/latest/UserGuide/reference_sigv-create-signed-request.html
+func signAWSRequest(req *http.Request, payload []byte, creds AWSCredentials, region, service string, t time.Time) {
+	amzDate := t.Format("20060102T150405Z")
+	date := t.Format("20060102")
+
+	req.Header.Set("X-Amz-Date", amzDate)
+	if creds.SessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", creds.SessionToken)
+	}
+
+	// Canonical headers: host plus every header set on the request, lowercased and sorted
+	headers := map[string]string{"host": req.URL.Host}
+	for name, values := range req.Header {
+		headers[strings.ToLower(name)] = strings.TrimSpace(strings.Join(values, ","))
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalURI := req.URL.EscapedPath()
+	if canonicalURI == "" {
+		canonicalURI = "/"
+	}
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		canonicalURI,
+		canonicalQuery(req.URL.Query()),
+		canonicalHeaders.String(),
+		signedHeaders,
+		hexSHA256(payload),
+	}, "\n")
+
+	scope := date + "/" + region + "/" + service + "/aws4_request"
+	stringToSign := strings.Join([]string{
+		"AWS4-HMAC-SHA256",
+		amzDate,
+		scope,
+		hexSHA256([]byte(canonicalRequest)),
+	}, "\n")
+
+	signature := hex.EncodeToString(hmacSHA256(deriveSigningKey(creds.SecretAccessKey, date, region, service), stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		creds.AccessKeyID, scope, signedHeaders, signature))
+}
+
+// deriveSigningKey derives the SigV4 signing key for a date, region, and service.
+func deriveSigningKey(secretAccessKey, date, region, service string) []byte {
+	key := hmacSHA256([]byte("AWS4"+secretAccessKey), date)
+	key = hmacSHA256(key, region)
+	key = hmacSHA256(key, service)
+	return hmacSHA256(key, "aws4_request")
+}
+
+// canonicalQuery encodes query parameters sorted by key, as SigV4 requires.
+func canonicalQuery(values url.Values) string {
+	// url.Values.Encode sorts by key; SigV4 requires %20 rather than + for spaces
+	return strings.ReplaceAll(values.Encode(), "+", "%20")
+}
+
+// hmacSHA256 returns the HMAC-SHA256 of data with key.
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+// hexSHA256 returns the hex-encoded SHA-256 digest of data.
+func hexSHA256(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// Ensure AWSSecretsManagerProvider implements Provider.
+var _ Provider = (*AWSSecretsManagerProvider)(nil)
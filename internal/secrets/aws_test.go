@@ -0,0 +1,139 @@
+// Package secrets fetches Slack tokens from external secret stores (HashiCorp Vault,
+// AWS Secrets Manager) for deployments that forbid long-lived secrets in the
+// process environment.
+package secrets
+
+import (
+	"context"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// exampleCredentials are the example credentials from the AWS SigV4 documentation.
+var exampleCredentials = AWSCredentials{
+	AccessKeyID:     "AKIDEXAMPLE",
+	SecretAccessKey: "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY",
+}
+
+func TestDeriveSigningKey(t *testing.T) {
+	// Example from the AWS documentation on deriving a SigV4 signing key
+	got := hex.EncodeToString(deriveSigningKey(exampleCredentials.SecretAccessKey, "20150830", "us-east-1", "iam"))
+	want := "c4afb1cc5771d871763a393e44b703571b55cc28424d1a5e86da6ed3c154a4b9"
+	if got != want {
+		t.Errorf("signing key = %s, want %s", got, want)
+	}
+}
+
+func TestSignAWSRequest(t *testing.T) {
+	// Example request from the AWS SigV4 documentation (IAM ListUsers)
+	req, err := http.NewRequest(http.MethodGet, "https://iam.amazonaws.com/?Action=ListUsers&Version=2010-05-08", nil)
+	if err != nil {
+		t.Fatalf("failed to create request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded; charset=utf-8")
+
+	signAWSRequest(req, nil, exampleCredentials, "us-east-1", "iam", time.Date(2015, 8, 30, 12, 36, 0, 0, time.UTC))
+
+	want := "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20150830/us-east-1/iam/aws4_request, " +
+		"SignedHeaders=content-type;host;x-amz-date, " +
+		"Signature=5d672d79c15b13162d9279b0855cfba6789a8edb4c82c400e06b5924a6f2b5d7"
+	if got := req.Header.Get("Authorization"); got != want {
+		t.Errorf("Authorization = %q, want %q", got, want)
+	}
+}
+
+func TestAWSSecretsManagerProvider_Fetch(t *testing.T) {
+	tests := []struct {
+		name       string
+		field      string
+		status     int
+		response   string
+		want       string
+		wantErrMsg string
+	}{
+		{
+			name:     "plain secret string",
+			status:   http.StatusOK,
+			response: `{"Name":"slack-mcp","SecretString":"xoxb-from-aws"}`,
+			want:     "xoxb-from-aws",
+		},
+		{
+			name:     "field from JSON secret string",
+			field:    "bot_token",
+			status:   http.StatusOK,
+			response: `{"Name":"slack-mcp","SecretString":"{\"bot_token\":\"xoxb-from-aws\"}"}`,
+			want:     "xoxb-from-aws",
+		},
+		{
+			name:       "field missing",
+			field:      "bot_token",
+			status:     http.StatusOK,
+			response:   `{"Name":"slack-mcp","SecretString":"{\"other\":\"x\"}"}`,
+			wantErrMsg: `field "bot_token" not found`,
+		},
+		{
+			name:       "binary secret",
+			status:     http.StatusOK,
+			response:   `{"Name":"slack-mcp","SecretBinary":"eG94Yi0="}`,
+			wantErrMsg: "binary secrets are not supported",
+		},
+		{
+			name:       "not found",
+			status:     http.StatusBadRequest,
+			response:   `{"__type":"ResourceNotFoundException","message":"Secrets Manager can't find the specified secret."}`,
+			wantErrMsg: "ResourceNotFoundException",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if got := r.Header.Get("X-Amz-Target"); got != "secretsmanager.GetSecretValue" {
+					t.Errorf("X-Amz-Target = %q", got)
+				}
+				if got := r.Header.Get("X-Amz-Security-Token"); got != "session" {
+					t.Errorf("X-Amz-Security-Token = %q, want %q", got, "session")
+				}
+				auth := r.Header.Get("Authorization")
+				if !strings.HasPrefix(auth, "AWS4-HMAC-SHA256 Credential=AKID/20240115/us-west-2/secretsmanager/aws4_request") {
+					t.Errorf("unexpected Authorization header: %q", auth)
+				}
+				body, _ := io.ReadAll(r.Body)
+				if string(body) != `{"SecretId":"slack-mcp"}` {
+					t.Errorf("request body = %s", body)
+				}
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(tt.response))
+			}))
+			defer srv.Close()
+
+			provider := &AWSSecretsManagerProvider{
+				Region:      "us-west-2",
+				SecretID:    "slack-mcp",
+				Field:       tt.field,
+				Credentials: AWSCredentials{AccessKeyID: "AKID", SecretAccessKey: "secret", SessionToken: "session"},
+				Endpoint:    srv.URL,
+				now:         func() time.Time { return time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC) },
+			}
+
+			got, err := provider.Fetch(context.Background())
+			if tt.wantErrMsg != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErrMsg) {
+					t.Fatalf("error = %v, want to contain %q", err, tt.wantErrMsg)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Fetch() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
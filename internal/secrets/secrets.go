@@ -0,0 +1,49 @@
+// Package secrets fetches Slack tokens from external secret stores (HashiCorp Vault,
+// AWS Secrets Manager) for deployments that forbid long-lived secrets in the
+// process environment.
+package secrets
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// defaultHTTPTimeout bounds a single request to a secret store.
+const defaultHTTPTimeout = 10 * time.Second
+
+// Provider fetches the current value of a secret.
+// Implementations must be safe to call repeatedly so rotated secrets can be re-fetched.
+type Provider interface {
+	// Fetch returns the current secret value.
+	Fetch(ctx context.Context) (string, error)
+	// Name describes the secret source for log and error messages (e.g., "vault secret/data/slack").
+	Name() string
+}
+
+// httpClientOrDefault returns client, or a client with defaultHTTPTimeout if client is nil.
+func httpClientOrDefault(client *http.Client) *http.Client {
+	if client != nil {
+		return client
+	}
+	return &http.Client{Timeout: defaultHTTPTimeout}
+}
+
+// stringField extracts a string field from a JSON object.
+func stringField(obj map[string]json.RawMessage, field string) (string, error) {
+	raw, ok := obj[field]
+	if !ok {
+		return "", fmt.Errorf("field %q not found in secret", field)
+	}
+
+	var value string
+	if err := json.Unmarshal(raw, &value); err != nil {
+		return "", fmt.Errorf("field %q in secret is not a string", field)
+	}
+	if value == "" {
+		return "", fmt.Errorf("field %q in secret is empty", field)
+	}
+	return value, nil
+}
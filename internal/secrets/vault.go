@@ -0,0 +1,88 @@
+// Package secrets fetches Slack tokens from external secret stores (HashiCorp Vault,
+// AWS Secrets Manager) for deployments that forbid long-lived secrets in the
+// process environment.
+package secrets
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// VaultProvider reads a secret field from a HashiCorp Vault KV secrets engine
+// (version 1 or 2) over the Vault HTTP API.
+type VaultProvider struct {
+	// Addr is the Vault server address (e.g., "https://vault.example.com:8200").
+	Addr string
+	// Token is the Vault token used to authenticate the read.
+	Token string
+	// Namespace is the Vault Enterprise namespace, or empty for none.
+	Namespace string
+	// Path is the secret path as used by the HTTP API, without the /v1/ prefix
+	// (e.g., "secret/data/slack-mcp" for KV v2, "secret/slack-mcp" for KV v1).
+	Path string
+	// Field is the key within the secret that holds the value (e.g., "bot_token").
+	Field string
+	// HTTPClient is the client used for requests. Optional; a client with a 10s timeout is used if nil.
+	HTTPClient *http.Client
+}
+
+// Name implements Provider.
+func (p *VaultProvider) Name() string {
+	return "vault " + p.Path
+}
+
+// Fetch implements Provider. It reads the secret at Path and returns its Field.
+func (p *VaultProvider) Fetch(ctx context.Context) (string, error) {
+	url := strings.TrimRight(p.Addr, "/") + "/v1/" + strings.TrimLeft(p.Path, "/")
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", p.Name(), err)
+	}
+	req.Header.Set("X-Vault-Token", p.Token)
+	if p.Namespace != "" {
+		req.Header.Set("X-Vault-Namespace", p.Namespace)
+	}
+
+	resp, err := httpClientOrDefault(p.HTTPClient).Do(req)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", p.Name(), err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("%s: reading response: %w", p.Name(), err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("%s: vault returned HTTP %d", p.Name(), resp.StatusCode)
+	}
+
+	var secret struct {
+		Data map[string]json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal(body, &secret); err != nil {
+		return "", fmt.Errorf("%s: decoding response: %w", p.Name(), err)
+	}
+
+	// KV v2 nests the secret's key/value pairs under data.data, next to data.metadata
+	fields := secret.Data
+	if nested, ok := secret.Data["data"]; ok {
+		var kv2 map[string]json.RawMessage
+		if err := json.Unmarshal(nested, &kv2); err == nil {
+			fields = kv2
+		}
+	}
+
+	value, err := stringField(fields, p.Field)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", p.Name(), err)
+	}
+	return value, nil
+}
+
+// Ensure VaultProvider implements Provider.
+var _ Provider = (*VaultProvider)(nil)
@@ -0,0 +1,98 @@
+// Package server provides the MCP server setup and tool registration
+// for the Slack MCP server.
+package server
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Bitovi/slack-mcp-server/pkg/types"
+)
+
+const (
+	// maxReportedChannels caps the member channels listed by name in an access report.
+	maxReportedChannels = 100
+	// startupReportTimeout bounds the channel listing for the startup access report.
+	startupReportTimeout = 2 * time.Minute
+)
+
+// channelAccessLister is implemented by Slack clients that can list the channels
+// visible to the bot.
+type channelAccessLister interface {
+	ListChannelAccess(ctx context.Context) ([]types.ChannelAccess, bool, error)
+}
+
+// ReportAccess writes a summary of the channels the bot is a member of to w,
+// making it clear which channels read_message and list_channel_messages can read.
+//
+// Returns an error if the Slack client cannot list channels or the listing fails
+// (e.g., the channels:read scope is missing).
+func (s *Server) ReportAccess(ctx context.Context, w io.Writer) error {
+	lister, ok := s.slackClient.(channelAccessLister)
+	if !ok {
+		return fmt.Errorf("the Slack client does not support listing channels")
+	}
+
+	channels, truncated, err := lister.ListChannelAccess(ctx)
+	if err != nil {
+		return err
+	}
+
+	var members []*types.ChannelInfo
+	for _, channel := range channels {
+		if channel.BotIsMember {
+			members = append(members, channel.Channel)
+		}
+	}
+	sort.Slice(members, func(i, j int) bool { return members[i].Name < members[j].Name })
+
+	header := "Channel access report"
+	if workspace, err := s.slackClient.GetWorkspaceInfo(ctx); err == nil {
+		header += fmt.Sprintf(" for %s (%s)", workspace.Name, workspace.Domain)
+	}
+	fmt.Fprintf(w, "%s\n", header)
+
+	total := fmt.Sprintf("%d", len(channels))
+	if truncated {
+		total = fmt.Sprintf("at least %d", len(channels))
+	}
+	fmt.Fprintf(w, "  The bot is a member of %d of %s unarchived channels it can see:\n", len(members), total)
+
+	for i, channel := range members {
+		if i == maxReportedChannels {
+			fmt.Fprintf(w, "    ... and %d more\n", len(members)-maxReportedChannels)
+			break
+		}
+		suffix := ""
+		if channel.IsPrivate {
+			suffix = " [private]"
+		}
+		fmt.Fprintf(w, "    #%s (%s)%s\n", channel.Name, channel.ID, suffix)
+	}
+
+	if len(members) < len(channels) || truncated {
+		fmt.Fprintf(w, "  read_message and list_channel_messages fail with not_in_channel for the other channels "+
+			"until the bot is invited (/invite @bot-name).\n")
+	}
+	fmt.Fprintf(w, "  Private channels and DMs the bot is not part of are not visible to it and are not counted.\n")
+
+	return nil
+}
+
+// logAccessReport writes the access report to the server log. Failures are logged
+// and otherwise ignored, since the report is informational.
+func (s *Server) logAccessReport() {
+	ctx, cancel := context.WithTimeout(context.Background(), startupReportTimeout)
+	defer cancel()
+
+	var report strings.Builder
+	if err := s.ReportAccess(ctx, &report); err != nil {
+		logger.Printf("channel access report failed: %v", err)
+		return
+	}
+	logger.Printf("%s", report.String())
+}
@@ -0,0 +1,338 @@
+// Package server provides the Streamable HTTP transport for remote MCP clients.
+package server
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+const (
+	// DefaultHTTPListenAddress is the address the HTTP transport listens on when
+	// --listen is not given. It only accepts local connections; deployments behind a
+	// reverse proxy on another host listen on e.g. ":8080".
+	DefaultHTTPListenAddress = "127.0.0.1:8080"
+	// httpEndpointPath is the path of the MCP endpoint.
+	httpEndpointPath = "/mcp"
+	// httpSessionHeader carries the MCP session ID, per the Streamable HTTP transport.
+	httpSessionHeader = "Mcp-Session-Id"
+	// maxHTTPRequestBytes bounds the size of a request body.
+	maxHTTPRequestBytes = 4 << 20
+	// httpShutdownTimeout is how long in-flight requests may run after SIGINT or SIGTERM.
+	httpShutdownTimeout = 10 * time.Second
+)
+
+// httpSessions holds the sessions of the HTTP transport, keyed by session ID.
+// It is safe for concurrent use.
+type httpSessions struct {
+	mu       sync.Mutex
+	sessions map[string]*httpSession
+}
+
+// httpSession is the MCP session of one HTTP client. Unlike a socket connection it
+// has no stream to the client, so it ends when the client deletes it or it has
+// been idle for sessionIdleTimeout.
+type httpSession struct {
+	connSession
+	// idle closes the session after sessionIdleTimeout without requests; nil if disabled.
+	idle *time.Timer
+}
+
+// serveHTTP serves MCP clients over the Streamable HTTP transport on addr until
+// SIGINT or SIGTERM. Clients POST JSON-RPC messages to /mcp and receive the
+// responses as JSON in the HTTP response. A session starts with an initialize
+// request, whose response carries the Mcp-Session-Id header that later requests
+// must send, and ends with a DELETE of /mcp or after sessionIdleTimeout without
+// requests. Any number of clients can be served at once.
+//
+// The server does not open event streams, so GET requests are refused and server
+// notifications (e.g., list changes) are not delivered. Requests from web pages are
+// refused (see guardRequests).
+func (s *Server) serveHTTP(addr string) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	sessions := &httpSessions{sessions: make(map[string]*httpSession)}
+	mux := http.NewServeMux()
+	mux.HandleFunc(httpEndpointPath, func(w http.ResponseWriter, r *http.Request) {
+		s.handleHTTP(w, r, sessions)
+	})
+
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+	srv := &http.Server{Handler: s.guardRequests(listener.Addr(), mux), ReadHeaderTimeout: 10 * time.Second}
+	logger.Printf("serving MCP over HTTP on http://%s%s", listener.Addr(), httpEndpointPath)
+
+	serveErr := make(chan error, 1)
+	go func() { serveErr <- srv.Serve(listener) }()
+
+	select {
+	case err := <-serveErr:
+		return err
+	case <-ctx.Done():
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), httpShutdownTimeout)
+	defer cancel()
+	err = srv.Shutdown(shutdownCtx)
+	sessions.closeAll(s)
+	return err
+}
+
+// handleHTTP handles one request to the MCP endpoint.
+func (s *Server) handleHTTP(w http.ResponseWriter, r *http.Request, sessions *httpSessions) {
+	switch r.Method {
+	case http.MethodPost:
+		s.handleHTTPPost(w, r, sessions)
+	case http.MethodDelete:
+		if !sessions.close(s, r.Header.Get(httpSessionHeader)) {
+			http.Error(w, "unknown session", http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		// No event stream is offered for server-initiated messages
+		w.Header().Set("Allow", "POST, DELETE")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// loopbackHosts are the Host header names accepted by a server listening on a
+// loopback address, besides the configured allowed hosts.
+var loopbackHosts = []string{"localhost", "127.0.0.1", "::1"}
+
+// guardRequests wraps a transport's handler so requests a web page could send are
+// refused. The transports have no authentication, so any page the operator visits
+// could otherwise call the tools with the Slack tokens:
+//   - Requests with an Origin header for another host are refused (see isCrossOrigin).
+//   - When addr is a loopback address, requests with a Host header other than
+//     loopbackHosts or allowedHosts are refused. A page on a rebound domain (DNS
+//     rebinding) sends matching Origin and Host headers naming its own domain.
+func (s *Server) guardRequests(addr net.Addr, next http.Handler) http.Handler {
+	var hosts map[string]bool
+	if tcpAddr, ok := addr.(*net.TCPAddr); ok && tcpAddr.IP.IsLoopback() {
+		hosts = make(map[string]bool)
+		for _, host := range append(loopbackHosts, s.allowedHosts...) {
+			hosts[normalizeHost(host)] = true
+		}
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if hosts != nil && !hosts[normalizeHost(r.Host)] {
+			http.Error(w, "host not allowed", http.StatusForbidden)
+			return
+		}
+		if isCrossOrigin(r) {
+			http.Error(w, "cross-origin requests are not allowed", http.StatusForbidden)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// normalizeHost returns the lowercase host name of a Host header value, without
+// its port or IPv6 brackets.
+func normalizeHost(host string) string {
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	return strings.ToLower(strings.Trim(host, "[]"))
+}
+
+// isCrossOrigin reports whether r comes from a web page on another origin than the
+// host it was sent to. Browsers send an Origin header; other clients do not.
+func isCrossOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return false
+	}
+	u, err := url.Parse(origin)
+	return err != nil || u.Host != r.Host
+}
+
+// handleHTTPPost handles a POST of one JSON-RPC message or a batch of them.
+func (s *Server) handleHTTPPost(w http.ResponseWriter, r *http.Request, sessions *httpSessions) {
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxHTTPRequestBytes))
+	if err != nil {
+		http.Error(w, "request body too large or unreadable", http.StatusRequestEntityTooLarge)
+		return
+	}
+
+	batch := false
+	var messages []json.RawMessage
+	if trimmed := bytes.TrimSpace(body); len(trimmed) > 0 && trimmed[0] == '[' {
+		batch = true
+		err = json.Unmarshal(trimmed, &messages)
+	} else {
+		var message json.RawMessage
+		err = json.Unmarshal(trimmed, &message)
+		messages = []json.RawMessage{message}
+	}
+	if err != nil || len(messages) == 0 {
+		response := mcp.JSONRPCError{JSONRPC: mcp.JSONRPC_VERSION}
+		response.Error.Code = mcp.PARSE_ERROR
+		response.Error.Message = "Parse error"
+		writeHTTPJSON(w, http.StatusBadRequest, response)
+		return
+	}
+
+	// An initialize request starts a new session; every other request must name one
+	var session *httpSession
+	if isInitialize(messages) {
+		if batch {
+			http.Error(w, "initialize must not be sent in a batch", http.StatusBadRequest)
+			return
+		}
+		session, err = sessions.open(s)
+		if err != nil {
+			http.Error(w, "failed to start session", http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set(httpSessionHeader, session.id)
+	} else {
+		id := r.Header.Get(httpSessionHeader)
+		if id == "" {
+			http.Error(w, "missing "+httpSessionHeader+" header", http.StatusBadRequest)
+			return
+		}
+		if session = sessions.get(id); session == nil {
+			// Tells the client to start a new session
+			http.Error(w, "unknown session", http.StatusNotFound)
+			return
+		}
+	}
+	if session.idle != nil {
+		session.idle.Reset(s.sessionIdleTimeout)
+	}
+
+	ctx := s.mcpServer.WithContext(r.Context(), session)
+	var responses []interface{}
+	for _, message := range messages {
+		// The server sends no requests, so responses from the client are ignored
+		if isResponse(string(message)) {
+			continue
+		}
+		if response := s.mcpServer.HandleMessage(ctx, message); response != nil {
+			responses = append(responses, response)
+		}
+	}
+
+	switch {
+	case len(responses) == 0:
+		// Only notifications (or responses) were sent
+		w.WriteHeader(http.StatusAccepted)
+	case batch:
+		writeHTTPJSON(w, http.StatusOK, responses)
+	default:
+		writeHTTPJSON(w, http.StatusOK, responses[0])
+	}
+}
+
+// isInitialize reports whether messages contain an initialize request.
+func isInitialize(messages []json.RawMessage) bool {
+	for _, message := range messages {
+		var base struct {
+			Method string `json:"method"`
+		}
+		if json.Unmarshal(message, &base) == nil && base.Method == string(mcp.MethodInitialize) {
+			return true
+		}
+	}
+	return false
+}
+
+// writeHTTPJSON writes v as a JSON response with the given status.
+func writeHTTPJSON(w http.ResponseWriter, status int, v interface{}) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		http.Error(w, "failed to encode response", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_, _ = w.Write(data)
+}
+
+// open starts a new session with a random ID and registers it with the MCP server.
+func (h *httpSessions) open(s *Server) (*httpSession, error) {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return nil, err
+	}
+	session := &httpSession{connSession: connSession{
+		id: hex.EncodeToString(b[:]),
+		// Nothing reads notifications; the MCP server drops them when the channel is full
+		notifications: make(chan mcp.JSONRPCNotification, 1),
+	}}
+	if err := s.mcpServer.RegisterSession(context.Background(), session); err != nil {
+		return nil, err
+	}
+
+	h.mu.Lock()
+	h.sessions[session.id] = session
+	h.mu.Unlock()
+
+	if s.sessionIdleTimeout > 0 {
+		session.idle = time.AfterFunc(s.sessionIdleTimeout, func() {
+			if h.close(s, session.id) {
+				logger.Printf("session %s: closed after %s without requests", session.id, s.sessionIdleTimeout)
+			}
+		})
+	}
+	return session, nil
+}
+
+// get returns the session with the given ID, or nil if there is none.
+func (h *httpSessions) get(id string) *httpSession {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.sessions[id]
+}
+
+// close ends the session with the given ID and unregisters it from the MCP server.
+// Returns false if there is no such session.
+func (h *httpSessions) close(s *Server, id string) bool {
+	h.mu.Lock()
+	session, ok := h.sessions[id]
+	delete(h.sessions, id)
+	h.mu.Unlock()
+	if !ok {
+		return false
+	}
+
+	if session.idle != nil {
+		session.idle.Stop()
+	}
+	s.mcpServer.UnregisterSession(id)
+	return true
+}
+
+// closeAll ends every session, on shutdown.
+func (h *httpSessions) closeAll(s *Server) {
+	h.mu.Lock()
+	ids := make([]string, 0, len(h.sessions))
+	for id := range h.sessions {
+		ids = append(ids, id)
+	}
+	h.mu.Unlock()
+
+	for _, id := range ids {
+		h.close(s, id)
+	}
+}
@@ -0,0 +1,245 @@
+// Package server provides tests for the Streamable HTTP transport.
+package server
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+const (
+	// testInitialize is an initialize request, which starts a session.
+	testInitialize = `{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2024-11-05",` +
+		`"capabilities":{},"clientInfo":{"name":"test","version":"1.0.0"}}}`
+	// testPing is a ping request, answered within a session.
+	testPing = `{"jsonrpc":"2.0","id":2,"method":"ping"}`
+	// testInitialized is the notification a client sends after initialize.
+	testInitialized = `{"jsonrpc":"2.0","method":"notifications/initialized"}`
+)
+
+// newHTTPTestServer starts the HTTP transport's MCP endpoint on a test server,
+// closing idle sessions after idleTimeout (zero disables it).
+func newHTTPTestServer(t *testing.T, idleTimeout time.Duration) (*httptest.Server, *httpSessions) {
+	t.Helper()
+	s := NewWithClient(nil)
+	s.sessionIdleTimeout = idleTimeout
+	sessions := &httpSessions{sessions: make(map[string]*httpSession)}
+	srv := httptest.NewUnstartedServer(nil)
+	srv.Config.Handler = s.guardRequests(srv.Listener.Addr(), http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.handleHTTP(w, r, sessions)
+	}))
+	srv.Start()
+	t.Cleanup(func() {
+		srv.Close()
+		sessions.closeAll(s)
+	})
+	return srv, sessions
+}
+
+// httpRequest sends a request to the test server's MCP endpoint with the given
+// session ID (if any) and returns the response and its body.
+func httpRequest(t *testing.T, srv *httptest.Server, method, sessionID, body string) (*http.Response, string) {
+	t.Helper()
+	req, err := http.NewRequest(method, srv.URL+httpEndpointPath, strings.NewReader(body))
+	if err != nil {
+		t.Fatalf("failed to build request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if sessionID != "" {
+		req.Header.Set(httpSessionHeader, sessionID)
+	}
+	resp, err := srv.Client().Do(req)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("failed to read response: %v", err)
+	}
+	return resp, string(data)
+}
+
+// initializeSession starts a session on the test server and returns its ID.
+func initializeSession(t *testing.T, srv *httptest.Server) string {
+	t.Helper()
+	resp, body := httpRequest(t, srv, http.MethodPost, "", testInitialize)
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("initialize: status %d, body %s", resp.StatusCode, body)
+	}
+	id := resp.Header.Get(httpSessionHeader)
+	if id == "" {
+		t.Fatalf("initialize: expected a %s header", httpSessionHeader)
+	}
+	return id
+}
+
+func TestHTTPTransport_Session(t *testing.T) {
+	srv, sessions := newHTTPTestServer(t, 0)
+
+	resp, body := httpRequest(t, srv, http.MethodPost, "", testInitialize)
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("initialize: status %d, body %s", resp.StatusCode, body)
+	}
+	id := resp.Header.Get(httpSessionHeader)
+	if len(id) != 32 || sessions.get(id) == nil {
+		t.Fatalf("expected a registered session with a random ID, got %q", id)
+	}
+	var initResult struct {
+		ID     int `json:"id"`
+		Result struct {
+			ServerInfo struct {
+				Name string `json:"name"`
+			} `json:"serverInfo"`
+		} `json:"result"`
+	}
+	if err := json.Unmarshal([]byte(body), &initResult); err != nil || initResult.ID != 1 || initResult.Result.ServerInfo.Name == "" {
+		t.Errorf("unexpected initialize response %s (%v)", body, err)
+	}
+
+	// Another initialize starts another session
+	if other := initializeSession(t, srv); other == id {
+		t.Error("expected each initialize to start a new session")
+	}
+
+	// Notifications are accepted without a response body
+	if resp, body := httpRequest(t, srv, http.MethodPost, id, testInitialized); resp.StatusCode != http.StatusAccepted {
+		t.Errorf("notification: status %d, body %s", resp.StatusCode, body)
+	}
+
+	// A request is answered with a single response, a batch with an array
+	resp, body = httpRequest(t, srv, http.MethodPost, id, testPing)
+	if resp.StatusCode != http.StatusOK || !strings.HasPrefix(body, `{`) || !strings.Contains(body, `"id":2`) {
+		t.Errorf("ping: status %d, body %s", resp.StatusCode, body)
+	}
+	resp, body = httpRequest(t, srv, http.MethodPost, id, "["+testPing+","+testInitialized+"]")
+	var batch []json.RawMessage
+	if resp.StatusCode != http.StatusOK || json.Unmarshal([]byte(body), &batch) != nil || len(batch) != 1 {
+		t.Errorf("batch: status %d, body %s; want an array of one response", resp.StatusCode, body)
+	}
+
+	// Requests outside a session are refused
+	if resp, _ := httpRequest(t, srv, http.MethodPost, "", testPing); resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("request without a session: status %d, want %d", resp.StatusCode, http.StatusBadRequest)
+	}
+	if resp, _ := httpRequest(t, srv, http.MethodPost, id, "{not json"); resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("malformed request: status %d, want %d", resp.StatusCode, http.StatusBadRequest)
+	}
+}
+
+func TestHTTPTransport_InitializeInBatch(t *testing.T) {
+	srv, sessions := newHTTPTestServer(t, 0)
+
+	resp, body := httpRequest(t, srv, http.MethodPost, "", "["+testInitialize+","+testPing+"]")
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("status %d, body %s; want %d", resp.StatusCode, body, http.StatusBadRequest)
+	}
+	if resp.Header.Get(httpSessionHeader) != "" || len(sessions.sessions) != 0 {
+		t.Error("expected no session to be started")
+	}
+}
+
+func TestHTTPTransport_UnknownSession(t *testing.T) {
+	srv, _ := newHTTPTestServer(t, 0)
+
+	if resp, body := httpRequest(t, srv, http.MethodPost, "0123456789abcdef", testPing); resp.StatusCode != http.StatusNotFound {
+		t.Errorf("status %d, body %s; want %d", resp.StatusCode, body, http.StatusNotFound)
+	}
+}
+
+func TestHTTPTransport_Delete(t *testing.T) {
+	srv, sessions := newHTTPTestServer(t, 0)
+	id := initializeSession(t, srv)
+
+	if resp, body := httpRequest(t, srv, http.MethodDelete, id, ""); resp.StatusCode != http.StatusNoContent {
+		t.Fatalf("delete: status %d, body %s; want %d", resp.StatusCode, body, http.StatusNoContent)
+	}
+	if sessions.get(id) != nil {
+		t.Error("expected the session to be closed")
+	}
+
+	// The session is gone, so it can neither be used nor deleted again
+	if resp, _ := httpRequest(t, srv, http.MethodPost, id, testPing); resp.StatusCode != http.StatusNotFound {
+		t.Errorf("request after delete: status %d, want %d", resp.StatusCode, http.StatusNotFound)
+	}
+	if resp, _ := httpRequest(t, srv, http.MethodDelete, id, ""); resp.StatusCode != http.StatusNotFound {
+		t.Errorf("second delete: status %d, want %d", resp.StatusCode, http.StatusNotFound)
+	}
+}
+
+func TestHTTPTransport_MethodNotAllowed(t *testing.T) {
+	srv, _ := newHTTPTestServer(t, 0)
+	id := initializeSession(t, srv)
+
+	resp, _ := httpRequest(t, srv, http.MethodGet, id, "")
+	if resp.StatusCode != http.StatusMethodNotAllowed {
+		t.Errorf("status %d, want %d", resp.StatusCode, http.StatusMethodNotAllowed)
+	}
+	if allow := resp.Header.Get("Allow"); allow != "POST, DELETE" {
+		t.Errorf("Allow = %q, want %q", allow, "POST, DELETE")
+	}
+}
+
+func TestHTTPTransport_CrossOrigin(t *testing.T) {
+	srv, sessions := newHTTPTestServer(t, 0)
+
+	for origin, want := range map[string]int{
+		"https://evil.example": http.StatusForbidden,
+		"http://localhost":     http.StatusForbidden,
+		srv.URL:                http.StatusOK,
+	} {
+		req, _ := http.NewRequest(http.MethodPost, srv.URL+httpEndpointPath, strings.NewReader(testInitialize))
+		req.Header.Set("Origin", origin)
+		resp, err := srv.Client().Do(req)
+		if err != nil {
+			t.Fatalf("request failed: %v", err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != want {
+			t.Errorf("Origin %s: status %d, want %d", origin, resp.StatusCode, want)
+		}
+	}
+	// A page on a domain rebound to 127.0.0.1 sends matching Origin and Host headers
+	req, _ := http.NewRequest(http.MethodPost, srv.URL+httpEndpointPath, strings.NewReader(testInitialize))
+	req.Host = "evil.example:" + srv.URL[strings.LastIndex(srv.URL, ":")+1:]
+	req.Header.Set("Origin", "http://"+req.Host)
+	resp, err := srv.Client().Do(req)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusForbidden {
+		t.Errorf("rebound host: status %d, want %d", resp.StatusCode, http.StatusForbidden)
+	}
+
+	if len(sessions.sessions) != 1 {
+		t.Errorf("expected only the same-origin request to start a session, got %d sessions", len(sessions.sessions))
+	}
+}
+
+func TestHTTPTransport_IdleTimeout(t *testing.T) {
+	const idleTimeout = 100 * time.Millisecond
+	srv, sessions := newHTTPTestServer(t, idleTimeout)
+	id := initializeSession(t, srv)
+
+	// Requests keep the session open past the idle timeout
+	for i := 0; i < 4; i++ {
+		time.Sleep(idleTimeout / 2)
+		if resp, body := httpRequest(t, srv, http.MethodPost, id, testPing); resp.StatusCode != http.StatusOK {
+			t.Fatalf("ping %d: status %d, body %s", i, resp.StatusCode, body)
+		}
+	}
+
+	// Without requests, it is closed
+	time.Sleep(2 * idleTimeout)
+	if sessions.get(id) != nil {
+		t.Error("expected the idle session to be closed")
+	}
+	if resp, _ := httpRequest(t, srv, http.MethodPost, id, testPing); resp.StatusCode != http.StatusNotFound {
+		t.Errorf("request after idle timeout: status %d, want %d", resp.StatusCode, http.StatusNotFound)
+	}
+}
@@ -0,0 +1,204 @@
+// Package server provides the MCP server setup and tool registration
+// for the Slack MCP server.
+package server
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/Bitovi/slack-mcp-server/internal/requestid"
+	slackclient "github.com/Bitovi/slack-mcp-server/internal/slack"
+)
+
+// logger writes server logs to stderr, since stdout carries the Stdio transport.
+var logger = log.New(os.Stderr, "slack-mcp: ", log.LstdFlags)
+
+// requestIDMiddleware assigns a request ID to each tool call and propagates it
+// through the context, the server log, error payloads, and the result's _meta.request_id,
+// so failures reported by agent users can be correlated with server logs.
+func requestIDMiddleware(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		id := requestid.New()
+		ctx = requestid.WithID(ctx, id)
+		start := time.Now()
+
+		result, err := next(ctx, request)
+
+		switch {
+		case err != nil:
+			logger.Printf("request_id=%s tool=%s duration=%s status=failed error=%q",
+				id, request.Params.Name, time.Since(start).Round(time.Millisecond), err.Error())
+			return result, fmt.Errorf("%w (request_id: %s)", err, id)
+		case result == nil:
+			return result, err
+		case result.IsError:
+			logger.Printf("request_id=%s tool=%s duration=%s status=error error=%q",
+				id, request.Params.Name, time.Since(start).Round(time.Millisecond), resultText(result))
+			appendRequestID(result, id)
+		default:
+			logger.Printf("request_id=%s tool=%s duration=%s status=ok",
+				id, request.Params.Name, time.Since(start).Round(time.Millisecond))
+		}
+
+		if result.Meta == nil {
+			result.Meta = make(map[string]interface{})
+		}
+		result.Meta["request_id"] = id
+
+		return result, nil
+	}
+}
+
+// appendRequestID appends the request ID to the text of an error result so it is
+// visible to agents and users even when the client does not surface _meta.
+func appendRequestID(result *mcp.CallToolResult, id string) {
+	for i, content := range result.Content {
+		if text, ok := content.(mcp.TextContent); ok {
+			text.Text = fmt.Sprintf("%s (request_id: %s)", text.Text, id)
+			result.Content[i] = text
+			return
+		}
+	}
+}
+
+// resultText returns the text of the first text content in a result.
+func resultText(result *mcp.CallToolResult) string {
+	for _, content := range result.Content {
+		if text, ok := content.(mcp.TextContent); ok {
+			return text.Text
+		}
+	}
+	return ""
+}
+
+// concurrencyLimitMiddleware returns a middleware that allows at most maxConcurrent
+// tool calls to execute at once. Additional calls wait in a queue for a free slot;
+// calls that wait longer than queueTimeout are rejected with a "server busy" error
+// so bursts from multiple agents cannot spawn unbounded work against the Slack API.
+func concurrencyLimitMiddleware(maxConcurrent int, queueTimeout time.Duration) server.ToolHandlerMiddleware {
+	slots := make(chan struct{}, maxConcurrent)
+
+	return func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			timer := time.NewTimer(queueTimeout)
+			defer timer.Stop()
+
+			select {
+			case slots <- struct{}{}:
+			case <-timer.C:
+				return mcp.NewToolResultError(fmt.Sprintf(
+					"Server busy: %d tool calls are already running and this call waited %s without "+
+						"getting a slot. Please retry shortly.", maxConcurrent, queueTimeout)), nil
+			case <-ctx.Done():
+				return mcp.NewToolResultError(fmt.Sprintf(
+					"Tool call cancelled while waiting for a free slot: %s", ctx.Err())), nil
+			}
+			defer func() { <-slots }()
+
+			return next(ctx, request)
+		}
+	}
+}
+
+// apiCallStatsMiddleware records the Slack API usage of each tool call and attaches
+// a summary to the result as _meta.api_calls. It is only installed in debug mode.
+func apiCallStatsMiddleware(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		ctx, stats := slackclient.WithCallStats(ctx)
+
+		result, err := next(ctx, request)
+		if result == nil {
+			return result, err
+		}
+
+		if result.Meta == nil {
+			result.Meta = make(map[string]interface{})
+		}
+		result.Meta["api_calls"] = stats.Summary()
+
+		return result, err
+	}
+}
+
+// DefaultSlowCallThreshold is the key of SlowCallThresholds that applies to tools
+// without a threshold of their own.
+const DefaultSlowCallThreshold = "*"
+
+// ParseSlowCallThresholds parses a comma-separated list of tool=duration pairs, such
+// as "search_messages=10s,*=5s". The tool "*" sets the threshold for all other tools,
+// and a duration of 0 disables the warning for a tool.
+func ParseSlowCallThresholds(spec string) (map[string]time.Duration, error) {
+	thresholds := make(map[string]time.Duration)
+	for _, pair := range strings.Split(spec, ",") {
+		if pair = strings.TrimSpace(pair); pair == "" {
+			continue
+		}
+		tool, value, ok := strings.Cut(pair, "=")
+		tool = strings.TrimSpace(tool)
+		if !ok || tool == "" {
+			return nil, fmt.Errorf("invalid threshold %q: expected tool=duration", pair)
+		}
+		d, err := time.ParseDuration(strings.TrimSpace(value))
+		if err != nil || d < 0 {
+			return nil, fmt.Errorf("invalid threshold %q: must be a duration such as 5s", pair)
+		}
+		thresholds[tool] = d
+	}
+	return thresholds, nil
+}
+
+// slowCallMiddleware returns a middleware that logs a warning for each tool call that
+// takes longer than its tool's threshold, with a breakdown of where the time went:
+// Slack API requests, user and bot name resolution, and processing in the server
+// (filtering, formatting, and marshaling the result).
+//
+// Slack and resolution times are the summed latency of the call's API requests, so
+// for tools that make requests concurrently they can add up to more than the duration.
+func slowCallMiddleware(thresholds map[string]time.Duration) server.ToolHandlerMiddleware {
+	return func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			threshold, ok := thresholds[request.Params.Name]
+			if !ok {
+				threshold = thresholds[DefaultSlowCallThreshold]
+			}
+			if threshold <= 0 {
+				return next(ctx, request)
+			}
+
+			ctx, stats := slackclient.WithCallStats(ctx)
+			start := time.Now()
+			result, err := next(ctx, request)
+			duration := time.Since(start)
+			if duration < threshold {
+				return result, err
+			}
+
+			summary := stats.Summary()
+			slackTime := time.Duration(summary.TotalLatencyMS) * time.Millisecond
+			resolutionTime := time.Duration(summary.ResolutionLatencyMS) * time.Millisecond
+			processingTime := duration - slackTime
+			if processingTime < 0 {
+				processingTime = 0
+			}
+			resultBytes := 0
+			if result != nil {
+				resultBytes = len(resultText(result))
+			}
+			logger.Printf("request_id=%s tool=%s status=slow duration=%s threshold=%s "+
+				"slack_time=%s resolution_time=%s processing_time=%s "+
+				"slack_calls=%d cache_hits=%d retries=%d rate_limited=%d result_bytes=%d",
+				requestid.FromContext(ctx), request.Params.Name, duration.Round(time.Millisecond), threshold,
+				slackTime-resolutionTime, resolutionTime, processingTime.Round(time.Millisecond),
+				summary.Calls, summary.CacheHits, summary.Retries, summary.RateLimited, resultBytes)
+
+			return result, err
+		}
+	}
+}
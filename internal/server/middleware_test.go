@@ -0,0 +1,66 @@
+// Package server provides tests for the tool handler middlewares.
+package server
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func TestConcurrencyLimitMiddleware(t *testing.T) {
+	const queueTimeout = 50 * time.Millisecond
+	release := make(chan struct{})
+	running := make(chan struct{}, 2)
+	handler := concurrencyLimitMiddleware(2, queueTimeout)(func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		running <- struct{}{}
+		<-release
+		return mcp.NewToolResultText("ok"), nil
+	})
+	request := newToolRequest("list_channels", nil)
+
+	// Fill both slots
+	var wg sync.WaitGroup
+	for i := 0; i < 2; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if result, err := handler(context.Background(), request); err != nil || result.IsError {
+				t.Errorf("expected the call holding a slot to succeed, got %v, %v", result, err)
+			}
+		}()
+	}
+	for i := 0; i < 2; i++ {
+		<-running
+	}
+
+	// A further call waits for the queue timeout, then fails
+	start := time.Now()
+	result, err := handler(context.Background(), request)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !result.IsError || !strings.Contains(resultText(result), "Server busy") {
+		t.Errorf("expected a server busy error, got %s", resultText(result))
+	}
+	if waited := time.Since(start); waited < queueTimeout {
+		t.Errorf("expected the call to wait %s for a slot, waited %s", queueTimeout, waited)
+	}
+
+	// A call whose context ends while it waits gives up
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if result, _ := handler(ctx, request); !result.IsError || !strings.Contains(resultText(result), "cancelled") {
+		t.Errorf("expected a cancelled error, got %s", resultText(result))
+	}
+
+	// Once a slot is free, calls run again
+	close(release)
+	wg.Wait()
+	if result, err := handler(context.Background(), request); err != nil || result.IsError {
+		t.Errorf("expected the call to run once a slot was free, got %v, %v", result, err)
+	}
+}
@@ -0,0 +1,210 @@
+// Package server provides the tool result cache for repeated identical tool calls.
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	slackclient "github.com/Bitovi/slack-mcp-server/internal/slack"
+)
+
+// resultCacheMaxEntries bounds the number of tool results held by the result cache.
+const resultCacheMaxEntries = 100
+
+// cacheableTools are the tools whose results may be served from the result cache.
+// Write tools are never cached, and neither is read_dm_history, whose every read
+// must reach the audit log. For the same reason, results of calls that read DMs in
+// act-as-user mode are not cached either (see resultCacheMiddleware).
+var cacheableTools = map[string]bool{
+	"read_message":            true,
+	"list_channel_messages":   true,
+	"search_messages":         true,
+	"check_channel_access":    true,
+	"channel_briefing":        true,
+	"get_channel_origin":      true,
+	"list_workspaces":         true,
+	"list_shared_links":       true,
+	"list_channels":           true,
+	"get_user_profile":        true,
+	"get_emoji_stats":         true,
+	"get_workspace_analytics": true,
+	"read_audit_logs":         true,
+}
+
+// freshnessArguments are the arguments that ask whether something changed since an
+// earlier read (read_message's thread polling). Calls that pass them are never
+// answered from the cache.
+var freshnessArguments = []string{"known_reply_count", "if_changed_since"}
+
+// cachedResult is a tool result and its expiry time.
+type cachedResult struct {
+	result  *mcp.CallToolResult
+	expires time.Time
+}
+
+// resultCache holds recent tool results keyed by tool name and normalized arguments.
+// It is safe for concurrent use.
+type resultCache struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	entries map[string]cachedResult
+	// cleared counts the calls to clear, so results of calls that started before
+	// the last one are not stored.
+	cleared uint64
+}
+
+// newResultCache creates a resultCache whose entries expire after ttl.
+func newResultCache(ttl time.Duration) *resultCache {
+	return &resultCache{ttl: ttl, entries: make(map[string]cachedResult)}
+}
+
+// get returns a copy of the cached result for key if present and not expired.
+func (c *resultCache) get(key string) (*mcp.CallToolResult, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	if time.Now().After(entry.expires) {
+		delete(c.entries, key)
+		return nil, false
+	}
+	return copyResult(entry.result), true
+}
+
+// generation returns the number of times the cache was cleared, to pass to set.
+func (c *resultCache) generation() uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.cleared
+}
+
+// set stores a copy of result under key, unless the cache was cleared since
+// generation was taken: the result may then predate a write. When the cache is
+// full, expired entries are pruned first and then arbitrary entries are evicted.
+func (c *resultCache) set(key string, result *mcp.CallToolResult, generation uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if generation != c.cleared {
+		return
+	}
+
+	now := time.Now()
+	if _, exists := c.entries[key]; !exists && len(c.entries) >= resultCacheMaxEntries {
+		for k, entry := range c.entries {
+			if now.After(entry.expires) {
+				delete(c.entries, k)
+			}
+		}
+		for k := range c.entries {
+			if len(c.entries) < resultCacheMaxEntries {
+				break
+			}
+			delete(c.entries, k)
+		}
+	}
+
+	c.entries[key] = cachedResult{result: copyResult(result), expires: now.Add(c.ttl)}
+}
+
+// clear removes every entry.
+func (c *resultCache) clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	clear(c.entries)
+	c.cleared++
+}
+
+// copyResult returns a copy of result that can be modified (e.g., by adding _meta
+// entries) without affecting the original.
+func copyResult(result *mcp.CallToolResult) *mcp.CallToolResult {
+	copied := *result
+	copied.Content = append([]mcp.Content(nil), result.Content...)
+	copied.Meta = nil
+	if result.Meta != nil {
+		copied.Meta = make(map[string]interface{}, len(result.Meta))
+		for k, v := range result.Meta {
+			copied.Meta[k] = v
+		}
+	}
+	return &copied
+}
+
+// resultCacheKey returns the cache key for a tool call: the tool name and its
+// arguments as JSON. Object keys are sorted when marshaled and arguments set to
+// null are dropped, so calls that differ only in argument order or in explicit
+// nulls share an entry.
+func resultCacheKey(request mcp.CallToolRequest) (string, bool) {
+	args := make(map[string]interface{}, len(request.Params.Arguments))
+	for name, value := range request.Params.Arguments {
+		if value != nil {
+			args[name] = value
+		}
+	}
+	data, err := json.Marshal(args)
+	if err != nil {
+		return "", false
+	}
+	return request.Params.Name + " " + string(data), true
+}
+
+// resultCacheMiddleware returns a middleware that serves repeated identical calls
+// to read-only tools from a cache of results less than ttl old, so an agent that
+// repeats a call in a loop does not cause more Slack API traffic. Only successful
+// results are cached, and not those of calls that read direct messages in act-as-user
+// mode, which are audited on every read. Results served from the cache are marked
+// with _meta.cached.
+//
+// The cache is cleared whenever another tool call succeeds, since it may have been a
+// write (e.g., post_message or add_reactions_bulk): an agent that posts and then
+// re-reads sees its own write rather than the result cached before it.
+func resultCacheMiddleware(ttl time.Duration) server.ToolHandlerMiddleware {
+	cache := newResultCache(ttl)
+
+	return func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			if !cacheableTools[request.Params.Name] {
+				result, err := next(ctx, request)
+				if err == nil && result != nil && !result.IsError {
+					cache.clear()
+				}
+				return result, err
+			}
+			for _, name := range freshnessArguments {
+				if request.Params.Arguments[name] != nil {
+					return next(ctx, request)
+				}
+			}
+			key, ok := resultCacheKey(request)
+			if !ok {
+				return next(ctx, request)
+			}
+
+			if result, ok := cache.get(key); ok {
+				if result.Meta == nil {
+					result.Meta = make(map[string]interface{})
+				}
+				result.Meta["cached"] = true
+				return result, nil
+			}
+
+			ctx, stats := slackclient.WithCallStats(ctx)
+			generation := cache.generation()
+			result, err := next(ctx, request)
+			if err == nil && result != nil && !result.IsError && !stats.ReadDMs() {
+				cache.set(key, result, generation)
+			}
+			return result, err
+		}
+	}
+}
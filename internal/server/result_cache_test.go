@@ -0,0 +1,257 @@
+// Package server provides tests for the tool result cache.
+package server
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	slackclient "github.com/Bitovi/slack-mcp-server/internal/slack"
+)
+
+// newToolRequest returns a call of the named tool with the given arguments.
+func newToolRequest(name string, args map[string]interface{}) mcp.CallToolRequest {
+	var request mcp.CallToolRequest
+	request.Params.Name = name
+	request.Params.Arguments = args
+	return request
+}
+
+// countingHandler returns a tool handler that counts its calls and returns a text
+// result naming the call, or an error result if the arguments set "fail".
+func countingHandler(calls *atomic.Int32) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		n := calls.Add(1)
+		if request.Params.Arguments["fail"] != nil {
+			return mcp.NewToolResultError("failed"), nil
+		}
+		return mcp.NewToolResultText(fmt.Sprintf("call %d", n)), nil
+	}
+}
+
+func TestResultCacheKey(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b mcp.CallToolRequest
+		same bool
+	}{
+		{
+			name: "argument order does not matter",
+			a:    newToolRequest("list_channel_messages", map[string]interface{}{"channel_id": "C01234567", "limit": float64(10)}),
+			b:    newToolRequest("list_channel_messages", map[string]interface{}{"limit": float64(10), "channel_id": "C01234567"}),
+			same: true,
+		},
+		{
+			name: "null arguments are dropped",
+			a:    newToolRequest("list_channel_messages", map[string]interface{}{"channel_id": "C01234567", "oldest": nil}),
+			b:    newToolRequest("list_channel_messages", map[string]interface{}{"channel_id": "C01234567"}),
+			same: true,
+		},
+		{
+			name: "argument values differ",
+			a:    newToolRequest("list_channel_messages", map[string]interface{}{"channel_id": "C01234567"}),
+			b:    newToolRequest("list_channel_messages", map[string]interface{}{"channel_id": "C07654321"}),
+		},
+		{
+			name: "tools differ",
+			a:    newToolRequest("list_channel_messages", map[string]interface{}{"channel_id": "C01234567"}),
+			b:    newToolRequest("get_emoji_stats", map[string]interface{}{"channel_id": "C01234567"}),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, okA := resultCacheKey(tt.a)
+			b, okB := resultCacheKey(tt.b)
+			if !okA || !okB {
+				t.Fatal("expected both keys to be built")
+			}
+			if (a == b) != tt.same {
+				t.Errorf("keys %q and %q: same = %v, want %v", a, b, a == b, tt.same)
+			}
+		})
+	}
+}
+
+func TestResultCacheMiddleware(t *testing.T) {
+	var calls atomic.Int32
+	handler := resultCacheMiddleware(time.Minute)(countingHandler(&calls))
+	request := newToolRequest("list_channel_messages", map[string]interface{}{"channel_id": "C01234567"})
+
+	first, err := handler(context.Background(), request)
+	if err != nil {
+		t.Fatalf("call failed: %v", err)
+	}
+	if first.Meta["cached"] != nil {
+		t.Error("expected the first result not to be marked cached")
+	}
+
+	second, err := handler(context.Background(), request)
+	if err != nil {
+		t.Fatalf("call failed: %v", err)
+	}
+	if calls.Load() != 1 {
+		t.Errorf("expected the repeated call to be served from the cache, got %d calls", calls.Load())
+	}
+	if second.Meta["cached"] != true {
+		t.Errorf("expected _meta.cached on the cached result, got %v", second.Meta)
+	}
+	if resultText(second) != "call 1" {
+		t.Errorf("expected the cached result, got %q", resultText(second))
+	}
+	if first.Meta["cached"] != nil {
+		t.Error("expected marking the cached result not to change the original")
+	}
+
+	// Error results and tools that are not cacheable are not cached
+	failing := newToolRequest("list_channel_messages", map[string]interface{}{"channel_id": "C01234567", "fail": true})
+	readDMs := newToolRequest("read_dm_history", map[string]interface{}{"user": "U01234567"})
+	for _, request := range []mcp.CallToolRequest{failing, failing, readDMs, readDMs} {
+		if _, err := handler(context.Background(), request); err != nil {
+			t.Fatalf("call failed: %v", err)
+		}
+	}
+	if calls.Load() != 5 {
+		t.Errorf("expected 5 calls, got %d", calls.Load())
+	}
+
+	// Neither are calls that ask whether a thread changed
+	poll := newToolRequest("read_message", map[string]interface{}{"url": "https://acme.slack.com/archives/C01234567/p1355517523000008",
+		"known_reply_count": float64(3)})
+	for i := 0; i < 2; i++ {
+		if _, err := handler(context.Background(), poll); err != nil {
+			t.Fatalf("call failed: %v", err)
+		}
+	}
+	if calls.Load() != 7 {
+		t.Errorf("expected thread polls to always run, got %d calls", calls.Load())
+	}
+}
+
+func TestResultCacheMiddleware_Expiry(t *testing.T) {
+	var calls atomic.Int32
+	handler := resultCacheMiddleware(20 * time.Millisecond)(countingHandler(&calls))
+	request := newToolRequest("list_channels", nil)
+
+	for i := 0; i < 2; i++ {
+		if _, err := handler(context.Background(), request); err != nil {
+			t.Fatalf("call failed: %v", err)
+		}
+	}
+	time.Sleep(30 * time.Millisecond)
+	result, err := handler(context.Background(), request)
+	if err != nil {
+		t.Fatalf("call failed: %v", err)
+	}
+	if calls.Load() != 2 || result.Meta["cached"] != nil {
+		t.Errorf("expected the expired result to be fetched again, got %d calls", calls.Load())
+	}
+}
+
+func TestResultCacheMiddleware_WriteClearsCache(t *testing.T) {
+	var calls atomic.Int32
+	handler := resultCacheMiddleware(time.Minute)(countingHandler(&calls))
+	read := newToolRequest("read_message", map[string]interface{}{"url": "https://acme.slack.com/archives/C01234567/p1355517523000008"})
+	post := newToolRequest("post_message", map[string]interface{}{"channel_id": "C01234567", "text": "hi"})
+
+	if _, err := handler(context.Background(), read); err != nil {
+		t.Fatalf("call failed: %v", err)
+	}
+	if _, err := handler(context.Background(), post); err != nil {
+		t.Fatalf("call failed: %v", err)
+	}
+	result, err := handler(context.Background(), read)
+	if err != nil {
+		t.Fatalf("call failed: %v", err)
+	}
+	if calls.Load() != 3 || result.Meta["cached"] != nil {
+		t.Errorf("expected the read after a write to reach the tool, got %d calls", calls.Load())
+	}
+
+	// A failed write changes nothing, so the cache is kept
+	failedPost := newToolRequest("post_message", map[string]interface{}{"fail": true})
+	if _, err := handler(context.Background(), failedPost); err != nil {
+		t.Fatalf("call failed: %v", err)
+	}
+	if result, _ := handler(context.Background(), read); result.Meta["cached"] != true {
+		t.Error("expected the read after a failed write to be served from the cache")
+	}
+}
+
+func TestResultCache_ClearedDuringCall(t *testing.T) {
+	cache := newResultCache(time.Minute)
+
+	// A result computed while the cache was cleared may predate the write
+	generation := cache.generation()
+	cache.clear()
+	cache.set("key", mcp.NewToolResultText("stale"), generation)
+	if _, ok := cache.get("key"); ok {
+		t.Error("expected a result from before the clear not to be stored")
+	}
+
+	cache.set("key", mcp.NewToolResultText("fresh"), cache.generation())
+	if _, ok := cache.get("key"); !ok {
+		t.Error("expected a result from after the clear to be stored")
+	}
+}
+
+func TestResultCache_Eviction(t *testing.T) {
+	cache := newResultCache(time.Minute)
+	for i := 0; i < resultCacheMaxEntries+10; i++ {
+		cache.set(fmt.Sprintf("key %d", i), mcp.NewToolResultText("result"), cache.generation())
+	}
+	if len(cache.entries) != resultCacheMaxEntries {
+		t.Errorf("expected the cache to hold %d entries, got %d", resultCacheMaxEntries, len(cache.entries))
+	}
+	if _, ok := cache.get(fmt.Sprintf("key %d", resultCacheMaxEntries+9)); !ok {
+		t.Error("expected the newest entry to be kept")
+	}
+}
+
+func TestResultCacheMiddleware_DMReads(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"ok":true,"messages":[{"type":"message","user":"U01234567","text":"hi","ts":"1355517523.000008"}]}`))
+	}))
+	defer srv.Close()
+	client := slackclient.NewClient("xoxb-test", "xoxp-test", slackclient.WithAPIURL(srv.URL+"/"),
+		slackclient.WithActAsUser(), slackclient.WithDMReadAudit(func(context.Context, string, string, error) {}))
+
+	var calls atomic.Int32
+	handler := resultCacheMiddleware(time.Minute)(func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		calls.Add(1)
+		channelID := request.Params.Arguments["channel_id"].(string)
+		if _, _, err := client.GetChannelHistory(ctx, channelID, slackclient.HistoryOptions{Limit: 1}); err != nil {
+			return nil, err
+		}
+		return mcp.NewToolResultText("ok"), nil
+	})
+
+	// Reads of a DM are never cached, so each one is audited
+	for i := 0; i < 2; i++ {
+		request := newToolRequest("list_channel_messages", map[string]interface{}{"channel_id": "D01234567"})
+		if _, err := handler(context.Background(), request); err != nil {
+			t.Fatalf("call failed: %v", err)
+		}
+	}
+	if calls.Load() != 2 {
+		t.Errorf("expected both DM reads to run, got %d", calls.Load())
+	}
+
+	// Reads of a channel are
+	for i := 0; i < 2; i++ {
+		request := newToolRequest("list_channel_messages", map[string]interface{}{"channel_id": "C01234567"})
+		if _, err := handler(context.Background(), request); err != nil {
+			t.Fatalf("call failed: %v", err)
+		}
+	}
+	if calls.Load() != 3 {
+		t.Errorf("expected the repeated channel read to be cached, got %d calls", calls.Load())
+	}
+}
@@ -3,12 +3,20 @@
 package server
 
 import (
+	"context"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
 
+	"github.com/Bitovi/slack-mcp-server/internal/digest"
+	"github.com/Bitovi/slack-mcp-server/internal/references"
+	"github.com/Bitovi/slack-mcp-server/internal/requestid"
+	"github.com/Bitovi/slack-mcp-server/internal/secrets"
 	slackclient "github.com/Bitovi/slack-mcp-server/internal/slack"
+	"github.com/Bitovi/slack-mcp-server/internal/templates"
 	"github.com/Bitovi/slack-mcp-server/internal/tools"
 )
 
@@ -17,6 +25,20 @@ const (
 	ServerName = "slack-mcp"
 	// ServerVersion is the version of the MCP server.
 	ServerVersion = "1.0.0"
+	// DefaultMessageCacheTTL is the default TTL for cached messages and threads.
+	DefaultMessageCacheTTL = 60 * time.Second
+	// DefaultUserCacheTTL is the default age after which cached users are
+	// refreshed in the background.
+	DefaultUserCacheTTL = time.Hour
+	// DefaultToolCallQueueTimeout is the default time a tool call waits for a
+	// free slot when the concurrency limit is reached.
+	DefaultToolCallQueueTimeout = 30 * time.Second
+	// DefaultSessionIdleTimeout is the default time a socket session may go without
+	// requests before it is closed.
+	DefaultSessionIdleTimeout = 30 * time.Minute
+	// DefaultSessionPingInterval is the default interval between keepalive pings on
+	// socket sessions.
+	DefaultSessionPingInterval = 30 * time.Second
 )
 
 // Server represents the Slack MCP server.
@@ -32,6 +54,74 @@ type Server struct {
 	listChannelMessagesHandler *tools.ListChannelMessagesHandler
 	// searchMessagesHandler handles the search_messages tool.
 	searchMessagesHandler *tools.SearchMessagesHandler
+	// buildMessageURLHandler handles the build_message_url tool.
+	buildMessageURLHandler *tools.BuildMessageURLHandler
+	// composeBlocksHandler handles the compose_blocks tool.
+	composeBlocksHandler *tools.ComposeBlocksHandler
+	// checkChannelAccessHandler handles the check_channel_access tool.
+	checkChannelAccessHandler *tools.CheckChannelAccessHandler
+	// channelBriefingHandler handles the channel_briefing tool.
+	channelBriefingHandler *tools.ChannelBriefingHandler
+	// getChannelOriginHandler handles the get_channel_origin tool.
+	getChannelOriginHandler *tools.GetChannelOriginHandler
+	// listWorkspacesHandler handles the list_workspaces tool.
+	listWorkspacesHandler *tools.ListWorkspacesHandler
+	// listSharedLinksHandler handles the list_shared_links tool.
+	listSharedLinksHandler *tools.ListSharedLinksHandler
+	// listChannelsHandler handles the list_channels tool.
+	listChannelsHandler *tools.ListChannelsHandler
+	// getUserProfileHandler handles the get_user_profile tool.
+	getUserProfileHandler *tools.GetUserProfileHandler
+	// getEmojiStatsHandler handles the get_emoji_stats tool.
+	getEmojiStatsHandler *tools.GetEmojiStatsHandler
+	// getWorkspaceAnalyticsHandler handles the get_workspace_analytics tool, nil unless an
+	// admin token is configured.
+	getWorkspaceAnalyticsHandler *tools.GetWorkspaceAnalyticsHandler
+	// readAuditLogsHandler handles the read_audit_logs tool, nil unless an audit token is configured.
+	readAuditLogsHandler *tools.ReadAuditLogsHandler
+	// readDMHistoryHandler handles the read_dm_history tool, nil unless DM reads are allowed.
+	readDMHistoryHandler *tools.ReadDMHistoryHandler
+	// postMessageHandler handles the post_message tool, nil unless write tools are enabled.
+	postMessageHandler *tools.PostMessageHandler
+	// replyInThreadHandler handles the reply_in_thread tool, nil unless write tools are enabled.
+	replyInThreadHandler *tools.ReplyInThreadHandler
+	// postEphemeralHandler handles the post_ephemeral tool, nil unless write tools are enabled.
+	postEphemeralHandler *tools.PostEphemeralHandler
+	// postSnippetHandler handles the post_snippet tool, nil unless write tools are enabled.
+	postSnippetHandler *tools.PostSnippetHandler
+	// updateUserGroupMembersHandler handles the update_usergroup_members tool, nil unless
+	// write tools are enabled.
+	updateUserGroupMembersHandler *tools.UpdateUserGroupMembersHandler
+	// addReactionsBulkHandler handles the add_reactions_bulk tool, nil unless write tools are enabled.
+	addReactionsBulkHandler *tools.AddReactionsBulkHandler
+	// postFromTemplateHandler handles the post_from_template tool, nil unless write tools
+	// are enabled and templates are configured.
+	postFromTemplateHandler *tools.PostFromTemplateHandler
+	// templates is the message template library, nil if no templates file is configured.
+	templates *templates.Library
+	// botTokenProvider re-fetches the bot token while the server runs, nil if the token is static.
+	botTokenProvider secrets.Provider
+	// botTokenRefreshInterval is how often botTokenProvider is polled.
+	botTokenRefreshInterval time.Duration
+	// validateBotToken checks re-fetched bot tokens before they are used, nil to accept any token.
+	validateBotToken func(token string) error
+	// botToken is the bot token the server was created with.
+	botToken string
+	// reportAccessOnStartup logs the channel access report when Run starts.
+	reportAccessOnStartup bool
+	// prefetchUsersOnStartup loads the user directory into the user cache when Run starts.
+	prefetchUsersOnStartup bool
+	// digestRunner posts scheduled digests while the server runs, nil unless write
+	// tools are enabled and digests are configured.
+	digestRunner *digest.Runner
+	// sessionIdleTimeout closes idle socket and HTTP sessions, zero if disabled.
+	sessionIdleTimeout time.Duration
+	// sessionPingInterval is how often socket sessions are pinged and SSE streams
+	// kept alive, zero if disabled.
+	sessionPingInterval time.Duration
+	// allowedHosts are Host header names accepted by the HTTP and SSE transports when
+	// they listen on a loopback address, besides localhost (see guardRequests).
+	allowedHosts []string
 }
 
 // Config holds the configuration for creating a new Server.
@@ -43,6 +133,113 @@ type Config struct {
 	// Optional. Required for the search_messages tool (uses search:read scope).
 	// If not provided, search_messages will return an error when called.
 	SlackUserToken string
+	// SlackAdminToken is an Enterprise Grid org admin token for admin.* API methods.
+	// Optional. The get_workspace_analytics tool is only registered if it is set.
+	SlackAdminToken string
+	// SlackAuditToken is an Enterprise Grid org owner token for the Audit Logs API.
+	// Optional. The read_audit_logs tool is only registered if it is set.
+	SlackAuditToken string
+	// SlackAPIURL is the Slack Web API base URL (e.g., slackclient.GovSlackAPIURL for GovSlack).
+	// Optional. If empty, commercial Slack (https://slack.com/api/) is used.
+	SlackAPIURL string
+	// SessionCookie is the browser "d" cookie sent with browser session tokens (xoxc-).
+	// Optional. Only set in session token compatibility mode.
+	SessionCookie string
+	// UserAgent is a custom User-Agent header sent on every Slack API request.
+	// Optional. If empty, the Slack library default is used.
+	UserAgent string
+	// MessageCacheTTL is how long fetched messages and threads are cached.
+	// Optional. Zero disables caching.
+	MessageCacheTTL time.Duration
+	// UserPrefetch selects when the workspace user directory is loaded into the user
+	// cache with users.list. Optional. Defaults to UserPrefetchOff.
+	UserPrefetch UserPrefetchMode
+	// UserCacheTTL is how long cached users are considered fresh. Stale users are
+	// still served, and refreshed in the background.
+	// Optional. Zero caches users for the lifetime of the server.
+	UserCacheTTL time.Duration
+	// MaxRetries is the number of times a Slack API request rejected by rate
+	// limiting is retried. Zero disables retries.
+	MaxRetries int
+	// MaxRetryWait is the longest Retry-After delay waited before a retry; requests
+	// that would wait longer fail with a rate_limited error.
+	MaxRetryWait time.Duration
+	// ResultCacheTTL is how long the results of read-only tools are reused for
+	// identical calls (same tool and arguments).
+	// Optional. Zero disables the result cache.
+	ResultCacheTTL time.Duration
+	// MaxConcurrentToolCalls caps the number of tool calls that execute at once.
+	// Optional. Zero means unlimited.
+	MaxConcurrentToolCalls int
+	// ToolCallQueueTimeout is how long a tool call waits for a free slot when
+	// MaxConcurrentToolCalls is reached before it is rejected.
+	// Optional. Defaults to DefaultToolCallQueueTimeout.
+	ToolCallQueueTimeout time.Duration
+	// OnResolutionError controls how tool results are reported when user resolution fails:
+	// ignore (omit the user fields), warn (add a warnings entry), or fail (reject the call).
+	// Optional. Defaults to tools.ResolutionErrorIgnore.
+	OnResolutionError tools.ResolutionErrorPolicy
+	// BotTokenProvider is the secret store SlackToken was fetched from. If set together
+	// with BotTokenRefreshInterval, the token is re-fetched periodically while the server
+	// runs and rotated tokens are used for subsequent Slack API calls.
+	// Optional. Nil means SlackToken is static.
+	BotTokenProvider secrets.Provider
+	// BotTokenRefreshInterval is how often BotTokenProvider is polled.
+	// Optional. Zero disables refreshing.
+	BotTokenRefreshInterval time.Duration
+	// ValidateBotToken checks a token re-fetched from BotTokenProvider before it is
+	// used. A token that fails the check is logged and the current token is kept.
+	// Optional. Nil accepts any token.
+	ValidateBotToken func(token string) error
+	// RetentionWindow is how much message history the workspace keeps, used to warn
+	// when a list_channel_messages window is empty because of retention limits.
+	// Optional. Zero disables the warning.
+	RetentionWindow time.Duration
+	// EnableWriteTools registers the tools that post to Slack (e.g., post_message).
+	// Optional. Defaults to false, which keeps the server read-only.
+	EnableWriteTools bool
+	// AllowDMRead registers read_dm_history, which reads the user token's direct messages
+	// and records each read in the audit log. Optional. Defaults to false.
+	AllowDMRead bool
+	// ActAsUser makes the read tools use SlackUserToken instead of SlackToken, so they
+	// see every channel the user can see, and the current user is that person. Reads of
+	// the user's DMs are refused unless AllowDMRead is set, and then audited. Write
+	// tools still post as the bot. Requires SlackUserToken. Optional. Defaults to false.
+	ActAsUser bool
+	// Templates is the message template library for post_from_template.
+	// Optional. The tool is only registered if it is set and EnableWriteTools is true.
+	Templates *templates.Library
+	// ReferenceTrackers are the patterns used by the extract_references argument of the read tools.
+	// Optional. Defaults to Jira issue keys and GitHub issues and pull requests.
+	ReferenceTrackers []references.Tracker
+	// Digests are scheduled digest jobs, run in the background while the server runs.
+	// Optional. Digests only run if EnableWriteTools is true, since they post messages.
+	Digests *digest.Config
+	// ReportAccessOnStartup logs a summary of the channels the bot is a member of
+	// when the server starts (see ReportAccess).
+	// Optional. Defaults to false.
+	ReportAccessOnStartup bool
+	// Debug enables debug mode, which attaches a summary of the Slack API calls
+	// made by each tool call to its result as _meta.api_calls.
+	Debug bool
+	// SlowCallThresholds maps tool names to the duration after which a call is logged
+	// as slow, with a breakdown of its time (see ParseSlowCallThresholds). The key
+	// DefaultSlowCallThreshold applies to tools not listed.
+	// Optional. Nil disables slow-call warnings.
+	SlowCallThresholds map[string]time.Duration
+	// SessionIdleTimeout closes socket and HTTP sessions that send no requests for this long.
+	// Optional. Zero disables the timeout. Not used for stdio.
+	SessionIdleTimeout time.Duration
+	// SessionPingInterval is how often socket sessions are sent an MCP ping; a
+	// session whose ping is unanswered by the next one is closed. SSE streams are
+	// sent a keepalive comment at this interval instead.
+	// Optional. Zero disables pings. Not used for stdio or Streamable HTTP.
+	SessionPingInterval time.Duration
+	// AllowedHosts are the Host header names, besides localhost, 127.0.0.1, and ::1,
+	// that the HTTP and SSE transports accept when listening on a loopback address
+	// (e.g., a name in /etc/hosts). Other hosts are refused to block DNS rebinding.
+	// Optional. Not used when listening on other addresses.
+	AllowedHosts []string
 }
 
 // New creates a new Slack MCP server with the provided configuration.
@@ -57,25 +254,100 @@ func New(cfg Config) (*Server, error) {
 	if cfg.SlackToken == "" {
 		return nil, fmt.Errorf("SLACK_BOT_TOKEN is required")
 	}
+	if cfg.ActAsUser && cfg.SlackUserToken == "" {
+		return nil, fmt.Errorf("act-as-user mode requires SLACK_USER_TOKEN")
+	}
 
 	// Create the Slack client with both bot token and optional user token
-	slackClient := slackclient.NewClient(cfg.SlackToken, cfg.SlackUserToken)
+	var clientOpts []slackclient.ClientOption
+	if cfg.UserAgent != "" {
+		clientOpts = append(clientOpts, slackclient.WithUserAgent(cfg.UserAgent))
+	}
+	if cfg.MessageCacheTTL > 0 {
+		clientOpts = append(clientOpts, slackclient.WithMessageCacheTTL(cfg.MessageCacheTTL))
+	}
+	if cfg.UserCacheTTL > 0 {
+		clientOpts = append(clientOpts, slackclient.WithUserCacheTTL(cfg.UserCacheTTL))
+	}
+	if cfg.UserPrefetch == UserPrefetchLazy {
+		clientOpts = append(clientOpts, slackclient.WithLazyUserPrefetch())
+	}
+	clientOpts = append(clientOpts, slackclient.WithRetries(cfg.MaxRetries, cfg.MaxRetryWait))
+	if cfg.SlackAPIURL != "" {
+		clientOpts = append(clientOpts, slackclient.WithAPIURL(cfg.SlackAPIURL))
+	}
+	if cfg.SessionCookie != "" {
+		clientOpts = append(clientOpts, slackclient.WithSessionCookie(cfg.SessionCookie))
+	}
+	if cfg.SlackAdminToken != "" {
+		clientOpts = append(clientOpts, slackclient.WithAdminToken(cfg.SlackAdminToken))
+	}
+	if cfg.SlackAuditToken != "" {
+		clientOpts = append(clientOpts, slackclient.WithAuditToken(cfg.SlackAuditToken))
+	}
+	if cfg.ActAsUser {
+		// Reads see everything the user can: make it obvious in the log which identity is used
+		logger.Printf("act-as-user mode: read tools use SLACK_USER_TOKEN and see every channel " +
+			"its user can see; write tools still post as the bot")
+		clientOpts = append(clientOpts, slackclient.WithActAsUser())
+		if cfg.AllowDMRead {
+			// DM reads through the channel read tools are audited like read_dm_history
+			clientOpts = append(clientOpts, slackclient.WithDMReadAudit(auditDMRead))
+		}
+	}
+	slackClient := slackclient.NewClient(cfg.SlackToken, cfg.SlackUserToken, clientOpts...)
 
 	// Create the MCP server with tool capabilities enabled
-	mcpServer := server.NewMCPServer(
-		ServerName,
-		ServerVersion,
-		server.WithToolCapabilities(true),
-	)
+	mcpServer := newMCPServer(cfg)
+
+	// Options shared by all tool handlers
+	var handlerOpts []tools.HandlerOption
+	if cfg.OnResolutionError != "" {
+		handlerOpts = append(handlerOpts, tools.WithResolutionErrorPolicy(cfg.OnResolutionError))
+	}
+	handlerOpts = append(handlerOpts, tools.WithRetentionWindow(cfg.RetentionWindow))
+	if len(cfg.ReferenceTrackers) > 0 {
+		handlerOpts = append(handlerOpts, tools.WithReferenceTrackers(cfg.ReferenceTrackers))
+	}
 
 	// Create the read_message handler
-	readMessageHandler := tools.NewReadMessageHandler(slackClient)
+	readMessageHandler := tools.NewReadMessageHandler(slackClient, handlerOpts...)
 
 	// Create the list_channel_messages handler
-	listChannelMessagesHandler := tools.NewListChannelMessagesHandler(slackClient)
+	listChannelMessagesHandler := tools.NewListChannelMessagesHandler(slackClient, handlerOpts...)
 
 	// Create the search_messages handler
-	searchMessagesHandler := tools.NewSearchMessagesHandler(slackClient)
+	searchMessagesHandler := tools.NewSearchMessagesHandler(slackClient, handlerOpts...)
+
+	// Create the build_message_url handler
+	buildMessageURLHandler := tools.NewBuildMessageURLHandler(slackClient, handlerOpts...)
+
+	// Create the compose_blocks handler
+	composeBlocksHandler := tools.NewComposeBlocksHandler()
+
+	// Create the check_channel_access handler
+	checkChannelAccessHandler := tools.NewCheckChannelAccessHandler(slackClient, handlerOpts...)
+
+	// Create the channel_briefing handler
+	channelBriefingHandler := tools.NewChannelBriefingHandler(slackClient, handlerOpts...)
+
+	// Create the get_channel_origin handler
+	getChannelOriginHandler := tools.NewGetChannelOriginHandler(slackClient, handlerOpts...)
+
+	// Create the list_workspaces handler
+	listWorkspacesHandler := tools.NewListWorkspacesHandler(slackClient, handlerOpts...)
+
+	// Create the list_shared_links handler
+	listSharedLinksHandler := tools.NewListSharedLinksHandler(slackClient, handlerOpts...)
+
+	// Create the list_channels handler
+	listChannelsHandler := tools.NewListChannelsHandler(slackClient, handlerOpts...)
+
+	// Create the get_user_profile handler
+	getUserProfileHandler := tools.NewGetUserProfileHandler(slackClient, handlerOpts...)
+
+	// Create the get_emoji_stats handler
+	getEmojiStatsHandler := tools.NewGetEmojiStatsHandler(slackClient, handlerOpts...)
 
 	s := &Server{
 		mcpServer:                  mcpServer,
@@ -83,6 +355,54 @@ func New(cfg Config) (*Server, error) {
 		readMessageHandler:         readMessageHandler,
 		listChannelMessagesHandler: listChannelMessagesHandler,
 		searchMessagesHandler:      searchMessagesHandler,
+		buildMessageURLHandler:     buildMessageURLHandler,
+		composeBlocksHandler:       composeBlocksHandler,
+		checkChannelAccessHandler:  checkChannelAccessHandler,
+		channelBriefingHandler:     channelBriefingHandler,
+		getChannelOriginHandler:    getChannelOriginHandler,
+		listWorkspacesHandler:      listWorkspacesHandler,
+		listSharedLinksHandler:     listSharedLinksHandler,
+		listChannelsHandler:        listChannelsHandler,
+		getUserProfileHandler:      getUserProfileHandler,
+		getEmojiStatsHandler:       getEmojiStatsHandler,
+		botToken:                   cfg.SlackToken,
+		reportAccessOnStartup:      cfg.ReportAccessOnStartup,
+		prefetchUsersOnStartup:     cfg.UserPrefetch == UserPrefetchStartup,
+		sessionIdleTimeout:         cfg.SessionIdleTimeout,
+		sessionPingInterval:        cfg.SessionPingInterval,
+		allowedHosts:               cfg.AllowedHosts,
+	}
+	if cfg.SlackAdminToken != "" {
+		s.getWorkspaceAnalyticsHandler = tools.NewGetWorkspaceAnalyticsHandler(slackClient, handlerOpts...)
+	}
+	if cfg.SlackAuditToken != "" {
+		s.readAuditLogsHandler = tools.NewReadAuditLogsHandler(slackClient, handlerOpts...)
+	}
+	if cfg.AllowDMRead {
+		// DM reads are always audited; the handler refuses to read without an audit log
+		s.readDMHistoryHandler = tools.NewReadDMHistoryHandler(slackClient,
+			append(handlerOpts, tools.WithAuditLog(logger))...)
+	}
+	if cfg.EnableWriteTools {
+		s.postMessageHandler = tools.NewPostMessageHandler(slackClient, handlerOpts...)
+		s.replyInThreadHandler = tools.NewReplyInThreadHandler(slackClient, handlerOpts...)
+		s.postEphemeralHandler = tools.NewPostEphemeralHandler(slackClient, handlerOpts...)
+		s.postSnippetHandler = tools.NewPostSnippetHandler(slackClient, handlerOpts...)
+		s.updateUserGroupMembersHandler = tools.NewUpdateUserGroupMembersHandler(slackClient,
+			append(handlerOpts, tools.WithAuditLog(logger))...)
+		s.addReactionsBulkHandler = tools.NewAddReactionsBulkHandler(slackClient, handlerOpts...)
+		if cfg.Templates != nil {
+			s.templates = cfg.Templates
+			s.postFromTemplateHandler = tools.NewPostFromTemplateHandler(slackClient, cfg.Templates, handlerOpts...)
+		}
+		if cfg.Digests != nil {
+			s.digestRunner = digest.NewRunner(slackClient, cfg.Digests, logger)
+		}
+	}
+	if cfg.BotTokenProvider != nil && cfg.BotTokenRefreshInterval > 0 {
+		s.botTokenProvider = cfg.BotTokenProvider
+		s.botTokenRefreshInterval = cfg.BotTokenRefreshInterval
+		s.validateBotToken = cfg.ValidateBotToken
 	}
 
 	// Register tools
@@ -91,6 +411,18 @@ func New(cfg Config) (*Server, error) {
 	return s, nil
 }
 
+// auditDMRead records a read of a direct message made in act-as-user mode in the
+// audit log, as read_dm_history does for its reads. Only which conversation was
+// read is recorded, never message content.
+func auditDMRead(ctx context.Context, method, channelID string, err error) {
+	status := "ok"
+	if err != nil {
+		status = fmt.Sprintf("failed error=%q", err.Error())
+	}
+	logger.Printf("audit request_id=%s method=%s channel=%s status=%s",
+		requestid.FromContext(ctx), method, channelID, status)
+}
+
 // NewWithClient creates a new Slack MCP server with a custom Slack client.
 // This is primarily useful for testing with mock clients.
 //
@@ -100,11 +432,7 @@ func New(cfg Config) (*Server, error) {
 // Returns a new Server instance.
 func NewWithClient(client slackclient.ClientInterface) *Server {
 	// Create the MCP server with tool capabilities enabled
-	mcpServer := server.NewMCPServer(
-		ServerName,
-		ServerVersion,
-		server.WithToolCapabilities(true),
-	)
+	mcpServer := newMCPServer(Config{})
 
 	// Create the read_message handler
 	readMessageHandler := tools.NewReadMessageHandler(client)
@@ -115,12 +443,52 @@ func NewWithClient(client slackclient.ClientInterface) *Server {
 	// Create the search_messages handler
 	searchMessagesHandler := tools.NewSearchMessagesHandler(client)
 
+	// Create the build_message_url handler
+	buildMessageURLHandler := tools.NewBuildMessageURLHandler(client)
+
+	// Create the compose_blocks handler
+	composeBlocksHandler := tools.NewComposeBlocksHandler()
+
+	// Create the check_channel_access handler
+	checkChannelAccessHandler := tools.NewCheckChannelAccessHandler(client)
+
+	// Create the channel_briefing handler
+	channelBriefingHandler := tools.NewChannelBriefingHandler(client)
+
+	// Create the get_channel_origin handler
+	getChannelOriginHandler := tools.NewGetChannelOriginHandler(client)
+
+	// Create the list_workspaces handler
+	listWorkspacesHandler := tools.NewListWorkspacesHandler(client)
+
+	// Create the list_shared_links handler
+	listSharedLinksHandler := tools.NewListSharedLinksHandler(client)
+
+	// Create the list_channels handler
+	listChannelsHandler := tools.NewListChannelsHandler(client)
+
+	// Create the get_user_profile handler
+	getUserProfileHandler := tools.NewGetUserProfileHandler(client)
+
+	// Create the get_emoji_stats handler
+	getEmojiStatsHandler := tools.NewGetEmojiStatsHandler(client)
+
 	s := &Server{
 		mcpServer:                  mcpServer,
 		slackClient:                client,
 		readMessageHandler:         readMessageHandler,
 		listChannelMessagesHandler: listChannelMessagesHandler,
 		searchMessagesHandler:      searchMessagesHandler,
+		buildMessageURLHandler:     buildMessageURLHandler,
+		composeBlocksHandler:       composeBlocksHandler,
+		checkChannelAccessHandler:  checkChannelAccessHandler,
+		channelBriefingHandler:     channelBriefingHandler,
+		getChannelOriginHandler:    getChannelOriginHandler,
+		listWorkspacesHandler:      listWorkspacesHandler,
+		listSharedLinksHandler:     listSharedLinksHandler,
+		listChannelsHandler:        listChannelsHandler,
+		getUserProfileHandler:      getUserProfileHandler,
+		getEmojiStatsHandler:       getEmojiStatsHandler,
 	}
 
 	// Register tools
@@ -129,6 +497,36 @@ func NewWithClient(client slackclient.ClientInterface) *Server {
 	return s
 }
 
+// newMCPServer creates the underlying MCP server with tool capabilities enabled
+// and the tool handler middlewares selected by the configuration.
+// Middlewares run in the order they are added, so the request ID is assigned first.
+func newMCPServer(cfg Config) *server.MCPServer {
+	opts := []server.ServerOption{
+		server.WithToolCapabilities(true),
+		server.WithToolHandlerMiddleware(requestIDMiddleware),
+	}
+	if cfg.MaxConcurrentToolCalls > 0 {
+		queueTimeout := cfg.ToolCallQueueTimeout
+		if queueTimeout <= 0 {
+			queueTimeout = DefaultToolCallQueueTimeout
+		}
+		opts = append(opts, server.WithToolHandlerMiddleware(
+			concurrencyLimitMiddleware(cfg.MaxConcurrentToolCalls, queueTimeout)))
+	}
+	if cfg.Debug {
+		opts = append(opts, server.WithToolHandlerMiddleware(apiCallStatsMiddleware))
+	}
+	if len(cfg.SlowCallThresholds) > 0 {
+		opts = append(opts, server.WithToolHandlerMiddleware(slowCallMiddleware(cfg.SlowCallThresholds)))
+	}
+	// Innermost, so a cached result is reported like a call that made no API requests
+	if cfg.ResultCacheTTL > 0 {
+		opts = append(opts, server.WithToolHandlerMiddleware(resultCacheMiddleware(cfg.ResultCacheTTL)))
+	}
+
+	return server.NewMCPServer(ServerName, ServerVersion, opts...)
+}
+
 // registerTools registers all MCP tools with the server.
 // This method is called during server initialization.
 func (s *Server) registerTools() {
@@ -142,6 +540,39 @@ func (s *Server) registerTools() {
 			mcp.Description("Slack message or thread URL to read. "+
 				"Format: https://workspace.slack.com/archives/{channel_id}/p{timestamp}"),
 		),
+		mcp.WithBoolean("include_archived",
+			mcp.Description("If the channel is archived and the bot is not a member, read it with the user token "+
+				"(SLACK_USER_TOKEN) instead of failing (default: false)"),
+		),
+		mcp.WithBoolean("detect_language",
+			mcp.Description("Tag each message with a detected ISO 639-1 language code in a 'lang' field (default: false)"),
+		),
+		mcp.WithBoolean("extract_references",
+			mcp.Description("List the issue, pull request, and ticket references (e.g., Jira keys, GitHub issues) "+
+				"found in each message's text in a 'references' field (default: false)"),
+		),
+		mcp.WithBoolean("include_raw",
+			mcp.Description("Attach each message's Slack API message object, before conversion, in a 'raw' field, "+
+				"to diagnose fields the server does not return (default: false)"),
+		),
+		mcp.WithNumber("max_tokens_estimate",
+			mcp.Description("Approximate token budget for the result. Long threads are trimmed to fit, keeping the parent, "+
+				"the first and latest replies, and the most-reacted and latest replies; the timestamps of elided replies "+
+				"are listed in 'elided_replies' (default: no budget)"),
+		),
+		mcp.WithNumber("known_reply_count",
+			mcp.Description("The thread's reply_count from an earlier read. If the thread has no more replies now, "+
+				"only {\"unchanged\": true, ...} is returned instead of the messages"),
+		),
+		mcp.WithString("if_changed_since",
+			mcp.Description("The thread's latest_reply timestamp from an earlier read. If no reply is newer, "+
+				"only {\"unchanged\": true, ...} is returned instead of the messages"),
+		),
+		mcp.WithArray("fields",
+			mcp.Description("Only return these fields on each message (e.g., [\"user_name\", \"text\", \"timestamp\"]) "+
+				"to reduce result size (default: all fields)"),
+			mcp.Items(map[string]interface{}{"type": "string"}),
+		),
 	)
 
 	// Register the tool with the ReadMessageHandler
@@ -153,16 +584,59 @@ func (s *Server) registerTools() {
 			"Returns messages in reverse chronological order (newest first)."),
 		mcp.WithString("channel_id",
 			mcp.Required(),
-			mcp.Description("The Slack channel ID (e.g., 'C01234567')"),
+			mcp.Description("The Slack channel ID (e.g., 'C01234567') or name (e.g., '#general'). "+
+				"For direct messages with a user, use read_dm_history"),
 		),
 		mcp.WithNumber("limit",
 			mcp.Description("Number of messages to retrieve (default: 100, max: 200)"),
 		),
 		mcp.WithString("oldest",
-			mcp.Description("Only messages after this Unix timestamp (inclusive)"),
+			mcp.Description("Only messages after this Unix timestamp"),
 		),
 		mcp.WithString("latest",
-			mcp.Description("Only messages before this Unix timestamp (inclusive)"),
+			mcp.Description("Only messages before this Unix timestamp"),
+		),
+		mcp.WithBoolean("inclusive",
+			mcp.Description("Include messages exactly at the oldest/latest timestamps (default: false). "+
+				"Leave false when paginating with latest set to the previous page's oldest timestamp "+
+				"so the boundary message is not returned twice."),
+		),
+		mcp.WithBoolean("count_only",
+			mcp.Description("Return only the number of messages in the oldest/latest window instead of "+
+				"the messages themselves (default: false). Counts stop at 10000."),
+		),
+		mcp.WithBoolean("include_archived",
+			mcp.Description("If the channel is archived and the bot is not a member, read it with the user token "+
+				"(SLACK_USER_TOKEN) instead of failing (default: false)"),
+		),
+		mcp.WithBoolean("detect_language",
+			mcp.Description("Tag each message with a detected ISO 639-1 language code in a 'lang' field (default: false)"),
+		),
+		mcp.WithBoolean("extract_references",
+			mcp.Description("List the issue, pull request, and ticket references (e.g., Jira keys, GitHub issues) "+
+				"found in each message's text in a 'references' field (default: false)"),
+		),
+		mcp.WithBoolean("include_raw",
+			mcp.Description("Attach each message's Slack API message object, before conversion, in a 'raw' field, "+
+				"to diagnose fields the server does not return (default: false)"),
+		),
+		mcp.WithBoolean("summary",
+			mcp.Description("Return one line per message (time, author, first 120 characters, reply and "+
+				"reaction counts, timestamp) instead of full message objects, for a quick first scan (default: false)"),
+		),
+		mcp.WithBoolean("score",
+			mcp.Description("Attach an importance score to each message in a 'score' field, from its reactions, "+
+				"replies, mentions of the current user, length, and author role (default: false)"),
+		),
+		mcp.WithNumber("min_score",
+			mcp.Description("Only return messages with an importance score of at least this value, to pull only "+
+				"high-signal messages; implies score. Filtering happens after the limit is applied, so fewer "+
+				"than limit messages may be returned"),
+		),
+		mcp.WithArray("fields",
+			mcp.Description("Only return these fields on each message (e.g., [\"user_name\", \"text\", \"timestamp\"]) "+
+				"to reduce result size (default: all fields)"),
+			mcp.Items(map[string]interface{}{"type": "string"}),
 		),
 	)
 
@@ -183,18 +657,592 @@ func (s *Server) registerTools() {
 		mcp.WithString("sort",
 			mcp.Description("Sort order: 'score' (relevance) or 'timestamp' (default: score)"),
 		),
+		mcp.WithBoolean("count_only",
+			mcp.Description("Return only the total number of matching messages instead of the matches (default: false)"),
+		),
+		mcp.WithBoolean("expand_threads",
+			mcp.Description("For matches that are thread replies, include the thread parent message "+
+				"and its reply count (default: false)"),
+		),
+		mcp.WithNumber("include_context",
+			mcp.Description("Number of messages around each match to include as context, split between "+
+				"the messages before and after it (default: 0, max: 10). Context is fetched for the first 10 matches only."),
+		),
+		mcp.WithBoolean("detect_language",
+			mcp.Description("Tag each message with a detected ISO 639-1 language code in a 'lang' field (default: false)"),
+		),
+		mcp.WithBoolean("extract_references",
+			mcp.Description("List the issue, pull request, and ticket references (e.g., Jira keys, GitHub issues) "+
+				"found in each message's text in a 'references' field (default: false)"),
+		),
+		mcp.WithBoolean("include_raw",
+			mcp.Description("Attach each match's Slack API match object, before conversion, in a 'raw' field, "+
+				"to diagnose fields the server does not return (default: false)"),
+		),
+		mcp.WithArray("fields",
+			mcp.Description("Only return these fields on each match (e.g., [\"user_name\", \"text\", \"timestamp\"]) "+
+				"to reduce result size (default: all fields)"),
+			mcp.Items(map[string]interface{}{"type": "string"}),
+		),
 	)
 
 	// Register the tool with the SearchMessagesHandler
 	s.mcpServer.AddTool(searchMessagesTool, s.searchMessagesHandler.HandleFunc())
+
+	// Create the build_message_url tool
+	buildMessageURLTool := mcp.NewTool("build_message_url",
+		mcp.WithDescription("Build a shareable Slack message URL from a channel ID and message timestamp, "+
+			"such as those returned by the other tools. This is the inverse of the URLs accepted by read_message."),
+		mcp.WithString("channel_id",
+			mcp.Required(),
+			mcp.Description("Slack channel ID (e.g., C01234567)"),
+		),
+		mcp.WithString("timestamp",
+			mcp.Required(),
+			mcp.Description("Message timestamp in Slack API format (e.g., 1355517523.000008)"),
+		),
+		mcp.WithString("thread_ts",
+			mcp.Description("Parent thread timestamp, if the message is a thread reply"),
+		),
+	)
+
+	// Register the tool with the BuildMessageURLHandler
+	s.mcpServer.AddTool(buildMessageURLTool, s.buildMessageURLHandler.HandleFunc())
+
+	// Create the compose_blocks tool
+	composeBlocksTool := mcp.NewTool("compose_blocks",
+		mcp.WithDescription("Convert Markdown (headings, lists, quotes, code blocks, links, bold/italic) into "+
+			"Slack Block Kit JSON. Returns the blocks and a mrkdwn fallback text to send alongside them "+
+			"(e.g., as post_message's blocks and text). Does not post anything."),
+		mcp.WithString("markdown",
+			mcp.Required(),
+			mcp.Description("Markdown to convert"),
+		),
+	)
+
+	// Register the tool with the ComposeBlocksHandler
+	s.mcpServer.AddTool(composeBlocksTool, s.composeBlocksHandler.HandleFunc())
+
+	// Create the check_channel_access tool
+	checkChannelAccessTool := mcp.NewTool("check_channel_access",
+		mcp.WithDescription("Check whether the bot (and the user token, if configured) can read a channel "+
+			"before starting a task: reports membership, whether the channel is archived or private, and which "+
+			"read tools (read_message, list_channel_messages, search_messages) will work, with guidance when they will not."),
+		mcp.WithString("channel_id",
+			mcp.Required(),
+			mcp.Description("Slack channel ID (e.g., C01234567) or name (e.g., #general)"),
+		),
+	)
+
+	// Register the tool with the CheckChannelAccessHandler
+	s.mcpServer.AddTool(checkChannelAccessTool, s.checkChannelAccessHandler.HandleFunc())
+
+	// Create the channel_briefing tool
+	channelBriefingTool := mcp.NewTool("channel_briefing",
+		mcp.WithDescription("Orient yourself in a channel with one call: returns its topic, purpose, pinned "+
+			"messages, bookmarks, canvas preview, and most recent active threads in a compact form. Pinned "+
+			"messages and threads are one line each with their timestamps, to read in full with read_message."),
+		mcp.WithString("channel_id",
+			mcp.Required(),
+			mcp.Description("Slack channel ID (e.g., C01234567) or name (e.g., #general)"),
+		),
+		mcp.WithNumber("threads",
+			mcp.Description("Number of recent threads to include (default: 5, max: 20, 0 to skip)"),
+		),
+	)
+
+	// Register the tool with the ChannelBriefingHandler
+	s.mcpServer.AddTool(channelBriefingTool, s.channelBriefingHandler.HandleFunc())
+
+	// Create the get_channel_origin tool
+	getChannelOriginTool := mcp.NewTool("get_channel_origin",
+		mcp.WithDescription("Look up when a channel was created, by whom, and its earliest available message, "+
+			"e.g., to answer how long a project channel has existed or to bound a backfill of its history."),
+		mcp.WithString("channel_id",
+			mcp.Required(),
+			mcp.Description("Slack channel ID (e.g., C01234567) or name (e.g., #general)"),
+		),
+	)
+
+	// Register the tool with the GetChannelOriginHandler
+	s.mcpServer.AddTool(getChannelOriginTool, s.getChannelOriginHandler.HandleFunc())
+
+	// Create the list_workspaces tool
+	listWorkspacesTool := mcp.NewTool("list_workspaces",
+		mcp.WithDescription("List the Slack workspaces this server can access (team ID, name, domain, URL) and "+
+			"which one (current) the other tools act on. On Enterprise Grid, an app installed org-wide can "+
+			"access several workspaces; otherwise only the current workspace is listed. Use it to confirm the "+
+			"target workspace before acting when several Slack servers are configured."),
+	)
+
+	// Register the tool with the ListWorkspacesHandler
+	s.mcpServer.AddTool(listWorkspacesTool, s.listWorkspacesHandler.HandleFunc())
+
+	// Create the list_shared_links tool
+	listSharedLinksTool := mcp.NewTool("list_shared_links",
+		mcp.WithDescription("List the URLs shared in a Slack channel over a time range, deduplicated, with how "+
+			"many messages shared each, who shared it, and when and where it first appeared. Use it to "+
+			"collect everything shared about a topic. Most shared links are listed first."),
+		mcp.WithString("channel_id",
+			mcp.Required(),
+			mcp.Description("Slack channel ID (e.g., C01234567) or name (e.g., #general)"),
+		),
+		mcp.WithString("oldest",
+			mcp.Description("Only scan messages after this Unix timestamp (e.g., \"1234567890.123456\")"),
+		),
+		mcp.WithString("latest",
+			mcp.Description("Only scan messages before this Unix timestamp (e.g., \"1234567890.123456\")"),
+		),
+		mcp.WithNumber("limit",
+			mcp.Description("Maximum number of channel messages to scan, newest first (default: 500, max: 1000)"),
+		),
+		mcp.WithBoolean("include_threads",
+			mcp.Description("Also scan thread replies, in the 20 most recent threads (default: false)"),
+		),
+	)
+
+	// Register the tool with the ListSharedLinksHandler
+	s.mcpServer.AddTool(listSharedLinksTool, s.listSharedLinksHandler.HandleFunc())
+
+	// Create the list_channels tool
+	listChannelsTool := mcp.NewTool("list_channels",
+		mcp.WithDescription("List the channels visible to the bot, with their IDs, names, topics, and whether the "+
+			"bot is a member, to find the channel_id the other tools take. Private channels are only listed "+
+			"if the bot is a member. Page with next_cursor."),
+		mcp.WithArray("types",
+			mcp.Description("Conversation types to list: public, private, im, mpim (default: [\"public\", \"private\"])"),
+			mcp.Items(map[string]interface{}{"type": "string", "enum": []string{"public", "private", "im", "mpim"}}),
+		),
+		mcp.WithBoolean("exclude_archived",
+			mcp.Description("Leave out archived channels (default: true)"),
+		),
+		mcp.WithBoolean("member_only",
+			mcp.Description("Only list channels the bot is a member of, i.e., those it can read (default: false)"),
+		),
+		mcp.WithString("name",
+			mcp.Description("Only list channels whose name contains this text, ignoring case (e.g., \"eng\")"),
+		),
+		mcp.WithNumber("limit",
+			mcp.Description("Number of channels to return (default: 100, max: 1000)"),
+		),
+		mcp.WithString("cursor",
+			mcp.Description("next_cursor from a previous call with the same filters, to list the next page"),
+		),
+	)
+
+	// Register the tool with the ListChannelsHandler
+	s.mcpServer.AddTool(listChannelsTool, s.listChannelsHandler.HandleFunc())
+
+	// Create the get_user_profile tool
+	getUserProfileTool := mcp.NewTool("get_user_profile",
+		mcp.WithDescription("Get a user's full profile: title, email, phone, timezone, status, and avatar. "+
+			"Look the user up by exactly one of user_id, email, or name. A name that matches several "+
+			"users equally well returns them as candidates instead."),
+		mcp.WithString("user_id",
+			mcp.Description("Slack user ID (e.g., U01234567) or profile link"),
+		),
+		mcp.WithString("email",
+			mcp.Description("The user's email address (requires the users:read.email scope)"),
+		),
+		mcp.WithString("name",
+			mcp.Description("Username, display name, or real name to search for, ignoring case (e.g., \"@jane\" or \"Jane Smith\")"),
+		),
+	)
+
+	// Register the tool with the GetUserProfileHandler
+	s.mcpServer.AddTool(getUserProfileTool, s.getUserProfileHandler.HandleFunc())
+
+	// Create the get_emoji_stats tool
+	getEmojiStatsTool := mcp.NewTool("get_emoji_stats",
+		mcp.WithDescription("Get the emoji most used in reactions over a time range, in one channel or across "+
+			"the channels the bot is a member of, with how many times, on how many messages, and by how "+
+			"many users each was used. Skin tone variants count as the base emoji."),
+		mcp.WithString("channel_id",
+			mcp.Description("Slack channel ID (e.g., C01234567) or name (e.g., #general). "+
+				"Omit for stats across up to 25 channels the bot is a member of"),
+		),
+		mcp.WithString("oldest",
+			mcp.Description("Only count reactions on messages after this Unix timestamp (default: 30 days before latest)"),
+		),
+		mcp.WithString("latest",
+			mcp.Description("Only count reactions on messages before this Unix timestamp (default: now)"),
+		),
+		mcp.WithNumber("top",
+			mcp.Description("Number of emoji to return, most used first (default: 10, max: 100)"),
+		),
+		mcp.WithNumber("limit",
+			mcp.Description("Maximum number of messages to scan per channel, newest first (default: 200, max: 1000)"),
+		),
+	)
+
+	// Register the tool with the GetEmojiStatsHandler
+	s.mcpServer.AddTool(getEmojiStatsTool, s.getEmojiStatsHandler.HandleFunc())
+
+	// read_dm_history is only registered when DM reads are explicitly allowed
+	if s.readDMHistoryHandler != nil {
+		// Create the read_dm_history tool
+		readDMHistoryTool := mcp.NewTool("read_dm_history",
+			mcp.WithDescription("Read the direct messages between the configured user (SLACK_USER_TOKEN) and "+
+				"another user, newest first. DMs are private conversations: only read them when the task "+
+				"requires it. Every read is recorded in the server's audit log."),
+			mcp.WithString("user",
+				mcp.Required(),
+				mcp.Description("The other user in the conversation: a Slack user ID (e.g., U01234567) or profile link"),
+			),
+			mcp.WithNumber("limit",
+				mcp.Description("Number of messages to return (default: 50, max: 200)"),
+			),
+			mcp.WithString("oldest",
+				mcp.Description("Only messages after this Unix timestamp (e.g., 1234567890.123456)"),
+			),
+			mcp.WithString("latest",
+				mcp.Description("Only messages before this Unix timestamp (e.g., 1234567890.123456)"),
+			),
+		)
+
+		// Register the tool with the ReadDMHistoryHandler
+		s.mcpServer.AddTool(readDMHistoryTool, s.readDMHistoryHandler.HandleFunc())
+	}
+
+	// Admin tools are only registered when an admin token is configured
+	if s.getWorkspaceAnalyticsHandler != nil {
+		// Create the get_workspace_analytics tool
+		getWorkspaceAnalyticsTool := mcp.NewTool("get_workspace_analytics",
+			mcp.WithDescription("Get Enterprise Grid admin analytics for one day: per-member activity (type member: "+
+				"active, messages posted, reactions, client usage) or per-public-channel activity (type public_channel). "+
+				"Returns totals across all rows (numeric fields summed, boolean fields counted where true, e.g., "+
+				"totals.is_active is the number of active members) and the first rows. Slack publishes each day's "+
+				"export after the day ends, so the most recent day or two may not be available."),
+			mcp.WithString("type",
+				mcp.Required(),
+				mcp.Description("Export type: 'member' for member activity, 'public_channel' for channel activity"),
+				mcp.Enum(slackclient.AnalyticsTypeMember, slackclient.AnalyticsTypePublicChannel),
+			),
+			mcp.WithString("date",
+				mcp.Description("Day to report on, as YYYY-MM-DD in UTC (default: two days ago)"),
+			),
+			mcp.WithNumber("limit",
+				mcp.Description("Number of rows to return (default: 50, max: 500); totals cover every row"),
+			),
+		)
+
+		// Register the tool with the GetWorkspaceAnalyticsHandler
+		s.mcpServer.AddTool(getWorkspaceAnalyticsTool, s.getWorkspaceAnalyticsHandler.HandleFunc())
+	}
+
+	// The audit log tool is only registered when an audit token is configured
+	if s.readAuditLogsHandler != nil {
+		// Create the read_audit_logs tool
+		readAuditLogsTool := mcp.NewTool("read_audit_logs",
+			mcp.WithDescription("Read Enterprise Grid audit log entries (who did what to which object, and when), "+
+				"newest first, to answer questions such as \"who archived #incidents\" (action channel_archive, "+
+				"entity the channel ID) or \"when was the retention policy changed\" (action "+
+				"pref.retention_policy_changed). Filters can be combined; page with next_cursor."),
+			mcp.WithString("action",
+				mcp.Description("Audit action to match (e.g., channel_archive, channel_deleted, user_login, "+
+					"pref.retention_policy_changed, role_change_to_admin)"),
+			),
+			mcp.WithString("actor",
+				mcp.Description("ID of the user who performed the action (e.g., U01234567)"),
+			),
+			mcp.WithString("entity",
+				mcp.Description("ID of the object acted on (e.g., a channel, user, file, or workspace ID)"),
+			),
+			mcp.WithString("oldest",
+				mcp.Description("Only entries at or after this Unix timestamp"),
+			),
+			mcp.WithString("latest",
+				mcp.Description("Only entries at or before this Unix timestamp"),
+			),
+			mcp.WithNumber("limit",
+				mcp.Description("Number of entries to return (default: 50, max: 1000)"),
+			),
+			mcp.WithString("cursor",
+				mcp.Description("next_cursor from a previous call, to read the next page"),
+			),
+		)
+
+		// Register the tool with the ReadAuditLogsHandler
+		s.mcpServer.AddTool(readAuditLogsTool, s.readAuditLogsHandler.HandleFunc())
+	}
+
+	// Write tools are only registered when enabled
+	if s.postMessageHandler == nil {
+		return
+	}
+
+	// Create the post_message tool
+	postMessageTool := mcp.NewTool("post_message",
+		mcp.WithDescription("Post a message to a channel, or reply in a thread (e.g., to post a status update or "+
+			"answer a question). Everyone in the channel sees it. Returns the message's timestamp and permalink. "+
+			"The bot must be a member of the channel and have the chat:write scope."),
+		mcp.WithString("channel_id",
+			mcp.Required(),
+			mcp.Description("Slack channel ID (e.g., C01234567) or name (e.g., #general)"),
+		),
+		mcp.WithString("text",
+			mcp.Required(),
+			mcp.Description("Message text, in Slack mrkdwn. With blocks, the fallback shown in notifications "+
+				"and clients that cannot render blocks (e.g., compose_blocks' text)"),
+		),
+		mcp.WithArray("blocks",
+			mcp.Description("Block Kit blocks to post, e.g., the blocks returned by compose_blocks (at most 50)"),
+		),
+		mcp.WithString("thread_ts",
+			mcp.Description("Parent message timestamp, to post the message as a reply in that thread"),
+		),
+		mcp.WithBoolean("reply_broadcast",
+			mcp.Description("Also show the thread reply in the channel (requires thread_ts, default: false)"),
+		),
+		mcp.WithBoolean("unfurl_links",
+			mcp.Description("Show previews of links in the message (default: Slack's default)"),
+		),
+		mcp.WithBoolean("unfurl_media",
+			mcp.Description("Show previews of media links in the message (default: true)"),
+		),
+		mcp.WithString("on_long_text",
+			mcp.Description("What to do if the message is over Slack's 40,000 character limit: 'split' posts it "+
+				"as a message followed by continuations in its thread (default), 'snippet' uploads it as a text "+
+				"snippet file instead (requires the files:write scope), 'error' rejects it"),
+			mcp.Enum("split", "snippet", "error"),
+		),
+	)
+
+	// Register the tool with the PostMessageHandler
+	s.mcpServer.AddTool(postMessageTool, s.postMessageHandler.HandleFunc())
+
+	// Create the reply_in_thread tool
+	replyInThreadTool := mcp.NewTool("reply_in_thread",
+		mcp.WithDescription("Reply in the thread of a Slack message, given the message's URL (the same URL format "+
+			"read_message accepts). A URL of a thread reply posts in that reply's thread. Returns the reply's "+
+			"timestamp and permalink. The bot must be a member of the channel and have the chat:write scope."),
+		mcp.WithString("url",
+			mcp.Required(),
+			mcp.Description("Slack message URL (e.g., https://workspace.slack.com/archives/C01234567/p1234567890123456)"),
+		),
+		mcp.WithString("text",
+			mcp.Required(),
+			mcp.Description("Reply text, in Slack mrkdwn"),
+		),
+		mcp.WithBoolean("reply_broadcast",
+			mcp.Description("Also show the reply in the channel (default: false)"),
+		),
+		mcp.WithString("on_long_text",
+			mcp.Description("What to do if the reply is over Slack's 40,000 character limit: 'split' posts it "+
+				"as several replies (default), 'snippet' uploads it to the thread as a text snippet file instead "+
+				"(requires the files:write scope; not broadcast), 'error' rejects it"),
+			mcp.Enum("split", "snippet", "error"),
+		),
+	)
+
+	// Register the tool with the ReplyInThreadHandler
+	s.mcpServer.AddTool(replyInThreadTool, s.replyInThreadHandler.HandleFunc())
+
+	// Create the post_ephemeral tool
+	postEphemeralTool := mcp.NewTool("post_ephemeral",
+		mcp.WithDescription("Show a message to a single user in a channel, without notifying anyone else "+
+			"(e.g., \"I summarized this thread here: ...\"). Only that user sees it, and it is not stored in the "+
+			"channel history. The user must be a member of the channel."),
+		mcp.WithString("channel_id",
+			mcp.Required(),
+			mcp.Description("Slack channel ID (e.g., C01234567) or name (e.g., #general)"),
+		),
+		mcp.WithString("user_id",
+			mcp.Required(),
+			mcp.Description("ID of the user who sees the message (e.g., U01234567)"),
+		),
+		mcp.WithString("text",
+			mcp.Required(),
+			mcp.Description("Message text, in Slack mrkdwn"),
+		),
+		mcp.WithString("thread_ts",
+			mcp.Description("Parent message timestamp, to show the message in that thread"),
+		),
+	)
+
+	// Register the tool with the PostEphemeralHandler
+	s.mcpServer.AddTool(postEphemeralTool, s.postEphemeralHandler.HandleFunc())
+
+	// Create the post_snippet tool
+	postSnippetTool := mcp.NewTool("post_snippet",
+		mcp.WithDescription("Share text as a snippet file with syntax highlighting in a channel or thread. Use it "+
+			"for logs, diffs, stack traces, or SQL that would be unreadable as a plain message. The highlighting is "+
+			"taken from snippet_type, or else from the filename's extension (e.g., query.sql)."),
+		mcp.WithString("channel_id",
+			mcp.Required(),
+			mcp.Description("Slack channel ID (e.g., C01234567) or name (e.g., #general)"),
+		),
+		mcp.WithString("content",
+			mcp.Required(),
+			mcp.Description("Snippet text (at most 1 MB)"),
+		),
+		mcp.WithString("filename",
+			mcp.Description("File name, whose extension sets the highlighting if snippet_type is not given "+
+				"(default: snippet with an extension for snippet_type, e.g., snippet.py)"),
+		),
+		mcp.WithString("snippet_type",
+			mcp.Description("Syntax highlighting type (e.g., python, diff, sql, json, shell, text)"),
+		),
+		mcp.WithString("title",
+			mcp.Description("Title shown above the snippet (default: the file name)"),
+		),
+		mcp.WithString("initial_comment",
+			mcp.Description("Message text, in Slack mrkdwn, posted with the snippet"),
+		),
+		mcp.WithString("thread_ts",
+			mcp.Description("Parent message timestamp, to share the snippet as a thread reply"),
+		),
+	)
+
+	// Register the tool with the PostSnippetHandler
+	s.mcpServer.AddTool(postSnippetTool, s.postSnippetHandler.HandleFunc())
+
+	// Create the update_usergroup_members tool
+	updateUserGroupMembersTool := mcp.NewTool("update_usergroup_members",
+		mcp.WithDescription("Change who is in a user group (e.g., hand @oncall to the next person at a shift "+
+			"change). Pass users to set the complete membership, or add and/or remove to change it. Every change "+
+			"is recorded in the server's audit log. User groups cannot be left empty."),
+		mcp.WithString("usergroup",
+			mcp.Required(),
+			mcp.Description("User group handle (e.g., oncall or @oncall) or ID (e.g., S01234567)"),
+		),
+		mcp.WithArray("users",
+			mcp.Description("The complete new membership, as user IDs (e.g., U01234567); replaces the current members"),
+		),
+		mcp.WithArray("add",
+			mcp.Description("User IDs to add to the group"),
+		),
+		mcp.WithArray("remove",
+			mcp.Description("User IDs to remove from the group"),
+		),
+	)
+
+	// Register the tool with the UpdateUserGroupMembersHandler
+	s.mcpServer.AddTool(updateUserGroupMembersTool, s.updateUserGroupMembersHandler.HandleFunc())
+
+	// Create the add_reactions_bulk tool
+	addReactionsBulkTool := mcp.NewTool("add_reactions_bulk",
+		mcp.WithDescription("Add one emoji reaction to many messages (e.g., mark processed support requests with "+
+			"white_check_mark). Calls are paced to stay under Slack's rate limit (about 50 per minute), so large "+
+			"batches take a while. Reports the outcome per message; if the batch stops early (rate limit, "+
+			"authentication), the remaining messages are reported as skipped and can be retried."),
+		mcp.WithString("reaction",
+			mcp.Required(),
+			mcp.Description("Emoji name, with or without colons (e.g., white_check_mark)"),
+		),
+		mcp.WithArray("messages",
+			mcp.Required(),
+			mcp.Description("Messages to react to (at most 100), each a Slack message URL or an object "+
+				"with channel_id, timestamp, and thread_ts (the parent's timestamp) for thread replies"),
+		),
+	)
+
+	// Register the tool with the AddReactionsBulkHandler
+	s.mcpServer.AddTool(addReactionsBulkTool, s.addReactionsBulkHandler.HandleFunc())
+
+	if s.postFromTemplateHandler == nil {
+		return
+	}
+
+	// Create the post_from_template tool, listing the configured templates
+	var templateList strings.Builder
+	for _, tmpl := range s.templates.Templates() {
+		templateList.WriteString("\n- " + tmpl.Name)
+		if vars := tmpl.Variables(); len(vars) > 0 {
+			templateList.WriteString(" (vars: " + strings.Join(vars, ", ") + ")")
+		}
+		if tmpl.Description != "" {
+			templateList.WriteString(": " + tmpl.Description)
+		}
+	}
+	postFromTemplateTool := mcp.NewTool("post_from_template",
+		mcp.WithDescription("Post a message rendered from a named, preconfigured template, so standardized "+
+			"updates (incidents, status reports) stay consistent. Every template variable must be given a value. "+
+			"Available templates:"+templateList.String()),
+		mcp.WithString("name",
+			mcp.Required(),
+			mcp.Description("Template name"),
+		),
+		mcp.WithObject("vars",
+			mcp.Description("Template variables, as an object of name to string value (e.g., {\"status\": \"resolved\"})"),
+		),
+		mcp.WithString("channel_id",
+			mcp.Description("Slack channel ID or name (e.g., #general) to post in (default: the template's channel, if it has one)"),
+		),
+		mcp.WithString("thread_ts",
+			mcp.Description("Parent message timestamp, to post the message as a thread reply"),
+		),
+		mcp.WithBoolean("reply_broadcast",
+			mcp.Description("Also show the thread reply in the channel (requires thread_ts, default: false)"),
+		),
+		mcp.WithBoolean("unfurl_links",
+			mcp.Description("Show previews of links in the message (default: Slack's default)"),
+		),
+		mcp.WithBoolean("unfurl_media",
+			mcp.Description("Show previews of media links in the message (default: true)"),
+		),
+		mcp.WithString("on_long_text",
+			mcp.Description("What to do if the message is over Slack's 40,000 character limit: 'split' posts it "+
+				"as a message followed by continuations in its thread (default), 'snippet' uploads it as a text "+
+				"snippet file instead (requires the files:write scope), 'error' rejects it"),
+			mcp.Enum("split", "snippet", "error"),
+		),
+	)
+
+	// Register the tool with the PostFromTemplateHandler
+	s.mcpServer.AddTool(postFromTemplateTool, s.postFromTemplateHandler.HandleFunc())
 }
 
-// Run starts the MCP server using Stdio transport.
-// This method blocks until the server is terminated.
+// Validate checks the Slack bot token with a fresh auth.test call before the
+// server starts serving, even if the client already cached an identity. The
+// resulting bot identity is cached by the client, so tool calls do not repeat
+// auth.test.
+//
+// Returns an error if the token is rejected or Slack cannot be reached.
+func (s *Server) Validate(ctx context.Context) error {
+	return s.slackClient.ValidateAuth(ctx)
+}
+
+// Run starts the MCP server on the given transport (see ParseTransport): stdio, a
+// Unix domain socket, Streamable HTTP, or SSE. The zero Transport is stdio. This
+// method blocks until the server is terminated.
 //
 // Returns an error if the server fails to start or encounters an error during operation.
-func (s *Server) Run() error {
-	return server.ServeStdio(s.mcpServer)
+func (s *Server) Run(transport Transport) error {
+	// Keep the bot token current while serving, if it comes from a secret store
+	if setter, ok := s.slackClient.(botTokenSetter); ok && s.botTokenProvider != nil {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		go refreshBotToken(ctx, setter, s.botTokenProvider, s.validateBotToken, s.botToken, s.botTokenRefreshInterval)
+	}
+
+	// Post scheduled digests until the server stops serving
+	if s.digestRunner != nil {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		go s.digestRunner.Run(ctx)
+	}
+
+	// Log which channels the bot can read, without delaying startup
+	if s.reportAccessOnStartup {
+		go s.logAccessReport()
+	}
+
+	// Fill the user cache, so reads resolve their authors without a users.info call each
+	if s.prefetchUsersOnStartup {
+		go s.prefetchUsers()
+	}
+
+	switch transport.Kind {
+	case TransportUnix:
+		return s.serveUnix(transport.Address)
+	case TransportHTTP:
+		return s.serveHTTP(transport.Address)
+	case TransportSSE:
+		return s.serveSSE(transport.Address)
+	default:
+		return server.ServeStdio(s.mcpServer)
+	}
 }
 
 // MCPServer returns the underlying MCP server instance.
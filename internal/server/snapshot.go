@@ -0,0 +1,18 @@
+// Package server provides the channel snapshot run by the snapshot command.
+package server
+
+import (
+	"context"
+	"io"
+	"log"
+
+	"github.com/Bitovi/slack-mcp-server/internal/snapshot"
+)
+
+// Snapshot writes the history of the channels in opts to JSON files, using the
+// server's Slack client. Progress is written to w, one line per channel.
+//
+// Returns an error if any channel could not be written (see snapshot.Write).
+func (s *Server) Snapshot(ctx context.Context, opts snapshot.Options, w io.Writer) error {
+	return snapshot.Write(ctx, s.slackClient, opts, log.New(w, "", log.LstdFlags))
+}
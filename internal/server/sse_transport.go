@@ -0,0 +1,60 @@
+// Package server provides the SSE transport for web-based MCP clients.
+package server
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// serveSSE serves MCP clients over the HTTP with Server-Sent Events transport on
+// addr until SIGINT or SIGTERM, using mcp-go's SSE server. Clients open an event
+// stream with a GET of /sse, which first sends the URL to POST messages to
+// (/message with the session ID), and receive responses and notifications on the
+// stream. Each stream is its own MCP session and ends when the client disconnects.
+//
+// Requests from web pages are refused, as with the HTTP transport (see guardRequests).
+// While sessionPingInterval is set, a comment line is sent on idle streams at that
+// interval so proxies do not close them.
+func (s *Server) serveSSE(addr string) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	srv := &http.Server{ReadHeaderTimeout: 10 * time.Second}
+	opts := []server.SSEOption{server.WithHTTPServer(srv)}
+	if s.sessionPingInterval > 0 {
+		opts = append(opts, server.WithKeepAlive(true), server.WithKeepAliveInterval(s.sessionPingInterval))
+	}
+	sseServer := server.NewSSEServer(s.mcpServer, opts...)
+
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+	srv.Handler = s.guardRequests(listener.Addr(), sseServer)
+	logger.Printf("serving MCP over SSE on http://%s/sse", listener.Addr())
+
+	serveErr := make(chan error, 1)
+	go func() { serveErr <- srv.Serve(listener) }()
+
+	select {
+	case err := <-serveErr:
+		return err
+	case <-ctx.Done():
+	}
+
+	// Shutdown closes the event streams, then waits for in-flight message requests
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), httpShutdownTimeout)
+	defer cancel()
+	if err := sseServer.Shutdown(shutdownCtx); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
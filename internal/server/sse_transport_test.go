@@ -0,0 +1,61 @@
+// Package server provides tests for the SSE transport.
+package server
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGuardRequests(t *testing.T) {
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	s := NewWithClient(nil)
+	s.allowedHosts = []string{"MCP.Internal"}
+	loopback := s.guardRequests(&net.TCPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 8080}, next)
+	anyAddress := s.guardRequests(&net.TCPAddr{IP: net.IPv4zero, Port: 8080}, next)
+
+	tests := []struct {
+		name    string
+		handler http.Handler
+		method  string
+		host    string
+		origin  string
+		want    int
+	}{
+		{name: "no origin", handler: loopback, method: http.MethodPost, host: "127.0.0.1:8080", want: http.StatusOK},
+		{name: "same origin", handler: loopback, method: http.MethodPost, host: "127.0.0.1:8080", origin: "http://127.0.0.1:8080", want: http.StatusOK},
+		{name: "localhost", handler: loopback, method: http.MethodPost, host: "localhost:8080", origin: "http://localhost:8080", want: http.StatusOK},
+		{name: "ipv6 loopback", handler: loopback, method: http.MethodPost, host: "[::1]:8080", want: http.StatusOK},
+		{name: "allowed host", handler: loopback, method: http.MethodPost, host: "mcp.internal:8080", want: http.StatusOK},
+		{name: "foreign origin", handler: loopback, method: http.MethodPost, host: "127.0.0.1:8080", origin: "https://evil.example", want: http.StatusForbidden},
+		{name: "foreign origin event stream", handler: loopback, method: http.MethodGet, host: "127.0.0.1:8080", origin: "https://evil.example", want: http.StatusForbidden},
+		{name: "foreign origin preflight", handler: loopback, method: http.MethodOptions, host: "127.0.0.1:8080", origin: "https://evil.example", want: http.StatusForbidden},
+		{name: "malformed origin", handler: loopback, method: http.MethodPost, host: "127.0.0.1:8080", origin: "://", want: http.StatusForbidden},
+		// A page on a domain rebound to 127.0.0.1 sends its own domain as both
+		{name: "dns rebinding", handler: loopback, method: http.MethodPost, host: "evil.example:8080", origin: "http://evil.example:8080", want: http.StatusForbidden},
+		{name: "rebound host without origin", handler: loopback, method: http.MethodGet, host: "evil.example:8080", want: http.StatusForbidden},
+		// Behind a reverse proxy, clients reach the server by the proxy's name
+		{name: "any host off loopback", handler: anyAddress, method: http.MethodPost, host: "mcp.example.com", origin: "https://mcp.example.com", want: http.StatusOK},
+		{name: "foreign origin off loopback", handler: anyAddress, method: http.MethodPost, host: "mcp.example.com", origin: "https://evil.example", want: http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, "http://"+tt.host+"/message?sessionId=abc", nil)
+			if tt.origin != "" {
+				req.Header.Set("Origin", tt.origin)
+			}
+			rec := httptest.NewRecorder()
+			tt.handler.ServeHTTP(rec, req)
+
+			if rec.Code != tt.want {
+				t.Errorf("expected status %d, got %d", tt.want, rec.Code)
+			}
+			if got := rec.Header().Get("Access-Control-Allow-Origin"); got != "" {
+				t.Errorf("expected no CORS header, got %q", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,55 @@
+// Package server provides the MCP server setup and tool registration
+// for the Slack MCP server.
+package server
+
+import (
+	"context"
+	"time"
+
+	"github.com/Bitovi/slack-mcp-server/internal/secrets"
+)
+
+// botTokenSetter is implemented by Slack clients whose bot token can be replaced at runtime.
+type botTokenSetter interface {
+	SetBotToken(botToken string)
+}
+
+// refreshBotToken re-fetches the bot token from provider every interval until ctx
+// is canceled, and swaps it into client when it changes. Fetch failures, and tokens
+// rejected by validate (if not nil), are logged and the current token is kept, so a
+// secret store outage or a bad write to the secret does not interrupt service until
+// the current token itself stops working.
+func refreshBotToken(ctx context.Context, client botTokenSetter, provider secrets.Provider,
+	validate func(token string) error, current string, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		fetchCtx, cancel := context.WithTimeout(ctx, interval)
+		token, err := provider.Fetch(fetchCtx)
+		cancel()
+		if err != nil {
+			logger.Printf("bot token refresh from %s failed, keeping current token: %v", provider.Name(), err)
+			continue
+		}
+
+		if token == current {
+			continue
+		}
+		if validate != nil {
+			if err := validate(token); err != nil {
+				logger.Printf("bot token from %s rejected, keeping current token: %v", provider.Name(), err)
+				continue
+			}
+		}
+		client.SetBotToken(token)
+		current = token
+		logger.Printf("bot token rotated from %s", provider.Name())
+	}
+}
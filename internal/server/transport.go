@@ -0,0 +1,357 @@
+// Package server provides the transports the MCP server serves clients over.
+package server
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// Transport kinds.
+const (
+	// TransportStdio serves a single client over stdin and stdout.
+	TransportStdio = "stdio"
+	// TransportUnix serves clients that connect to a Unix domain socket.
+	TransportUnix = "unix"
+	// TransportHTTP serves remote clients over the MCP Streamable HTTP transport.
+	TransportHTTP = "http"
+	// TransportSSE serves remote clients over the MCP HTTP with Server-Sent Events transport.
+	TransportSSE = "sse"
+	// transportNamedPipe is the Windows named pipe scheme. It is recognized only to
+	// point users at TransportUnix, which Windows 10 and later support.
+	transportNamedPipe = "npipe"
+)
+
+// Transport selects how the server talks to MCP clients.
+type Transport struct {
+	// Kind is TransportStdio, TransportUnix, TransportHTTP, or TransportSSE. Empty
+	// means TransportStdio.
+	Kind string
+	// Address is the socket path for TransportUnix, or the listen address
+	// (host:port) for TransportHTTP and TransportSSE.
+	Address string
+}
+
+// String returns the transport in the form ParseTransport accepts.
+func (t Transport) String() string {
+	if t.Address == "" {
+		return t.Kind
+	}
+	return t.Kind + ":" + t.Address
+}
+
+// ParseTransport parses a transport specification: "stdio", "unix:<path>" for a
+// Unix domain socket at path, "http" for Streamable HTTP, or "sse" for HTTP with
+// Server-Sent Events. Both HTTP transports listen on DefaultHTTPListenAddress; the
+// listen address is set with the --listen flag rather than in the specification.
+func ParseTransport(spec string) (Transport, error) {
+	kind, address, _ := strings.Cut(spec, ":")
+	switch kind {
+	case TransportStdio:
+		if address != "" {
+			return Transport{}, fmt.Errorf("invalid transport %q: stdio takes no address", spec)
+		}
+		return Transport{Kind: TransportStdio}, nil
+	case TransportUnix:
+		if address == "" {
+			return Transport{}, fmt.Errorf("invalid transport %q: expected unix:<socket path>", spec)
+		}
+		return Transport{Kind: TransportUnix, Address: address}, nil
+	case TransportHTTP, TransportSSE:
+		if address != "" {
+			return Transport{}, fmt.Errorf("invalid transport %q: set the HTTP listen address with --listen", spec)
+		}
+		return Transport{Kind: kind, Address: DefaultHTTPListenAddress}, nil
+	case transportNamedPipe:
+		return Transport{}, fmt.Errorf("invalid transport %q: Windows named pipes are not supported; use unix:<socket path>, which Windows 10 and later support", spec)
+	default:
+		return Transport{}, fmt.Errorf("invalid transport %q: must be 'stdio', 'unix:<socket path>', 'http', or 'sse'", spec)
+	}
+}
+
+// serveUnix serves MCP clients on a Unix domain socket at path until SIGINT or
+// SIGTERM. Each connection is an independent MCP session, exchanging
+// newline-delimited JSON-RPC messages as on stdio.
+//
+// A stale socket file left by an earlier run is replaced. The socket is created
+// readable and writable only by the current user, and removed on shutdown.
+func (s *Server) serveUnix(path string) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	listener, err := listenUnix(path)
+	if err != nil {
+		return err
+	}
+	defer listener.Close()
+	logger.Printf("serving MCP on unix socket %s", path)
+
+	return s.serveSocket(ctx, listener)
+}
+
+// listenUnix listens on a Unix domain socket at path, readable and writable only
+// by the current user. A socket file that no server accepts connections on is
+// replaced; one that is in use is an error.
+func listenUnix(path string) (net.Listener, error) {
+	if info, err := os.Lstat(path); err == nil && info.Mode()&os.ModeSocket != 0 {
+		if conn, err := net.Dial("unix", path); err == nil {
+			conn.Close()
+			return nil, fmt.Errorf("socket %s is in use by another server", path)
+		}
+		os.Remove(path)
+	}
+
+	listener, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.Chmod(path, 0o600); err != nil {
+		listener.Close()
+		return nil, err
+	}
+	return listener, nil
+}
+
+// serveSocket serves each connection accepted on listener as an MCP session until
+// ctx is cancelled, then closes listener and waits for the sessions to end.
+func (s *Server) serveSocket(ctx context.Context, listener net.Listener) error {
+	go func() {
+		<-ctx.Done()
+		listener.Close()
+	}()
+
+	var wg sync.WaitGroup
+	defer wg.Wait()
+	var sessions atomic.Int64
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			id := fmt.Sprintf("unix-%d", sessions.Add(1))
+			if err := s.serveConn(ctx, id, conn); err != nil {
+				logger.Printf("session %s: %v", id, err)
+			}
+		}()
+	}
+}
+
+// connSession is the MCP session of one socket connection.
+type connSession struct {
+	id            string
+	notifications chan mcp.JSONRPCNotification
+	initialized   atomic.Bool
+}
+
+// SessionID implements server.ClientSession.
+func (s *connSession) SessionID() string { return s.id }
+
+// NotificationChannel implements server.ClientSession.
+func (s *connSession) NotificationChannel() chan<- mcp.JSONRPCNotification { return s.notifications }
+
+// Initialize implements server.ClientSession.
+func (s *connSession) Initialize() { s.initialized.Store(true) }
+
+// Initialized implements server.ClientSession.
+func (s *connSession) Initialized() bool { return s.initialized.Load() }
+
+var _ server.ClientSession = (*connSession)(nil)
+
+// serveConn serves one MCP session over conn until the client disconnects, the
+// session is closed for inactivity, or ctx is cancelled. Messages are handled one
+// at a time, in order, as on stdio. When the session ends, its in-flight tool call
+// is cancelled and it is unregistered from the MCP server.
+func (s *Server) serveConn(ctx context.Context, id string, conn net.Conn) error {
+	defer conn.Close()
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	// Unblock the read below when the session ends (ctx is replaced below, so its
+	// done channel is taken here)
+	done := ctx.Done()
+	go func() {
+		<-done
+		conn.Close()
+	}()
+
+	session := &connSession{id: id, notifications: make(chan mcp.JSONRPCNotification, 100)}
+	if err := s.mcpServer.RegisterSession(ctx, session); err != nil {
+		return err
+	}
+	defer s.mcpServer.UnregisterSession(id)
+	ctx = s.mcpServer.WithContext(ctx, session)
+
+	var mu sync.Mutex
+	write := func(message interface{}) error {
+		data, err := json.Marshal(message)
+		if err != nil {
+			return err
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		_, err = conn.Write(append(data, '\n'))
+		return err
+	}
+
+	go func() {
+		for {
+			select {
+			case notification := <-session.notifications:
+				if err := write(notification); err != nil {
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	// pingPending is set while a keepalive ping is unanswered
+	var pingPending atomic.Bool
+	if s.sessionPingInterval > 0 {
+		go s.keepAlive(ctx, id, write, &pingPending, cancel)
+	}
+
+	// Read on a separate goroutine, so ping responses are seen while a request is handled
+	lines := make(chan string)
+	readErr := make(chan error, 1)
+	go func() {
+		defer close(lines)
+		reader := bufio.NewReader(conn)
+		for {
+			line, err := reader.ReadString('\n')
+			if line = strings.TrimSpace(line); line != "" {
+				if isResponse(line) {
+					// The server only sends pings, so any response answers one
+					pingPending.Store(false)
+				} else {
+					select {
+					case lines <- line:
+					case <-ctx.Done():
+						return
+					}
+				}
+			}
+			if err != nil {
+				readErr <- err
+				return
+			}
+		}
+	}()
+
+	// The idle timer restarts after each request is handled
+	var idle *time.Timer
+	var idleC <-chan time.Time
+	if s.sessionIdleTimeout > 0 {
+		idle = time.NewTimer(s.sessionIdleTimeout)
+		defer idle.Stop()
+		idleC = idle.C
+	}
+
+	for {
+		var line string
+		var ok bool
+		select {
+		case line, ok = <-lines:
+		case <-idleC:
+			logger.Printf("session %s: closed after %s without requests", id, s.sessionIdleTimeout)
+			return nil
+		case <-ctx.Done():
+			return nil
+		}
+		if !ok {
+			err := <-readErr
+			if errors.Is(err, io.EOF) || errors.Is(err, net.ErrClosed) || ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+
+		if response := s.handleLine(ctx, line); response != nil {
+			if err := write(response); err != nil {
+				return err
+			}
+		}
+		if idle != nil {
+			idle.Reset(s.sessionIdleTimeout)
+		}
+	}
+}
+
+// keepAlive sends the client an MCP ping every sessionPingInterval, and ends the
+// session if a ping is still unanswered when the next one is due.
+func (s *Server) keepAlive(ctx context.Context, id string, write func(interface{}) error, pending *atomic.Bool, closeSession func()) {
+	ticker := time.NewTicker(s.sessionPingInterval)
+	defer ticker.Stop()
+	for n := 1; ; n++ {
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+		if pending.Swap(true) {
+			logger.Printf("session %s: closed after the client did not answer a ping within %s", id, s.sessionPingInterval)
+			closeSession()
+			return
+		}
+		ping := mcp.JSONRPCRequest{
+			JSONRPC: mcp.JSONRPC_VERSION,
+			ID:      fmt.Sprintf("ping-%d", n),
+			Request: mcp.Request{Method: string(mcp.MethodPing)},
+		}
+		if err := write(ping); err != nil {
+			return
+		}
+	}
+}
+
+// isResponse reports whether a JSON-RPC message line is a response (a result or an
+// error) rather than a request or notification from the client.
+func isResponse(line string) bool {
+	var message struct {
+		ID     interface{}      `json:"id"`
+		Method string           `json:"method"`
+		Result json.RawMessage  `json:"result"`
+		Error  *json.RawMessage `json:"error"`
+	}
+	if json.Unmarshal([]byte(line), &message) != nil {
+		return false
+	}
+	return message.Method == "" && message.ID != nil && (message.Result != nil || message.Error != nil)
+}
+
+// handleLine handles one JSON-RPC message line and returns the response to send.
+// Notifications have no response, so nil is returned for them.
+func (s *Server) handleLine(ctx context.Context, line string) interface{} {
+	var message json.RawMessage
+	if err := json.Unmarshal([]byte(line), &message); err != nil {
+		response := mcp.JSONRPCError{JSONRPC: mcp.JSONRPC_VERSION}
+		response.Error.Code = mcp.PARSE_ERROR
+		response.Error.Message = "Parse error"
+		return response
+	}
+	if response := s.mcpServer.HandleMessage(ctx, message); response != nil {
+		return response
+	}
+	return nil
+}
@@ -0,0 +1,295 @@
+// Package server provides tests for the stdio and Unix socket transports.
+package server
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseTransport(t *testing.T) {
+	tests := []struct {
+		spec    string
+		want    Transport
+		wantErr string
+	}{
+		{spec: "stdio", want: Transport{Kind: TransportStdio}},
+		{spec: "unix:/tmp/slack-mcp.sock", want: Transport{Kind: TransportUnix, Address: "/tmp/slack-mcp.sock"}},
+		{spec: `unix:C:\Users\me\slack-mcp.sock`, want: Transport{Kind: TransportUnix, Address: `C:\Users\me\slack-mcp.sock`}},
+		{spec: "http", want: Transport{Kind: TransportHTTP, Address: DefaultHTTPListenAddress}},
+		{spec: "sse", want: Transport{Kind: TransportSSE, Address: DefaultHTTPListenAddress}},
+		{spec: "stdio:foo", wantErr: "stdio takes no address"},
+		{spec: "unix", wantErr: "expected unix:<socket path>"},
+		{spec: "unix:", wantErr: "expected unix:<socket path>"},
+		{spec: "http::9090", wantErr: "--listen"},
+		{spec: "sse:127.0.0.1:9090", wantErr: "--listen"},
+		{spec: `npipe:\\.\pipe\slack-mcp`, wantErr: "use unix:<socket path>"},
+		{spec: "tcp:8080", wantErr: "must be 'stdio'"},
+		{spec: "", wantErr: "must be 'stdio'"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			got, err := ParseTransport(tt.spec)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("expected an error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+			if roundTrip, err := ParseTransport(got.String()); tt.want.Kind == TransportUnix && (err != nil || roundTrip != got) {
+				t.Errorf("String() = %q does not parse back to the transport: %+v, %v", got.String(), roundTrip, err)
+			}
+		})
+	}
+}
+
+func TestListenUnix(t *testing.T) {
+	t.Run("socket is private to the user", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "mcp.sock")
+		listener, err := listenUnix(path)
+		if err != nil {
+			t.Fatalf("listenUnix failed: %v", err)
+		}
+		defer listener.Close()
+
+		info, err := os.Stat(path)
+		if err != nil {
+			t.Fatalf("stat failed: %v", err)
+		}
+		if perm := info.Mode().Perm(); perm != 0o600 {
+			t.Errorf("socket permissions = %o, want 600", perm)
+		}
+	})
+
+	t.Run("stale socket is replaced", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "mcp.sock")
+		// A listener closed without removing its file leaves a socket nobody accepts on
+		stale, err := net.Listen("unix", path)
+		if err != nil {
+			t.Fatalf("listen failed: %v", err)
+		}
+		stale.(*net.UnixListener).SetUnlinkOnClose(false)
+		stale.Close()
+		if _, err := os.Lstat(path); err != nil {
+			t.Fatalf("expected the stale socket file to remain: %v", err)
+		}
+
+		listener, err := listenUnix(path)
+		if err != nil {
+			t.Fatalf("expected the stale socket to be replaced, got %v", err)
+		}
+		listener.Close()
+	})
+
+	t.Run("socket in use is an error", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "mcp.sock")
+		first, err := listenUnix(path)
+		if err != nil {
+			t.Fatalf("listenUnix failed: %v", err)
+		}
+		defer first.Close()
+
+		if _, err := listenUnix(path); err == nil || !strings.Contains(err.Error(), "in use") {
+			t.Errorf("expected an in use error, got %v", err)
+		}
+	})
+
+	t.Run("other files are not replaced", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "mcp.sock")
+		if err := os.WriteFile(path, []byte("data"), 0o600); err != nil {
+			t.Fatalf("write failed: %v", err)
+		}
+		if listener, err := listenUnix(path); err == nil {
+			listener.Close()
+			t.Error("expected an error for a path that is a regular file")
+		}
+		if data, err := os.ReadFile(path); err != nil || string(data) != "data" {
+			t.Errorf("expected the file to be left alone, got %q, %v", data, err)
+		}
+	})
+}
+
+func TestServeSocket_RoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "mcp.sock")
+	listener, err := listenUnix(path)
+	if err != nil {
+		t.Fatalf("listenUnix failed: %v", err)
+	}
+
+	s := NewWithClient(nil)
+	ctx, cancel := context.WithCancel(context.Background())
+	served := make(chan error, 1)
+	go func() { served <- s.serveSocket(ctx, listener) }()
+
+	conn, err := net.Dial("unix", path)
+	if err != nil {
+		t.Fatalf("dial failed: %v", err)
+	}
+	defer conn.Close()
+	_ = conn.SetDeadline(time.Now().Add(5 * time.Second))
+	reader := bufio.NewReader(conn)
+
+	for _, request := range []string{testInitialize, testPing} {
+		if _, err := conn.Write([]byte(request + "\n")); err != nil {
+			t.Fatalf("write failed: %v", err)
+		}
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			t.Fatalf("read failed: %v", err)
+		}
+		if !strings.Contains(line, `"result"`) {
+			t.Errorf("expected a result for %s, got %s", request, line)
+		}
+	}
+
+	// Shutdown ends the session and removes the socket
+	cancel()
+	select {
+	case err := <-served:
+		if err != nil {
+			t.Errorf("serveSocket returned %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected serveSocket to return after shutdown")
+	}
+	if _, err := os.Lstat(path); !os.IsNotExist(err) {
+		t.Errorf("expected the socket to be removed, got %v", err)
+	}
+}
+
+// pipeSession serves an MCP session over one end of an in-memory pipe. It returns the
+// client end, the lines the server writes to it, and the result of serveConn once
+// the session ends.
+func pipeSession(t *testing.T, s *Server) (net.Conn, <-chan string, <-chan error) {
+	t.Helper()
+	serverConn, clientConn := net.Pipe()
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- s.serveConn(ctx, "test", serverConn) }()
+
+	// Writes to a pipe block until read, so the server's lines are always drained
+	lines := make(chan string, 100)
+	go func() {
+		defer close(lines)
+		reader := bufio.NewReader(clientConn)
+		for {
+			line, err := reader.ReadString('\n')
+			if err != nil {
+				return
+			}
+			lines <- line
+		}
+	}()
+
+	t.Cleanup(func() {
+		cancel()
+		clientConn.Close()
+	})
+	return clientConn, lines, done
+}
+
+// nextLine returns the next line the server wrote, failing the test after a timeout.
+func nextLine(t *testing.T, lines <-chan string) string {
+	t.Helper()
+	select {
+	case line, ok := <-lines:
+		if !ok {
+			t.Fatal("expected a line, but the session ended")
+		}
+		return line
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for a line")
+		return ""
+	}
+}
+
+func TestServeConn_UnansweredPingClosesSession(t *testing.T) {
+	s := NewWithClient(nil)
+	s.sessionPingInterval = 20 * time.Millisecond
+	_, lines, done := pipeSession(t, s)
+
+	if line := nextLine(t, lines); !strings.Contains(line, `"method":"ping"`) {
+		t.Fatalf("expected a ping, got %s", line)
+	}
+
+	// The ping is not answered, so the session ends when the next one is due
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("serveConn returned %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected the session to be closed")
+	}
+}
+
+func TestServeConn_PingResponseClearsPending(t *testing.T) {
+	s := NewWithClient(nil)
+	s.sessionPingInterval = 20 * time.Millisecond
+	conn, lines, done := pipeSession(t, s)
+
+	// Answer every ping; each answer clears the pending flag before the next ping
+	for i := 0; i < 5; i++ {
+		var ping struct {
+			ID     string `json:"id"`
+			Method string `json:"method"`
+		}
+		line := nextLine(t, lines)
+		if err := json.Unmarshal([]byte(line), &ping); err != nil || ping.Method != "ping" {
+			t.Fatalf("expected a ping, got %s", line)
+		}
+		if _, err := conn.Write([]byte(`{"jsonrpc":"2.0","id":"` + ping.ID + `","result":{}}` + "\n")); err != nil {
+			t.Fatalf("write failed: %v", err)
+		}
+	}
+
+	select {
+	case err := <-done:
+		t.Fatalf("expected the session to stay open while pings are answered, it ended with %v", err)
+	default:
+	}
+}
+
+func TestServeConn_IdleTimerResetsAfterRequest(t *testing.T) {
+	const idleTimeout = 100 * time.Millisecond
+	s := NewWithClient(nil)
+	s.sessionIdleTimeout = idleTimeout
+	conn, lines, done := pipeSession(t, s)
+
+	// Requests keep the session open past the idle timeout
+	start := time.Now()
+	for i := 0; i < 4; i++ {
+		time.Sleep(idleTimeout / 2)
+		if _, err := conn.Write([]byte(testPing + "\n")); err != nil {
+			t.Fatalf("write %d failed: %v", i, err)
+		}
+		if line := nextLine(t, lines); !strings.Contains(line, `"result"`) {
+			t.Fatalf("expected a ping response, got %s", line)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < idleTimeout {
+		t.Fatalf("requests took %s, less than the idle timeout", elapsed)
+	}
+
+	// Without requests, the session ends after the idle timeout
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("serveConn returned %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected the idle session to be closed")
+	}
+}
@@ -0,0 +1,67 @@
+// Package server provides the MCP server setup and tool registration
+// for the Slack MCP server.
+package server
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// startupPrefetchTimeout bounds the user directory load at startup.
+const startupPrefetchTimeout = 5 * time.Minute
+
+// UserPrefetchMode selects when the workspace user directory is loaded into the
+// user cache with users.list, instead of looking users up one at a time.
+type UserPrefetchMode string
+
+const (
+	// UserPrefetchOff looks users up one at a time with users.info, as they are needed.
+	UserPrefetchOff UserPrefetchMode = "off"
+	// UserPrefetchStartup loads the user directory in the background when the server starts.
+	UserPrefetchStartup UserPrefetchMode = "startup"
+	// UserPrefetchLazy loads the user directory once a number of users were not in the
+	// cache, so small workspaces and light use never list every user.
+	UserPrefetchLazy UserPrefetchMode = "lazy"
+)
+
+// ParseUserPrefetchMode parses a user prefetch mode name.
+// An empty string selects UserPrefetchOff.
+func ParseUserPrefetchMode(s string) (UserPrefetchMode, error) {
+	switch mode := UserPrefetchMode(strings.ToLower(strings.TrimSpace(s))); mode {
+	case "":
+		return UserPrefetchOff, nil
+	case UserPrefetchOff, UserPrefetchStartup, UserPrefetchLazy:
+		return mode, nil
+	default:
+		return "", fmt.Errorf("unknown user prefetch mode %q: must be 'off', 'startup', or 'lazy'", s)
+	}
+}
+
+// userPrefetcher is implemented by Slack clients that can load the user directory
+// into their user cache.
+type userPrefetcher interface {
+	PrefetchUsers(ctx context.Context) (int, error)
+}
+
+// prefetchUsers loads the user directory into the Slack client's user cache and logs
+// the outcome. Failures are logged and otherwise ignored: users are then looked up
+// one at a time, as without prefetching.
+func (s *Server) prefetchUsers() {
+	prefetcher, ok := s.slackClient.(userPrefetcher)
+	if !ok {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), startupPrefetchTimeout)
+	defer cancel()
+
+	start := time.Now()
+	n, err := prefetcher.PrefetchUsers(ctx)
+	if err != nil {
+		logger.Printf("user prefetch failed, users will be looked up as needed: %v", err)
+		return
+	}
+	logger.Printf("prefetched %d users in %s", n, time.Since(start).Round(time.Millisecond))
+}
@@ -0,0 +1,69 @@
+// Package slack provides tests for Enterprise Grid admin analytics exports.
+package slack
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/slack-go/slack"
+)
+
+// newAnalyticsTestClient creates a client with an admin token whose API calls go to handler.
+func newAnalyticsTestClient(t *testing.T, handler http.HandlerFunc) *Client {
+	t.Helper()
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+	return NewClient("xoxb-test", "", WithAPIURL(srv.URL+"/"), WithAdminToken("xoxp-admin"))
+}
+
+func TestClient_ReadAnalyticsFile(t *testing.T) {
+	client := newAnalyticsTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+		if r.URL.Path != "/admin.analytics.getFile" || r.PostForm.Get("token") != "xoxp-admin" {
+			t.Errorf("unexpected request %s with token %q", r.URL.Path, r.PostForm.Get("token"))
+		}
+		if r.PostForm.Get("date") == "2024-03-02" {
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"ok":false,"error":"file_not_yet_available"}`))
+			return
+		}
+
+		var file bytes.Buffer
+		gz := gzip.NewWriter(&file)
+		_, _ = gz.Write([]byte(`{"user_id":"U01111111","is_active":true,"messages_posted":3}` + "\n" +
+			`{"user_id":"U02222222","is_active":false,"messages_posted":0}` + "\n"))
+		_ = gz.Close()
+		w.Header().Set("Content-Type", "application/gzip")
+		_, _ = w.Write(file.Bytes())
+	})
+
+	var rows []map[string]interface{}
+	err := client.ReadAnalyticsFile(context.Background(), AnalyticsTypeMember, "2024-03-01", func(row map[string]interface{}) {
+		rows = append(rows, row)
+	})
+	if err != nil {
+		t.Fatalf("ReadAnalyticsFile failed: %v", err)
+	}
+	if len(rows) != 2 || rows[0]["user_id"] != "U01111111" || rows[0]["messages_posted"] != float64(3) {
+		t.Errorf("unexpected rows %v", rows)
+	}
+
+	err = client.ReadAnalyticsFile(context.Background(), AnalyticsTypeMember, "2024-03-02", func(map[string]interface{}) {})
+	if err == nil || !strings.Contains(err.Error(), "file_not_yet_available") {
+		t.Errorf("expected file_not_yet_available error, got %v", err)
+	}
+}
+
+func TestClient_ReadAnalyticsFile_NoAdminToken(t *testing.T) {
+	client := &Client{}
+	client.api.Store(slack.New("xoxb-test"))
+	err := client.ReadAnalyticsFile(context.Background(), AnalyticsTypeMember, "2024-03-01", func(map[string]interface{}) {})
+	if !IsAdminTokenNotConfigured(err) {
+		t.Errorf("expected admin_token_not_configured error, got %v", err)
+	}
+}
@@ -0,0 +1,92 @@
+// Package slack provides reads from archived channels for the Slack client.
+package slack
+
+import (
+	"context"
+	"time"
+
+	"github.com/slack-go/slack"
+
+	"github.com/Bitovi/slack-mcp-server/pkg/types"
+)
+
+// archivedReadsKey is the context key that enables archived channel reads.
+type archivedReadsKey struct{}
+
+// WithArchivedReads returns a context that lets channel reads fall back to the user
+// token when the bot is not a member of an archived channel. Archived channels cannot
+// be joined, so a bot that was not invited before the channel was archived can never
+// read it with the bot token. Reads of other channels are unaffected.
+func WithArchivedReads(ctx context.Context) context.Context {
+	return context.WithValue(ctx, archivedReadsKey{}, true)
+}
+
+// archivedReadsEnabled reports whether ctx was returned by WithArchivedReads.
+func archivedReadsEnabled(ctx context.Context) bool {
+	enabled, _ := ctx.Value(archivedReadsKey{}).(bool)
+	return enabled
+}
+
+// readChannel makes a channel read call (conversations.history or conversations.replies)
+// with the token routed for method.
+//
+// If the bot is not a member of the channel and the channel is archived, the error is
+// reported as channel_archived instead of not_in_channel. If archived reads are enabled
+// for ctx (see WithArchivedReads) and a user token is configured, the call is instead
+// retried with the user token, and later reads of the channel use the user token directly.
+//
+// In act-as-user mode, reads of direct messages are refused, or reported to the DM
+// read audit if one is set (see checkDMRead).
+//
+// Returns the wrapped error from the call, if any.
+func (c *Client) readChannel(ctx context.Context, method, channelID string, call func(api *slack.Client) error) (err error) {
+	api, err := c.apiFor(method)
+	if err != nil {
+		return err
+	}
+	audit, err := c.checkDMRead(ctx, method, channelID)
+	if err != nil {
+		return err
+	}
+	defer func() { audit(err) }()
+	useUserToken := archivedReadsEnabled(ctx) && c.userTokenAPI != nil
+	if _, ok := c.archivedFallback.Load(channelID); ok && useUserToken {
+		api = c.userTokenAPI
+	}
+
+	start := time.Now()
+	err = call(api)
+	recordCall(ctx, method, start, err)
+	if err == nil {
+		return nil
+	}
+	wrapped := c.checkAuth(wrapSlackError(err))
+	if api == c.userTokenAPI || !IsNotInChannel(wrapped) {
+		return wrapped
+	}
+
+	// The channel cache does not expire, and a channel cached before it was archived
+	// would still read as active, so the archived state is always fetched fresh
+	channel, infoErr := c.fetchChannel(ctx, channelID)
+	if infoErr != nil {
+		return wrapped
+	}
+	c.cacheChannel(convertChannel(channelID, channel))
+	if !channel.IsArchived {
+		return wrapped
+	}
+	if !useUserToken {
+		return types.NewSlackError(types.ErrCodeChannelArchived,
+			"Channel is archived and the bot is not a member. Archived channels cannot be joined.")
+	}
+
+	c.archivedFallback.Store(channelID, true)
+	c.notifyRetry(ctx, method, wrapped)
+	start = time.Now()
+	err = call(c.userTokenAPI)
+	recordCall(ctx, method, start, err)
+	if err != nil {
+		return wrapSlackError(err)
+	}
+	return nil
+}
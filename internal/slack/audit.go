@@ -0,0 +1,128 @@
+// Package slack provides Enterprise Grid Audit Logs API reads for the Slack client.
+package slack
+
+import (
+	"context"
+	"time"
+
+	"github.com/slack-go/slack"
+
+	"github.com/Bitovi/slack-mcp-server/pkg/types"
+)
+
+const (
+	// auditLogsAPIURL is the base URL of the Audit Logs API, which is served from
+	// api.slack.com rather than the Web API host.
+	auditLogsAPIURL = "https://api.slack.com/"
+	// govAuditLogsAPIURL is the base URL of the Audit Logs API for GovSlack.
+	govAuditLogsAPIURL = "https://api.slack-gov.com/"
+)
+
+// AuditLogQuery filters an Audit Logs API read. Empty fields are not filtered on.
+type AuditLogQuery struct {
+	// Action is the audit action (e.g., "channel_archive", "pref.retention_policy_changed").
+	Action string
+	// Actor is the ID of the user who performed the action.
+	Actor string
+	// Entity is the ID of the object acted on (e.g., a channel, user, or file ID).
+	Entity string
+	// Oldest and Latest bound the time of the entries, as Unix times; zero is unbounded.
+	Oldest, Latest int64
+	// Limit is the number of entries to return.
+	Limit int
+	// Cursor continues a previous read.
+	Cursor string
+}
+
+// auditLogsURL returns the Audit Logs API base URL for the configured Slack environment.
+// A custom Web API URL (e.g., a proxy) is used as is.
+func (c *Client) auditLogsURL() string {
+	switch c.apiURL {
+	case "":
+		return auditLogsAPIURL
+	case GovSlackAPIURL:
+		return govAuditLogsAPIURL
+	default:
+		return c.apiURL
+	}
+}
+
+// GetAuditLogs reads a page of Audit Logs API entries, newest first.
+//
+// Returns the entries and the cursor for the next page, empty if there are no more.
+func (c *Client) GetAuditLogs(ctx context.Context, query AuditLogQuery) ([]types.AuditEntry, string, error) {
+	api, err := c.apiFor("audit/v1/logs")
+	if err != nil {
+		return nil, "", err
+	}
+
+	start := time.Now()
+	entries, cursor, err := api.GetAuditLogsContext(ctx, slack.AuditLogParameters{
+		Action: query.Action,
+		Actor:  query.Actor,
+		Entity: query.Entity,
+		Oldest: int(query.Oldest),
+		Latest: int(query.Latest),
+		Limit:  query.Limit,
+		Cursor: query.Cursor,
+	})
+	recordCall(ctx, "audit/v1/logs", start, err)
+	if err != nil {
+		// Not checkAuth: a rejected audit token says nothing about the bot token
+		return nil, "", wrapSlackError(err)
+	}
+
+	result := make([]types.AuditEntry, 0, len(entries))
+	for i := range entries {
+		result = append(result, convertAuditEntry(&entries[i]))
+	}
+	return result, cursor, nil
+}
+
+// convertAuditEntry converts an Audit Logs API entry to our AuditEntry type,
+// keeping only the entity fields for the entity's type.
+func convertAuditEntry(entry *slack.AuditEntry) types.AuditEntry {
+	result := types.AuditEntry{
+		ID:        entry.ID,
+		Time:      time.Unix(int64(entry.DateCreate), 0).UTC().Format(time.RFC3339),
+		Action:    entry.Action,
+		IPAddress: entry.Context.IPAddress,
+		UserAgent: entry.Context.UA,
+		Actor: types.AuditObject{
+			Type:  entry.Actor.Type,
+			ID:    entry.Actor.User.ID,
+			Name:  entry.Actor.User.Name,
+			Email: entry.Actor.User.Email,
+		},
+		Location: &types.AuditObject{
+			Type:   entry.Context.Location.Type,
+			ID:     entry.Context.Location.ID,
+			Name:   entry.Context.Location.Name,
+			Domain: entry.Context.Location.Domain,
+		},
+		NewValue:      entry.Details.NewValue,
+		PreviousValue: entry.Details.PreviousValue,
+	}
+	if result.Location.ID == "" {
+		result.Location = nil
+	}
+
+	entity := types.AuditObject{Type: entry.Entity.Type}
+	switch entry.Entity.Type {
+	case "user":
+		entity.ID, entity.Name, entity.Email = entry.Entity.User.ID, entry.Entity.User.Name, entry.Entity.User.Email
+	case "channel":
+		entity.ID, entity.Name = entry.Entity.Channel.ID, entry.Entity.Channel.Name
+	case "file":
+		entity.ID, entity.Name = entry.Entity.File.ID, entry.Entity.File.Name
+	case "app":
+		entity.ID, entity.Name = entry.Entity.App.ID, entry.Entity.App.Name
+	case "workspace":
+		entity.ID, entity.Name, entity.Domain = entry.Entity.Workspace.ID, entry.Entity.Workspace.Name, entry.Entity.Workspace.Domain
+	case "enterprise":
+		entity.ID, entity.Name, entity.Domain = entry.Entity.Enterprise.ID, entry.Entity.Enterprise.Name, entry.Entity.Enterprise.Domain
+	}
+	result.Entity = entity
+
+	return result
+}
@@ -0,0 +1,81 @@
+// Package slack provides tests for Enterprise Grid Audit Logs API reads.
+package slack
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/slack-go/slack"
+)
+
+func TestClient_GetAuditLogs(t *testing.T) {
+	var query map[string]string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/audit/v1/logs" {
+			t.Errorf("unexpected request path %s", r.URL.Path)
+		}
+		query = map[string]string{
+			"action": r.URL.Query().Get("action"),
+			"entity": r.URL.Query().Get("entity"),
+			"oldest": r.URL.Query().Get("oldest"),
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"ok":true,"entries":[{"id":"0123a45b","date_create":1709290800,"action":"channel_archive",` +
+			`"actor":{"type":"user","user":{"id":"U01234567","name":"alice","email":"alice@example.com"}},` +
+			`"entity":{"type":"channel","channel":{"id":"C01234567","name":"incidents","privacy":"public"}},` +
+			`"context":{"location":{"type":"workspace","id":"T01234567","name":"Acme","domain":"acme"},"ip_address":"192.0.2.1"}}],` +
+			`"response_metadata":{"next_cursor":"dXNlcjpVMEc5V0ZYTlo="}}`))
+	}))
+	t.Cleanup(srv.Close)
+	client := NewClient("xoxb-test", "", WithAPIURL(srv.URL+"/"), WithAuditToken("xoxp-audit"))
+
+	entries, cursor, err := client.GetAuditLogs(context.Background(), AuditLogQuery{
+		Action: "channel_archive",
+		Entity: "C01234567",
+		Oldest: 1709251200,
+	})
+	if err != nil {
+		t.Fatalf("GetAuditLogs failed: %v", err)
+	}
+	if query["action"] != "channel_archive" || query["entity"] != "C01234567" || query["oldest"] != "1709251200" {
+		t.Errorf("unexpected query %v", query)
+	}
+	if cursor != "dXNlcjpVMEc5V0ZYTlo=" {
+		t.Errorf("cursor = %q", cursor)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("expected 1 entry, got %d", len(entries))
+	}
+	entry := entries[0]
+	if entry.Time != "2024-03-01T11:00:00Z" || entry.Actor.Name != "alice" || entry.Entity.Name != "incidents" ||
+		entry.Entity.ID != "C01234567" || entry.Location == nil || entry.Location.Domain != "acme" {
+		t.Errorf("unexpected entry %+v", entry)
+	}
+}
+
+func TestClient_GetAuditLogs_NoAuditToken(t *testing.T) {
+	client := &Client{}
+	client.api.Store(slack.New("xoxb-test"))
+	if _, _, err := client.GetAuditLogs(context.Background(), AuditLogQuery{}); !IsAuditTokenNotConfigured(err) {
+		t.Errorf("expected audit_token_not_configured error, got %v", err)
+	}
+}
+
+func TestClient_AuditLogsURL(t *testing.T) {
+	tests := []struct {
+		apiURL string
+		want   string
+	}{
+		{"", "https://api.slack.com/"},
+		{GovSlackAPIURL, "https://api.slack-gov.com/"},
+		{"https://proxy.example.com/slack/", "https://proxy.example.com/slack/"},
+	}
+	for _, tt := range tests {
+		client := &Client{apiURL: tt.apiURL}
+		if got := client.auditLogsURL(); got != tt.want {
+			t.Errorf("auditLogsURL() with API URL %q = %q, want %q", tt.apiURL, got, tt.want)
+		}
+	}
+}
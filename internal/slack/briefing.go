@@ -0,0 +1,125 @@
+// Package slack provides reads of channel pins, bookmarks, and canvases for the Slack client.
+package slack
+
+import (
+	"context"
+	"time"
+
+	"github.com/slack-go/slack"
+
+	"github.com/Bitovi/slack-mcp-server/pkg/types"
+)
+
+// ListPins retrieves the messages pinned in a channel, via pins.list (pins:read).
+// Pinned files and file comments are skipped.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeouts
+//   - channelID: The Slack channel ID (e.g., "C01234567")
+//
+// In act-as-user mode, pins of direct messages are subject to the DM read policy
+// (see checkDMRead).
+//
+// Returns the pinned messages in the order Slack lists them (most recently pinned first).
+func (c *Client) ListPins(ctx context.Context, channelID string) (_ []types.Message, err error) {
+	api, err := c.apiFor("pins.list")
+	if err != nil {
+		return nil, err
+	}
+	audit, err := c.checkDMRead(ctx, "pins.list", channelID)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { audit(err) }()
+
+	start := time.Now()
+	items, _, err := api.ListPinsContext(ctx, channelID)
+	recordCall(ctx, "pins.list", start, err)
+	if err != nil {
+		return nil, c.checkAuth(wrapSlackError(err))
+	}
+
+	var messages []types.Message
+	for _, item := range items {
+		if item.Type != slack.TYPE_MESSAGE || item.Message == nil {
+			continue
+		}
+		messages = append(messages, *convertMessage(item.Message))
+	}
+	return messages, nil
+}
+
+// ListBookmarks retrieves the links bookmarked in a channel's header, via
+// bookmarks.list (bookmarks:read).
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeouts
+//   - channelID: The Slack channel ID (e.g., "C01234567")
+//
+// In act-as-user mode, bookmarks of direct messages are subject to the DM read policy
+// (see checkDMRead).
+//
+// Returns the bookmarks in the order they appear in the channel header.
+func (c *Client) ListBookmarks(ctx context.Context, channelID string) (_ []types.Bookmark, err error) {
+	api, err := c.apiFor("bookmarks.list")
+	if err != nil {
+		return nil, err
+	}
+	audit, err := c.checkDMRead(ctx, "bookmarks.list", channelID)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { audit(err) }()
+
+	start := time.Now()
+	bookmarks, err := api.ListBookmarksContext(ctx, channelID)
+	recordCall(ctx, "bookmarks.list", start, err)
+	if err != nil {
+		return nil, c.checkAuth(wrapSlackError(err))
+	}
+
+	result := make([]types.Bookmark, 0, len(bookmarks))
+	for _, bookmark := range bookmarks {
+		result = append(result, types.Bookmark{
+			Title: bookmark.Title,
+			Link:  bookmark.Link,
+			Emoji: bookmark.Emoji,
+		})
+	}
+	return result, nil
+}
+
+// GetCanvasSummary retrieves the title and a text preview of a canvas, via
+// files.info (files:read). The preview is Slack's excerpt of the start of the canvas,
+// not its full content. In act-as-user mode, canvases of direct messages are subject
+// to the DM read policy (see checkDMRead).
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeouts
+//   - channelID: The Slack channel ID the canvas belongs to (e.g., "C01234567")
+//   - canvasID: The canvas file ID (e.g., "F01234567"), from ChannelInfo.CanvasID
+func (c *Client) GetCanvasSummary(ctx context.Context, channelID, canvasID string) (_ *types.CanvasSummary, err error) {
+	api, err := c.apiFor("files.info")
+	if err != nil {
+		return nil, err
+	}
+	audit, err := c.checkDMRead(ctx, "files.info", channelID)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { audit(err) }()
+
+	start := time.Now()
+	file, _, _, err := api.GetFileInfoContext(ctx, canvasID, 0, 0)
+	recordCall(ctx, "files.info", start, err)
+	if err != nil {
+		return nil, c.checkAuth(wrapSlackError(err))
+	}
+
+	return &types.CanvasSummary{
+		ID:        canvasID,
+		Title:     file.Title,
+		Preview:   file.Preview,
+		Permalink: file.Permalink,
+	}, nil
+}
@@ -0,0 +1,104 @@
+// Package slack provides a small TTL cache used for read-through caching of Slack API results.
+package slack
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// defaultCacheMaxEntries bounds the number of entries held by a ttlCache.
+const defaultCacheMaxEntries = 1000
+
+// freshReadsKey is the context key that bypasses the message and thread caches.
+type freshReadsKey struct{}
+
+// WithFreshReads returns a context whose GetMessage and GetThread calls are not
+// served from the message and thread caches, for checks that must see the current
+// state (e.g., whether a thread has new replies). Their results still refresh the
+// caches for later reads.
+func WithFreshReads(ctx context.Context) context.Context {
+	return context.WithValue(ctx, freshReadsKey{}, true)
+}
+
+// freshReadsEnabled reports whether ctx was returned by WithFreshReads.
+func freshReadsEnabled(ctx context.Context) bool {
+	enabled, _ := ctx.Value(freshReadsKey{}).(bool)
+	return enabled
+}
+
+// cacheEntry is a cached value and its expiry time.
+type cacheEntry[V any] struct {
+	value   V
+	expires time.Time
+}
+
+// ttlCache is a concurrency-safe map whose entries expire after a fixed TTL.
+// When the cache is full, expired entries are pruned first and then arbitrary
+// entries are evicted, which is sufficient for the short TTLs it is used with.
+type ttlCache[V any] struct {
+	mu         sync.Mutex
+	ttl        time.Duration
+	maxEntries int
+	entries    map[string]cacheEntry[V]
+	now        func() time.Time
+}
+
+// newTTLCache creates a ttlCache with the given TTL and entry limit.
+func newTTLCache[V any](ttl time.Duration, maxEntries int) *ttlCache[V] {
+	return &ttlCache[V]{
+		ttl:        ttl,
+		maxEntries: maxEntries,
+		entries:    make(map[string]cacheEntry[V]),
+		now:        time.Now,
+	}
+}
+
+// get returns the cached value for key if present and not expired.
+func (c *ttlCache[V]) get(key string) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[key]
+	if !ok {
+		var zero V
+		return zero, false
+	}
+	if c.now().After(entry.expires) {
+		delete(c.entries, key)
+		var zero V
+		return zero, false
+	}
+	return entry.value, true
+}
+
+// set stores value under key, evicting entries if the cache is full.
+func (c *ttlCache[V]) set(key string, value V) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	if _, exists := c.entries[key]; !exists && len(c.entries) >= c.maxEntries {
+		for k, entry := range c.entries {
+			if now.After(entry.expires) {
+				delete(c.entries, k)
+			}
+		}
+		for k := range c.entries {
+			if len(c.entries) < c.maxEntries {
+				break
+			}
+			delete(c.entries, k)
+		}
+	}
+
+	c.entries[key] = cacheEntry[V]{value: value, expires: now.Add(c.ttl)}
+}
+
+// delete removes the entry for key, if any.
+func (c *ttlCache[V]) delete(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.entries, key)
+}
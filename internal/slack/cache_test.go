@@ -0,0 +1,51 @@
+// Package slack provides a small TTL cache used for read-through caching of Slack API results.
+package slack
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTTLCache_Expiry(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	cache := newTTLCache[string](time.Minute, 10)
+	cache.now = func() time.Time { return now }
+
+	cache.set("C01234567:1355517523.000008", "hello")
+
+	if v, ok := cache.get("C01234567:1355517523.000008"); !ok || v != "hello" {
+		t.Fatalf("expected cached value 'hello', got %q (ok=%v)", v, ok)
+	}
+
+	now = now.Add(2 * time.Minute)
+	if _, ok := cache.get("C01234567:1355517523.000008"); ok {
+		t.Error("expected entry to expire after TTL")
+	}
+}
+
+func TestTTLCache_MaxEntries(t *testing.T) {
+	cache := newTTLCache[int](time.Minute, 3)
+
+	for i, key := range []string{"a", "b", "c", "d", "e"} {
+		cache.set(key, i)
+	}
+
+	if len(cache.entries) > 3 {
+		t.Errorf("expected at most 3 entries, got %d", len(cache.entries))
+	}
+	if v, ok := cache.get("e"); !ok || v != 4 {
+		t.Errorf("expected most recently set entry to be present, got %d (ok=%v)", v, ok)
+	}
+}
+
+func TestTTLCache_Delete(t *testing.T) {
+	cache := newTTLCache[string](time.Minute, 10)
+
+	cache.set("C01234567:1355517523.000008", "hello")
+	cache.delete("C01234567:1355517523.000008")
+	cache.delete("missing")
+
+	if _, ok := cache.get("C01234567:1355517523.000008"); ok {
+		t.Error("expected entry to be removed")
+	}
+}
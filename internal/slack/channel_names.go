@@ -0,0 +1,83 @@
+// Package slack provides resolution of channel names to channel IDs for the Slack client.
+package slack
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/slack-go/slack"
+
+	"github.com/Bitovi/slack-mcp-server/pkg/types"
+)
+
+// ResolveChannelName returns the ID of the channel with the given name (without
+// the "#"), using a cache to minimize API calls. It lets tools accept "#general"
+// where a channel ID is expected.
+//
+// Slack has no lookup by name, so on a cache miss the public and private channels
+// visible to the bot, archived ones included, are paged through with
+// conversations.list until the name is found (at most maxChannelListPages pages of
+// 200). Every channel seen on the way is cached, so later lookups of other names
+// are usually served from the cache too. Private channels are only visible if the
+// bot is a member.
+//
+// A cached name keeps resolving to its channel after the channel is renamed, until
+// the new name is looked up or the channel is listed again.
+//
+// Returns a channel_not_found error if no visible channel has the name.
+func (c *Client) ResolveChannelName(ctx context.Context, name string) (string, error) {
+	// Check cache first
+	if cached, ok := c.channelNameCache.Load(name); ok {
+		recordCacheHit(ctx)
+		return cached.(string), nil
+	}
+
+	api, err := c.apiFor("conversations.list")
+	if err != nil {
+		return "", err
+	}
+
+	params := &slack.GetConversationsParameters{
+		Types: []string{types.ChannelTypePublic, types.ChannelTypePrivate},
+		Limit: 200,
+	}
+	for page := 0; page < maxChannelListPages; page++ {
+		start := time.Now()
+		batch, nextCursor, err := api.GetConversationsContext(ctx, params)
+		recordCall(ctx, "conversations.list", start, err)
+		if err != nil {
+			return "", c.checkAuth(wrapSlackError(err))
+		}
+
+		channelID := ""
+		for i := range batch {
+			c.cacheChannel(convertChannel(batch[i].ID, &batch[i]))
+			if batch[i].Name == name {
+				channelID = batch[i].ID
+			}
+		}
+		if channelID != "" {
+			return channelID, nil
+		}
+
+		if nextCursor == "" {
+			break
+		}
+		params.Cursor = nextCursor
+	}
+
+	return "", types.NewSlackError(types.ErrCodeChannelNotFound,
+		fmt.Sprintf("no channel named #%s is visible to the bot; private channels are only visible "+
+			"once the bot is invited", name))
+}
+
+// cacheChannel stores channel information in the channel cache, and its name in
+// the channel name cache. Conversations without a name (direct messages) are only
+// stored in the channel cache.
+func (c *Client) cacheChannel(channelInfo *types.ChannelInfo) {
+	c.channelCache.Store(channelInfo.ID, channelInfo)
+	if channelInfo.Name != "" {
+		c.channelNameCache.Store(channelInfo.Name, channelInfo.ID)
+	}
+}
@@ -0,0 +1,60 @@
+// Package slack provides tests for resolution of channel names to channel IDs.
+package slack
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"testing"
+)
+
+func TestClient_ResolveChannelName(t *testing.T) {
+	var forms []url.Values
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+		forms = append(forms, r.Form)
+		w.Header().Set("Content-Type", "application/json")
+		if r.Form.Get("cursor") == "" {
+			_, _ = w.Write([]byte(`{"ok":true,"channels":[{"id":"C01234567","name":"general","is_channel":true}],` +
+				`"response_metadata":{"next_cursor":"page2"}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"ok":true,"channels":[{"id":"G07654321","name":"eng-private","is_group":true,"is_private":true}]}`))
+	})
+	ctx := context.Background()
+
+	// A name on the second page is found by paging
+	channelID, err := client.ResolveChannelName(ctx, "eng-private")
+	if err != nil {
+		t.Fatalf("ResolveChannelName failed: %v", err)
+	}
+	if channelID != "G07654321" {
+		t.Errorf("channel ID = %q, want %q", channelID, "G07654321")
+	}
+	if len(forms) != 2 {
+		t.Fatalf("expected 2 conversations.list requests, got %d", len(forms))
+	}
+	if got := forms[0].Get("exclude_archived"); got == "true" {
+		t.Error("archived channels should be included")
+	}
+
+	// Channels seen while paging are cached, so neither call reaches the API
+	if channelID, err := client.ResolveChannelName(ctx, "general"); err != nil || channelID != "C01234567" {
+		t.Errorf("ResolveChannelName(general) = %q, %v; want C01234567", channelID, err)
+	}
+	if info, err := client.GetChannelInfo(ctx, "G07654321"); err != nil || info.Name != "eng-private" {
+		t.Errorf("GetChannelInfo = %+v, %v; want the cached channel", info, err)
+	}
+	if len(forms) != 2 {
+		t.Errorf("expected cached lookups, got %d requests", len(forms))
+	}
+
+	// An unknown name reads every page and reports channel_not_found
+	_, err = client.ResolveChannelName(ctx, "nonexistent")
+	if !IsChannelNotFound(err) {
+		t.Errorf("expected channel_not_found, got %v", err)
+	}
+	if len(forms) != 4 {
+		t.Errorf("expected 4 requests after a miss, got %d", len(forms))
+	}
+}
@@ -3,41 +3,308 @@
 package slack
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
-	"regexp"
+	"io"
+	"net/http"
+	"net/url"
 	"strings"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/slack-go/slack"
 
 	"github.com/Bitovi/slack-mcp-server/pkg/types"
 )
 
-// mentionPattern matches Slack user mentions in the format <@UXXXXXXXX>
-var mentionPattern = regexp.MustCompile(`<@(U[A-Z0-9]+)>`)
+// tombstoneSubtype is the subtype of the placeholder Slack keeps for a deleted
+// thread parent that has replies.
+const tombstoneSubtype = "tombstone"
+
+// countPageSize is the page size used when counting messages.
+// conversations.history accepts up to 1000 messages per page.
+const countPageSize = 1000
 
 // Client wraps the Slack API client to provide message and thread retrieval.
 type Client struct {
-	api          *slack.Client
-	userTokenAPI *slack.Client // User token API client for operations requiring user token (e.g., search)
-	userCache    sync.Map      // Maps user ID (string) to user display name (string)
+	userTokenAPI     *slack.Client // User token API client for methods requiring a user token (e.g., search), nil if not configured
+	adminTokenAPI    *slack.Client // Org admin token API client for admin.* methods, nil if not configured
+	adminToken       string        // Org admin token (SLACK_ADMIN_TOKEN), for admin methods slack-go does not wrap
+	auditTokenAPI    *slack.Client // Audit Logs API client, nil if not configured
+	auditToken       string        // Audit Logs API token (SLACK_AUDIT_TOKEN)
+	userCache        sync.Map      // Maps user ID (string) to *cachedUser
+	userRefreshes    sync.Map      // Set of user IDs (string) being refreshed in the background (see GetUserInfo)
+	botCache         sync.Map      // Maps bot ID (string) to *types.BotInfo
+	channelCache     sync.Map      // Maps channel ID (string) to *types.ChannelInfo
+	channelNameCache sync.Map      // Maps channel name (string) to channel ID (string)
+	dmCache          sync.Map      // Maps user ID (string) to the ID of the IM channel with that user (string)
+	archivedFallback sync.Map      // Set of archived channel IDs (string) read with the user token (see readChannel)
+	userAgent        string        // Custom User-Agent sent on every Slack API request, empty for the default
+	apiURL           string        // Slack Web API base URL, empty for commercial Slack (https://slack.com/api/)
+	sessionCookie    string        // Browser "d" cookie sent with session tokens (xoxc-), empty otherwise
+	hooks            []Hooks       // Instrumentation hooks called for every Slack API request (see WithHooks)
+	limiter          *rateLimiter  // Paces Slack API requests per method, shared by every token's API client
+	maxRetries       int           // Number of times a rate limited request is retried (see WithRetries)
+	maxRetryWait     time.Duration // Longest Retry-After waited before a retry
+	actAsUser        bool          // Calls read methods with the user token (see WithActAsUser)
+	dmReadAudit      DMReadAudit   // Allows and records DM reads in act-as-user mode, nil refuses them (see WithDMReadAudit)
+	lazyUserPrefetch bool          // Loads the user directory after repeated user cache misses (see WithLazyUserPrefetch)
+
+	userDirectoryMu     sync.Mutex   // Serializes user directory loads (see PrefetchUsers)
+	userDirectoryLoaded atomic.Bool  // Whether the user directory has been loaded into the user cache
+	userCacheMisses     atomic.Int32 // User cache misses counted toward a lazy user directory load
+
+	api      atomic.Pointer[slack.Client] // Bot token API client, used for most methods (see methodTokens); replaced by SetBotToken
+	identity atomic.Pointer[authIdentity] // Bot identity from auth.test, nil until resolved or after invalid_auth
+
+	userCacheTTL    time.Duration              // Age after which cached users are refreshed in the background, zero never refreshes
+	messageCacheTTL time.Duration              // TTL for cached messages and threads, zero disables caching
+	messageCache    *ttlCache[types.Message]   // Maps "channel:ts" to a message fetched by GetMessage
+	threadCache     *ttlCache[[]types.Message] // Maps "channel:thread_ts" to a thread fetched by GetThread
+}
+
+// authIdentity is the bot identity reported by auth.test.
+type authIdentity struct {
+	userID    string
+	workspace *types.WorkspaceInfo
+}
+
+// ClientOption configures optional Client behavior.
+type ClientOption func(*Client)
+
+// WithUserAgent sets a custom User-Agent header on every outgoing Slack API request.
+// Enterprise Slack admins use it to attribute API traffic to this server in audit reviews.
+func WithUserAgent(userAgent string) ClientOption {
+	return func(c *Client) {
+		c.userAgent = userAgent
+	}
+}
+
+// GovSlackAPIURL is the Slack Web API base URL for GovSlack, Slack's FedRAMP environment.
+const GovSlackAPIURL = "https://slack-gov.com/api/"
+
+// WithAPIURL sets the Slack Web API base URL (e.g., GovSlackAPIURL). Both the bot
+// and user token clients use it. An empty URL keeps the commercial Slack default.
+func WithAPIURL(apiURL string) ClientOption {
+	return func(c *Client) {
+		c.apiURL = apiURL
+	}
+}
+
+// WithSessionCookie sends the browser "d" cookie with every request, which Slack
+// requires alongside browser session tokens (xoxc-). Session tokens are not
+// supported by Slack for API use; this exists only as an explicit opt-in
+// compatibility mode for teams that cannot create a Slack app.
+func WithSessionCookie(cookie string) ClientOption {
+	return func(c *Client) {
+		c.sessionCookie = cookie
+	}
+}
+
+// WithAdminToken sets an Enterprise Grid org admin user token, used for admin.*
+// methods such as admin.analytics.getFile. An empty token leaves admin methods
+// unavailable.
+func WithAdminToken(token string) ClientOption {
+	return func(c *Client) {
+		c.adminToken = token
+	}
+}
+
+// WithAuditToken sets the token for the Audit Logs API: an Enterprise Grid org
+// owner's user token with the auditlogs:read scope. An empty token leaves audit
+// log reads unavailable.
+func WithAuditToken(token string) ClientOption {
+	return func(c *Client) {
+		c.auditToken = token
+	}
+}
+
+// WithMessageCacheTTL enables read-through caching of GetMessage and GetThread
+// results for the given TTL. Agents frequently re-read the same thread several
+// times within a single conversation turn, so a short TTL (30-120s) avoids
+// repeated API calls without serving noticeably stale data. A zero TTL disables caching.
+func WithMessageCacheTTL(ttl time.Duration) ClientOption {
+	return func(c *Client) {
+		c.messageCacheTTL = ttl
+	}
+}
+
+// WithUserCacheTTL sets how long cached user info is considered fresh. Past the
+// TTL, GetUserInfo still returns the cached user immediately and refreshes it in
+// the background, so renamed users catch up without adding latency to reads.
+// A zero TTL caches users for the lifetime of the client.
+func WithUserCacheTTL(ttl time.Duration) ClientOption {
+	return func(c *Client) {
+		c.userCacheTTL = ttl
+	}
 }
 
 // NewClient creates a new Slack client with the provided tokens.
 // The botToken is required for bot-level API operations (messages, channels).
 // The userToken is optional and used for user-level API operations (search).
 // If userToken is empty, search operations will return an error when called.
-func NewClient(botToken, userToken string) *Client {
+func NewClient(botToken, userToken string, opts ...ClientOption) *Client {
 	client := &Client{
-		api: slack.New(botToken),
+		limiter:      newRateLimiter(),
+		maxRetries:   DefaultMaxRetries,
+		maxRetryWait: DefaultMaxRetryWait,
 	}
+	for _, opt := range opts {
+		opt(client)
+	}
+
+	if client.messageCacheTTL > 0 {
+		client.messageCache = newTTLCache[types.Message](client.messageCacheTTL, defaultCacheMaxEntries)
+		client.threadCache = newTTLCache[[]types.Message](client.messageCacheTTL, defaultCacheMaxEntries)
+	}
+
+	apiOpts := client.apiOptions()
+	client.api.Store(slack.New(botToken, apiOpts...))
 	if userToken != "" {
-		client.userTokenAPI = slack.New(userToken)
+		client.userTokenAPI = slack.New(userToken, apiOpts...)
+	}
+	if client.adminToken != "" {
+		client.adminTokenAPI = slack.New(client.adminToken, apiOpts...)
+	}
+	if client.auditToken != "" {
+		client.auditTokenAPI = slack.New(client.auditToken,
+			slack.OptionHTTPClient(client.httpClient()), slack.OptionAPIURL(client.auditLogsURL()))
 	}
 	return client
 }
 
+// SetBotToken replaces the bot token used by subsequent API calls, e.g., when a
+// secret provider returns a rotated token. Calls already in flight finish with the
+// previous token. The cached auth.test identity is cleared so the new token is
+// re-identified on next use; other caches are kept, since a rotated token belongs
+// to the same app and workspace.
+func (c *Client) SetBotToken(botToken string) {
+	c.api.Store(slack.New(botToken, c.apiOptions()...))
+	c.identity.Store(nil)
+}
+
+// apiOptions returns the slack-go options derived from the client configuration.
+func (c *Client) apiOptions() []slack.Option {
+	opts := []slack.Option{slack.OptionHTTPClient(c.httpClient())}
+	if c.apiURL != "" {
+		opts = append(opts, slack.OptionAPIURL(c.apiURL))
+	}
+	return opts
+}
+
+// httpClient returns an HTTP client for Slack API requests, with the transports
+// derived from the client configuration.
+func (c *Client) httpClient() *http.Client {
+	var transport http.RoundTripper = http.DefaultTransport
+	if c.userAgent != "" {
+		transport = &userAgentTransport{
+			userAgent: c.userAgent,
+			base:      transport,
+		}
+	}
+	if c.sessionCookie != "" {
+		transport = &sessionCookieTransport{
+			cookie: c.sessionCookie,
+			base:   transport,
+		}
+	}
+	transport = &scopeHintTransport{base: transport}
+	if len(c.hooks) > 0 {
+		// Outside the other transports, so hooks see errors as slack-go will (e.g., missing_scope hints)
+		transport = &hooksTransport{
+			hooks: c.hooks,
+			base:  transport,
+		}
+	}
+	if c.limiter != nil {
+		// Outermost, so hooks see each attempt of a retried request
+		transport = &retryTransport{
+			limiter:    c.limiter,
+			maxRetries: c.maxRetries,
+			maxWait:    c.maxRetryWait,
+			onRetry:    c.notifyRetry,
+			base:       transport,
+		}
+	}
+
+	return &http.Client{Transport: transport}
+}
+
+// userAgentTransport is an http.RoundTripper that sets the User-Agent header on each request.
+type userAgentTransport struct {
+	userAgent string
+	base      http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *userAgentTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// RoundTrippers must not modify the original request
+	clone := req.Clone(req.Context())
+	clone.Header.Set("User-Agent", t.userAgent)
+	return t.base.RoundTrip(clone)
+}
+
+// sessionCookieTransport is an http.RoundTripper that sends the browser session
+// "d" cookie that authenticates xoxc- tokens.
+type sessionCookieTransport struct {
+	cookie string
+	base   http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *sessionCookieTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// RoundTrippers must not modify the original request
+	clone := req.Clone(req.Context())
+	clone.AddCookie(&http.Cookie{Name: "d", Value: t.cookie})
+	return t.base.RoundTrip(clone)
+}
+
+// scopeHintTransport is an http.RoundTripper that preserves the scope named in
+// missing_scope errors. Slack reports it in a "needed" field that slack-go drops,
+// so the transport appends it to the error code ("missing_scope (needed: channels:history)"),
+// where wrapSlackError can find it.
+type scopeHintTransport struct {
+	base http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *scopeHintTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.base.RoundTrip(req)
+	if err != nil || !strings.HasPrefix(resp.Header.Get("Content-Type"), "application/json") {
+		return resp, err
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	if !bytes.Contains(body, []byte(`"missing_scope"`)) {
+		return resp, nil
+	}
+
+	var fields map[string]json.RawMessage
+	var needed string
+	if json.Unmarshal(body, &fields) != nil || json.Unmarshal(fields["needed"], &needed) != nil || needed == "" {
+		return resp, nil
+	}
+
+	fields["error"], _ = json.Marshal(fmt.Sprintf("missing_scope (needed: %s)", needed))
+	rewritten, err := json.Marshal(fields)
+	if err != nil {
+		return resp, nil
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(rewritten))
+	resp.ContentLength = int64(len(rewritten))
+	resp.Header.Del("Content-Length")
+	return resp, nil
+}
+
 // GetMessage retrieves a single message from a Slack channel by its timestamp.
 //
 // Parameters:
@@ -45,19 +312,39 @@ func NewClient(botToken, userToken string) *Client {
 //   - channelID: The Slack channel ID (e.g., "C01234567")
 //   - timestamp: The message timestamp in API format (e.g., "1234567890.123456")
 //
+// Results are served from the message cache when caching is enabled, except for
+// DMs read in act-as-user mode (see WithDMReadAudit) and with WithFreshReads.
+//
 // Returns the message if found, or an error if the message cannot be retrieved.
 func (c *Client) GetMessage(ctx context.Context, channelID, timestamp string) (*types.Message, error) {
+	cacheKey := channelID + ":" + timestamp
+	cache := c.messageCache
+	if dm, _ := c.actAsUserDM(ctx, channelID); dm {
+		cache = nil
+	}
+	if cache != nil && !freshReadsEnabled(ctx) {
+		if cached, ok := cache.get(cacheKey); ok {
+			recordCacheHit(ctx)
+			return &cached, nil
+		}
+	}
+
 	params := &slack.GetConversationHistoryParameters{
-		ChannelID: channelID,
-		Oldest:    timestamp,
-		Latest:    timestamp,
-		Inclusive: true,
-		Limit:     1,
+		ChannelID:          channelID,
+		Oldest:             timestamp,
+		Latest:             timestamp,
+		Inclusive:          true,
+		Limit:              1,
+		IncludeAllMetadata: true,
 	}
 
-	history, err := c.api.GetConversationHistoryContext(ctx, params)
+	var history *slack.GetConversationHistoryResponse
+	err := c.readChannel(ctx, "conversations.history", channelID, func(api *slack.Client) (err error) {
+		history, err = api.GetConversationHistoryContext(ctx, params)
+		return err
+	})
 	if err != nil {
-		return nil, wrapSlackError(err)
+		return nil, err
 	}
 
 	if !history.Ok {
@@ -71,7 +358,13 @@ func (c *Client) GetMessage(ctx context.Context, channelID, timestamp string) (*
 	}
 
 	msg := history.Messages[0]
-	return convertMessage(&msg), nil
+	message := convertMessage(&msg)
+
+	if cache != nil {
+		cache.set(cacheKey, *message)
+	}
+
+	return message, nil
 }
 
 // GetThread retrieves all messages in a thread, including the parent message.
@@ -81,12 +374,29 @@ func (c *Client) GetMessage(ctx context.Context, channelID, timestamp string) (*
 //   - channelID: The Slack channel ID (e.g., "C01234567")
 //   - threadTS: The parent message timestamp (thread_ts) in API format
 //
+// Results are served from the thread cache when caching is enabled, except for
+// DMs read in act-as-user mode (see WithDMReadAudit) and with WithFreshReads.
+//
 // Returns all messages in the thread in chronological order, or an error
 // if the thread cannot be retrieved.
 func (c *Client) GetThread(ctx context.Context, channelID, threadTS string) ([]types.Message, error) {
+	cacheKey := channelID + ":" + threadTS
+	cache := c.threadCache
+	if dm, _ := c.actAsUserDM(ctx, channelID); dm {
+		cache = nil
+	}
+	if cache != nil && !freshReadsEnabled(ctx) {
+		if cached, ok := cache.get(cacheKey); ok {
+			recordCacheHit(ctx)
+			// Return a copy so callers can modify messages without affecting the cache
+			return append([]types.Message(nil), cached...), nil
+		}
+	}
+
 	params := &slack.GetConversationRepliesParameters{
-		ChannelID: channelID,
-		Timestamp: threadTS,
+		ChannelID:          channelID,
+		Timestamp:          threadTS,
+		IncludeAllMetadata: true,
 	}
 
 	var allMessages []types.Message
@@ -95,9 +405,15 @@ func (c *Client) GetThread(ctx context.Context, channelID, threadTS string) ([]t
 	for {
 		params.Cursor = cursor
 
-		messages, hasMore, nextCursor, err := c.api.GetConversationRepliesContext(ctx, params)
+		var messages []slack.Message
+		var hasMore bool
+		var nextCursor string
+		err := c.readChannel(ctx, "conversations.replies", channelID, func(api *slack.Client) (err error) {
+			messages, hasMore, nextCursor, err = api.GetConversationRepliesContext(ctx, params)
+			return err
+		})
 		if err != nil {
-			return nil, wrapSlackError(err)
+			return nil, err
 		}
 
 		for i := range messages {
@@ -115,6 +431,10 @@ func (c *Client) GetThread(ctx context.Context, channelID, threadTS string) ([]t
 			fmt.Sprintf("thread not found in channel %s with timestamp %s", channelID, threadTS))
 	}
 
+	if cache != nil {
+		cache.set(cacheKey, append([]types.Message(nil), allMessages...))
+	}
+
 	return allMessages, nil
 }
 
@@ -124,27 +444,46 @@ func (c *Client) HasThread(message *types.Message) bool {
 	return message != nil && message.ReplyCount > 0
 }
 
+// HistoryOptions select the messages GetChannelHistory retrieves.
+type HistoryOptions struct {
+	// Limit is the maximum number of messages to retrieve.
+	Limit int
+	// Oldest only includes messages after this Unix timestamp. Empty for no filter.
+	Oldest string
+	// Latest only includes messages before this Unix timestamp. Empty for no filter.
+	Latest string
+	// Cursor resumes from a conversations.history response_metadata cursor.
+	// Empty to start at the newest message in the window.
+	Cursor string
+	// Inclusive includes messages exactly at the Oldest and Latest boundaries.
+	// When false, boundary messages are excluded, which allows windowed pagination
+	// (passing the previous page's oldest as the next latest) without duplicates.
+	Inclusive bool
+	// IncludeAllMetadata returns the metadata apps attached to messages.
+	IncludeAllMetadata bool
+}
+
 // GetChannelHistory retrieves messages from a Slack channel.
 //
 // Parameters:
 //   - ctx: Context for cancellation and timeouts
 //   - channelID: The Slack channel ID (e.g., "C01234567")
-//   - limit: Maximum number of messages to retrieve (capped at internal maximum)
-//   - oldest: Only messages after this Unix timestamp (inclusive), empty for no filter
-//   - latest: Only messages before this Unix timestamp (inclusive), empty for no filter
+//   - opts: The window and number of messages to retrieve
 //
 // Returns messages in reverse chronological order (newest first), a boolean indicating
 // if more messages are available, or an error if the channel cannot be accessed.
-func (c *Client) GetChannelHistory(ctx context.Context, channelID string, limit int, oldest, latest string) ([]types.Message, bool, error) {
+func (c *Client) GetChannelHistory(ctx context.Context, channelID string, opts HistoryOptions) ([]types.Message, bool, error) {
 	params := &slack.GetConversationHistoryParameters{
-		ChannelID: channelID,
-		Oldest:    oldest,
-		Latest:    latest,
+		ChannelID:          channelID,
+		Oldest:             opts.Oldest,
+		Latest:             opts.Latest,
+		Inclusive:          opts.Inclusive,
+		IncludeAllMetadata: opts.IncludeAllMetadata,
 	}
 
 	var allMessages []types.Message
-	cursor := ""
-	remaining := limit
+	cursor := opts.Cursor
+	remaining := opts.Limit
 
 	for remaining > 0 {
 		params.Cursor = cursor
@@ -155,9 +494,13 @@ func (c *Client) GetChannelHistory(ctx context.Context, channelID string, limit
 			params.Limit = remaining
 		}
 
-		history, err := c.api.GetConversationHistoryContext(ctx, params)
+		var history *slack.GetConversationHistoryResponse
+		err := c.readChannel(ctx, "conversations.history", channelID, func(api *slack.Client) (err error) {
+			history, err = api.GetConversationHistoryContext(ctx, params)
+			return err
+		})
 		if err != nil {
-			return nil, false, wrapSlackError(err)
+			return nil, false, err
 		}
 
 		// Convert and append messages
@@ -176,28 +519,136 @@ func (c *Client) GetChannelHistory(ctx context.Context, channelID string, limit
 	return allMessages, true, nil // hasMore indicates more messages exist
 }
 
-// GetCurrentUser retrieves information about the currently authenticated bot user.
+// CountChannelMessages counts the messages in a Slack channel within a time window
+// without converting or returning message content.
 //
 // Parameters:
 //   - ctx: Context for cancellation and timeouts
+//   - channelID: The Slack channel ID (e.g., "C01234567")
+//   - opts: The window to count; Limit stops counting once that many messages have
+//     been seen. IncludeAllMetadata is ignored.
 //
-// This method uses the auth.test API to identify the current user, then fetches
-// their full profile information. Results are cached via GetUserInfo.
+// Pages are requested at the conversations.history maximum page size to keep the
+// number of API calls low. Returns the message count, a boolean indicating the count
+// stopped at opts.Limit before reaching the end of the window, or an error if the
+// channel cannot be accessed.
+func (c *Client) CountChannelMessages(ctx context.Context, channelID string, opts HistoryOptions) (int, bool, error) {
+	params := &slack.GetConversationHistoryParameters{
+		ChannelID: channelID,
+		Oldest:    opts.Oldest,
+		Latest:    opts.Latest,
+		Inclusive: opts.Inclusive,
+		Limit:     countPageSize,
+	}
+
+	count := 0
+	cursor := opts.Cursor
+	maxCount := opts.Limit
+
+	for count < maxCount {
+		params.Cursor = cursor
+
+		var history *slack.GetConversationHistoryResponse
+		err := c.readChannel(ctx, "conversations.history", channelID, func(api *slack.Client) (err error) {
+			history, err = api.GetConversationHistoryContext(ctx, params)
+			return err
+		})
+		if err != nil {
+			return 0, false, err
+		}
+
+		count += len(history.Messages)
+
+		if !history.HasMore {
+			return count, false, nil
+		}
+		cursor = history.ResponseMetaData.NextCursor
+	}
+
+	return maxCount, true, nil
+}
+
+// GetEarliestMessage finds the oldest message still available in a Slack channel.
+// conversations.history lists messages newest first, so the channel's history is
+// paged through at the maximum page size, as in CountChannelMessages, and the last
+// message of the last page is returned.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeouts
+//   - channelID: The Slack channel ID (e.g., "C01234567")
+//   - maxScan: Stop once this many messages have been seen
+//
+// Returns the earliest message seen (nil if the channel has no messages), a boolean
+// indicating the scan stopped at maxScan so older messages exist, or an error if
+// the channel cannot be accessed.
+func (c *Client) GetEarliestMessage(ctx context.Context, channelID string, maxScan int) (*types.Message, bool, error) {
+	params := &slack.GetConversationHistoryParameters{
+		ChannelID: channelID,
+		Limit:     countPageSize,
+	}
+
+	var earliest *types.Message
+	scanned := 0
+	for scanned < maxScan {
+		var history *slack.GetConversationHistoryResponse
+		err := c.readChannel(ctx, "conversations.history", channelID, func(api *slack.Client) (err error) {
+			history, err = api.GetConversationHistoryContext(ctx, params)
+			return err
+		})
+		if err != nil {
+			return nil, false, err
+		}
+
+		if n := len(history.Messages); n > 0 {
+			earliest = convertMessage(&history.Messages[n-1])
+			scanned += n
+		}
+
+		if !history.HasMore {
+			return earliest, false, nil
+		}
+		params.Cursor = history.ResponseMetaData.NextCursor
+	}
+
+	return earliest, true, nil
+}
+
+// GetCurrentUser retrieves information about the currently authenticated bot user,
+// or in act-as-user mode (see WithActAsUser) the user behind the user token.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeouts
+//
+// This method uses the cached auth.test identity (see identify) to find the current
+// user, then fetches their full profile information. Results are cached via GetUserInfo.
 //
 // Returns the current user info, or an error if the authentication test fails.
 func (c *Client) GetCurrentUser(ctx context.Context) (*types.UserInfo, error) {
-	// Call auth.test to get the current user ID
-	authResp, err := c.api.AuthTestContext(ctx)
+	identity, err := c.identify(ctx)
 	if err != nil {
-		return nil, wrapSlackError(err)
+		return nil, err
 	}
 
 	// Use GetUserInfo to fetch full user details (benefits from caching)
-	return c.GetUserInfo(ctx, authResp.UserID)
+	return c.GetUserInfo(ctx, identity.userID)
+}
+
+// userRefreshTimeout bounds a background refresh of a stale cached user.
+const userRefreshTimeout = 30 * time.Second
+
+// cachedUser is a user in the user cache and when it was fetched.
+type cachedUser struct {
+	info    *types.UserInfo
+	fetched time.Time
 }
 
 // GetUserInfo retrieves user information from Slack, using a cache to minimize API calls.
 //
+// A cached user older than the user cache TTL (see WithUserCacheTTL) is stale: it
+// is still returned immediately, and refreshed with users.info in the background so
+// later calls see renames and profile changes. If the refresh fails, the stale
+// user stays cached and the next call tries again.
+//
 // Parameters:
 //   - ctx: Context for cancellation and timeouts
 //   - userID: The Slack user ID (e.g., "U06025G6B28")
@@ -212,40 +663,842 @@ func (c *Client) GetUserInfo(ctx context.Context, userID string) (*types.UserInf
 
 	// Check cache first
 	if cached, ok := c.userCache.Load(userID); ok {
-		return cached.(*types.UserInfo), nil
+		recordCacheHit(ctx)
+		entry := cached.(*cachedUser)
+		if c.userCacheTTL > 0 && time.Since(entry.fetched) > c.userCacheTTL {
+			c.refreshUser(userID)
+		}
+		return entry.info, nil
 	}
 
-	// Fetch from Slack API
-	user, err := c.api.GetUserInfoContext(ctx, userID)
+	// Start loading the whole user directory for later lookups, once enough users were missing
+	c.prefetchOnMiss()
+
+	return c.fetchUser(ctx, userID)
+}
+
+// refreshUser re-fetches a stale cached user in the background. Concurrent
+// refreshes of the same user are collapsed into one.
+func (c *Client) refreshUser(userID string) {
+	if _, refreshing := c.userRefreshes.LoadOrStore(userID, struct{}{}); refreshing {
+		return
+	}
+
+	go func() {
+		defer c.userRefreshes.Delete(userID)
+
+		// The refresh outlives the tool call that found the user stale, so it is not
+		// tied to (or counted against) that call's context
+		ctx, cancel := context.WithTimeout(context.Background(), userRefreshTimeout)
+		defer cancel()
+		_, _ = c.fetchUser(ctx, userID)
+	}()
+}
+
+// fetchUser fetches user information with users.info and caches it.
+func (c *Client) fetchUser(ctx context.Context, userID string) (*types.UserInfo, error) {
+	api, err := c.apiFor("users.info")
+	if err != nil {
+		return nil, err
+	}
+	start := time.Now()
+	user, err := api.GetUserInfoContext(ctx, userID)
+	recordCall(ctx, "users.info", start, err)
 	if err != nil {
 		// Check if user was not found (deleted user)
 		errStr := err.Error()
 		if strings.Contains(errStr, "user_not_found") || strings.Contains(errStr, "users_not_found") {
-			// Return placeholder for deleted user
-			deletedUser := &types.UserInfo{
-				ID:          userID,
-				Name:        "deleted_user",
-				DisplayName: "Deleted User",
-				RealName:    "Deleted User",
-				IsBot:       false,
-				IsDeleted:   true,
-			}
-			// Cache the placeholder to avoid repeated lookups
-			c.userCache.Store(userID, deletedUser)
-			return deletedUser, nil
+			// Return placeholder for deleted user, cached to avoid repeated lookups
+			placeholder := deletedUser(userID)
+			c.userCache.Store(userID, &cachedUser{info: placeholder, fetched: time.Now()})
+			return placeholder, nil
 		}
-		return nil, wrapSlackError(err)
+		return nil, c.checkAuth(wrapSlackError(err))
 	}
 
 	// Convert to our UserInfo type
 	userInfo := convertUser(user)
 
 	// Cache the result
-	c.userCache.Store(userID, userInfo)
+	c.userCache.Store(userID, &cachedUser{info: userInfo, fetched: time.Now()})
 
 	return userInfo, nil
 }
 
+// deletedUser returns the placeholder returned for a user that users.info cannot find.
+func deletedUser(userID string) *types.UserInfo {
+	return &types.UserInfo{
+		ID:          userID,
+		Name:        "deleted_user",
+		DisplayName: "Deleted User",
+		RealName:    "Deleted User",
+		IsBot:       false,
+		IsDeleted:   true,
+	}
+}
+
+// GetWorkspaceInfo returns the workspace the bot token belongs to.
+//
+// The workspace is identified with auth.test on first use and cached for the
+// lifetime of the client, since a token cannot move between workspaces.
+//
+// Returns the workspace info, or an error if the authentication test fails.
+func (c *Client) GetWorkspaceInfo(ctx context.Context) (*types.WorkspaceInfo, error) {
+	identity, err := c.identify(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return identity.workspace, nil
+}
+
+// ValidateAuth checks the bot token with a fresh auth.test call and caches the
+// resulting identity, so it is meant to be called once at startup. Steady-state
+// tool calls then reuse the cached identity instead of calling auth.test each time.
+//
+// Returns an invalid_token error if the token is rejected by Slack.
+func (c *Client) ValidateAuth(ctx context.Context) error {
+	c.identity.Store(nil)
+	_, err := c.identify(ctx)
+	return err
+}
+
+// identify returns the bot identity, calling auth.test on first use and caching
+// the result for the lifetime of the client. The cache is cleared when any call
+// reports an invalid token (see checkAuth), forcing a refresh on the next call.
+func (c *Client) identify(ctx context.Context) (*authIdentity, error) {
+	// Check cache first
+	if cached := c.identity.Load(); cached != nil {
+		recordCacheHit(ctx)
+		return cached, nil
+	}
+
+	// Fetch from Slack API
+	api, err := c.apiFor("auth.test")
+	if err != nil {
+		return nil, err
+	}
+	start := time.Now()
+	authResp, err := api.AuthTestContext(ctx)
+	recordCall(ctx, "auth.test", start, err)
+	if err != nil {
+		return nil, wrapSlackError(err)
+	}
+
+	identity := &authIdentity{
+		userID: authResp.UserID,
+		workspace: &types.WorkspaceInfo{
+			TeamID: authResp.TeamID,
+			Name:   authResp.Team,
+			Domain: workspaceDomain(authResp.URL),
+			URL:    authResp.URL,
+		},
+	}
+
+	// Cache the result
+	c.identity.Store(identity)
+
+	return identity, nil
+}
+
+// checkAuth clears the cached auth.test identity if err reports an invalid or
+// revoked token, so the next call re-validates instead of trusting stale state.
+// It returns err unchanged.
+func (c *Client) checkAuth(err error) error {
+	if IsInvalidToken(err) {
+		c.identity.Store(nil)
+	}
+	return err
+}
+
+// workspaceDomain extracts the workspace subdomain from a workspace URL,
+// e.g., "acme" from "https://acme.slack.com/". Returns an empty string if the
+// URL cannot be parsed.
+func workspaceDomain(workspaceURL string) string {
+	parsed, err := url.Parse(workspaceURL)
+	if err != nil || parsed.Hostname() == "" {
+		return ""
+	}
+
+	domain, _, _ := strings.Cut(parsed.Hostname(), ".")
+	return domain
+}
+
+// workspaceURLFor builds the URL of the workspace with the given subdomain, on the
+// same host as a known workspace URL (so GovSlack workspaces get slack-gov.com URLs).
+// Returns an empty string if either is missing.
+func workspaceURLFor(knownURL, domain string) string {
+	parsed, err := url.Parse(knownURL)
+	if err != nil || domain == "" {
+		return ""
+	}
+
+	_, host, found := strings.Cut(parsed.Hostname(), ".")
+	if !found {
+		return ""
+	}
+	return "https://" + domain + "." + host + "/"
+}
+
+// maxWorkspacePages caps the auth.teams.list pages read by ListWorkspaces.
+const maxWorkspacePages = 10
+
+// ListWorkspaces lists the workspaces the bot token can access with auth.teams.list.
+// An app installed on one workspace sees only that workspace; an app installed
+// org-wide on Enterprise Grid sees every workspace it has been granted.
+//
+// Returns truncated=true if the token can access more workspaces than are read
+// (maxWorkspacePages pages), or an error if the listing fails.
+func (c *Client) ListWorkspaces(ctx context.Context) ([]types.WorkspaceInfo, bool, error) {
+	api, err := c.apiFor("auth.teams.list")
+	if err != nil {
+		return nil, false, err
+	}
+
+	// Workspace URLs are built on the connected workspace's host
+	var knownURL string
+	if identity, err := c.identify(ctx); err == nil {
+		knownURL = identity.workspace.URL
+	}
+
+	params := slack.ListTeamsParameters{Limit: 100}
+	var workspaces []types.WorkspaceInfo
+	for page := 0; page < maxWorkspacePages; page++ {
+		start := time.Now()
+		teams, nextCursor, err := api.ListTeamsContext(ctx, params)
+		recordCall(ctx, "auth.teams.list", start, err)
+		if err != nil {
+			return nil, false, c.checkAuth(wrapSlackError(err))
+		}
+		for _, team := range teams {
+			workspaces = append(workspaces, types.WorkspaceInfo{
+				TeamID: team.ID,
+				Name:   team.Name,
+				Domain: team.Domain,
+				URL:    workspaceURLFor(knownURL, team.Domain),
+			})
+		}
+		if nextCursor == "" {
+			return workspaces, false, nil
+		}
+		params.Cursor = nextCursor
+	}
+	return workspaces, true, nil
+}
+
+// GetChannelInfo retrieves channel information from Slack, using a cache to minimize API calls.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeouts
+//   - channelID: The Slack channel ID (e.g., "C01234567")
+//
+// Returns the channel info, or an error if the conversations.info call fails
+// (e.g., the bot lacks the channels:read or groups:read scope).
+func (c *Client) GetChannelInfo(ctx context.Context, channelID string) (*types.ChannelInfo, error) {
+	// Check cache first
+	if cached, ok := c.channelCache.Load(channelID); ok {
+		recordCacheHit(ctx)
+		return cached.(*types.ChannelInfo), nil
+	}
+
+	channel, err := c.fetchChannel(ctx, channelID)
+	if err != nil {
+		return nil, err
+	}
+	channelInfo := convertChannel(channelID, channel)
+
+	// Cache the result
+	c.cacheChannel(channelInfo)
+
+	return channelInfo, nil
+}
+
+// GetChannelAccess reports whether the bot (and the user token, if configured) can
+// read a channel. Unlike GetChannelInfo it always calls conversations.info, since
+// membership changes; the channel cache is refreshed with the result.
+//
+// A channel the bot cannot see (it does not exist, or is a private channel the bot
+// is not a member of) is reported with a nil Channel rather than as an error, so
+// the user token's membership can still be checked.
+func (c *Client) GetChannelAccess(ctx context.Context, channelID string) (*types.ChannelAccess, error) {
+	access := &types.ChannelAccess{UserTokenConfigured: c.userTokenAPI != nil}
+
+	channel, err := c.fetchChannel(ctx, channelID)
+	switch {
+	case err == nil:
+		access.Channel = convertChannel(channelID, channel)
+		c.cacheChannel(access.Channel)
+		// conversations.info only returns DMs the bot is part of, and omits is_member for them
+		access.BotIsMember = channel.IsMember || channel.IsIM || channel.IsMpIM
+	case IsChannelNotFound(err):
+		// Leave Channel nil
+	default:
+		return nil, err
+	}
+
+	if access.UserTokenConfigured {
+		member, err := c.isUserTokenMember(ctx, channelID)
+		if err != nil {
+			access.UserMembershipError = err.Error()
+		} else {
+			access.UserIsMember = &member
+		}
+	}
+
+	return access, nil
+}
+
+// maxChannelListPages caps the conversations.list pages read by ListChannelAccess.
+const maxChannelListPages = 25
+
+// ListChannelAccess lists the unarchived public and private channels visible to the
+// bot and whether the bot is a member of each. Private channels are only visible
+// to the bot if it is a member. The channel cache is filled with the results.
+//
+// Returns truncated=true if the workspace has more channels than are read
+// (maxChannelListPages pages of 200).
+func (c *Client) ListChannelAccess(ctx context.Context) ([]types.ChannelAccess, bool, error) {
+	api, err := c.apiFor("conversations.list")
+	if err != nil {
+		return nil, false, err
+	}
+
+	params := &slack.GetConversationsParameters{
+		Types:           []string{"public_channel", "private_channel"},
+		ExcludeArchived: true,
+		Limit:           200,
+	}
+	var channels []types.ChannelAccess
+	for page := 0; page < maxChannelListPages; page++ {
+		start := time.Now()
+		batch, nextCursor, err := api.GetConversationsContext(ctx, params)
+		recordCall(ctx, "conversations.list", start, err)
+		if err != nil {
+			return nil, false, c.checkAuth(wrapSlackError(err))
+		}
+		for i := range batch {
+			channelInfo := convertChannel(batch[i].ID, &batch[i])
+			c.cacheChannel(channelInfo)
+			channels = append(channels, types.ChannelAccess{Channel: channelInfo, BotIsMember: batch[i].IsMember})
+		}
+		if nextCursor == "" {
+			return channels, false, nil
+		}
+		params.Cursor = nextCursor
+	}
+	return channels, true, nil
+}
+
+// maxListChannelsPages caps the conversations.list pages read by one ListChannels call.
+// A listing that stops at the cap returns a cursor to continue from.
+const maxListChannelsPages = 10
+
+// ListChannelsOptions select the conversations ListChannels returns.
+type ListChannelsOptions struct {
+	// Types are the conversation types to list: types.ChannelTypePublic,
+	// types.ChannelTypePrivate, types.ChannelTypeIM, and/or types.ChannelTypeMPIM.
+	// Empty lists public and private channels.
+	Types []string
+	// ExcludeArchived leaves out archived channels.
+	ExcludeArchived bool
+	// MemberOnly only lists conversations the bot is a member of.
+	MemberOnly bool
+	// NameContains only lists channels whose name contains this string, ignoring case.
+	// Direct messages have no name, so they are left out when it is set.
+	NameContains string
+	// Limit is the maximum number of conversations to return.
+	Limit int
+	// Cursor continues a listing from the cursor returned by a previous call.
+	// Empty starts at the beginning.
+	Cursor string
+}
+
+// ListChannels lists the conversations visible to the bot with conversations.list,
+// filtered by opts, and whether the bot is a member of each. Private channels are
+// only visible to the bot if it is a member. The channel cache is filled with the results.
+//
+// conversations.list cannot filter by name or membership, so pages are read and
+// filtered until opts.Limit conversations match. Each page is sized to the number
+// of matches still wanted, so a listing never stops partway through a page.
+//
+// Returns the matching conversations and a cursor to continue the listing from,
+// empty if every conversation has been read.
+func (c *Client) ListChannels(ctx context.Context, opts ListChannelsOptions) ([]types.ChannelAccess, string, error) {
+	api, err := c.apiFor("conversations.list")
+	if err != nil {
+		return nil, "", err
+	}
+
+	conversationTypes := opts.Types
+	if len(conversationTypes) == 0 {
+		conversationTypes = []string{types.ChannelTypePublic, types.ChannelTypePrivate}
+	}
+	params := &slack.GetConversationsParameters{
+		Types:           conversationTypes,
+		ExcludeArchived: opts.ExcludeArchived,
+		Cursor:          opts.Cursor,
+	}
+	nameFilter := strings.ToLower(opts.NameContains)
+
+	var channels []types.ChannelAccess
+	for page := 0; page < maxListChannelsPages && len(channels) < opts.Limit; page++ {
+		// conversations.list recommends pages of at most 200
+		params.Limit = min(opts.Limit-len(channels), 200)
+
+		start := time.Now()
+		batch, nextCursor, err := api.GetConversationsContext(ctx, params)
+		recordCall(ctx, "conversations.list", start, err)
+		if err != nil {
+			return nil, "", c.checkAuth(wrapSlackError(err))
+		}
+		for i := range batch {
+			channelInfo := convertChannel(batch[i].ID, &batch[i])
+			c.cacheChannel(channelInfo)
+
+			// conversations.list only returns DMs the bot is part of, and omits is_member for them
+			isMember := batch[i].IsMember || batch[i].IsIM || batch[i].IsMpIM
+			if opts.MemberOnly && !isMember {
+				continue
+			}
+			if nameFilter != "" && !strings.Contains(strings.ToLower(channelInfo.Name), nameFilter) {
+				continue
+			}
+			channels = append(channels, types.ChannelAccess{Channel: channelInfo, BotIsMember: isMember})
+		}
+		params.Cursor = nextCursor
+		if nextCursor == "" {
+			break
+		}
+	}
+	return channels, params.Cursor, nil
+}
+
+// maxMembershipPages caps the users.conversations pages read when checking the
+// user token's membership, so users in thousands of conversations do not turn
+// one check into dozens of API calls.
+const maxMembershipPages = 10
+
+// isUserTokenMember reports whether the user token's user is a member of a channel,
+// by paging through the conversations they belong to.
+func (c *Client) isUserTokenMember(ctx context.Context, channelID string) (bool, error) {
+	api, err := c.apiFor("users.conversations")
+	if err != nil {
+		return false, err
+	}
+
+	params := &slack.GetConversationsForUserParameters{
+		Types: []string{"public_channel", "private_channel", "mpim", "im"},
+		Limit: 200,
+	}
+	for page := 0; page < maxMembershipPages; page++ {
+		start := time.Now()
+		channels, nextCursor, err := api.GetConversationsForUserContext(ctx, params)
+		recordCall(ctx, "users.conversations", start, err)
+		if err != nil {
+			return false, wrapSlackError(err)
+		}
+		for _, channel := range channels {
+			if channel.ID == channelID {
+				return true, nil
+			}
+		}
+		if nextCursor == "" {
+			return false, nil
+		}
+		params.Cursor = nextCursor
+	}
+	return false, fmt.Errorf("membership check stopped after %d conversations", maxMembershipPages*params.Limit)
+}
+
+// fetchChannel calls conversations.info for a channel.
+func (c *Client) fetchChannel(ctx context.Context, channelID string) (*slack.Channel, error) {
+	api, err := c.apiFor("conversations.info")
+	if err != nil {
+		return nil, err
+	}
+	start := time.Now()
+	channel, err := api.GetConversationInfoContext(ctx, &slack.GetConversationInfoInput{
+		ChannelID: channelID,
+	})
+	recordCall(ctx, "conversations.info", start, err)
+	if err != nil {
+		return nil, c.checkAuth(wrapSlackError(err))
+	}
+	return channel, nil
+}
+
+// convertChannel converts a Slack API channel to our ChannelInfo type.
+func convertChannel(channelID string, channel *slack.Channel) *types.ChannelInfo {
+	channelInfo := &types.ChannelInfo{
+		ID:         channelID,
+		Name:       channel.Name,
+		IsPrivate:  channel.IsPrivate,
+		IsDM:       channel.IsIM || channel.IsMpIM,
+		IsArchived: channel.IsArchived,
+		Created:    int64(channel.Created),
+		Creator:    channel.Creator,
+		User:       channel.User,
+		Type:       types.ChannelTypePublic,
+		Topic:      channel.Topic.Value,
+		Purpose:    channel.Purpose.Value,
+	}
+	if channel.Properties != nil && !channel.Properties.Canvas.IsEmpty {
+		channelInfo.CanvasID = channel.Properties.Canvas.FileId
+	}
+	switch {
+	case channel.IsIM:
+		channelInfo.Type = types.ChannelTypeIM
+	case channel.IsMpIM:
+		channelInfo.Type = types.ChannelTypeMPIM
+	case channel.IsPrivate:
+		channelInfo.Type = types.ChannelTypePrivate
+	}
+	return channelInfo
+}
+
+// GetBotInfo retrieves a bot's name and app from Slack, using a cache to minimize API calls.
+// It is used to name the bot behind messages that arrive without a bot profile.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeouts
+//   - botID: The Slack bot ID (e.g., "B01234567")
+//
+// Returns the bot info, or an error if the bots.info call fails (e.g., the bot lacks
+// the users:read scope).
+func (c *Client) GetBotInfo(ctx context.Context, botID string) (*types.BotInfo, error) {
+	// Check cache first
+	if cached, ok := c.botCache.Load(botID); ok {
+		recordCacheHit(ctx)
+		return cached.(*types.BotInfo), nil
+	}
+
+	// Fetch from Slack API
+	api, err := c.apiFor("bots.info")
+	if err != nil {
+		return nil, err
+	}
+	start := time.Now()
+	bot, err := api.GetBotInfoContext(ctx, slack.GetBotInfoParameters{Bot: botID})
+	recordCall(ctx, "bots.info", start, err)
+	if err != nil {
+		return nil, c.checkAuth(wrapSlackError(err))
+	}
+
+	botInfo := &types.BotInfo{
+		ID:     bot.ID,
+		Name:   bot.Name,
+		AppID:  bot.AppID,
+		UserID: bot.UserID,
+	}
+
+	// Cache the result
+	c.botCache.Store(botID, botInfo)
+
+	return botInfo, nil
+}
+
+// maxConversationMembers caps the members returned by GetConversationMembers.
+// It is used for group DMs, which have at most 9 members.
+const maxConversationMembers = 200
+
+// GetConversationMembers lists the user IDs of a conversation's members, such as
+// the participants of a group DM. At most maxConversationMembers are returned.
+//
+// Returns the member IDs, or an error if the conversations.members call fails
+// (e.g., the bot lacks the mpim:read scope for a group DM).
+func (c *Client) GetConversationMembers(ctx context.Context, channelID string) ([]string, error) {
+	api, err := c.apiFor("conversations.members")
+	if err != nil {
+		return nil, err
+	}
+
+	start := time.Now()
+	members, _, err := api.GetUsersInConversationContext(ctx, &slack.GetUsersInConversationParameters{
+		ChannelID: channelID,
+		Limit:     maxConversationMembers,
+	})
+	recordCall(ctx, "conversations.members", start, err)
+	if err != nil {
+		return nil, c.checkAuth(wrapSlackError(err))
+	}
+	return members, nil
+}
+
+// OpenDMChannel returns the ID of the direct message channel with a user, using a cache
+// to minimize API calls. It is used by the write tools to resolve DM deep links that
+// reference a user (e.g., https://workspace.slack.com/archives/U01234567/p...) rather
+// than a channel. Reads must not call it: conversations.open may create a conversation.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeouts
+//   - userID: The Slack user ID (e.g., "U01234567")
+//
+// Returns the IM channel ID, or an error if the conversations.open call fails
+// (e.g., the bot lacks the im:write scope).
+func (c *Client) OpenDMChannel(ctx context.Context, userID string) (string, error) {
+	// Check cache first
+	if cached, ok := c.dmCache.Load(userID); ok {
+		recordCacheHit(ctx)
+		return cached.(string), nil
+	}
+
+	// Fetch from Slack API
+	api, err := c.apiFor("conversations.open")
+	if err != nil {
+		return "", err
+	}
+	start := time.Now()
+	channel, _, _, err := api.OpenConversationContext(ctx, &slack.OpenConversationParameters{
+		Users:    []string{userID},
+		ReturnIM: true,
+	})
+	recordCall(ctx, "conversations.open", start, err)
+	if err != nil {
+		return "", c.checkAuth(wrapSlackError(err))
+	}
+
+	// Cache the result
+	c.dmCache.Store(userID, channel.ID)
+
+	return channel.ID, nil
+}
+
+// PostEphemeral shows a message to a single user in a channel, optionally in a thread.
+// Ephemeral messages are not stored in the channel history, so no cached messages
+// or threads are affected.
+//
+// Returns the timestamp Slack assigned to the message.
+func (c *Client) PostEphemeral(ctx context.Context, channelID, userID, text, threadTS string) (string, error) {
+	api, err := c.apiFor("chat.postEphemeral")
+	if err != nil {
+		return "", err
+	}
+
+	options := []slack.MsgOption{slack.MsgOptionText(EscapeText(text), false)}
+	if threadTS != "" {
+		options = append(options, slack.MsgOptionTS(threadTS))
+	}
+
+	start := time.Now()
+	messageTS, err := api.PostEphemeralContext(ctx, channelID, userID, options...)
+	recordCall(ctx, "chat.postEphemeral", start, err)
+	if err != nil {
+		return "", c.checkAuth(wrapSlackError(err))
+	}
+
+	return messageTS, nil
+}
+
+// PostMessageOptions are optional settings for PostMessage.
+type PostMessageOptions struct {
+	// ThreadTS posts the message as a reply in this thread.
+	ThreadTS string
+	// ReplyBroadcast also shows a thread reply in the channel. Requires ThreadTS.
+	ReplyBroadcast bool
+	// UnfurlLinks enables or disables link previews. Nil uses Slack's default.
+	UnfurlLinks *bool
+	// UnfurlMedia disables media previews when false. Nil or true uses Slack's default (enabled).
+	UnfurlMedia *bool
+	// Blocks are Block Kit blocks to post; the text is then the fallback for
+	// notifications and clients that cannot render blocks. Nil posts the text alone.
+	Blocks []slack.Block
+}
+
+// PostMessage posts a message to a channel, optionally as a thread reply.
+// Cached copies of the thread and its parent message are invalidated, so
+// the next read includes the reply.
+//
+// Returns the timestamp of the posted message.
+func (c *Client) PostMessage(ctx context.Context, channelID, text string, opts PostMessageOptions) (string, error) {
+	api, err := c.apiFor("chat.postMessage")
+	if err != nil {
+		return "", err
+	}
+
+	options := []slack.MsgOption{slack.MsgOptionText(EscapeText(text), false)}
+	if opts.ThreadTS != "" {
+		options = append(options, slack.MsgOptionTS(opts.ThreadTS))
+		if opts.ReplyBroadcast {
+			options = append(options, slack.MsgOptionBroadcast())
+		}
+	}
+	if opts.UnfurlLinks != nil {
+		if *opts.UnfurlLinks {
+			options = append(options, slack.MsgOptionEnableLinkUnfurl())
+		} else {
+			options = append(options, slack.MsgOptionDisableLinkUnfurl())
+		}
+	}
+	if opts.UnfurlMedia != nil && !*opts.UnfurlMedia {
+		options = append(options, slack.MsgOptionDisableMediaUnfurl())
+	}
+	if len(opts.Blocks) > 0 {
+		options = append(options, slack.MsgOptionBlocks(opts.Blocks...))
+	}
+
+	start := time.Now()
+	_, messageTS, err := api.PostMessageContext(ctx, channelID, options...)
+	recordCall(ctx, "chat.postMessage", start, err)
+	if err != nil {
+		return "", c.checkAuth(wrapSlackError(err))
+	}
+
+	if opts.ThreadTS != "" {
+		c.invalidateThread(channelID, opts.ThreadTS)
+	}
+
+	return messageTS, nil
+}
+
+// SnippetOptions are optional settings for UploadSnippet.
+type SnippetOptions struct {
+	// ThreadTS shares the snippet as a reply in this thread.
+	ThreadTS string
+	// Title is shown above the snippet. Empty uses the filename.
+	Title string
+	// SnippetType is the syntax highlighting type (e.g., "python", "diff", "sql").
+	// Empty lets Slack detect it from the filename.
+	SnippetType string
+	// InitialComment is posted as a message along with the snippet.
+	InitialComment string
+}
+
+// UploadSnippet uploads content as a text snippet file and shares it in a channel,
+// optionally as a thread reply. Cached copies of the thread are invalidated, so the
+// next read includes the reply.
+//
+// Returns the ID of the uploaded file.
+func (c *Client) UploadSnippet(ctx context.Context, channelID, filename, content string, opts SnippetOptions) (string, error) {
+	// UploadFileV2 calls files.getUploadURLExternal, uploads the content, then shares
+	// the file with files.completeUploadExternal, all with the same token
+	api, err := c.apiFor("files.completeUploadExternal")
+	if err != nil {
+		return "", err
+	}
+
+	start := time.Now()
+	file, err := api.UploadFileV2Context(ctx, slack.UploadFileV2Parameters{
+		Content:         content,
+		FileSize:        len(content),
+		Filename:        filename,
+		Title:           opts.Title,
+		SnippetType:     opts.SnippetType,
+		InitialComment:  opts.InitialComment,
+		Channel:         channelID,
+		ThreadTimestamp: opts.ThreadTS,
+	})
+	recordCall(ctx, "files.completeUploadExternal", start, err)
+	if err != nil {
+		return "", c.checkAuth(wrapSlackError(err))
+	}
+
+	if opts.ThreadTS != "" {
+		c.invalidateThread(channelID, opts.ThreadTS)
+	}
+
+	return file.ID, nil
+}
+
+// ListUserGroups lists the workspace's user groups, including disabled groups,
+// with their members.
+//
+// Returns an error if the listing fails (e.g., the usergroups:read scope is missing).
+func (c *Client) ListUserGroups(ctx context.Context) ([]types.UserGroup, error) {
+	api, err := c.apiFor("usergroups.list")
+	if err != nil {
+		return nil, err
+	}
+
+	start := time.Now()
+	groups, err := api.GetUserGroupsContext(ctx,
+		slack.GetUserGroupsOptionIncludeUsers(true),
+		slack.GetUserGroupsOptionIncludeDisabled(true))
+	recordCall(ctx, "usergroups.list", start, err)
+	if err != nil {
+		return nil, c.checkAuth(wrapSlackError(err))
+	}
+
+	result := make([]types.UserGroup, 0, len(groups))
+	for _, group := range groups {
+		result = append(result, convertUserGroup(group))
+	}
+	return result, nil
+}
+
+// UpdateUserGroupMembers replaces the members of a user group with userIDs.
+//
+// Returns the updated user group.
+func (c *Client) UpdateUserGroupMembers(ctx context.Context, userGroupID string, userIDs []string) (*types.UserGroup, error) {
+	api, err := c.apiFor("usergroups.users.update")
+	if err != nil {
+		return nil, err
+	}
+
+	start := time.Now()
+	group, err := api.UpdateUserGroupMembersContext(ctx, userGroupID, strings.Join(userIDs, ","))
+	recordCall(ctx, "usergroups.users.update", start, err)
+	if err != nil {
+		return nil, c.checkAuth(wrapSlackError(err))
+	}
+
+	result := convertUserGroup(group)
+	return &result, nil
+}
+
+// convertUserGroup converts a Slack API user group to our UserGroup type.
+func convertUserGroup(group slack.UserGroup) types.UserGroup {
+	return types.UserGroup{
+		ID:         group.ID,
+		Handle:     group.Handle,
+		Name:       group.Name,
+		IsDisabled: group.DateDelete != 0,
+		Users:      group.Users,
+	}
+}
+
+// AddReaction adds an emoji reaction (name without colons, e.g., "white_check_mark")
+// to a message. threadTS is the parent message timestamp if the message is a thread
+// reply, or empty; when empty, it is taken from the cached copy of the message, if
+// any. Cached copies of the message, its thread if it is a parent, and the thread it
+// replies to are invalidated, so the next read reflects the new reaction count.
+//
+// Returns an already_reacted error if the bot already reacted with this emoji.
+func (c *Client) AddReaction(ctx context.Context, channelID, timestamp, threadTS, name string) error {
+	api, err := c.apiFor("reactions.add")
+	if err != nil {
+		return err
+	}
+
+	start := time.Now()
+	err = api.AddReactionContext(ctx, name, slack.NewRefToMessage(channelID, timestamp))
+	recordCall(ctx, "reactions.add", start, err)
+	if err != nil {
+		return c.checkAuth(wrapSlackError(err))
+	}
+
+	if threadTS == "" && c.messageCache != nil {
+		if msg, ok := c.messageCache.get(channelID + ":" + timestamp); ok {
+			threadTS = msg.ThreadTS
+		}
+	}
+	c.invalidateThread(channelID, timestamp)
+	if threadTS != "" && threadTS != timestamp {
+		c.invalidateThread(channelID, threadTS)
+	}
+	return nil
+}
+
+// invalidateThread removes a thread and its parent message from the message caches,
+// after a write changed them (e.g., a reply was posted).
+func (c *Client) invalidateThread(channelID, threadTS string) {
+	cacheKey := channelID + ":" + threadTS
+	if c.messageCache != nil {
+		c.messageCache.delete(cacheKey)
+	}
+	if c.threadCache != nil {
+		c.threadCache.delete(cacheKey)
+	}
+}
+
 // convertUser converts a Slack API user to our UserInfo type.
 func convertUser(user *slack.User) *types.UserInfo {
 	displayName := user.Profile.DisplayName
@@ -265,18 +1518,99 @@ func convertUser(user *slack.User) *types.UserInfo {
 		RealName:    user.Profile.RealName,
 		IsBot:       user.IsBot,
 		IsDeleted:   user.Deleted,
+		IsAdmin:     user.IsAdmin,
+		IsOwner:     user.IsOwner,
 	}
 }
 
-// convertMessage converts a Slack API message to our Message type.
+// convertMessage converts a Slack API message to our Message type. The Slack message
+// is kept in Raw, so fields the conversion drops can still be inspected.
 func convertMessage(msg *slack.Message) *types.Message {
-	return &types.Message{
-		User:       msg.User,
-		Text:       msg.Text,
-		Timestamp:  msg.Timestamp,
-		ThreadTS:   msg.ThreadTimestamp,
-		ReplyCount: msg.ReplyCount,
+	reactionCount := 0
+	var reactions []types.Reaction
+	for _, reaction := range msg.Reactions {
+		reactionCount += reaction.Count
+		reactions = append(reactions, types.Reaction{
+			Name:  reaction.Name,
+			Count: reaction.Count,
+			Users: reaction.Users,
+		})
+	}
+
+	message := &types.Message{
+		User:          msg.User,
+		BotID:         msg.BotID,
+		Text:          UnescapeText(msg.Text),
+		Timestamp:     msg.Timestamp,
+		ThreadTS:      msg.ThreadTimestamp,
+		ReplyCount:    msg.ReplyCount,
+		LatestReply:   msg.LatestReply,
+		ReactionCount: reactionCount,
+		Reactions:     reactions,
+		Deleted:       msg.SubType == tombstoneSubtype,
+		Raw:           rawJSON(msg),
+	}
+	if msg.Metadata.EventType != "" {
+		message.Metadata = &types.MessageMetadata{
+			EventType:    msg.Metadata.EventType,
+			EventPayload: msg.Metadata.EventPayload,
+		}
+	}
+	for _, file := range msg.Files {
+		message.Files = append(message.Files, types.FileInfo{
+			ID:         file.ID,
+			Name:       file.Name,
+			Title:      file.Title,
+			Mimetype:   file.Mimetype,
+			Size:       file.Size,
+			URLPrivate: file.URLPrivate,
+			Permalink:  file.Permalink,
+		})
+	}
+	for _, attachment := range msg.Attachments {
+		message.Attachments = append(message.Attachments, convertAttachment(&attachment))
+	}
+	if msg.BotID != "" {
+		message.Username = msg.Username
+		if msg.BotProfile != nil {
+			message.BotName = msg.BotProfile.Name
+		}
+	}
+	return message
+}
+
+// convertAttachment converts a Slack API legacy attachment to our Attachment type,
+// unescaping its text as convertMessage does.
+func convertAttachment(attachment *slack.Attachment) types.Attachment {
+	result := types.Attachment{
+		Title:       attachment.Title,
+		TitleLink:   attachment.TitleLink,
+		Pretext:     UnescapeText(attachment.Pretext),
+		Text:        UnescapeText(attachment.Text),
+		Fallback:    UnescapeText(attachment.Fallback),
+		AuthorName:  attachment.AuthorName,
+		ServiceName: attachment.ServiceName,
+		FromURL:     attachment.FromURL,
+		ImageURL:    attachment.ImageURL,
+	}
+	for _, field := range attachment.Fields {
+		result.Fields = append(result.Fields, types.AttachmentField{
+			Title: field.Title,
+			Value: UnescapeText(field.Value),
+		})
 	}
+	return result
+}
+
+// rawJSON encodes a Slack API object as JSON. The encoding has the fields slack-go
+// decoded, with their original names and unconverted values (e.g., escaped text);
+// fields slack-go does not model are not included. Returns nil if v cannot be encoded.
+func rawJSON(v interface{}) json.RawMessage {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return nil
+	}
+	return raw
 }
 
 // SearchMessages searches for messages across the Slack workspace.
@@ -290,11 +1624,6 @@ func convertMessage(msg *slack.Message) *types.Message {
 // Returns matching messages and the total count, or an error if the search cannot be performed.
 // This method requires a user token (SLACK_USER_TOKEN) to be configured.
 func (c *Client) SearchMessages(ctx context.Context, query string, count int, sort string) ([]types.SearchMatch, int, error) {
-	// Check if user token API is configured
-	if c.userTokenAPI == nil {
-		return nil, 0, ErrUserTokenNotConfigured
-	}
-
 	// Cap count at 100 (Slack API maximum)
 	if count > 100 {
 		count = 100
@@ -315,8 +1644,14 @@ func (c *Client) SearchMessages(ctx context.Context, query string, count int, so
 		Count:         count,
 	}
 
-	// Use the user token API for search
-	results, err := c.userTokenAPI.SearchMessagesContext(ctx, query, params)
+	// Search is routed to the user token API
+	api, err := c.apiFor("search.messages")
+	if err != nil {
+		return nil, 0, err
+	}
+	start := time.Now()
+	results, err := api.SearchMessagesContext(ctx, query, params)
+	recordCall(ctx, "search.messages", start, err)
 	if err != nil {
 		return nil, 0, wrapSlackError(err)
 	}
@@ -329,46 +1664,30 @@ func (c *Client) SearchMessages(ctx context.Context, query string, count int, so
 			ChannelName: match.Channel.Name,
 			User:        match.User,
 			UserName:    match.Username,
-			Text:        match.Text,
+			Text:        UnescapeText(match.Text),
 			Timestamp:   match.Timestamp,
 			Permalink:   match.Permalink,
+			Raw:         rawJSON(match),
 		})
 	}
 
 	return matches, results.Total, nil
 }
 
-// ExtractMentions extracts unique user IDs from Slack mentions in the given text.
+// ExtractEntities extracts the user mentions, channel links, user group mentions,
+// and URLs in the given text, so results can resolve what messages refer to.
 //
-// Slack mentions follow the format <@UXXXXXXXX> where U followed by alphanumeric
-// characters represents a user ID.
+// Slack entities are written in angle brackets, optionally with a display label:
+// <@UXXXXXXXX|alias>, <#CXXXXXXXX|general>, <!subteam^SXXXXXXXX|@oncall>, and
+// <https://example.com|label>. Enterprise Grid user IDs start with W instead of U.
+// Escaped text such as &lt;@UXXXXXXXX&gt; is not an entity.
 //
 // Parameters:
-//   - text: The message text that may contain user mentions
-//
-// Returns a slice of unique user IDs found in the text. Returns an empty slice
-// if no mentions are found.
-func (c *Client) ExtractMentions(text string) []string {
-	matches := mentionPattern.FindAllStringSubmatch(text, -1)
-	if len(matches) == 0 {
-		return []string{}
-	}
-
-	// Use a map to deduplicate user IDs
-	seen := make(map[string]bool)
-	var userIDs []string
-
-	for _, match := range matches {
-		if len(match) >= 2 {
-			userID := match[1]
-			if !seen[userID] {
-				seen[userID] = true
-				userIDs = append(userIDs, userID)
-			}
-		}
-	}
-
-	return userIDs
+//   - text: The message text that may contain entities
+//
+// Returns the unique IDs (or URLs) of each type, in order of first appearance.
+func (c *Client) ExtractEntities(text string) Entities {
+	return groupEntities(text)
 }
 
 // ClientInterface defines the interface for Slack client operations.
@@ -376,12 +1695,39 @@ func (c *Client) ExtractMentions(text string) []string {
 type ClientInterface interface {
 	GetMessage(ctx context.Context, channelID, timestamp string) (*types.Message, error)
 	GetThread(ctx context.Context, channelID, threadTS string) ([]types.Message, error)
-	GetChannelHistory(ctx context.Context, channelID string, limit int, oldest, latest string) ([]types.Message, bool, error)
+	GetChannelHistory(ctx context.Context, channelID string, opts HistoryOptions) ([]types.Message, bool, error)
+	CountChannelMessages(ctx context.Context, channelID string, opts HistoryOptions) (int, bool, error)
+	GetEarliestMessage(ctx context.Context, channelID string, maxScan int) (*types.Message, bool, error)
 	HasThread(message *types.Message) bool
 	GetUserInfo(ctx context.Context, userID string) (*types.UserInfo, error)
+	GetUserProfile(ctx context.Context, userID string) (*types.UserInfo, error)
+	LookupUserByEmail(ctx context.Context, email string) (*types.UserInfo, error)
+	ListUsers(ctx context.Context) ([]types.UserInfo, bool, error)
+	GetChannelInfo(ctx context.Context, channelID string) (*types.ChannelInfo, error)
+	ResolveChannelName(ctx context.Context, name string) (string, error)
+	OpenDMChannel(ctx context.Context, userID string) (string, error)
+	PostEphemeral(ctx context.Context, channelID, userID, text, threadTS string) (string, error)
+	PostMessage(ctx context.Context, channelID, text string, opts PostMessageOptions) (string, error)
+	AddReaction(ctx context.Context, channelID, timestamp, threadTS, name string) error
+	UploadSnippet(ctx context.Context, channelID, filename, content string, opts SnippetOptions) (string, error)
+	ListUserGroups(ctx context.Context) ([]types.UserGroup, error)
+	UpdateUserGroupMembers(ctx context.Context, userGroupID string, userIDs []string) (*types.UserGroup, error)
+	ReadAnalyticsFile(ctx context.Context, fileType, date string, fn func(row map[string]interface{})) error
+	GetAuditLogs(ctx context.Context, query AuditLogQuery) ([]types.AuditEntry, string, error)
+	GetChannelAccess(ctx context.Context, channelID string) (*types.ChannelAccess, error)
 	GetCurrentUser(ctx context.Context) (*types.UserInfo, error)
-	ExtractMentions(text string) []string
+	GetWorkspaceInfo(ctx context.Context) (*types.WorkspaceInfo, error)
+	ValidateAuth(ctx context.Context) error
+	ListWorkspaces(ctx context.Context) ([]types.WorkspaceInfo, bool, error)
+	ListChannels(ctx context.Context, opts ListChannelsOptions) ([]types.ChannelAccess, string, error)
+	GetBotInfo(ctx context.Context, botID string) (*types.BotInfo, error)
+	GetConversationMembers(ctx context.Context, channelID string) ([]string, error)
+	GetDMHistory(ctx context.Context, userID string, opts HistoryOptions) (string, []types.Message, bool, error)
+	ExtractEntities(text string) Entities
 	SearchMessages(ctx context.Context, query string, count int, sort string) ([]types.SearchMatch, int, error)
+	ListPins(ctx context.Context, channelID string) ([]types.Message, error)
+	ListBookmarks(ctx context.Context, channelID string) ([]types.Bookmark, error)
+	GetCanvasSummary(ctx context.Context, channelID, canvasID string) (*types.CanvasSummary, error)
 }
 
 // Ensure Client implements ClientInterface.
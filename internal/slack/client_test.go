@@ -0,0 +1,939 @@
+// Package slack provides tests for the Slack API client wrapper.
+package slack
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/slack-go/slack"
+
+	"github.com/Bitovi/slack-mcp-server/pkg/types"
+)
+
+// newTestClient creates a Client whose bot API calls are served by handler.
+func newTestClient(t *testing.T, handler http.HandlerFunc) *Client {
+	t.Helper()
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+	client := &Client{}
+	client.api.Store(slack.New("xoxb-test", slack.OptionAPIURL(srv.URL+"/")))
+	return client
+}
+
+func TestClient_IdentityCaching(t *testing.T) {
+	var authCalls atomic.Int32
+	var tokenRevoked atomic.Bool
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if tokenRevoked.Load() {
+			_, _ = w.Write([]byte(`{"ok":false,"error":"invalid_auth"}`))
+			return
+		}
+		switch r.URL.Path {
+		case "/auth.test":
+			authCalls.Add(1)
+			_, _ = w.Write([]byte(`{"ok":true,"url":"https://acme.slack.com/","team":"Acme","team_id":"T01234567","user_id":"U01234567"}`))
+		case "/users.info":
+			_, _ = w.Write([]byte(`{"ok":true,"user":{"id":"U01234567","name":"bot"}}`))
+		default:
+			http.NotFound(w, r)
+		}
+	})
+	ctx := context.Background()
+
+	if err := client.ValidateAuth(ctx); err != nil {
+		t.Fatalf("ValidateAuth failed: %v", err)
+	}
+
+	// Steady-state calls reuse the identity from startup validation
+	for i := 0; i < 3; i++ {
+		user, err := client.GetCurrentUser(ctx)
+		if err != nil {
+			t.Fatalf("GetCurrentUser failed: %v", err)
+		}
+		if user.ID != "U01234567" {
+			t.Errorf("user ID = %q, want %q", user.ID, "U01234567")
+		}
+	}
+	workspace, err := client.GetWorkspaceInfo(ctx)
+	if err != nil {
+		t.Fatalf("GetWorkspaceInfo failed: %v", err)
+	}
+	if workspace.Domain != "acme" {
+		t.Errorf("domain = %q, want %q", workspace.Domain, "acme")
+	}
+	if got := authCalls.Load(); got != 1 {
+		t.Errorf("auth.test called %d times, want 1", got)
+	}
+
+	// An invalid_auth response from any call clears the cached identity
+	tokenRevoked.Store(true)
+	if _, err := client.GetUserInfo(ctx, "U99999999"); !IsInvalidToken(err) {
+		t.Fatalf("expected invalid token error, got %v", err)
+	}
+	if _, err := client.GetWorkspaceInfo(ctx); !IsInvalidToken(err) {
+		t.Errorf("expected GetWorkspaceInfo to re-validate and fail, got %v", err)
+	}
+
+	// Once the token works again, the identity is refreshed with a new auth.test call
+	tokenRevoked.Store(false)
+	if _, err := client.GetWorkspaceInfo(ctx); err != nil {
+		t.Fatalf("GetWorkspaceInfo failed after refresh: %v", err)
+	}
+	if got := authCalls.Load(); got != 2 {
+		t.Errorf("auth.test called %d times, want 2", got)
+	}
+}
+
+func TestClient_ValidateAuth_InvalidToken(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"ok":false,"error":"invalid_auth"}`))
+	})
+
+	if err := client.ValidateAuth(context.Background()); !IsInvalidToken(err) {
+		t.Errorf("expected invalid token error, got %v", err)
+	}
+}
+
+func TestClient_MissingScopeHint(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		wantMsg string
+	}{
+		{
+			name:    "needed scope is named",
+			body:    `{"ok":false,"error":"missing_scope","needed":"channels:history","provided":"groups:history"}`,
+			wantMsg: "Missing scope channels:history. Add it",
+		},
+		{
+			name:    "needed scope absent",
+			body:    `{"ok":false,"error":"missing_scope"}`,
+			wantMsg: "lacks a required scope",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json; charset=utf-8")
+				_, _ = w.Write([]byte(tt.body))
+			}))
+			t.Cleanup(srv.Close)
+
+			client := &Client{}
+			client.api.Store(slack.New("xoxb-test", append(client.apiOptions(), slack.OptionAPIURL(srv.URL+"/"))...))
+
+			_, err := client.GetMessage(context.Background(), "C01234567", "1355517523.000008")
+			if !IsMissingScope(err) {
+				t.Fatalf("expected missing scope error, got %v", err)
+			}
+			if !strings.Contains(err.Error(), tt.wantMsg) {
+				t.Errorf("error = %q, want to contain %q", err.Error(), tt.wantMsg)
+			}
+		})
+	}
+}
+
+func TestNewClient_WithAPIURL(t *testing.T) {
+	var authTested atomic.Bool
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/auth.test" {
+			authTested.Store(true)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"ok":true,"url":"https://agency.slack-gov.com/","team":"Agency","team_id":"T01234567","user_id":"U01234567"}`))
+	}))
+	defer srv.Close()
+
+	client := NewClient("xoxb-test", "", WithAPIURL(srv.URL+"/api/"))
+	workspace, err := client.GetWorkspaceInfo(context.Background())
+	if err != nil {
+		t.Fatalf("GetWorkspaceInfo failed: %v", err)
+	}
+	if !authTested.Load() {
+		t.Error("expected auth.test to be sent to the configured API URL")
+	}
+	if workspace.Domain != "agency" {
+		t.Errorf("domain = %q, want %q", workspace.Domain, "agency")
+	}
+}
+
+func TestNewClient_WithSessionCookie(t *testing.T) {
+	var cookie atomic.Value
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if c, err := r.Cookie("d"); err == nil {
+			cookie.Store(c.Value)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"ok":true,"url":"https://acme.slack.com/","team":"Acme","team_id":"T01234567","user_id":"U01234567"}`))
+	}))
+	defer srv.Close()
+
+	client := NewClient("xoxc-test", "", WithAPIURL(srv.URL+"/api/"), WithSessionCookie("xoxd-abc%2Fdef"))
+	if _, err := client.GetWorkspaceInfo(context.Background()); err != nil {
+		t.Fatalf("GetWorkspaceInfo failed: %v", err)
+	}
+	if got, _ := cookie.Load().(string); got != "xoxd-abc%2Fdef" {
+		t.Errorf("d cookie = %q, want %q", got, "xoxd-abc%2Fdef")
+	}
+}
+
+func TestClient_PostMessage_InvalidatesThread(t *testing.T) {
+	var form atomic.Value
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+		form.Store(r.PostForm)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"ok":true,"channel":"C01234567","ts":"1355517524.000001"}`))
+	})
+	client.messageCache = newTTLCache[types.Message](time.Minute, 10)
+	client.threadCache = newTTLCache[[]types.Message](time.Minute, 10)
+	client.messageCache.set("C01234567:1355517523.000008", types.Message{Text: "parent"})
+	client.threadCache.set("C01234567:1355517523.000008", []types.Message{{Text: "parent"}})
+
+	unfurl := false
+	ts, err := client.PostMessage(context.Background(), "C01234567", "reply", PostMessageOptions{
+		ThreadTS:       "1355517523.000008",
+		ReplyBroadcast: true,
+		UnfurlLinks:    &unfurl,
+	})
+	if err != nil {
+		t.Fatalf("PostMessage failed: %v", err)
+	}
+	if ts != "1355517524.000001" {
+		t.Errorf("ts = %q, want %q", ts, "1355517524.000001")
+	}
+
+	sent := form.Load().(url.Values)
+	for key, want := range map[string]string{"thread_ts": "1355517523.000008", "reply_broadcast": "true", "unfurl_links": "false"} {
+		if got := sent.Get(key); got != want {
+			t.Errorf("%s = %q, want %q", key, got, want)
+		}
+	}
+
+	if _, ok := client.messageCache.get("C01234567:1355517523.000008"); ok {
+		t.Error("expected parent message to be removed from the cache")
+	}
+	if _, ok := client.threadCache.get("C01234567:1355517523.000008"); ok {
+		t.Error("expected thread to be removed from the cache")
+	}
+}
+
+func TestClient_PostMessage_Blocks(t *testing.T) {
+	var form atomic.Value
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+		form.Store(r.PostForm)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"ok":true,"channel":"C01234567","ts":"1355517524.000001"}`))
+	})
+
+	blocks := []slack.Block{slack.NewDividerBlock()}
+	if _, err := client.PostMessage(context.Background(), "C01234567", "fallback", PostMessageOptions{Blocks: blocks}); err != nil {
+		t.Fatalf("PostMessage failed: %v", err)
+	}
+
+	sent := form.Load().(url.Values)
+	if got := sent.Get("blocks"); got != `[{"type":"divider"}]` {
+		t.Errorf("blocks = %q, want the divider block", got)
+	}
+	if got := sent.Get("text"); got != "fallback" {
+		t.Errorf("text = %q, want the fallback text", got)
+	}
+}
+
+func TestClient_TextEscaping(t *testing.T) {
+	var posted atomic.Value
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/conversations.history":
+			_, _ = w.Write([]byte(`{"ok":true,"messages":[{"type":"message","user":"U01234567",` +
+				`"text":"Q&amp;A: is a &lt; b? <https://example.com/?a=1&amp;b=2|docs>","ts":"1355517523.000008"}]}`))
+		case "/chat.postMessage":
+			posted.Store(r.PostForm.Get("text"))
+			_, _ = w.Write([]byte(`{"ok":true,"channel":"C01234567","ts":"1355517524.000001"}`))
+		}
+	})
+
+	message, err := client.GetMessage(context.Background(), "C01234567", "1355517523.000008")
+	if err != nil {
+		t.Fatalf("GetMessage failed: %v", err)
+	}
+	if want := "Q&A: is a < b? <https://example.com/?a=1&b=2|docs>"; message.Text != want {
+		t.Errorf("Text = %q, want %q", message.Text, want)
+	}
+	// The raw payload keeps the text as Slack sent it
+	var raw struct {
+		Text string `json:"text"`
+	}
+	if err := json.Unmarshal(message.Raw, &raw); err != nil || !strings.Contains(raw.Text, "Q&amp;A") {
+		t.Errorf("Raw = %s (%v), want the escaped Slack text", message.Raw, err)
+	}
+
+	if _, err := client.PostMessage(context.Background(), "C01234567", message.Text+" <@U01234567>", PostMessageOptions{}); err != nil {
+		t.Fatalf("PostMessage failed: %v", err)
+	}
+	if got, want := posted.Load(), "Q&amp;A: is a &lt; b? <https://example.com/?a=1&amp;b=2|docs> <@U01234567>"; got != want {
+		t.Errorf("posted text = %q, want %q", got, want)
+	}
+}
+
+func TestCallStats_Resolution(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		time.Sleep(5 * time.Millisecond)
+		if strings.HasSuffix(r.URL.Path, "/users.info") {
+			_, _ = w.Write([]byte(`{"ok":true,"user":{"id":"U01234567","name":"alice"}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"ok":true,"messages":[]}`))
+	})
+
+	ctx, stats := WithCallStats(context.Background())
+	// A nested middleware shares the outer record
+	if nested, nestedStats := WithCallStats(ctx); nested != ctx || nestedStats != stats {
+		t.Fatal("expected WithCallStats to reuse the CallStats already in the context")
+	}
+
+	if _, _, err := client.GetChannelHistory(ctx, "C01234567", HistoryOptions{Limit: 10}); err != nil {
+		t.Fatalf("GetChannelHistory failed: %v", err)
+	}
+	if _, err := client.GetUserInfo(ctx, "U01234567"); err != nil {
+		t.Fatalf("GetUserInfo failed: %v", err)
+	}
+
+	summary := stats.Summary()
+	if summary.Calls != 2 || summary.ResolutionLatencyMS < 5 {
+		t.Errorf("calls = %d, resolution latency = %dms, want 2 and at least 5ms", summary.Calls, summary.ResolutionLatencyMS)
+	}
+	if summary.ResolutionLatencyMS >= summary.TotalLatencyMS {
+		t.Errorf("resolution latency %dms should exclude the history call (total %dms)",
+			summary.ResolutionLatencyMS, summary.TotalLatencyMS)
+	}
+}
+
+func TestClient_GetUserInfo_StaleWhileRevalidate(t *testing.T) {
+	var calls atomic.Int32
+	release := make(chan struct{})
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		<-release
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"ok":true,"user":{"id":"U01234567","name":"alice","profile":{"display_name":"Alice Renamed"}}}`))
+	})
+	client.userCacheTTL = time.Minute
+	client.userCache.Store("U01234567", &cachedUser{
+		info:    &types.UserInfo{ID: "U01234567", Name: "alice", DisplayName: "Alice"},
+		fetched: time.Now().Add(-2 * time.Minute),
+	})
+	ctx := context.Background()
+
+	// A stale user is returned without waiting for the refresh, which is only started once
+	for i := 0; i < 3; i++ {
+		user, err := client.GetUserInfo(ctx, "U01234567")
+		if err != nil {
+			t.Fatalf("GetUserInfo failed: %v", err)
+		}
+		if user.DisplayName != "Alice" {
+			t.Fatalf("display name = %q, want the stale %q", user.DisplayName, "Alice")
+		}
+	}
+	close(release)
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		if _, refreshing := client.userRefreshes.Load("U01234567"); !refreshing {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("background refresh did not finish")
+		}
+		time.Sleep(time.Millisecond)
+	}
+	if calls.Load() != 1 {
+		t.Errorf("expected 1 users.info call, got %d", calls.Load())
+	}
+
+	// The refreshed user is fresh, so it is served without another call
+	user, err := client.GetUserInfo(ctx, "U01234567")
+	if err != nil {
+		t.Fatalf("GetUserInfo failed: %v", err)
+	}
+	if user.DisplayName != "Alice Renamed" {
+		t.Errorf("display name = %q, want the refreshed %q", user.DisplayName, "Alice Renamed")
+	}
+	if calls.Load() != 1 {
+		t.Errorf("expected no call for a fresh user, got %d calls", calls.Load())
+	}
+}
+
+func TestClient_UpdateUserGroupMembers(t *testing.T) {
+	var users string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = r.ParseForm()
+		users = r.PostForm.Get("users")
+		if r.PostForm.Get("usergroup") == "S07654321" {
+			_, _ = w.Write([]byte(`{"ok":false,"error":"permission_denied"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"ok":true,"usergroup":{"id":"S01234567","handle":"oncall","name":"On-call","date_delete":0,"users":["U01111111","U02222222"]}}`))
+	})
+
+	group, err := client.UpdateUserGroupMembers(context.Background(), "S01234567", []string{"U01111111", "U02222222"})
+	if err != nil {
+		t.Fatalf("UpdateUserGroupMembers failed: %v", err)
+	}
+	if users != "U01111111,U02222222" {
+		t.Errorf("users = %q, want %q", users, "U01111111,U02222222")
+	}
+	if group.Handle != "oncall" || len(group.Users) != 2 || group.IsDisabled {
+		t.Errorf("unexpected group %+v", group)
+	}
+
+	if _, err := client.UpdateUserGroupMembers(context.Background(), "S07654321", []string{"U01111111"}); !IsPermissionDenied(err) {
+		t.Errorf("expected permission_denied error, got %v", err)
+	}
+}
+
+func TestClient_ListPins(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"ok":true,"items":[` +
+			`{"type":"message","channel":"C01234567","message":{"type":"message","user":"U01234567","text":"Runbook","ts":"1705314600.000001"}},` +
+			`{"type":"file","file":{"id":"F01234567","name":"plan.pdf"}}]}`))
+	})
+
+	pins, err := client.ListPins(context.Background(), "C01234567")
+	if err != nil {
+		t.Fatalf("ListPins failed: %v", err)
+	}
+	if len(pins) != 1 || pins[0].Text != "Runbook" || pins[0].Timestamp != "1705314600.000001" {
+		t.Errorf("pins = %+v, want only the pinned message", pins)
+	}
+}
+
+func TestClient_GetEarliestMessage(t *testing.T) {
+	var calls atomic.Int32
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		// Two pages of history, newest first
+		if calls.Add(1) == 1 {
+			_, _ = w.Write([]byte(`{"ok":true,"messages":[{"type":"message","text":"newest","ts":"3.0"},{"type":"message","text":"middle","ts":"2.0"}],` +
+				`"has_more":true,"response_metadata":{"next_cursor":"page2"}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"ok":true,"messages":[{"type":"message","text":"first","ts":"1.0"}],"has_more":false}`))
+	})
+
+	earliest, capped, err := client.GetEarliestMessage(context.Background(), "C01234567", 10000)
+	if err != nil {
+		t.Fatalf("GetEarliestMessage failed: %v", err)
+	}
+	if capped || earliest == nil || earliest.Text != "first" {
+		t.Errorf("earliest = %+v, capped = %v, want the first message", earliest, capped)
+	}
+
+	// A scan limit reached before the end of the history reports older messages
+	calls.Store(0)
+	earliest, capped, err = client.GetEarliestMessage(context.Background(), "C01234567", 2)
+	if err != nil {
+		t.Fatalf("GetEarliestMessage failed: %v", err)
+	}
+	if !capped || earliest == nil || earliest.Text != "middle" {
+		t.Errorf("earliest = %+v, capped = %v, want the middle message and capped", earliest, capped)
+	}
+}
+
+func TestClient_AddReaction_AlreadyReacted(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"ok":false,"error":"already_reacted"}`))
+	})
+
+	err := client.AddReaction(context.Background(), "C01234567", "1355517523.000008", "", "eyes")
+	if !IsAlreadyReacted(err) {
+		t.Errorf("expected already_reacted error, got %v", err)
+	}
+}
+
+func TestClient_AddReaction_InvalidatesParentThread(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"ok":true}`))
+	})
+	client.messageCache = newTTLCache[types.Message](time.Minute, 10)
+	client.threadCache = newTTLCache[[]types.Message](time.Minute, 10)
+	cacheThread := func() {
+		client.messageCache.set("C01234567:1355517524.000001", types.Message{Text: "reply", ThreadTS: "1355517523.000008"})
+		client.threadCache.set("C01234567:1355517523.000008", []types.Message{{Text: "parent"}, {Text: "reply"}})
+	}
+
+	// The parent thread is given by the caller
+	cacheThread()
+	if err := client.AddReaction(context.Background(), "C01234567", "1355517524.000001", "1355517523.000008", "eyes"); err != nil {
+		t.Fatalf("AddReaction failed: %v", err)
+	}
+	if _, ok := client.threadCache.get("C01234567:1355517523.000008"); ok {
+		t.Error("expected the reply's thread to be removed from the cache")
+	}
+	if _, ok := client.messageCache.get("C01234567:1355517524.000001"); ok {
+		t.Error("expected the reply to be removed from the cache")
+	}
+
+	// The parent thread is taken from the cached reply
+	cacheThread()
+	if err := client.AddReaction(context.Background(), "C01234567", "1355517524.000001", "", "eyes"); err != nil {
+		t.Fatalf("AddReaction failed: %v", err)
+	}
+	if _, ok := client.threadCache.get("C01234567:1355517523.000008"); ok {
+		t.Error("expected the thread of the cached reply to be removed from the cache")
+	}
+}
+
+func TestClient_GetChannelAccess(t *testing.T) {
+	var userCalls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/conversations.info":
+			if r.PostForm.Get("channel") == "C0PRIVATE" {
+				_, _ = w.Write([]byte(`{"ok":false,"error":"channel_not_found"}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"ok":true,"channel":{"id":"C01234567","name":"old-project","is_channel":true,"is_archived":true,"is_member":false}}`))
+		case "/users.conversations":
+			// The channel is on the second page
+			if userCalls.Add(1) == 1 {
+				_, _ = w.Write([]byte(`{"ok":true,"channels":[{"id":"C0OTHER"}],"response_metadata":{"next_cursor":"page2"}}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"ok":true,"channels":[{"id":"C01234567"},{"id":"C0PRIVATE"}],"response_metadata":{"next_cursor":""}}`))
+		}
+	}))
+	defer srv.Close()
+
+	api := slack.New("xoxb-test", slack.OptionAPIURL(srv.URL+"/"))
+	client := &Client{userTokenAPI: slack.New("xoxp-test", slack.OptionAPIURL(srv.URL+"/"))}
+	client.api.Store(api)
+
+	access, err := client.GetChannelAccess(context.Background(), "C01234567")
+	if err != nil {
+		t.Fatalf("GetChannelAccess failed: %v", err)
+	}
+	if access.Channel == nil || !access.Channel.IsArchived {
+		t.Errorf("channel = %+v, want archived channel", access.Channel)
+	}
+	if access.BotIsMember {
+		t.Error("expected bot not to be a member")
+	}
+	if access.UserIsMember == nil || !*access.UserIsMember {
+		t.Errorf("user is member = %v, want true", access.UserIsMember)
+	}
+	if userCalls.Load() != 2 {
+		t.Errorf("expected 2 users.conversations pages, got %d", userCalls.Load())
+	}
+
+	// A channel the bot cannot see is not an error
+	access, err = client.GetChannelAccess(context.Background(), "C0PRIVATE")
+	if err != nil {
+		t.Fatalf("GetChannelAccess failed: %v", err)
+	}
+	if access.Channel != nil {
+		t.Errorf("channel = %+v, want nil", access.Channel)
+	}
+	if access.UserIsMember == nil || !*access.UserIsMember {
+		t.Errorf("user is member = %v, want true", access.UserIsMember)
+	}
+}
+
+func TestClient_ListChannelAccess(t *testing.T) {
+	var calls atomic.Int32
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if calls.Add(1) == 1 {
+			_, _ = w.Write([]byte(`{"ok":true,"channels":[{"id":"C01234567","name":"general","is_channel":true,"is_member":true}],"response_metadata":{"next_cursor":"page2"}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"ok":true,"channels":[{"id":"C07654321","name":"random","is_channel":true}],"response_metadata":{"next_cursor":""}}`))
+	})
+
+	channels, truncated, err := client.ListChannelAccess(context.Background())
+	if err != nil {
+		t.Fatalf("ListChannelAccess failed: %v", err)
+	}
+	if truncated {
+		t.Error("expected a complete listing")
+	}
+	if len(channels) != 2 || !channels[0].BotIsMember || channels[1].BotIsMember {
+		t.Fatalf("unexpected channels: %+v", channels)
+	}
+	if _, ok := client.channelCache.Load("C07654321"); !ok {
+		t.Error("expected listed channels to be cached")
+	}
+}
+
+func TestClient_ListChannels(t *testing.T) {
+	var forms []url.Values
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+		forms = append(forms, r.Form)
+		w.Header().Set("Content-Type", "application/json")
+		if len(forms) == 1 {
+			_, _ = w.Write([]byte(`{"ok":true,"channels":[{"id":"C01234567","name":"eng-general","is_channel":true,"is_member":true},` +
+				`{"id":"C07654321","name":"random","is_channel":true,"is_member":true},` +
+				`{"id":"C09999999","name":"eng-oncall","is_channel":true}],"response_metadata":{"next_cursor":"page2"}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"ok":true,"channels":[{"id":"C05555555","name":"ENG-infra","is_channel":true,"is_member":true}],"response_metadata":{"next_cursor":"page3"}}`))
+	})
+
+	channels, nextCursor, err := client.ListChannels(context.Background(), ListChannelsOptions{
+		Types:        []string{types.ChannelTypePublic},
+		MemberOnly:   true,
+		NameContains: "eng",
+		Limit:        2,
+	})
+	if err != nil {
+		t.Fatalf("ListChannels failed: %v", err)
+	}
+	if len(channels) != 2 || channels[0].Channel.ID != "C01234567" || channels[1].Channel.ID != "C05555555" {
+		t.Fatalf("unexpected channels: %+v", channels)
+	}
+	if nextCursor != "page3" {
+		t.Errorf("next cursor = %q, want %q", nextCursor, "page3")
+	}
+
+	// Each page asks for only the matches still wanted, so no page is cut short
+	if len(forms) != 2 || forms[0].Get("limit") != "2" || forms[1].Get("limit") != "1" {
+		t.Fatalf("unexpected requests: %v", forms)
+	}
+	if got := forms[1].Get("cursor"); got != "page2" {
+		t.Errorf("second page cursor = %q, want %q", got, "page2")
+	}
+	if got := forms[0].Get("types"); got != "public_channel" {
+		t.Errorf("types = %q, want %q", got, "public_channel")
+	}
+}
+
+func TestClient_ListWorkspaces(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/auth.test":
+			_, _ = w.Write([]byte(`{"ok":true,"user_id":"UBOT","team_id":"T01234567","team":"Acme","url":"https://acme.slack-gov.com/"}`))
+		case "/auth.teams.list":
+			_, _ = w.Write([]byte(`{"ok":true,"teams":[{"id":"T01234567","name":"Acme","domain":"acme"},{"id":"T07654321","name":"Acme Labs","domain":"acme-labs"}]}`))
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+	})
+
+	workspaces, truncated, err := client.ListWorkspaces(context.Background())
+	if err != nil {
+		t.Fatalf("ListWorkspaces failed: %v", err)
+	}
+	if truncated {
+		t.Error("expected a complete listing")
+	}
+	if len(workspaces) != 2 || workspaces[1].TeamID != "T07654321" || workspaces[1].Domain != "acme-labs" {
+		t.Fatalf("unexpected workspaces: %+v", workspaces)
+	}
+	if workspaces[1].URL != "https://acme-labs.slack-gov.com/" {
+		t.Errorf("URL = %q, want it on the connected workspace's host", workspaces[1].URL)
+	}
+}
+
+func TestClient_GetChannelHistory_Options(t *testing.T) {
+	var forms []url.Values
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+		forms = append(forms, r.PostForm)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"ok":true,"has_more":true,"response_metadata":{"next_cursor":"page3"},` +
+			`"messages":[{"type":"message","user":"U01234567","text":"hi","ts":"1355517523.000008"}]}`))
+	})
+
+	messages, hasMore, err := client.GetChannelHistory(context.Background(), "C01234567", HistoryOptions{
+		Limit:              2,
+		Oldest:             "1355517000",
+		Latest:             "1355518000",
+		Cursor:             "page2",
+		Inclusive:          true,
+		IncludeAllMetadata: true,
+	})
+	if err != nil {
+		t.Fatalf("GetChannelHistory failed: %v", err)
+	}
+	if len(messages) != 2 || !hasMore {
+		t.Fatalf("got %d messages, hasMore %v; want 2 and true", len(messages), hasMore)
+	}
+	if len(forms) != 2 {
+		t.Fatalf("got %d requests, want 2", len(forms))
+	}
+
+	first := forms[0]
+	for name, want := range map[string]string{
+		"oldest": "1355517000", "latest": "1355518000", "cursor": "page2",
+		"inclusive": "1", "include_all_metadata": "1", "limit": "2",
+	} {
+		if got := first.Get(name); got != want {
+			t.Errorf("%s = %q, want %q", name, got, want)
+		}
+	}
+	if got := forms[1].Get("cursor"); got != "page3" {
+		t.Errorf("second page cursor = %q, want page3", got)
+	}
+}
+
+func TestClient_CountChannelMessages(t *testing.T) {
+	var forms []url.Values
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+		forms = append(forms, r.PostForm)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"ok":true,"messages":[{"type":"message","ts":"1700000002.000100"},` +
+			`{"type":"message","ts":"1700000001.000100"}],"has_more":true,"response_metadata":{"next_cursor":"next"}}`))
+	})
+
+	count, capped, err := client.CountChannelMessages(context.Background(), "C01234567", HistoryOptions{
+		Limit:     3,
+		Oldest:    "1700000000",
+		Latest:    "1700086400",
+		Cursor:    "start",
+		Inclusive: true,
+	})
+	if err != nil {
+		t.Fatalf("CountChannelMessages failed: %v", err)
+	}
+	if count != 3 || !capped {
+		t.Errorf("count = %d, capped = %v; want 3, true", count, capped)
+	}
+	if len(forms) != 2 {
+		t.Fatalf("expected 2 history requests, got %d", len(forms))
+	}
+	first := forms[0]
+	if first.Get("oldest") != "1700000000" || first.Get("latest") != "1700086400" ||
+		first.Get("inclusive") != "1" || first.Get("cursor") != "start" {
+		t.Errorf("unexpected first request %v", first)
+	}
+	if got := forms[1].Get("cursor"); got != "next" {
+		t.Errorf("second page cursor = %q, want next", got)
+	}
+}
+
+func TestClient_GetChannelHistory_ArchivedChannel(t *testing.T) {
+	var userTokenReads atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.URL.Path == "/conversations.info":
+			_, _ = w.Write([]byte(`{"ok":true,"channel":{"id":"C01234567","name":"inc-42","is_channel":true,"is_archived":true}}`))
+		case r.PostForm.Get("token") == "xoxp-test":
+			userTokenReads.Add(1)
+			_, _ = w.Write([]byte(`{"ok":true,"messages":[{"type":"message","user":"U01234567","text":"resolved","ts":"1355517523.000008"}]}`))
+		default:
+			_, _ = w.Write([]byte(`{"ok":false,"error":"not_in_channel"}`))
+		}
+	}))
+	defer srv.Close()
+
+	client := &Client{userTokenAPI: slack.New("xoxp-test", slack.OptionAPIURL(srv.URL+"/"))}
+	client.api.Store(slack.New("xoxb-test", slack.OptionAPIURL(srv.URL+"/")))
+
+	// Without archived reads, the failure is reported as channel_archived
+	_, _, err := client.GetChannelHistory(context.Background(), "C01234567", HistoryOptions{Limit: 10})
+	if !IsChannelArchived(err) {
+		t.Fatalf("expected channel_archived error, got %v", err)
+	}
+	if userTokenReads.Load() != 0 {
+		t.Fatal("expected no user token reads without archived reads enabled")
+	}
+
+	// With archived reads, the user token is used
+	ctx := WithArchivedReads(context.Background())
+	messages, _, err := client.GetChannelHistory(ctx, "C01234567", HistoryOptions{Limit: 10})
+	if err != nil {
+		t.Fatalf("GetChannelHistory failed: %v", err)
+	}
+	if len(messages) != 1 || messages[0].Text != "resolved" {
+		t.Errorf("unexpected messages: %+v", messages)
+	}
+
+	// Later reads go straight to the user token
+	ctx, stats := WithCallStats(ctx)
+	if _, _, err := client.GetChannelHistory(ctx, "C01234567", HistoryOptions{Limit: 10}); err != nil {
+		t.Fatalf("GetChannelHistory failed: %v", err)
+	}
+	if summary := stats.Summary(); summary.Calls != 1 {
+		t.Errorf("calls = %d, want 1", summary.Calls)
+	}
+	if userTokenReads.Load() != 2 {
+		t.Errorf("user token reads = %d, want 2", userTokenReads.Load())
+	}
+}
+
+func TestClient_GetChannelHistory_ArchivedAfterCaching(t *testing.T) {
+	var infoCalls atomic.Int32
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/conversations.info":
+			infoCalls.Add(1)
+			_, _ = w.Write([]byte(`{"ok":true,"channel":{"id":"C01234567","name":"inc-42","is_channel":true,"is_archived":true}}`))
+		default:
+			_, _ = w.Write([]byte(`{"ok":false,"error":"not_in_channel"}`))
+		}
+	})
+
+	// The channel was cached while it was still active
+	client.cacheChannel(&types.ChannelInfo{ID: "C01234567", Name: "inc-42"})
+
+	_, _, err := client.GetChannelHistory(context.Background(), "C01234567", HistoryOptions{Limit: 10})
+	if !IsChannelArchived(err) {
+		t.Fatalf("expected channel_archived error, got %v", err)
+	}
+	if infoCalls.Load() != 1 {
+		t.Errorf("conversations.info calls = %d, want 1", infoCalls.Load())
+	}
+
+	// The cache is refreshed with the archived state
+	channel, err := client.GetChannelInfo(context.Background(), "C01234567")
+	if err != nil {
+		t.Fatalf("GetChannelInfo failed: %v", err)
+	}
+	if !channel.IsArchived {
+		t.Error("expected the cached channel to be archived")
+	}
+}
+
+func TestClient_GetMessage_Tombstone(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"ok":true,"messages":[{"type":"message","subtype":"tombstone","text":"This message was deleted.","ts":"1355517523.000008","reply_count":2}]}`))
+	})
+
+	message, err := client.GetMessage(context.Background(), "C01234567", "1355517523.000008")
+	if err != nil {
+		t.Fatalf("GetMessage failed: %v", err)
+	}
+	if !message.Deleted {
+		t.Error("expected tombstone to be marked deleted")
+	}
+}
+
+func TestClient_GetMessage_BotMessage(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"ok":true,"messages":[{"type":"message","subtype":"bot_message","bot_id":"B01234567","username":"New Joiner Intake","bot_profile":{"id":"B01234567","name":"Workflow Builder"},"text":"Welcome!","ts":"1355517523.000008"}]}`))
+	})
+
+	message, err := client.GetMessage(context.Background(), "C01234567", "1355517523.000008")
+	if err != nil {
+		t.Fatalf("GetMessage failed: %v", err)
+	}
+	if message.BotID != "B01234567" || message.BotName != "Workflow Builder" || message.Username != "New Joiner Intake" {
+		t.Errorf("unexpected bot fields: %+v", message)
+	}
+}
+
+func TestClient_GetMessage_Reactions(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"ok":true,"messages":[{"type":"message","user":"U01234567","text":"Ship it?","ts":"1355517523.000008","reactions":[{"name":"+1","count":2,"users":["U11111111","U22222222"]},{"name":"eyes","count":1,"users":["U11111111"]}]}]}`))
+	})
+
+	message, err := client.GetMessage(context.Background(), "C01234567", "1355517523.000008")
+	if err != nil {
+		t.Fatalf("GetMessage failed: %v", err)
+	}
+	if message.ReactionCount != 3 || len(message.Reactions) != 2 {
+		t.Fatalf("unexpected reactions: count %d, %+v", message.ReactionCount, message.Reactions)
+	}
+	if r := message.Reactions[0]; r.Name != "+1" || r.Count != 2 || len(r.Users) != 2 || r.Users[1] != "U22222222" {
+		t.Errorf("unexpected reaction: %+v", r)
+	}
+}
+
+func TestClient_GetMessage_FilesAndAttachments(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"ok":true,"messages":[{"type":"message","user":"U01234567","text":"Q3 numbers","ts":"1355517523.000008",` +
+			`"files":[{"id":"F01234567","name":"q3.pdf","title":"Q3 report","mimetype":"application/pdf","size":52480,"url_private":"https://files.slack.com/files-pri/T1-F01234567/q3.pdf"}],` +
+			`"attachments":[{"title":"Fix login","title_link":"https://github.com/acme/api/pull/42","text":"a &lt; b","service_name":"GitHub","fields":[{"title":"Status","value":"Open"}]}]}]}`))
+	})
+
+	message, err := client.GetMessage(context.Background(), "C01234567", "1355517523.000008")
+	if err != nil {
+		t.Fatalf("GetMessage failed: %v", err)
+	}
+	if len(message.Files) != 1 {
+		t.Fatalf("files = %+v, want one", message.Files)
+	}
+	if f := message.Files[0]; f.Name != "q3.pdf" || f.Mimetype != "application/pdf" || f.Size != 52480 ||
+		f.URLPrivate != "https://files.slack.com/files-pri/T1-F01234567/q3.pdf" {
+		t.Errorf("unexpected file: %+v", f)
+	}
+	if len(message.Attachments) != 1 {
+		t.Fatalf("attachments = %+v, want one", message.Attachments)
+	}
+	if a := message.Attachments[0]; a.Title != "Fix login" || a.Text != "a < b" || a.ServiceName != "GitHub" ||
+		len(a.Fields) != 1 || a.Fields[0].Value != "Open" {
+		t.Errorf("unexpected attachment: %+v", a)
+	}
+}
+
+func TestClient_GetBotInfo_Cached(t *testing.T) {
+	var calls atomic.Int32
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"ok":true,"bot":{"id":"B01234567","name":"PagerDuty","app_id":"A01234567"}}`))
+	})
+
+	for i := 0; i < 2; i++ {
+		bot, err := client.GetBotInfo(context.Background(), "B01234567")
+		if err != nil {
+			t.Fatalf("GetBotInfo failed: %v", err)
+		}
+		if bot.Name != "PagerDuty" || bot.AppID != "A01234567" {
+			t.Errorf("unexpected bot %+v", bot)
+		}
+	}
+	if calls.Load() != 1 {
+		t.Errorf("expected 1 bots.info call, got %d", calls.Load())
+	}
+}
+
+func TestClient_GetMessage_Metadata(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+		if r.PostForm.Get("include_all_metadata") != "1" {
+			t.Errorf("include_all_metadata = %q, want 1", r.PostForm.Get("include_all_metadata"))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"ok":true,"messages":[{"type":"message","bot_id":"B01234567","text":"Incident declared","ts":"1355517523.000008",` +
+			`"metadata":{"event_type":"incident_created","event_payload":{"id":"INC-42","severity":"sev1"}}}]}`))
+	})
+
+	message, err := client.GetMessage(context.Background(), "C01234567", "1355517523.000008")
+	if err != nil {
+		t.Fatalf("GetMessage failed: %v", err)
+	}
+	if message.Metadata == nil || message.Metadata.EventType != "incident_created" || message.Metadata.EventPayload["id"] != "INC-42" {
+		t.Errorf("unexpected metadata: %+v", message.Metadata)
+	}
+}
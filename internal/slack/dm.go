@@ -0,0 +1,104 @@
+// Package slack provides reads of the user token's direct messages for the Slack client.
+package slack
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/slack-go/slack"
+
+	"github.com/Bitovi/slack-mcp-server/pkg/types"
+)
+
+// GetDMHistory reads the direct message conversation between the user token's user
+// and another user. It reads the human's DMs, so it is only reachable through the
+// read_dm_history tool and its config gate; the channel read tools refuse user IDs.
+//
+// The conversation is found by listing the user token's IMs with users.conversations
+// (im:read), rather than opening it with conversations.open, so a read never creates a
+// conversation. Its history is then read with the user token (im:history).
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeouts
+//   - userID: The other user in the conversation (e.g., "U01234567")
+//   - opts: The window and number of messages to retrieve
+//
+// Returns the IM channel ID, its messages newest first, and whether more messages are
+// available. Returns a channel_not_found error if the user token's user has no DM with
+// the user, or a user_token_not_configured error if SLACK_USER_TOKEN is not set.
+func (c *Client) GetDMHistory(ctx context.Context, userID string, opts HistoryOptions) (string, []types.Message, bool, error) {
+	channelID, err := c.findUserTokenDM(ctx, userID)
+	if err != nil {
+		return "", nil, false, err
+	}
+
+	// Routed to the bot token for channel reads; DM reads always use the user token,
+	// which users.conversations above has already checked is configured
+	api := c.userTokenAPI
+	params := &slack.GetConversationHistoryParameters{
+		ChannelID:          channelID,
+		Oldest:             opts.Oldest,
+		Latest:             opts.Latest,
+		Cursor:             opts.Cursor,
+		Inclusive:          opts.Inclusive,
+		IncludeAllMetadata: opts.IncludeAllMetadata,
+	}
+
+	var messages []types.Message
+	for remaining := opts.Limit; remaining > 0; remaining = opts.Limit - len(messages) {
+		// Slack API limit is 100 per request
+		params.Limit = min(remaining, 100)
+
+		start := time.Now()
+		history, err := api.GetConversationHistoryContext(ctx, params)
+		recordCall(ctx, "conversations.history", start, err)
+		if err != nil {
+			return "", nil, false, wrapSlackError(err)
+		}
+
+		for i := range history.Messages {
+			messages = append(messages, *convertMessage(&history.Messages[i]))
+		}
+		if !history.HasMore {
+			return channelID, messages, false, nil
+		}
+		params.Cursor = history.ResponseMetaData.NextCursor
+	}
+
+	return channelID, messages, true, nil
+}
+
+// findUserTokenDM returns the ID of the user token's IM with a user, paging through
+// the user token's IMs up to maxMembershipPages pages.
+func (c *Client) findUserTokenDM(ctx context.Context, userID string) (string, error) {
+	api, err := c.apiFor("users.conversations")
+	if err != nil {
+		return "", err
+	}
+
+	params := &slack.GetConversationsForUserParameters{
+		Types: []string{"im"},
+		Limit: 200,
+	}
+	for page := 0; page < maxMembershipPages; page++ {
+		start := time.Now()
+		channels, nextCursor, err := api.GetConversationsForUserContext(ctx, params)
+		recordCall(ctx, "users.conversations", start, err)
+		if err != nil {
+			return "", wrapSlackError(err)
+		}
+		for _, channel := range channels {
+			if channel.User == userID {
+				return channel.ID, nil
+			}
+		}
+		if nextCursor == "" {
+			break
+		}
+		params.Cursor = nextCursor
+	}
+
+	return "", types.NewSlackError(types.ErrCodeChannelNotFound,
+		fmt.Sprintf("no direct message conversation with user %s was found for the user token's user", userID))
+}
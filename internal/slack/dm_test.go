@@ -0,0 +1,56 @@
+// Package slack provides tests for reads of the user token's direct messages.
+package slack
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/slack-go/slack"
+)
+
+func TestClient_GetDMHistory(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+		if token := r.PostForm.Get("token"); token != "xoxp-user" {
+			t.Errorf("%s called with token %q, want the user token", r.URL.Path, token)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/users.conversations":
+			_, _ = w.Write([]byte(`{"ok":true,"channels":[{"id":"D01111111","is_im":true,"user":"U01111111"},{"id":"D02222222","is_im":true,"user":"U02222222"}]}`))
+		case "/conversations.history":
+			if r.PostForm.Get("channel") != "D02222222" {
+				t.Errorf("read channel %q, want D02222222", r.PostForm.Get("channel"))
+			}
+			_, _ = w.Write([]byte(`{"ok":true,"messages":[{"type":"message","user":"U02222222","text":"hi","ts":"1700000000.000100"}],"has_more":false}`))
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	client := NewClient("xoxb-test", "xoxp-user", WithAPIURL(srv.URL+"/"))
+
+	channelID, messages, hasMore, err := client.GetDMHistory(context.Background(), "U02222222", HistoryOptions{Limit: 50})
+	if err != nil {
+		t.Fatalf("GetDMHistory failed: %v", err)
+	}
+	if channelID != "D02222222" || len(messages) != 1 || messages[0].Text != "hi" || hasMore {
+		t.Errorf("unexpected result %s %+v %v", channelID, messages, hasMore)
+	}
+
+	_, _, _, err = client.GetDMHistory(context.Background(), "U03333333", HistoryOptions{Limit: 50})
+	if !IsChannelNotFound(err) {
+		t.Errorf("expected channel_not_found for a user with no DM, got %v", err)
+	}
+}
+
+func TestClient_GetDMHistory_NoUserToken(t *testing.T) {
+	client := &Client{}
+	client.api.Store(slack.New("xoxb-test"))
+	_, _, _, err := client.GetDMHistory(context.Background(), "U02222222", HistoryOptions{Limit: 50})
+	if !IsUserTokenNotConfigured(err) {
+		t.Errorf("expected user_token_not_configured error, got %v", err)
+	}
+}
@@ -0,0 +1,172 @@
+// Package slack provides extraction of Slack's angle-bracket entities from message text.
+package slack
+
+import (
+	"regexp"
+	"strings"
+)
+
+// EntityType identifies the kind of a Slack message text entity.
+type EntityType string
+
+const (
+	// EntityUser is a user mention: <@U01234567> or <@U01234567|alias>.
+	// Enterprise Grid user IDs start with W.
+	EntityUser EntityType = "user"
+	// EntityChannel is a channel link: <#C01234567> or <#C01234567|general>.
+	EntityChannel EntityType = "channel"
+	// EntityUserGroup is a user group mention: <!subteam^S01234567> or <!subteam^S01234567|@oncall>.
+	EntityUserGroup EntityType = "usergroup"
+	// EntityBroadcast is a special mention: <!here>, <!channel>, or <!everyone>.
+	EntityBroadcast EntityType = "broadcast"
+	// EntityLink is a URL: <https://example.com> or <https://example.com|label>.
+	EntityLink EntityType = "link"
+	// EntityDate is a date shown in each reader's time zone: <!date^1392734382^{date}|Feb 18>.
+	// Its ID is the Unix timestamp.
+	EntityDate EntityType = "date"
+)
+
+// Entity is a Slack entity found in message text.
+type Entity struct {
+	// Type is the kind of entity.
+	Type EntityType
+	// ID is the entity's target: a user, channel, or user group ID, the broadcast
+	// name (e.g., "here"), or the URL.
+	ID string
+	// Label is the display text after '|', if the entity has one.
+	Label string
+}
+
+// entityPattern matches one angle-bracket entity and splits its target from its
+// optional label. In Slack's escaped text (&lt; and &gt;), only real entities contain
+// literal angle brackets; in text from UnescapeText, so can literal text.
+var entityPattern = regexp.MustCompile(`<([^<>|]+)(?:\|([^<>]*))?>`)
+
+// entityKinds maps entity targets to entity types. Each pattern matches a whole
+// target, and its first group is the entity's ID. Targets are checked in order;
+// targets no pattern matches are skipped, and escaped by EscapeText. To recognize
+// a new kind of entity, add a row.
+var entityKinds = []struct {
+	entityType EntityType
+	pattern    *regexp.Regexp
+}{
+	{EntityUser, regexp.MustCompile(`^@([UW][A-Z0-9]+)$`)},
+	{EntityChannel, regexp.MustCompile(`^#([CGD][A-Z0-9]+)$`)},
+	{EntityUserGroup, regexp.MustCompile(`^!subteam\^([A-Z0-9]+)$`)},
+	{EntityBroadcast, regexp.MustCompile(`^!(here|channel|everyone)$`)},
+	{EntityLink, regexp.MustCompile(`^((?:https?|mailto|tel):\S+)$`)},
+	{EntityDate, regexp.MustCompile(`^!date\^([0-9]+)\^\S+`)},
+}
+
+// ExtractEntities returns the entities in message text, in order of appearance.
+// Returns nil if there are none.
+func ExtractEntities(text string) []Entity {
+	var entities []Entity
+	for _, match := range entityPattern.FindAllStringSubmatch(text, -1) {
+		target, label := match[1], match[2]
+		for _, kind := range entityKinds {
+			if m := kind.pattern.FindStringSubmatch(target); m != nil {
+				entities = append(entities, Entity{Type: kind.entityType, ID: m[1], Label: label})
+				break
+			}
+		}
+	}
+	return entities
+}
+
+// Entities holds the IDs of the entities in message text, grouped by type. Each
+// slice has unique IDs in order of first appearance, and is nil if there are none.
+type Entities struct {
+	// Users are the IDs of the mentioned users.
+	Users []string
+	// Channels are the IDs of the linked channels.
+	Channels []string
+	// UserGroups are the IDs of the mentioned user groups.
+	UserGroups []string
+	// Links are the linked URLs.
+	Links []string
+}
+
+// groupEntities returns the entities in text grouped by type. Broadcasts and dates
+// are left out; they name nothing to resolve.
+func groupEntities(text string) Entities {
+	var entities Entities
+	seen := make(map[Entity]bool)
+	for _, entity := range ExtractEntities(text) {
+		key := Entity{Type: entity.Type, ID: entity.ID}
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		switch entity.Type {
+		case EntityUser:
+			entities.Users = append(entities.Users, entity.ID)
+		case EntityChannel:
+			entities.Channels = append(entities.Channels, entity.ID)
+		case EntityUserGroup:
+			entities.UserGroups = append(entities.UserGroups, entity.ID)
+		case EntityLink:
+			entities.Links = append(entities.Links, entity.ID)
+		}
+	}
+	return entities
+}
+
+// textUnescaper reverses Slack's escaping of &, <, and > in one pass, so "&amp;lt;"
+// becomes "&lt;" rather than "<".
+var textUnescaper = strings.NewReplacer("&lt;", "<", "&gt;", ">", "&amp;", "&")
+
+// escapes maps the characters Slack requires escaped to their escape sequences.
+var escapes = map[string]string{"&": "&amp;", "<": "&lt;", ">": "&gt;"}
+
+// escapeSequencePattern matches the escape sequences Slack uses, or a character that needs one.
+var escapeSequencePattern = regexp.MustCompile(`&(?:amp|lt|gt);|[&<>]`)
+
+// UnescapeText reverses the escaping Slack applies to &, <, and > in message text,
+// so text reads as it was typed. Entities such as <@U01234567> keep their angle
+// brackets, and escapes inside them (e.g., in URLs) are reversed too.
+//
+// Unescaped text no longer distinguishes a typed "<@U01234567>" from a mention.
+func UnescapeText(text string) string {
+	return textUnescaper.Replace(text)
+}
+
+// EscapeText escapes &, <, and > as Slack requires in message text, leaving the
+// entities ExtractEntities recognizes (mentions, channel links, URLs) intact so
+// they still render. Existing &amp;, &lt;, and &gt; sequences are kept as they
+// are, so text that is already escaped is not escaped twice.
+func EscapeText(text string) string {
+	var b strings.Builder
+	last := 0
+	for _, loc := range entityPattern.FindAllStringSubmatchIndex(text, -1) {
+		if !isEntityTarget(text[loc[2]:loc[3]]) {
+			continue
+		}
+		b.WriteString(escapeFragment(text[last:loc[0]], "<>&"))
+		// Entities keep their brackets, but & in URLs and labels is still escaped
+		b.WriteString(escapeFragment(text[loc[0]:loc[1]], "&"))
+		last = loc[1]
+	}
+	b.WriteString(escapeFragment(text[last:], "<>&"))
+	return b.String()
+}
+
+// escapeFragment escapes the given characters in text, skipping existing escape sequences.
+func escapeFragment(text, chars string) string {
+	return escapeSequencePattern.ReplaceAllStringFunc(text, func(s string) string {
+		if len(s) > 1 || !strings.Contains(chars, s) {
+			return s
+		}
+		return escapes[s]
+	})
+}
+
+// isEntityTarget reports whether an entity target is one of the recognized entity kinds.
+func isEntityTarget(target string) bool {
+	for _, kind := range entityKinds {
+		if kind.pattern.MatchString(target) {
+			return true
+		}
+	}
+	return false
+}
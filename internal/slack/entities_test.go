@@ -0,0 +1,167 @@
+// Package slack provides tests for Slack message text entity extraction.
+package slack
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestExtractEntities(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want []Entity
+	}{
+		{
+			name: "user mention",
+			text: "hi <@U01234567>",
+			want: []Entity{{Type: EntityUser, ID: "U01234567"}},
+		},
+		{
+			name: "user mention with alias",
+			text: "hi <@U01234567|jsmith>",
+			want: []Entity{{Type: EntityUser, ID: "U01234567", Label: "jsmith"}},
+		},
+		{
+			name: "enterprise user mention",
+			text: "cc <@W01234567>",
+			want: []Entity{{Type: EntityUser, ID: "W01234567"}},
+		},
+		{
+			name: "channel, group, broadcast, and link",
+			text: "<!here> <!subteam^S01234567|@oncall> see <#C01234567|incidents> and <https://example.com/a?b=1&amp;c=2|the doc>",
+			want: []Entity{
+				{Type: EntityBroadcast, ID: "here"},
+				{Type: EntityUserGroup, ID: "S01234567", Label: "@oncall"},
+				{Type: EntityChannel, ID: "C01234567", Label: "incidents"},
+				{Type: EntityLink, ID: "https://example.com/a?b=1&amp;c=2", Label: "the doc"},
+			},
+		},
+		{
+			name: "escaped text is not an entity",
+			text: "type &lt;@U01234567&gt; to mention someone",
+			want: nil,
+		},
+		{
+			name: "date",
+			text: "due <!date^1392734382^{date_short}|Feb 18, 2014>",
+			want: []Entity{{Type: EntityDate, ID: "1392734382", Label: "Feb 18, 2014"}},
+		},
+		{
+			name: "unknown entities are skipped",
+			text: "<b>bold</b> <@not-a-user> <@u01234567>",
+			want: nil,
+		},
+		{
+			name: "unterminated entity",
+			text: "<@U01234567 and <@U07654321>",
+			want: []Entity{{Type: EntityUser, ID: "U07654321"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ExtractEntities(tt.text); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ExtractEntities() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClient_ExtractEntities(t *testing.T) {
+	client := &Client{}
+	tests := []struct {
+		name string
+		text string
+		want Entities
+	}{
+		{name: "no entities", text: "hello", want: Entities{}},
+		{name: "plain mention", text: "<@U01234567> hi", want: Entities{Users: []string{"U01234567"}}},
+		{name: "alias mention", text: "<@U01234567|jsmith> hi", want: Entities{Users: []string{"U01234567"}}},
+		{name: "enterprise mention", text: "<@W01234567>", want: Entities{Users: []string{"W01234567"}}},
+		{
+			name: "deduplicated in order",
+			text: "<@U07654321> <@U01234567|jsmith> <@U07654321|adoe>",
+			want: Entities{Users: []string{"U07654321", "U01234567"}},
+		},
+		{
+			name: "grouped by type",
+			text: "<!subteam^S01234567|@oncall> see <#C01234567|general> and <https://example.com|docs>, <!here> <#C01234567>",
+			want: Entities{
+				Channels:   []string{"C01234567"},
+				UserGroups: []string{"S01234567"},
+				Links:      []string{"https://example.com"},
+			},
+		},
+		{name: "escaped mention", text: "&lt;@U01234567&gt;", want: Entities{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := client.ExtractEntities(tt.text); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ExtractEntities() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUnescapeText(t *testing.T) {
+	tests := []struct {
+		text string
+		want string
+	}{
+		{text: "a &lt; b &amp;&amp; c &gt; d", want: "a < b && c > d"},
+		{text: "<@U01234567> see <https://example.com/?a=1&amp;b=2|docs>", want: "<@U01234567> see <https://example.com/?a=1&b=2|docs>"},
+		// Escaped escape sequences are unescaped once
+		{text: "type &amp;lt; for <", want: "type &lt; for <"},
+		{text: "plain", want: "plain"},
+	}
+
+	for _, tt := range tests {
+		if got := UnescapeText(tt.text); got != tt.want {
+			t.Errorf("UnescapeText(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestEscapeText(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{name: "plain text", text: "if a < b && c > d", want: "if a &lt; b &amp;&amp; c &gt; d"},
+		{
+			name: "entities are kept",
+			text: "<!here> <@U01234567|jsmith> moved to <#C01234567> due <!date^1392734382^{date}|Feb 18>",
+			want: "<!here> <@U01234567|jsmith> moved to <#C01234567> due <!date^1392734382^{date}|Feb 18>",
+		},
+		{
+			name: "ampersands in links are escaped",
+			text: "<https://example.com/?a=1&b=2|Q&A>",
+			want: "<https://example.com/?a=1&amp;b=2|Q&amp;A>",
+		},
+		{name: "unknown tags are escaped", text: "<b>bold</b>", want: "&lt;b&gt;bold&lt;/b&gt;"},
+		{name: "already escaped", text: "a &lt; b &amp; <@U01234567>", want: "a &lt; b &amp; <@U01234567>"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := EscapeText(tt.text); got != tt.want {
+				t.Errorf("EscapeText(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEscapeText_RoundTrip(t *testing.T) {
+	// Text read from Slack and posted back is sent as Slack sent it
+	for _, text := range []string{
+		"a &lt; b &amp;&amp; c &gt; d",
+		"<@U01234567> see <https://example.com/?a=1&amp;b=2|docs> &amp; <#C01234567|general>",
+	} {
+		if got := EscapeText(UnescapeText(text)); got != text {
+			t.Errorf("EscapeText(UnescapeText(%q)) = %q", text, got)
+		}
+	}
+}
@@ -4,8 +4,11 @@ package slack
 import (
 	"errors"
 	"fmt"
+	"regexp"
 	"strings"
 
+	"github.com/slack-go/slack"
+
 	"github.com/Bitovi/slack-mcp-server/pkg/types"
 )
 
@@ -24,17 +27,33 @@ var (
 	// ErrNotInChannel indicates the bot is not a member of the channel.
 	ErrNotInChannel = types.NewSlackError(types.ErrCodeNotInChannel, "bot not in channel")
 
+	// ErrUserNotInChannel indicates a target user is not a member of the channel.
+	ErrUserNotInChannel = types.NewSlackError(types.ErrCodeUserNotInChannel, "user not in channel")
+
 	// ErrMessageNotFound indicates the message could not be found.
 	ErrMessageNotFound = types.NewSlackError(types.ErrCodeMessageNotFound, "message not found")
 
+	// ErrChannelArchived indicates the channel is archived.
+	ErrChannelArchived = types.NewSlackError(types.ErrCodeChannelArchived, "channel archived")
+
 	// ErrPermissionDenied indicates the bot lacks required permissions.
 	ErrPermissionDenied = types.NewSlackError(types.ErrCodePermissionDenied, "permission denied")
 
+	// ErrMissingScope indicates the Slack app lacks a required OAuth scope.
+	ErrMissingScope = types.NewSlackError(types.ErrCodeMissingScope, "missing scope")
+
+	// ErrAlreadyReacted indicates the bot already added the reaction to the message.
+	ErrAlreadyReacted = types.NewSlackError(types.ErrCodeAlreadyReacted, "already reacted")
+
 	// ErrUserTokenNotConfigured indicates the SLACK_USER_TOKEN is not set.
 	ErrUserTokenNotConfigured = types.NewSlackError(types.ErrCodeUserTokenNotConfigured,
 		"SLACK_USER_TOKEN not configured. Search requires a user token (xoxp-) with search:read scope.")
 )
 
+// neededScopePattern extracts the scope from a missing_scope error annotated by scopeHintTransport,
+// e.g., "missing_scope (needed: channels:history)".
+var neededScopePattern = regexp.MustCompile(`missing_scope \(needed: ([^)]+)\)`)
+
 // IsRateLimited checks if the error is a rate limiting error.
 func IsRateLimited(err error) bool {
 	return isSlackErrorCode(err, types.ErrCodeRateLimited)
@@ -55,21 +74,56 @@ func IsNotInChannel(err error) bool {
 	return isSlackErrorCode(err, types.ErrCodeNotInChannel)
 }
 
+// IsUserNotInChannel checks if the error is a "user not in channel" error.
+func IsUserNotInChannel(err error) bool {
+	return isSlackErrorCode(err, types.ErrCodeUserNotInChannel)
+}
+
 // IsMessageNotFound checks if the error is a message not found error.
 func IsMessageNotFound(err error) bool {
 	return isSlackErrorCode(err, types.ErrCodeMessageNotFound)
 }
 
+// IsChannelArchived checks if the error is an archived channel error.
+func IsChannelArchived(err error) bool {
+	return isSlackErrorCode(err, types.ErrCodeChannelArchived)
+}
+
+// IsDMReadNotAllowed checks if the error is a refused direct message read in act-as-user mode.
+func IsDMReadNotAllowed(err error) bool {
+	return isSlackErrorCode(err, types.ErrCodeDMReadNotAllowed)
+}
+
 // IsPermissionDenied checks if the error is a permission denied error.
 func IsPermissionDenied(err error) bool {
 	return isSlackErrorCode(err, types.ErrCodePermissionDenied)
 }
 
+// IsMissingScope checks if the error is a missing OAuth scope error.
+func IsMissingScope(err error) bool {
+	return isSlackErrorCode(err, types.ErrCodeMissingScope)
+}
+
+// IsAlreadyReacted checks if the error is an "already reacted" error.
+func IsAlreadyReacted(err error) bool {
+	return isSlackErrorCode(err, types.ErrCodeAlreadyReacted)
+}
+
 // IsUserTokenNotConfigured checks if the error is a user token not configured error.
 func IsUserTokenNotConfigured(err error) bool {
 	return isSlackErrorCode(err, types.ErrCodeUserTokenNotConfigured)
 }
 
+// IsAdminTokenNotConfigured checks if the error is an admin token not configured error.
+func IsAdminTokenNotConfigured(err error) bool {
+	return isSlackErrorCode(err, types.ErrCodeAdminTokenNotConfigured)
+}
+
+// IsAuditTokenNotConfigured checks if the error is an audit token not configured error.
+func IsAuditTokenNotConfigured(err error) bool {
+	return isSlackErrorCode(err, types.ErrCodeAuditTokenNotConfigured)
+}
+
 // isSlackErrorCode checks if the error is a SlackError with the given code.
 func isSlackErrorCode(err error, code string) bool {
 	var slackErr *types.SlackError
@@ -99,8 +153,9 @@ func wrapSlackError(err error) error {
 
 	errStr := err.Error()
 
-	// Check for rate limiting
-	if strings.Contains(errStr, "rate_limit") || strings.Contains(errStr, "ratelimited") {
+	// Check for rate limiting (HTTP 429 responses arrive as *slack.RateLimitedError)
+	var rateLimited *slack.RateLimitedError
+	if errors.As(err, &rateLimited) || strings.Contains(errStr, "rate_limit") || strings.Contains(errStr, "ratelimited") {
 		return types.NewSlackError(types.ErrCodeRateLimited,
 			"Slack API rate limit exceeded. Please wait and try again.")
 	}
@@ -111,10 +166,21 @@ func wrapSlackError(err error) error {
 			"Invalid or expired Slack bot token. Please check your SLACK_BOT_TOKEN.")
 	}
 
-	// Check for token scope errors
-	if strings.Contains(errStr, "missing_scope") || strings.Contains(errStr, "token_expired") {
+	// Check for missing scopes, naming the scope when Slack reported it (see scopeHintTransport)
+	if strings.Contains(errStr, "missing_scope") {
+		if matches := neededScopePattern.FindStringSubmatch(errStr); matches != nil {
+			return types.NewSlackError(types.ErrCodeMissingScope, fmt.Sprintf(
+				"Missing scope %s. Add it under OAuth & Permissions in the Slack app settings and reinstall the app.",
+				matches[1]))
+		}
+		return types.NewSlackError(types.ErrCodeMissingScope,
+			"Slack token lacks a required scope. Check the scopes under OAuth & Permissions and reinstall the app.")
+	}
+
+	// Check for expired tokens
+	if strings.Contains(errStr, "token_expired") {
 		return types.NewSlackError(types.ErrCodeInvalidToken,
-			"Slack bot token lacks required scopes or has expired.")
+			"Slack token has expired.")
 	}
 
 	// Check for channel not found
@@ -123,16 +189,34 @@ func wrapSlackError(err error) error {
 			"Channel not found. The channel may have been deleted or the ID is incorrect.")
 	}
 
+	// Check for a target user not in channel (before not_in_channel, which it contains)
+	if strings.Contains(errStr, "user_not_in_channel") {
+		return types.NewSlackError(types.ErrCodeUserNotInChannel,
+			"User is not a member of this channel.")
+	}
+
 	// Check for not in channel
 	if strings.Contains(errStr, "not_in_channel") {
 		return types.NewSlackError(types.ErrCodeNotInChannel,
 			"Bot is not a member of this channel. Please invite the bot to the channel.")
 	}
 
-	// Check for permission denied
-	if strings.Contains(errStr, "access_denied") || strings.Contains(errStr, "is_archived") {
+	// Check for archived channels (e.g., posting to one)
+	if strings.Contains(errStr, "is_archived") {
+		return types.NewSlackError(types.ErrCodeChannelArchived,
+			"Channel is archived.")
+	}
+
+	// Check for permission denied (permission_denied is used by, e.g., usergroups.users.update)
+	if strings.Contains(errStr, "access_denied") || strings.Contains(errStr, "permission_denied") {
 		return types.NewSlackError(types.ErrCodePermissionDenied,
-			"Access denied. The channel may be archived or the bot lacks permissions.")
+			"Access denied. The bot lacks permissions.")
+	}
+
+	// Check for a reaction the bot already added
+	if strings.Contains(errStr, "already_reacted") {
+		return types.NewSlackError(types.ErrCodeAlreadyReacted,
+			"The bot already added this reaction to the message.")
 	}
 
 	// Check for message not found
@@ -142,5 +226,5 @@ func wrapSlackError(err error) error {
 	}
 
 	// Generic error wrapping
-	return types.NewSlackError("slack_error", fmt.Sprintf("Slack API error: %s", errStr))
+	return types.NewSlackError(types.ErrCodeSlackError, fmt.Sprintf("Slack API error: %s", errStr))
 }
@@ -0,0 +1,144 @@
+// Package slack provides instrumentation hooks for observing Slack API traffic.
+package slack
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/slack-go/slack"
+)
+
+// Hooks observe the Slack API requests a Client makes, so metrics, tracing, and
+// audit layers can watch API traffic without changes to individual client methods.
+//
+// Any hook may be nil. Hooks are called synchronously on the goroutine making the
+// request, so they should return quickly, and must be safe for concurrent use.
+type Hooks struct {
+	// OnRequest is called before each HTTP request to Slack.
+	OnRequest func(ctx context.Context, method string)
+	// OnResponse is called after each HTTP request to Slack completes or fails.
+	OnResponse func(ctx context.Context, call CallInfo)
+	// OnRetry is called before the client retries a failed call, e.g., after a rate
+	// limit or with the user token for an archived channel. err is the error of the
+	// failed attempt. The retried request is reported to OnRequest and OnResponse again.
+	OnRetry func(ctx context.Context, method string, err error)
+}
+
+// CallInfo describes a completed Slack API request.
+type CallInfo struct {
+	// Method is the Slack API method (e.g., "conversations.history").
+	Method string
+	// StatusCode is the HTTP status code, or zero if no response was received.
+	StatusCode int
+	// Duration is the time from sending the request to receiving the response headers.
+	Duration time.Duration
+	// Err is the request's error: the transport error, or the Slack error for a
+	// response with "ok": false or HTTP 429, classified like the client's own errors
+	// (see IsRateLimited and GetErrorCode). Nil for a successful call.
+	Err error
+}
+
+// WithHooks adds hooks that observe the client's Slack API requests. It may be
+// given more than once; hooks are called in the order they were added.
+func WithHooks(hooks Hooks) ClientOption {
+	return func(c *Client) {
+		c.hooks = append(c.hooks, hooks)
+	}
+}
+
+// notifyRetry records a retry on the CallStats in ctx and reports it to the OnRetry hooks.
+func (c *Client) notifyRetry(ctx context.Context, method string, err error) {
+	recordRetry(ctx)
+	for _, hooks := range c.hooks {
+		if hooks.OnRetry != nil {
+			hooks.OnRetry(ctx, method, err)
+		}
+	}
+}
+
+// hooksTransport is an http.RoundTripper that reports each request to the client's hooks.
+type hooksTransport struct {
+	hooks []Hooks
+	base  http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *hooksTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	method := apiMethod(req)
+	for _, hooks := range t.hooks {
+		if hooks.OnRequest != nil {
+			hooks.OnRequest(ctx, method)
+		}
+	}
+
+	start := time.Now()
+	resp, err := t.base.RoundTrip(req)
+	call := CallInfo{Method: method, Duration: time.Since(start), Err: err}
+	if err == nil {
+		call.StatusCode = resp.StatusCode
+		call.Err, err = responseError(resp)
+		if err != nil {
+			call.Err = err
+			resp = nil
+		}
+	}
+
+	for _, hooks := range t.hooks {
+		if hooks.OnResponse != nil {
+			hooks.OnResponse(ctx, call)
+		}
+	}
+	return resp, err
+}
+
+// apiMethod returns the Slack API method a request calls: the URL path after the
+// API base URL (e.g., "conversations.history", or "audit/v1/logs" for the Audit Logs API).
+func apiMethod(req *http.Request) string {
+	path := req.URL.Path
+	if i := strings.Index(path, "/api/"); i >= 0 {
+		return path[i+len("/api/"):]
+	}
+	return strings.TrimPrefix(path, "/")
+}
+
+// responseError returns the Slack error reported by a response, if any. JSON bodies
+// are read to find "ok": false errors and replaced, so the caller can still read them.
+//
+// Returns a non-nil err only if the body could not be read.
+func responseError(resp *http.Response) (apiErr, err error) {
+	if resp.StatusCode == http.StatusTooManyRequests {
+		retryAfter, _ := strconv.Atoi(resp.Header.Get("Retry-After"))
+		return wrapSlackError(&slack.RateLimitedError{RetryAfter: time.Duration(retryAfter) * time.Second}), nil
+	}
+	if !strings.HasPrefix(resp.Header.Get("Content-Type"), "application/json") {
+		return nil, nil
+	}
+
+	body, readErr := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if readErr != nil {
+		return nil, readErr
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	var result struct {
+		OK    *bool  `json:"ok"`
+		Error string `json:"error"`
+	}
+	// The Audit Logs API has no "ok" field, so only an explicit false is an error
+	if json.Unmarshal(body, &result) != nil || result.OK == nil || *result.OK {
+		return nil, nil
+	}
+	if result.Error == "" {
+		result.Error = "unknown_error"
+	}
+	return wrapSlackError(errors.New(result.Error)), nil
+}
@@ -0,0 +1,97 @@
+// Package slack provides tests for the client instrumentation hooks.
+package slack
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+)
+
+// hookRecorder collects the events reported to a Hooks.
+type hookRecorder struct {
+	mu        sync.Mutex
+	requests  []string
+	responses []CallInfo
+	retries   []string
+}
+
+func (r *hookRecorder) hooks() Hooks {
+	return Hooks{
+		OnRequest: func(ctx context.Context, method string) {
+			r.mu.Lock()
+			defer r.mu.Unlock()
+			r.requests = append(r.requests, method)
+		},
+		OnResponse: func(ctx context.Context, call CallInfo) {
+			r.mu.Lock()
+			defer r.mu.Unlock()
+			r.responses = append(r.responses, call)
+		},
+		OnRetry: func(ctx context.Context, method string, err error) {
+			r.mu.Lock()
+			defer r.mu.Unlock()
+			r.retries = append(r.retries, method+": "+GetErrorCode(err))
+		},
+	}
+}
+
+func TestClient_Hooks(t *testing.T) {
+	reactionCalls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/conversations.history":
+			_, _ = w.Write([]byte(`{"ok":false,"error":"channel_not_found"}`))
+		case "/api/reactions.add":
+			reactionCalls++
+			if reactionCalls == 1 {
+				w.Header().Set("Retry-After", "0")
+				w.WriteHeader(http.StatusTooManyRequests)
+				_, _ = w.Write([]byte(`{"ok":false,"error":"ratelimited"}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"ok":true}`))
+		}
+	}))
+	defer srv.Close()
+
+	var first, second hookRecorder
+	client := NewClient("xoxb-test", "", WithAPIURL(srv.URL+"/api/"),
+		WithHooks(first.hooks()), WithHooks(second.hooks()))
+
+	// The response body is still decoded by slack-go after the hooks read it
+	_, err := client.GetMessage(context.Background(), "C01234567", "1355517523.000008")
+	if !IsChannelNotFound(err) {
+		t.Fatalf("GetMessage error = %v, want channel_not_found", err)
+	}
+	if err := client.AddReaction(context.Background(), "C01234567", "1355517523.000008", "", "eyes"); err != nil {
+		t.Fatalf("AddReaction failed: %v", err)
+	}
+
+	for _, r := range []*hookRecorder{&first, &second} {
+		wantRequests := []string{"conversations.history", "reactions.add", "reactions.add"}
+		if len(r.requests) != len(wantRequests) {
+			t.Fatalf("requests = %v, want %v", r.requests, wantRequests)
+		}
+		for i, want := range wantRequests {
+			if r.requests[i] != want || r.responses[i].Method != want {
+				t.Errorf("call %d = %q/%q, want %q", i, r.requests[i], r.responses[i].Method, want)
+			}
+		}
+
+		if call := r.responses[0]; call.StatusCode != http.StatusOK || !IsChannelNotFound(call.Err) {
+			t.Errorf("history response = %+v, want 200 with channel_not_found", call)
+		}
+		if call := r.responses[1]; call.StatusCode != http.StatusTooManyRequests || !IsRateLimited(call.Err) {
+			t.Errorf("first reaction response = %+v, want 429 rate limited", call)
+		}
+		if call := r.responses[2]; call.Err != nil {
+			t.Errorf("second reaction response error = %v, want nil", call.Err)
+		}
+		if len(r.retries) != 1 || r.retries[0] != "reactions.add: rate_limited" {
+			t.Errorf("retries = %v, want one reactions.add rate_limited retry", r.retries)
+		}
+	}
+}
@@ -0,0 +1,123 @@
+// Package slack provides full user profile lookups for the Slack client.
+package slack
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/slack-go/slack"
+
+	"github.com/Bitovi/slack-mcp-server/pkg/types"
+)
+
+// maxUserListPages caps the users.list pages read by ListUsers.
+const maxUserListPages = 50
+
+// GetUserProfile returns the full profile of a user (title, email, phone,
+// timezone, status, and avatar), always fetched with users.info so the status is
+// current. The user cache is refreshed with the result.
+//
+// Returns a placeholder for deleted users, as GetUserInfo does, or an error if the
+// users.info call fails.
+func (c *Client) GetUserProfile(ctx context.Context, userID string) (*types.UserInfo, error) {
+	api, err := c.apiFor("users.info")
+	if err != nil {
+		return nil, err
+	}
+	start := time.Now()
+	user, err := api.GetUserInfoContext(ctx, userID)
+	recordCall(ctx, "users.info", start, err)
+	if err != nil {
+		if strings.Contains(err.Error(), "user_not_found") {
+			placeholder := deletedUser(userID)
+			c.userCache.Store(userID, &cachedUser{info: placeholder, fetched: time.Now()})
+			return placeholder, nil
+		}
+		return nil, c.checkAuth(wrapSlackError(err))
+	}
+
+	c.userCache.Store(user.ID, &cachedUser{info: convertUser(user), fetched: time.Now()})
+	return convertUserProfile(user), nil
+}
+
+// LookupUserByEmail returns the full profile of the user with the given email
+// address, using users.lookupByEmail. Requires the users:read.email scope.
+//
+// Returns nil and no error if no user has the email address.
+func (c *Client) LookupUserByEmail(ctx context.Context, email string) (*types.UserInfo, error) {
+	api, err := c.apiFor("users.lookupByEmail")
+	if err != nil {
+		return nil, err
+	}
+	start := time.Now()
+	user, err := api.GetUserByEmailContext(ctx, email)
+	recordCall(ctx, "users.lookupByEmail", start, err)
+	if err != nil {
+		if strings.Contains(err.Error(), "users_not_found") {
+			return nil, nil
+		}
+		return nil, c.checkAuth(wrapSlackError(err))
+	}
+
+	c.userCache.Store(user.ID, &cachedUser{info: convertUser(user), fetched: time.Now()})
+	return convertUserProfile(user), nil
+}
+
+// ListUsers lists the members of the workspace with users.list, with their full
+// profiles, including deactivated users and bots. The user cache is filled with
+// the results.
+//
+// Returns truncated=true if the listing stopped at the cap (maxUserListPages
+// pages of 200) before every user was read.
+func (c *Client) ListUsers(ctx context.Context) ([]types.UserInfo, bool, error) {
+	api, err := c.apiFor("users.list")
+	if err != nil {
+		return nil, false, err
+	}
+
+	var users []types.UserInfo
+	pages := api.GetUsersPaginated(slack.GetUsersOptionLimit(200))
+	for page := 0; page < maxUserListPages; page++ {
+		start := time.Now()
+		pages, err = pages.Next(ctx)
+		if pages.Done(err) {
+			return users, false, nil
+		}
+		recordCall(ctx, "users.list", start, err)
+		if err != nil {
+			return nil, false, c.checkAuth(wrapSlackError(err))
+		}
+
+		fetched := time.Now()
+		for i := range pages.Users {
+			user := &pages.Users[i]
+			c.userCache.Store(user.ID, &cachedUser{info: convertUser(user), fetched: fetched})
+			users = append(users, *convertUserProfile(user))
+		}
+	}
+	return users, true, nil
+}
+
+// convertUserProfile converts a Slack API user to our UserInfo type, including the
+// full profile fields that user mappings leave out.
+func convertUserProfile(user *slack.User) *types.UserInfo {
+	userInfo := convertUser(user)
+	userInfo.Title = user.Profile.Title
+	userInfo.Email = user.Profile.Email
+	userInfo.Phone = user.Profile.Phone
+	userInfo.Timezone = user.TZ
+	userInfo.TimezoneLabel = user.TZLabel
+	userInfo.TimezoneOffset = user.TZOffset
+	userInfo.StatusEmoji = user.Profile.StatusEmoji
+	userInfo.StatusText = user.Profile.StatusText
+
+	// Prefer the largest photo
+	for _, url := range []string{user.Profile.ImageOriginal, user.Profile.Image512, user.Profile.Image192} {
+		if url != "" {
+			userInfo.AvatarURL = url
+			break
+		}
+	}
+	return userInfo
+}
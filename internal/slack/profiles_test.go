@@ -0,0 +1,89 @@
+// Package slack provides tests for full user profile lookups.
+package slack
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestClient_GetUserProfile(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"ok":true,"user":{"id":"U01234567","name":"jane","tz":"America/Chicago",` +
+			`"tz_label":"Central Standard Time","tz_offset":-21600,"profile":{"real_name":"Jane Smith",` +
+			`"title":"Engineer","email":"jane@example.com","phone":"555-0100","status_emoji":":palm_tree:",` +
+			`"status_text":"On vacation","image_192":"https://avatars.example.com/192.png",` +
+			`"image_512":"https://avatars.example.com/512.png"}}}`))
+	})
+
+	user, err := client.GetUserProfile(context.Background(), "U01234567")
+	if err != nil {
+		t.Fatalf("GetUserProfile failed: %v", err)
+	}
+	if user.Title != "Engineer" || user.Email != "jane@example.com" || user.Phone != "555-0100" {
+		t.Errorf("unexpected contact fields: %+v", user)
+	}
+	if user.Timezone != "America/Chicago" || user.TimezoneOffset != -21600 {
+		t.Errorf("unexpected timezone: %+v", user)
+	}
+	if user.StatusEmoji != ":palm_tree:" || user.StatusText != "On vacation" {
+		t.Errorf("unexpected status: %+v", user)
+	}
+	if user.AvatarURL != "https://avatars.example.com/512.png" {
+		t.Errorf("avatar URL = %q, want the largest photo", user.AvatarURL)
+	}
+
+	// The user cache holds the user without the profile fields, for user mappings
+	cached, err := client.GetUserInfo(context.Background(), "U01234567")
+	if err != nil {
+		t.Fatalf("GetUserInfo failed: %v", err)
+	}
+	if cached.RealName != "Jane Smith" || cached.Email != "" {
+		t.Errorf("cached user = %+v, want identity fields only", cached)
+	}
+}
+
+func TestClient_LookupUserByEmail_NotFound(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"ok":false,"error":"users_not_found"}`))
+	})
+
+	user, err := client.LookupUserByEmail(context.Background(), "nobody@example.com")
+	if err != nil || user != nil {
+		t.Errorf("LookupUserByEmail = %+v, %v; want nil, nil", user, err)
+	}
+}
+
+func TestClient_ListUsers(t *testing.T) {
+	var pages int
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+		pages++
+		w.Header().Set("Content-Type", "application/json")
+		if r.Form.Get("cursor") == "" {
+			_, _ = w.Write([]byte(`{"ok":true,"members":[{"id":"U01","name":"jane","profile":{"title":"Engineer"}}],` +
+				`"response_metadata":{"next_cursor":"page2"}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"ok":true,"members":[{"id":"U02","name":"john","deleted":true}],"response_metadata":{"next_cursor":""}}`))
+	})
+
+	users, truncated, err := client.ListUsers(context.Background())
+	if err != nil {
+		t.Fatalf("ListUsers failed: %v", err)
+	}
+	if truncated {
+		t.Error("expected a complete listing")
+	}
+	if len(users) != 2 || users[0].Title != "Engineer" || !users[1].IsDeleted {
+		t.Fatalf("unexpected users: %+v", users)
+	}
+	if pages != 2 {
+		t.Errorf("expected 2 users.list requests, got %d", pages)
+	}
+	if _, ok := client.userCache.Load("U02"); !ok {
+		t.Error("expected listed users to be cached")
+	}
+}
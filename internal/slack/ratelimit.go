@@ -0,0 +1,230 @@
+// Package slack provides client-side rate limiting and retries of rate limited Slack API requests.
+package slack
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/slack-go/slack"
+)
+
+const (
+	// DefaultMaxRetries is the default number of times a rate limited request is retried.
+	DefaultMaxRetries = 3
+	// DefaultMaxRetryWait is the default longest delay waited before retrying a rate
+	// limited request. A longer Retry-After is returned to the caller as rate_limited.
+	DefaultMaxRetryWait = 30 * time.Second
+	// retryBaseDelay is the first backoff delay when Slack rate limits a request without
+	// a Retry-After header; it doubles with each retry.
+	retryBaseDelay = time.Second
+)
+
+// Per-minute request rates of Slack's rate limit tiers
+// (https://api.slack.com/apis/rate-limits).
+const (
+	tier2 = 20
+	tier3 = 50
+	tier4 = 100
+)
+
+// methodRates maps Slack API methods to the requests per minute the client allows
+// them. Methods that are not listed get tier 3. chat.postMessage is limited to about
+// one message per second per channel rather than by tier.
+var methodRates = map[string]int{
+	"admin.analytics.getFile":      tier2,
+	"auth.teams.list":              tier2,
+	"auth.test":                    tier4,
+	"chat.postEphemeral":           tier4,
+	"chat.postMessage":             60,
+	"conversations.list":           tier2,
+	"conversations.members":        tier4,
+	"files.completeUploadExternal": tier4,
+	"files.getUploadURLExternal":   tier4,
+	"files.info":                   tier4,
+	"pins.list":                    tier2,
+	"search.messages":              tier2,
+	"usergroups.list":              tier2,
+	"usergroups.users.update":      tier2,
+	"users.info":                   tier4,
+	"users.list":                   tier2,
+}
+
+// WithRetries sets how rate limited Slack API requests are retried: at most
+// maxRetries times, waiting Slack's Retry-After delay (or an exponential backoff
+// starting at one second, if Slack gives none) before each retry. A request whose
+// delay would exceed maxWait is not retried, and fails with a rate_limited error.
+// Zero maxRetries disables retries. Defaults to DefaultMaxRetries and DefaultMaxRetryWait.
+func WithRetries(maxRetries int, maxWait time.Duration) ClientOption {
+	return func(c *Client) {
+		c.maxRetries = maxRetries
+		c.maxRetryWait = maxWait
+	}
+}
+
+// rateLimiter paces Slack API requests per method, so that bursts of calls (e.g.,
+// resolving many users) are spread out instead of being rejected by Slack. Each
+// method has a token bucket holding a minute's worth of requests at its tier's rate,
+// so short bursts go through immediately. It is safe for concurrent use.
+type rateLimiter struct {
+	mu      sync.Mutex
+	buckets map[string]*tokenBucket
+	now     func() time.Time
+}
+
+// tokenBucket is the request allowance of one Slack API method.
+type tokenBucket struct {
+	// tokens is the number of requests that may be sent now.
+	tokens float64
+	// perSecond is the rate at which tokens are added, up to capacity.
+	perSecond float64
+	// capacity is the largest number of tokens the bucket holds.
+	capacity float64
+	// updated is when tokens was last brought up to date.
+	updated time.Time
+	// pausedUntil holds requests back until Slack's Retry-After has passed.
+	pausedUntil time.Time
+}
+
+// newRateLimiter creates a rateLimiter with full buckets.
+func newRateLimiter() *rateLimiter {
+	return &rateLimiter{
+		buckets: make(map[string]*tokenBucket),
+		now:     time.Now,
+	}
+}
+
+// bucket returns the token bucket of method, with its tokens brought up to date.
+// The caller must hold l.mu.
+func (l *rateLimiter) bucket(method string, now time.Time) *tokenBucket {
+	b, ok := l.buckets[method]
+	if !ok {
+		rate, ok := methodRates[method]
+		if !ok {
+			rate = tier3
+		}
+		b = &tokenBucket{tokens: float64(rate), perSecond: float64(rate) / 60, capacity: float64(rate), updated: now}
+		l.buckets[method] = b
+	}
+	b.tokens = min(b.capacity, b.tokens+now.Sub(b.updated).Seconds()*b.perSecond)
+	b.updated = now
+	return b
+}
+
+// wait blocks until a request to method may be sent, and takes its token.
+//
+// Returns the context's error if ctx is done first.
+func (l *rateLimiter) wait(ctx context.Context, method string) error {
+	for {
+		l.mu.Lock()
+		now := l.now()
+		b := l.bucket(method, now)
+		var delay time.Duration
+		switch {
+		case now.Before(b.pausedUntil):
+			delay = b.pausedUntil.Sub(now)
+		case b.tokens >= 1:
+			b.tokens--
+			l.mu.Unlock()
+			return nil
+		default:
+			delay = time.Duration((1 - b.tokens) / b.perSecond * float64(time.Second))
+		}
+		l.mu.Unlock()
+
+		if err := sleep(ctx, delay); err != nil {
+			return err
+		}
+	}
+}
+
+// pause holds back requests to method for delay, after Slack rate limited one.
+func (l *rateLimiter) pause(method string, delay time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	b := l.bucket(method, now)
+	if until := now.Add(delay); until.After(b.pausedUntil) {
+		b.pausedUntil = until
+	}
+}
+
+// sleep waits for d, or until ctx is done.
+//
+// Returns the context's error if ctx is done first.
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// retryTransport is an http.RoundTripper that paces requests with a rateLimiter and
+// retries requests Slack rejects with HTTP 429, so transient rate limits do not
+// surface as tool errors.
+type retryTransport struct {
+	limiter    *rateLimiter
+	maxRetries int
+	maxWait    time.Duration
+	// onRetry is called before each retry with the rate limited error.
+	onRetry func(ctx context.Context, method string, err error)
+	base    http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	method := apiMethod(req)
+	for attempt := 0; ; attempt++ {
+		if err := t.limiter.wait(ctx, method); err != nil {
+			return nil, err
+		}
+
+		resp, err := t.base.RoundTrip(req)
+		if err != nil || resp.StatusCode != http.StatusTooManyRequests {
+			return resp, err
+		}
+		recordRateLimited(ctx)
+
+		delay := retryDelay(resp, attempt)
+		// A request body that cannot be rewound (e.g., a streamed file upload) is not retried
+		if attempt >= t.maxRetries || delay > t.maxWait || (req.Body != nil && req.GetBody == nil) {
+			// slack-go only reports a 429 as rate limited if it has a Retry-After
+			if resp.Header.Get("Retry-After") == "" {
+				resp.Header.Set("Retry-After", strconv.Itoa(int(delay/time.Second)))
+			}
+			return resp, nil
+		}
+		// Concurrent requests to the method wait too, rather than being rejected in turn
+		t.limiter.pause(method, delay)
+		_, _ = io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req = req.Clone(ctx)
+			req.Body = body
+		}
+		t.onRetry(ctx, method, wrapSlackError(&slack.RateLimitedError{RetryAfter: delay}))
+	}
+}
+
+// retryDelay returns how long to wait before retrying a rate limited request: the
+// response's Retry-After, or else an exponential backoff for the given attempt.
+func retryDelay(resp *http.Response, attempt int) time.Duration {
+	if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	return retryBaseDelay << attempt
+}
@@ -0,0 +1,144 @@
+// Package slack provides tests for client-side rate limiting and retries.
+package slack
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestClient_RetriesRateLimit(t *testing.T) {
+	tests := []struct {
+		name        string
+		retryAfter  string
+		limitedFor  int32
+		opts        []ClientOption
+		wantCalls   int32
+		wantRetries int
+		wantErr     bool
+	}{
+		{
+			name:        "retried after Retry-After",
+			retryAfter:  "0",
+			limitedFor:  2,
+			wantCalls:   3,
+			wantRetries: 2,
+		},
+		{
+			name:        "gives up after max retries",
+			retryAfter:  "0",
+			limitedFor:  5,
+			opts:        []ClientOption{WithRetries(1, time.Second)},
+			wantCalls:   2,
+			wantRetries: 1,
+			wantErr:     true,
+		},
+		{
+			name:       "Retry-After longer than max wait",
+			retryAfter: "60",
+			limitedFor: 1,
+			wantCalls:  1,
+			wantErr:    true,
+		},
+		{
+			name:       "backoff longer than max wait",
+			limitedFor: 1,
+			opts:       []ClientOption{WithRetries(3, 500*time.Millisecond)},
+			wantCalls:  1,
+			wantErr:    true,
+		},
+		{
+			name:       "retries disabled",
+			retryAfter: "0",
+			limitedFor: 1,
+			opts:       []ClientOption{WithRetries(0, time.Second)},
+			wantCalls:  1,
+			wantErr:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls atomic.Int32
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.ParseForm()
+				if r.PostForm.Get("name") != "eyes" {
+					t.Errorf("retried request lost its body: %v", r.PostForm)
+				}
+				w.Header().Set("Content-Type", "application/json")
+				if calls.Add(1) <= tt.limitedFor {
+					if tt.retryAfter != "" {
+						w.Header().Set("Retry-After", tt.retryAfter)
+					}
+					w.WriteHeader(http.StatusTooManyRequests)
+					return
+				}
+				_, _ = w.Write([]byte(`{"ok":true}`))
+			}))
+			defer srv.Close()
+
+			client := NewClient("xoxb-test", "", append([]ClientOption{WithAPIURL(srv.URL + "/api/")}, tt.opts...)...)
+			ctx, stats := WithCallStats(context.Background())
+			err := client.AddReaction(ctx, "C01234567", "1355517523.000008", "", "eyes")
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("AddReaction error = %v, want error: %v", err, tt.wantErr)
+			}
+			if err != nil && !IsRateLimited(err) {
+				t.Errorf("expected a rate_limited error, got %v", err)
+			}
+
+			if calls.Load() != tt.wantCalls {
+				t.Errorf("expected %d requests, got %d", tt.wantCalls, calls.Load())
+			}
+			summary := stats.Summary()
+			if summary.Retries != tt.wantRetries || summary.RateLimited != int(min(tt.limitedFor, tt.wantCalls)) {
+				t.Errorf("retries = %d, rate limited = %d, want %d and %d",
+					summary.Retries, summary.RateLimited, tt.wantRetries, min(tt.limitedFor, tt.wantCalls))
+			}
+		})
+	}
+}
+
+func TestRateLimiter_Wait(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	limiter := newRateLimiter()
+	limiter.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	// A minute's worth of requests goes through at once
+	for i := 0; i < tier2; i++ {
+		if err := limiter.wait(ctx, "conversations.list"); err != nil {
+			t.Fatalf("request %d was held back: %v", i, err)
+		}
+	}
+
+	// The next one waits for a token, until the context gives up
+	expired, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	if err := limiter.wait(expired, "conversations.list"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected the request to be held back, got %v", err)
+	}
+
+	// Other methods have their own allowance
+	if err := limiter.wait(ctx, "users.info"); err != nil {
+		t.Errorf("users.info was held back: %v", err)
+	}
+
+	// Tokens are added back at the tier's rate
+	now = now.Add(3 * time.Second)
+	if err := limiter.wait(ctx, "conversations.list"); err != nil {
+		t.Errorf("request after refill was held back: %v", err)
+	}
+
+	// A rate limited method is paused for its Retry-After, whatever its tokens
+	limiter.pause("users.info", time.Minute)
+	expired, cancel = context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	if err := limiter.wait(expired, "users.info"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected the paused method to be held back, got %v", err)
+	}
+}
@@ -0,0 +1,202 @@
+// Package slack provides token routing for Slack API methods.
+package slack
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/slack-go/slack"
+
+	"github.com/Bitovi/slack-mcp-server/pkg/types"
+)
+
+// tokenType identifies which Slack token an API method is called with.
+type tokenType int
+
+const (
+	// botToken routes a method to the bot token client (SLACK_BOT_TOKEN).
+	botToken tokenType = iota
+	// userToken routes a method to the user token client (SLACK_USER_TOKEN).
+	userToken
+	// adminToken routes a method to the org admin token client (SLACK_ADMIN_TOKEN).
+	adminToken
+	// auditToken routes a method to the Audit Logs API client (SLACK_AUDIT_TOKEN).
+	auditToken
+)
+
+// methodTokens lists the token each Slack API method used by the client is called with.
+// Every method must be listed, so adding a call forces an explicit routing decision.
+//
+// The bot token is preferred: it only sees channels the bot was invited to, which is
+// the access model workspace admins approve. The user token is used only for methods
+// that bot tokens cannot call, for reads of archived channels the bot cannot join
+// when the caller opts in (see readChannel), for reads of the user token's own
+// direct messages when DM reads are enabled (see GetDMHistory), and for the reads
+// in actAsUserMethods in act-as-user mode (see WithActAsUser).
+var methodTokens = map[string]tokenType{
+	"admin.analytics.getFile":      adminToken, // admin.* requires an Enterprise Grid org admin token
+	"audit/v1/logs":                auditToken, // the Audit Logs API has its own token and host
+	"auth.teams.list":              botToken,
+	"auth.test":                    botToken,
+	"bookmarks.list":               botToken,
+	"bots.info":                    botToken,
+	"chat.postEphemeral":           botToken,
+	"chat.postMessage":             botToken,
+	"conversations.history":        botToken,
+	"conversations.info":           botToken,
+	"conversations.list":           botToken,
+	"conversations.members":        botToken,
+	"conversations.open":           botToken,
+	"conversations.replies":        botToken,
+	"files.completeUploadExternal": botToken, // with files.getUploadURLExternal, uploads snippets
+	"files.getUploadURLExternal":   botToken,
+	"files.info":                   botToken, // canvas previews (channel_briefing)
+	"pins.list":                    botToken,
+	"reactions.add":                botToken,
+	"users.info":                   botToken,
+	"users.list":                   botToken,
+	"users.lookupByEmail":          botToken, // requires users:read.email
+	"usergroups.list":              botToken,
+	"usergroups.users.update":      botToken,
+	"users.conversations":          userToken, // checks the user token's own membership (check_channel_access)
+	"search.messages":              userToken, // search.* does not accept bot tokens
+}
+
+// actAsUserMethods lists the bot token methods that act-as-user mode calls with the
+// user token instead. They are the reads, including auth.test, so the current user
+// is the person behind the user token. Writes (posting, reactions, uploads, user
+// group changes) and conversations.open, which can create a DM, stay with the bot.
+var actAsUserMethods = map[string]bool{
+	"auth.teams.list":       true,
+	"auth.test":             true,
+	"bookmarks.list":        true,
+	"bots.info":             true,
+	"conversations.history": true,
+	"conversations.info":    true,
+	"conversations.list":    true,
+	"conversations.members": true,
+	"conversations.replies": true,
+	"files.info":            true,
+	"pins.list":             true,
+	"users.info":            true,
+	"users.list":            true,
+	"users.lookupByEmail":   true,
+	"usergroups.list":       true,
+}
+
+// WithActAsUser enables act-as-user mode: read methods (see actAsUserMethods) are
+// called with the user token instead of the bot token, so reads see every channel
+// the user can see, and GetCurrentUser returns the user rather than the bot. Reads
+// of the user's DMs are refused unless allowed with WithDMReadAudit. It is meant for personal-assistant deployments, where the server acts for one
+// person, rather than shared bots. Writes still use the bot token. Has no effect if
+// no user token is configured.
+func WithActAsUser() ClientOption {
+	return func(c *Client) {
+		c.actAsUser = true
+	}
+}
+
+// DMReadAudit records a read of a direct message or group direct message made in
+// act-as-user mode. err is the read's error, nil if it succeeded.
+type DMReadAudit func(ctx context.Context, method, channelID string, err error)
+
+// WithDMReadAudit allows reads of direct messages and group direct messages in
+// act-as-user mode, and reports each one to audit. Without it, act-as-user mode
+// refuses them with a dm_read_not_allowed error: the user token can read every DM
+// of its user, which must not be reachable from the channel read methods unless DM
+// reads were allowed and are audited. Such reads are never served from or stored in
+// the message and thread caches, so each one reaches audit.
+func WithDMReadAudit(audit DMReadAudit) ClientOption {
+	return func(c *Client) {
+		c.dmReadAudit = audit
+	}
+}
+
+// actAsUserDM reports whether a read of channelID is a read of a direct message or
+// group direct message through act-as-user mode. IDs starting with D are DMs; G is
+// shared by group DMs and private channels created before 2021, which are told
+// apart with GetChannelInfo.
+func (c *Client) actAsUserDM(ctx context.Context, channelID string) (bool, error) {
+	if !c.actAsUser || c.userTokenAPI == nil {
+		return false, nil
+	}
+	switch {
+	case strings.HasPrefix(channelID, "D"):
+		return true, nil
+	case strings.HasPrefix(channelID, "G"):
+		channel, err := c.GetChannelInfo(ctx, channelID)
+		if err != nil {
+			return false, err
+		}
+		return channel.IsDM, nil
+	default:
+		return false, nil
+	}
+}
+
+// checkDMRead applies the act-as-user DM read policy to a read of channelID with
+// method. Reads of direct messages are refused with a dm_read_not_allowed error
+// unless a DM read audit is set (see WithDMReadAudit); allowed DM reads are recorded
+// in the call stats, so their results are not cached.
+//
+// Returns a function to call with the read's outcome, which reports DM reads to the
+// audit and does nothing for other reads.
+func (c *Client) checkDMRead(ctx context.Context, method, channelID string) (func(error), error) {
+	dm, err := c.actAsUserDM(ctx, channelID)
+	if err != nil {
+		return nil, err
+	}
+	if !dm {
+		return func(error) {}, nil
+	}
+	if c.dmReadAudit == nil {
+		return nil, types.NewSlackError(types.ErrCodeDMReadNotAllowed,
+			"Reading direct messages in act-as-user mode requires SLACK_MCP_ALLOW_DM_READ=true, "+
+				"so that DM reads are audited.")
+	}
+	recordDMRead(ctx)
+	return func(err error) { c.dmReadAudit(ctx, method, channelID, err) }, nil
+}
+
+// apiFor returns the API client that calls the given Slack API method.
+//
+// Returns a user_token_not_configured error if the method requires a user token
+// and SLACK_USER_TOKEN is not set.
+func (c *Client) apiFor(method string) (*slack.Client, error) {
+	token, ok := methodTokens[method]
+	if !ok {
+		// A programming error: every method must have a routing entry
+		return nil, fmt.Errorf("no token routing for Slack API method %q", method)
+	}
+	if token == botToken && c.actAsUser && c.userTokenAPI != nil && actAsUserMethods[method] {
+		token = userToken
+	}
+
+	if token == adminToken {
+		if c.adminTokenAPI == nil {
+			return nil, types.NewSlackError(types.ErrCodeAdminTokenNotConfigured,
+				fmt.Sprintf("SLACK_ADMIN_TOKEN not configured. %s requires an Enterprise Grid org admin token (xoxp-).", method))
+		}
+		return c.adminTokenAPI, nil
+	}
+
+	if token == auditToken {
+		if c.auditTokenAPI == nil {
+			return nil, types.NewSlackError(types.ErrCodeAuditTokenNotConfigured,
+				fmt.Sprintf("SLACK_AUDIT_TOKEN not configured. %s requires an Enterprise Grid org owner token "+
+					"(xoxp-) with the auditlogs:read scope.", method))
+		}
+		return c.auditTokenAPI, nil
+	}
+
+	if token == userToken {
+		if c.userTokenAPI == nil {
+			return nil, types.NewSlackError(types.ErrCodeUserTokenNotConfigured,
+				fmt.Sprintf("SLACK_USER_TOKEN not configured. %s requires a user token (xoxp-); "+
+					"search also needs the search:read scope.", method))
+		}
+		return c.userTokenAPI, nil
+	}
+	return c.api.Load(), nil
+}
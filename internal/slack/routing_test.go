@@ -0,0 +1,202 @@
+// Package slack provides tests for Slack API method token routing.
+package slack
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/slack-go/slack"
+
+	"github.com/Bitovi/slack-mcp-server/pkg/types"
+)
+
+func TestClient_APIFor(t *testing.T) {
+	botAPI := slack.New("xoxb-test")
+	userAPI := slack.New("xoxp-test")
+
+	t.Run("bot token methods use the bot client", func(t *testing.T) {
+		client := &Client{userTokenAPI: userAPI}
+		client.api.Store(botAPI)
+		api, err := client.apiFor("conversations.history")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if api != botAPI {
+			t.Error("expected the bot token client")
+		}
+	})
+
+	t.Run("user token methods use the user client", func(t *testing.T) {
+		client := &Client{userTokenAPI: userAPI}
+		client.api.Store(botAPI)
+		api, err := client.apiFor("search.messages")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if api != userAPI {
+			t.Error("expected the user token client")
+		}
+	})
+
+	t.Run("user token methods fail without a user token", func(t *testing.T) {
+		client := &Client{}
+		client.api.Store(botAPI)
+		if _, err := client.apiFor("search.messages"); !IsUserTokenNotConfigured(err) {
+			t.Errorf("expected user_token_not_configured error, got %v", err)
+		}
+	})
+
+	t.Run("act-as-user mode reads with the user client", func(t *testing.T) {
+		client := &Client{userTokenAPI: userAPI, actAsUser: true}
+		client.api.Store(botAPI)
+		for method, want := range map[string]*slack.Client{
+			"conversations.history": userAPI,
+			"auth.test":             userAPI,
+			"chat.postMessage":      botAPI,
+			"conversations.open":    botAPI,
+		} {
+			api, err := client.apiFor(method)
+			if err != nil {
+				t.Fatalf("%s: unexpected error: %v", method, err)
+			}
+			if api != want {
+				t.Errorf("%s: routed to the wrong token client", method)
+			}
+		}
+	})
+
+	t.Run("act-as-user mode without a user token keeps the bot client", func(t *testing.T) {
+		client := &Client{actAsUser: true}
+		client.api.Store(botAPI)
+		api, err := client.apiFor("conversations.history")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if api != botAPI {
+			t.Error("expected the bot token client")
+		}
+	})
+
+	t.Run("act-as-user methods are bot token methods", func(t *testing.T) {
+		for method := range actAsUserMethods {
+			if token, ok := methodTokens[method]; !ok || token != botToken {
+				t.Errorf("%s is not routed to the bot token", method)
+			}
+		}
+	})
+
+	t.Run("unrouted methods are rejected", func(t *testing.T) {
+		client := &Client{userTokenAPI: userAPI}
+		client.api.Store(botAPI)
+		if _, err := client.apiFor("chat.delete"); err == nil {
+			t.Error("expected an error for a method without a routing entry")
+		}
+	})
+}
+
+func TestClient_ActAsUser_DMReads(t *testing.T) {
+	var historyCalls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/conversations.info":
+			// G01111111 is a group DM, G02222222 a private channel created before 2021
+			if r.PostForm.Get("channel") == "G01111111" {
+				_, _ = w.Write([]byte(`{"ok":true,"channel":{"id":"G01111111","is_mpim":true,"is_private":true}}`))
+			} else {
+				_, _ = w.Write([]byte(`{"ok":true,"channel":{"id":"G02222222","name":"legacy","is_group":true,"is_private":true}}`))
+			}
+		case "/conversations.history":
+			historyCalls.Add(1)
+			_, _ = w.Write([]byte(`{"ok":true,"messages":[{"type":"message","user":"U01234567","text":"hi","ts":"1355517523.000008"}]}`))
+		default:
+			t.Errorf("unexpected request to %s", r.URL.Path)
+		}
+	}))
+	defer srv.Close()
+
+	newClient := func(opts ...ClientOption) *Client {
+		client := &Client{
+			userTokenAPI: slack.New("xoxp-test", slack.OptionAPIURL(srv.URL+"/")),
+			actAsUser:    true,
+			messageCache: newTTLCache[types.Message](time.Minute, defaultCacheMaxEntries),
+		}
+		client.api.Store(slack.New("xoxb-test", slack.OptionAPIURL(srv.URL+"/")))
+		for _, opt := range opts {
+			opt(client)
+		}
+		return client
+	}
+
+	t.Run("DM reads are refused without a DM read audit", func(t *testing.T) {
+		client := newClient()
+		for _, channelID := range []string{"D01234567", "G01111111"} {
+			_, _, err := client.GetChannelHistory(context.Background(), channelID, HistoryOptions{Limit: 10})
+			if GetErrorCode(err) != types.ErrCodeDMReadNotAllowed {
+				t.Errorf("%s: expected dm_read_not_allowed error, got %v", channelID, err)
+			}
+		}
+		if historyCalls.Load() != 0 {
+			t.Errorf("expected no history requests, got %d", historyCalls.Load())
+		}
+
+		// Pins, bookmarks, and canvases of DMs are read with the user token too
+		if _, err := client.ListPins(context.Background(), "D01234567"); !IsDMReadNotAllowed(err) {
+			t.Errorf("ListPins: expected dm_read_not_allowed error, got %v", err)
+		}
+		if _, err := client.ListBookmarks(context.Background(), "D01234567"); !IsDMReadNotAllowed(err) {
+			t.Errorf("ListBookmarks: expected dm_read_not_allowed error, got %v", err)
+		}
+		if _, err := client.GetCanvasSummary(context.Background(), "D01234567", "F01234567"); !IsDMReadNotAllowed(err) {
+			t.Errorf("GetCanvasSummary: expected dm_read_not_allowed error, got %v", err)
+		}
+
+		// Channels, including private channels with G IDs, are still read
+		for _, channelID := range []string{"C01234567", "G02222222"} {
+			if _, _, err := client.GetChannelHistory(context.Background(), channelID, HistoryOptions{Limit: 10}); err != nil {
+				t.Errorf("%s: unexpected error: %v", channelID, err)
+			}
+		}
+	})
+
+	t.Run("DM reads are audited and not cached", func(t *testing.T) {
+		var audited []string
+		client := newClient(WithDMReadAudit(func(ctx context.Context, method, channelID string, err error) {
+			audited = append(audited, method+" "+channelID)
+		}))
+		historyCalls.Store(0)
+
+		ctx, stats := WithCallStats(context.Background())
+		for i := 0; i < 2; i++ {
+			if _, err := client.GetMessage(ctx, "D01234567", "1355517523.000008"); err != nil {
+				t.Fatalf("GetMessage failed: %v", err)
+			}
+		}
+		if historyCalls.Load() != 2 {
+			t.Errorf("expected every DM read to reach Slack, got %d history requests", historyCalls.Load())
+		}
+		if len(audited) != 2 || audited[0] != "conversations.history D01234567" {
+			t.Errorf("unexpected audit records: %v", audited)
+		}
+		if !stats.ReadDMs() {
+			t.Error("expected the call stats to record the DM read")
+		}
+
+		// Channel reads are cached and not audited
+		ctx, stats = WithCallStats(context.Background())
+		for i := 0; i < 2; i++ {
+			if _, err := client.GetMessage(ctx, "C01234567", "1355517523.000008"); err != nil {
+				t.Fatalf("GetMessage failed: %v", err)
+			}
+		}
+		if historyCalls.Load() != 3 || len(audited) != 2 || stats.ReadDMs() {
+			t.Errorf("expected one uncached, unaudited channel read; got %d history requests, audit %v",
+				historyCalls.Load(), audited)
+		}
+	})
+}
@@ -0,0 +1,162 @@
+// Package slack provides Slack API usage accounting for individual tool calls.
+package slack
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/Bitovi/slack-mcp-server/pkg/types"
+)
+
+// resolutionMethods are the Slack API methods used to resolve user and bot IDs to
+// names; their latency is also reported separately as resolution latency.
+var resolutionMethods = map[string]bool{
+	"users.info": true,
+	"bots.info":  true,
+}
+
+// callStatsKey is the context key under which a *CallStats is stored.
+type callStatsKey struct{}
+
+// CallStats accumulates Slack API usage for a single tool call.
+// It is safe for concurrent use.
+type CallStats struct {
+	mu          sync.Mutex
+	calls       int
+	cacheHits   int
+	retries     int
+	rateLimited int
+	latency     time.Duration
+	resolution  time.Duration
+	methods     map[string]int
+	dmReads     int
+}
+
+// WithCallStats returns a context that records Slack API usage into a new CallStats.
+// Client methods called with the returned context (or a context derived from it)
+// record each API call, cache hit, and retry.
+//
+// If ctx already records into a CallStats, ctx and that CallStats are returned,
+// so nested tool middlewares share one record of the call.
+func WithCallStats(ctx context.Context) (context.Context, *CallStats) {
+	if stats := callStatsFromContext(ctx); stats != nil {
+		return ctx, stats
+	}
+	stats := &CallStats{methods: make(map[string]int)}
+	return context.WithValue(ctx, callStatsKey{}, stats), stats
+}
+
+// callStatsFromContext returns the CallStats stored in ctx, or nil if none is present.
+func callStatsFromContext(ctx context.Context) *CallStats {
+	stats, _ := ctx.Value(callStatsKey{}).(*CallStats)
+	return stats
+}
+
+// Summary returns a snapshot of the accumulated usage.
+func (s *CallStats) Summary() types.APICallStats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	methods := make(map[string]int, len(s.methods))
+	for method, count := range s.methods {
+		methods[method] = count
+	}
+
+	return types.APICallStats{
+		Calls:               s.calls,
+		CacheHits:           s.cacheHits,
+		Retries:             s.retries,
+		RateLimited:         s.rateLimited,
+		TotalLatencyMS:      s.latency.Milliseconds(),
+		ResolutionLatencyMS: s.resolution.Milliseconds(),
+		Methods:             methods,
+	}
+}
+
+// ReadDMs reports whether the tool call read a direct message in act-as-user mode
+// (see WithDMReadAudit). Such results must not be cached, so every read is audited.
+func (s *CallStats) ReadDMs() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.dmReads > 0
+}
+
+// recordCall records a completed Slack API call on the CallStats in ctx, if any.
+//
+// Parameters:
+//   - ctx: The context passed to the client method
+//   - method: The Slack API method name (e.g., "conversations.history")
+//   - start: The time the call was started
+//   - err: The error returned by the call, if any
+func recordCall(ctx context.Context, method string, start time.Time, err error) {
+	stats := callStatsFromContext(ctx)
+	if stats == nil {
+		return
+	}
+
+	stats.mu.Lock()
+	defer stats.mu.Unlock()
+
+	stats.calls++
+	stats.methods[method]++
+	elapsed := time.Since(start)
+	stats.latency += elapsed
+	if resolutionMethods[method] {
+		stats.resolution += elapsed
+	}
+}
+
+// recordRateLimited records a Slack API request rejected by rate limiting, whether
+// or not it is retried.
+func recordRateLimited(ctx context.Context) {
+	stats := callStatsFromContext(ctx)
+	if stats == nil {
+		return
+	}
+
+	stats.mu.Lock()
+	defer stats.mu.Unlock()
+
+	stats.rateLimited++
+}
+
+// recordRetry records that a Slack API call is being retried (e.g., after a rate limit).
+func recordRetry(ctx context.Context) {
+	stats := callStatsFromContext(ctx)
+	if stats == nil {
+		return
+	}
+
+	stats.mu.Lock()
+	defer stats.mu.Unlock()
+
+	stats.retries++
+}
+
+// recordCacheHit records a lookup served from a client cache instead of the Slack API.
+func recordCacheHit(ctx context.Context) {
+	stats := callStatsFromContext(ctx)
+	if stats == nil {
+		return
+	}
+
+	stats.mu.Lock()
+	defer stats.mu.Unlock()
+
+	stats.cacheHits++
+}
+
+// recordDMRead records a read of a direct message in act-as-user mode.
+func recordDMRead(ctx context.Context) {
+	stats := callStatsFromContext(ctx)
+	if stats == nil {
+		return
+	}
+
+	stats.mu.Lock()
+	defer stats.mu.Unlock()
+
+	stats.dmReads++
+}
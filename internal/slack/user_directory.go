@@ -0,0 +1,73 @@
+// Package slack provides bulk loading of the workspace user directory for the Slack client.
+package slack
+
+import (
+	"context"
+	"time"
+)
+
+// lazyPrefetchMisses is the number of user cache misses after which lazy prefetch
+// (see WithLazyUserPrefetch) loads the user directory. Fewer users are cheaper to
+// look up one at a time than to list.
+const lazyPrefetchMisses = 20
+
+// userDirectoryTimeout bounds a user directory load started by a cache miss, which
+// outlives the tool call that triggered it.
+const userDirectoryTimeout = 2 * time.Minute
+
+// WithLazyUserPrefetch loads the workspace user directory with users.list into the
+// user cache once lazyPrefetchMisses users were missing from it, instead of
+// continuing to look users up one at a time with users.info. Large channel reads
+// then resolve their authors from the cache rather than fanning out into hundreds
+// of rate limited users.info calls. See also PrefetchUsers, to load it up front.
+func WithLazyUserPrefetch() ClientOption {
+	return func(c *Client) {
+		c.lazyUserPrefetch = true
+	}
+}
+
+// PrefetchUsers loads the workspace user directory with users.list (up to
+// maxUserListPages pages of 200) into the user cache, so later user lookups need no
+// API call. Concurrent calls share one load, and the directory is only loaded once
+// per client: later calls return immediately.
+//
+// Returns the number of users loaded, or an error if the listing fails; a failed
+// load may be retried.
+func (c *Client) PrefetchUsers(ctx context.Context) (int, error) {
+	c.userDirectoryMu.Lock()
+	defer c.userDirectoryMu.Unlock()
+	if c.userDirectoryLoaded.Load() {
+		return 0, nil
+	}
+
+	users, _, err := c.ListUsers(ctx)
+	if err != nil {
+		return 0, err
+	}
+	c.userDirectoryLoaded.Store(true)
+	return len(users), nil
+}
+
+// prefetchOnMiss counts a user cache miss and, in lazy prefetch mode, starts loading
+// the user directory in the background on the lazyPrefetchMisses-th miss. Neither
+// the lookup that triggered the load nor those that miss while it runs wait for it;
+// they look their user up with users.info.
+func (c *Client) prefetchOnMiss() {
+	if !c.lazyUserPrefetch || c.userDirectoryLoaded.Load() {
+		return
+	}
+	if c.userCacheMisses.Add(1) != lazyPrefetchMisses {
+		return
+	}
+
+	// The load serves every later lookup, so it is not tied to the tool call that
+	// triggered it, and its users.list calls are not counted against that call
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), userDirectoryTimeout)
+		defer cancel()
+		if _, err := c.PrefetchUsers(ctx); err != nil {
+			// Try again after another round of misses, rather than on every miss
+			c.userCacheMisses.Store(0)
+		}
+	}()
+}
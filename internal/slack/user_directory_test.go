@@ -0,0 +1,148 @@
+// Package slack provides tests for bulk loading of the workspace user directory.
+package slack
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newDirectoryTestClient returns a test client for a workspace of n users (U000 to
+// U<n-1>), and counters of its users.list and users.info requests.
+func newDirectoryTestClient(t *testing.T, n int) (*Client, *atomic.Int32, *atomic.Int32) {
+	t.Helper()
+	var listCalls, infoCalls atomic.Int32
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/users.list":
+			listCalls.Add(1)
+			members := make([]string, n)
+			for i := range members {
+				members[i] = fmt.Sprintf(`{"id":"U%03d","name":"user%d"}`, i, i)
+			}
+			_, _ = w.Write([]byte(`{"ok":true,"members":[` + strings.Join(members, ",") + `],"response_metadata":{"next_cursor":""}}`))
+		case "/users.info":
+			infoCalls.Add(1)
+			_, _ = w.Write([]byte(fmt.Sprintf(`{"ok":true,"user":{"id":%q,"name":"looked-up"}}`, r.Form.Get("user"))))
+		default:
+			t.Errorf("unexpected request to %s", r.URL.Path)
+		}
+	})
+	return client, &listCalls, &infoCalls
+}
+
+func TestClient_PrefetchUsers(t *testing.T) {
+	client, listCalls, infoCalls := newDirectoryTestClient(t, 3)
+
+	n, err := client.PrefetchUsers(context.Background())
+	if err != nil {
+		t.Fatalf("PrefetchUsers failed: %v", err)
+	}
+	if n != 3 {
+		t.Errorf("expected 3 users loaded, got %d", n)
+	}
+
+	// The directory is only loaded once
+	if _, err := client.PrefetchUsers(context.Background()); err != nil {
+		t.Fatalf("second PrefetchUsers failed: %v", err)
+	}
+	if listCalls.Load() != 1 {
+		t.Errorf("expected 1 users.list request, got %d", listCalls.Load())
+	}
+
+	user, err := client.GetUserInfo(context.Background(), "U002")
+	if err != nil || user.Name != "user2" {
+		t.Fatalf("GetUserInfo = %+v, %v; want the prefetched user", user, err)
+	}
+	if infoCalls.Load() != 0 {
+		t.Errorf("expected prefetched users to need no users.info request, got %d", infoCalls.Load())
+	}
+}
+
+func TestClient_GetUserInfo_LazyPrefetch(t *testing.T) {
+	client, listCalls, infoCalls := newDirectoryTestClient(t, 30)
+	WithLazyUserPrefetch()(client)
+
+	lookup := func(i int) {
+		t.Helper()
+		user, err := client.GetUserInfo(context.Background(), fmt.Sprintf("U%03d", i))
+		if err != nil || user == nil {
+			t.Fatalf("GetUserInfo(U%03d) = %+v, %v", i, user, err)
+		}
+	}
+
+	// The misses up to the threshold are looked up one at a time, including the one
+	// that starts loading the directory in the background
+	for i := 0; i < lazyPrefetchMisses; i++ {
+		lookup(i)
+	}
+	if infoCalls.Load() != lazyPrefetchMisses {
+		t.Errorf("expected %d users.info requests, got %d", lazyPrefetchMisses, infoCalls.Load())
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for !client.userDirectoryLoaded.Load() {
+		if time.Now().After(deadline) {
+			t.Fatal("expected the user directory to be loaded")
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	// Once loaded, the directory serves every later lookup
+	for i := lazyPrefetchMisses; i < 30; i++ {
+		lookup(i)
+	}
+	if listCalls.Load() != 1 {
+		t.Errorf("expected 1 users.list request, got %d", listCalls.Load())
+	}
+	if infoCalls.Load() != lazyPrefetchMisses {
+		t.Errorf("expected no more users.info requests once the directory was loaded, got %d", infoCalls.Load())
+	}
+
+	// Users missing from the directory (e.g., from another workspace) are still looked up
+	user, err := client.GetUserInfo(context.Background(), "W999")
+	if err != nil || user.Name != "looked-up" {
+		t.Errorf("GetUserInfo(W999) = %+v, %v; want a users.info lookup", user, err)
+	}
+	if listCalls.Load() != 1 {
+		t.Errorf("expected the directory not to be loaded again, got %d users.list requests", listCalls.Load())
+	}
+}
+
+func TestClient_GetUserInfo_LazyPrefetchDoesNotWait(t *testing.T) {
+	release := make(chan struct{})
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/users.list":
+			<-release
+			_, _ = w.Write([]byte(`{"ok":true,"members":[],"response_metadata":{"next_cursor":""}}`))
+		case "/users.info":
+			_, _ = w.Write([]byte(fmt.Sprintf(`{"ok":true,"user":{"id":%q,"name":"looked-up"}}`, r.Form.Get("user"))))
+		}
+	})
+	WithLazyUserPrefetch()(client)
+	defer close(release)
+
+	// The lookup that starts the directory load is answered while users.list is still pending
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < lazyPrefetchMisses; i++ {
+			if _, err := client.GetUserInfo(context.Background(), fmt.Sprintf("U%03d", i)); err != nil {
+				t.Errorf("GetUserInfo(U%03d) failed: %v", i, err)
+			}
+		}
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected the lookup that triggered the directory load not to wait for it")
+	}
+}
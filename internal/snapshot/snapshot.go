@@ -0,0 +1,229 @@
+// Package snapshot writes channel history to JSON files for archival, without an MCP client.
+package snapshot
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+
+	slackclient "github.com/Bitovi/slack-mcp-server/internal/slack"
+	"github.com/Bitovi/slack-mcp-server/pkg/types"
+)
+
+// maxChannelMessages caps the top-level messages written per channel in one snapshot.
+// A channel with more messages in the window is written with has_more set.
+const maxChannelMessages = 50000
+
+// channelIDPattern matches the IDs of public channels, private channels, and DMs.
+var channelIDPattern = regexp.MustCompile(`^[CGD][A-Z0-9]{8,}$`)
+
+// Options select what a snapshot contains and where it is written.
+type Options struct {
+	// Channels are the IDs of the channels to snapshot.
+	Channels []string
+	// Since is how far back from now history is read.
+	Since time.Duration
+	// OutDir is the directory the channel files are written to. It is created if missing.
+	OutDir string
+}
+
+// Validate checks that the options describe a snapshot that can be taken.
+func (o Options) Validate() error {
+	if len(o.Channels) == 0 {
+		return errors.New("no channels to snapshot")
+	}
+	for _, channelID := range o.Channels {
+		if !channelIDPattern.MatchString(channelID) {
+			return fmt.Errorf("invalid channel ID %q: must be a channel ID such as C01234567", channelID)
+		}
+	}
+	if o.Since <= 0 {
+		return errors.New("since must be a positive duration")
+	}
+	if o.OutDir == "" {
+		return errors.New("no output directory")
+	}
+	return nil
+}
+
+// Channel is the content of one channel's snapshot file.
+type Channel struct {
+	// ChannelID is the channel's ID.
+	ChannelID string `json:"channel_id"`
+	// Channel describes the channel. Omitted if the channel could not be looked up.
+	Channel *types.ChannelInfo `json:"channel,omitempty"`
+	// Workspace identifies the workspace the channel belongs to.
+	Workspace *types.WorkspaceInfo `json:"workspace,omitempty"`
+	// TakenAt is when the snapshot was taken, in RFC 3339 format.
+	TakenAt string `json:"taken_at"`
+	// Oldest is the Unix timestamp the snapshot window starts at.
+	Oldest string `json:"oldest"`
+	// Messages are the channel's top-level messages in the window, oldest first.
+	Messages []types.Message `json:"messages"`
+	// Threads maps each thread parent's timestamp to its replies, oldest first.
+	Threads map[string][]types.Message `json:"threads,omitempty"`
+	// HasMore indicates the window has more messages than were written.
+	HasMore bool `json:"has_more"`
+	// UserMapping maps the IDs of message authors, mentioned users, and reacting users to their profiles.
+	UserMapping map[string]types.UserInfo `json:"user_mapping"`
+	// Warnings list the parts of the channel that could not be read (e.g., a thread).
+	Warnings []types.Warning `json:"warnings,omitempty"`
+}
+
+// Write snapshots each channel to <OutDir>/<channel ID>.json, replacing any earlier
+// snapshot of it. Progress and failures are written to the logger. A channel that
+// cannot be read does not stop the others.
+//
+// Returns an error if the options are invalid, the output directory cannot be
+// created, or any channel could not be written.
+func Write(ctx context.Context, client slackclient.ClientInterface, opts Options, logger *log.Logger) error {
+	if err := opts.Validate(); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(opts.OutDir, 0o700); err != nil {
+		return fmt.Errorf("creating output directory: %w", err)
+	}
+
+	now := time.Now()
+	oldest := strconv.FormatInt(now.Add(-opts.Since).Unix(), 10)
+	workspace, _ := client.GetWorkspaceInfo(ctx)
+
+	failed := 0
+	for _, channelID := range opts.Channels {
+		snapshot, err := read(ctx, client, channelID, oldest)
+		if err == nil {
+			snapshot.Workspace = workspace
+			snapshot.TakenAt = now.UTC().Format(time.RFC3339)
+			err = writeFile(filepath.Join(opts.OutDir, channelID+".json"), snapshot)
+		}
+		if err != nil {
+			failed++
+			logger.Printf("snapshot %s: %v", channelID, err)
+			continue
+		}
+		logger.Printf("snapshot %s: %d messages, %d threads", channelID, len(snapshot.Messages), len(snapshot.Threads))
+		if snapshot.HasMore {
+			logger.Printf("snapshot %s: only the newest %d messages were written", channelID, maxChannelMessages)
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d channels could not be snapshotted", failed, len(opts.Channels))
+	}
+	return nil
+}
+
+// read reads one channel's messages since oldest, with their threads and users.
+func read(ctx context.Context, client slackclient.ClientInterface, channelID, oldest string) (*Channel, error) {
+	messages, hasMore, err := client.GetChannelHistory(ctx, channelID, slackclient.HistoryOptions{
+		Limit:              maxChannelMessages,
+		Oldest:             oldest,
+		IncludeAllMetadata: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot := &Channel{
+		ChannelID:   channelID,
+		Oldest:      oldest,
+		Messages:    messages,
+		HasMore:     hasMore,
+		UserMapping: make(map[string]types.UserInfo),
+	}
+	if channel, err := client.GetChannelInfo(ctx, channelID); err == nil {
+		snapshot.Channel = channel
+	}
+
+	dropRaw(snapshot.Messages)
+	// History is newest first; archives read better oldest first
+	sort.SliceStable(snapshot.Messages, func(i, j int) bool {
+		return snapshot.Messages[i].Timestamp < snapshot.Messages[j].Timestamp
+	})
+
+	all := append([]types.Message(nil), snapshot.Messages...)
+	for _, message := range snapshot.Messages {
+		if !client.HasThread(&message) {
+			continue
+		}
+		thread, err := client.GetThread(ctx, channelID, message.Timestamp)
+		if err != nil {
+			snapshot.Warnings = append(snapshot.Warnings, types.Warning{
+				Code:    types.WarnCodeThreadFetchFailed,
+				Message: fmt.Sprintf("thread %s could not be read: %v", message.Timestamp, err),
+			})
+			continue
+		}
+		dropRaw(thread)
+		var replies []types.Message
+		for _, reply := range thread {
+			if reply.Timestamp != message.Timestamp {
+				replies = append(replies, reply)
+			}
+		}
+		if len(replies) > 0 {
+			if snapshot.Threads == nil {
+				snapshot.Threads = make(map[string][]types.Message)
+			}
+			snapshot.Threads[message.Timestamp] = replies
+			all = append(all, replies...)
+		}
+	}
+
+	for _, message := range all {
+		userIDs := append([]string{message.User}, client.ExtractEntities(message.Text).Users...)
+		for _, reaction := range message.Reactions {
+			userIDs = append(userIDs, reaction.Users...)
+		}
+		for _, userID := range userIDs {
+			if _, done := snapshot.UserMapping[userID]; done || userID == "" {
+				continue
+			}
+			if user, err := client.GetUserInfo(ctx, userID); err == nil {
+				snapshot.UserMapping[userID] = *user
+			}
+		}
+	}
+
+	return snapshot, nil
+}
+
+// dropRaw removes the Slack API payloads the client attaches to messages; snapshots
+// hold the converted messages, as the read tools return them by default.
+func dropRaw(messages []types.Message) {
+	for i := range messages {
+		messages[i].Raw = nil
+	}
+}
+
+// writeFile writes v as indented JSON to path, through a temporary file, so a
+// failed write never leaves a truncated snapshot in place of an earlier one.
+func writeFile(path string, v interface{}) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".snapshot-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(append(data, '\n')); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
@@ -0,0 +1,152 @@
+// Package snapshot provides tests for channel snapshots.
+package snapshot
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	slackclient "github.com/Bitovi/slack-mcp-server/internal/slack"
+	"github.com/Bitovi/slack-mcp-server/pkg/types"
+)
+
+// fakeClient serves channel history, threads, and users. Other ClientInterface
+// methods are not used by snapshots and panic if called.
+type fakeClient struct {
+	slackclient.ClientInterface
+	history map[string][]types.Message
+	threads map[string][]types.Message
+}
+
+func (f *fakeClient) GetChannelHistory(ctx context.Context, channelID string, opts slackclient.HistoryOptions) ([]types.Message, bool, error) {
+	messages, ok := f.history[channelID]
+	if !ok {
+		return nil, false, errors.New("not_in_channel")
+	}
+	return messages, false, nil
+}
+
+func (f *fakeClient) GetThread(ctx context.Context, channelID, threadTS string) ([]types.Message, error) {
+	thread, ok := f.threads[threadTS]
+	if !ok {
+		return nil, errors.New("thread_not_found")
+	}
+	return thread, nil
+}
+
+func (f *fakeClient) HasThread(message *types.Message) bool {
+	return message.ReplyCount > 0
+}
+
+func (f *fakeClient) GetChannelInfo(ctx context.Context, channelID string) (*types.ChannelInfo, error) {
+	return &types.ChannelInfo{ID: channelID, Name: "general"}, nil
+}
+
+func (f *fakeClient) GetUserInfo(ctx context.Context, userID string) (*types.UserInfo, error) {
+	return &types.UserInfo{ID: userID, Name: strings.ToLower(userID)}, nil
+}
+
+func (f *fakeClient) GetWorkspaceInfo(ctx context.Context) (*types.WorkspaceInfo, error) {
+	return &types.WorkspaceInfo{TeamID: "T01234567"}, nil
+}
+
+func (f *fakeClient) ExtractEntities(text string) slackclient.Entities {
+	if strings.Contains(text, "<@U03333333>") {
+		return slackclient.Entities{Users: []string{"U03333333"}}
+	}
+	return slackclient.Entities{}
+}
+
+func TestWrite(t *testing.T) {
+	client := &fakeClient{
+		history: map[string][]types.Message{
+			"C01234567": {
+				{User: "U02222222", Text: "second", Timestamp: "1700000200.000100", ReplyCount: 1, Raw: json.RawMessage(`{}`)},
+				{User: "U01111111", Text: "first", Timestamp: "1700000100.000100", ReplyCount: 3},
+			},
+		},
+		threads: map[string][]types.Message{
+			"1700000200.000100": {
+				{User: "U02222222", Text: "second", Timestamp: "1700000200.000100", ThreadTS: "1700000200.000100"},
+				{User: "U01111111", Text: "cc <@U03333333>", Timestamp: "1700000250.000100", ThreadTS: "1700000200.000100"},
+			},
+		},
+	}
+	out := filepath.Join(t.TempDir(), "snapshots")
+	var logs bytes.Buffer
+
+	err := Write(context.Background(), client, Options{
+		Channels: []string{"C01234567", "C07654321"},
+		Since:    24 * time.Hour,
+		OutDir:   out,
+	}, log.New(&logs, "", 0))
+	if err == nil || !strings.Contains(err.Error(), "1 of 2 channels") {
+		t.Fatalf("Write() error = %v, want one failed channel", err)
+	}
+	if !strings.Contains(logs.String(), "snapshot C07654321: not_in_channel") {
+		t.Errorf("log = %q, want the failed channel", logs.String())
+	}
+
+	data, err := os.ReadFile(filepath.Join(out, "C01234567.json"))
+	if err != nil {
+		t.Fatalf("failed to read snapshot: %v", err)
+	}
+	var snapshot Channel
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		t.Fatalf("failed to parse snapshot: %v", err)
+	}
+
+	if len(snapshot.Messages) != 2 || snapshot.Messages[0].Text != "first" {
+		t.Errorf("messages = %+v, want oldest first", snapshot.Messages)
+	}
+	if snapshot.Messages[1].Raw != nil {
+		t.Errorf("expected raw payloads to be dropped, got %s", snapshot.Messages[1].Raw)
+	}
+	replies := snapshot.Threads["1700000200.000100"]
+	if len(replies) != 1 || replies[0].Timestamp != "1700000250.000100" {
+		t.Errorf("thread replies = %+v, want the reply without its parent", replies)
+	}
+	// The other thread could not be read
+	if len(snapshot.Warnings) != 1 || snapshot.Warnings[0].Code != types.WarnCodeThreadFetchFailed {
+		t.Errorf("warnings = %+v, want one thread_fetch_failed", snapshot.Warnings)
+	}
+	for _, userID := range []string{"U01111111", "U02222222", "U03333333"} {
+		if _, ok := snapshot.UserMapping[userID]; !ok {
+			t.Errorf("user mapping is missing %s", userID)
+		}
+	}
+	if snapshot.Channel == nil || snapshot.Workspace == nil || snapshot.TakenAt == "" {
+		t.Errorf("expected channel, workspace, and taken_at, got %+v", snapshot)
+	}
+
+	// No temporary files are left behind
+	entries, _ := os.ReadDir(out)
+	if len(entries) != 1 {
+		t.Errorf("output directory has %d entries, want 1", len(entries))
+	}
+}
+
+func TestOptions_Validate(t *testing.T) {
+	valid := Options{Channels: []string{"C01234567"}, Since: time.Hour, OutDir: "out"}
+	if err := valid.Validate(); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+
+	for name, opts := range map[string]Options{
+		"no channels":    {Since: time.Hour, OutDir: "out"},
+		"bad channel":    {Channels: []string{"general"}, Since: time.Hour, OutDir: "out"},
+		"no window":      {Channels: []string{"C01234567"}, OutDir: "out"},
+		"no output path": {Channels: []string{"C01234567"}, Since: time.Hour},
+	} {
+		if err := opts.Validate(); err == nil {
+			t.Errorf("%s: Validate() succeeded, want error", name)
+		}
+	}
+}
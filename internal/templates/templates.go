@@ -0,0 +1,168 @@
+// Package templates loads named message templates from a config file and
+// renders them with variable substitution, so standardized messages posted
+// by agents (incident updates, status reports) stay consistent and reviewable.
+//
+// Templates are Slack mrkdwn with {{variable}} placeholders. Every placeholder
+// must be given a value, and unknown variables are rejected, so a typo cannot
+// silently post a half-filled template. Values are escaped, so they cannot
+// inject mentions such as <!channel> or links into the message.
+package templates
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// placeholderPattern matches a {{variable}} placeholder, allowing spaces inside the braces.
+var placeholderPattern = regexp.MustCompile(`\{\{\s*([A-Za-z_][A-Za-z0-9_]*)\s*\}\}`)
+
+// namePattern restricts template names to identifiers that are easy to pass as tool arguments.
+var namePattern = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// Template is a named message template.
+type Template struct {
+	// Name identifies the template in post_from_template calls.
+	Name string `json:"-"`
+	// Description explains when to use the template. It is shown to agents.
+	Description string `json:"description"`
+	// Text is the message text in Slack mrkdwn, with {{variable}} placeholders.
+	Text string `json:"text"`
+	// ChannelID is the channel the template posts to when the caller does not name one.
+	// Optional.
+	ChannelID string `json:"channel_id,omitempty"`
+
+	// variables are the placeholder names in Text, sorted and deduplicated.
+	variables []string
+}
+
+// Variables returns the names of the template's placeholders, sorted.
+func (t *Template) Variables() []string {
+	return t.variables
+}
+
+// Library is a set of templates loaded from a config file.
+type Library struct {
+	templates map[string]*Template
+}
+
+// file is the JSON layout of a templates config file:
+//
+//	{
+//	  "templates": {
+//	    "incident_update": {
+//	      "description": "Status update for an open incident",
+//	      "channel_id": "C01234567",
+//	      "text": ":rotating_light: *{{title}}* is now *{{status}}*\n{{summary}}"
+//	    }
+//	  }
+//	}
+type file struct {
+	Templates map[string]*Template `json:"templates"`
+}
+
+// Load reads a templates config file.
+//
+// Returns an error if the file cannot be read or parsed, or if a template
+// has an invalid name or no text.
+func Load(path string) (*Library, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading templates file: %w", err)
+	}
+	return Parse(data)
+}
+
+// Parse parses the contents of a templates config file. See Load.
+func Parse(data []byte) (*Library, error) {
+	var f file
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("parsing templates file: %w", err)
+	}
+	if len(f.Templates) == 0 {
+		return nil, fmt.Errorf("templates file defines no templates")
+	}
+
+	lib := &Library{templates: make(map[string]*Template, len(f.Templates))}
+	for name, tmpl := range f.Templates {
+		if !namePattern.MatchString(name) {
+			return nil, fmt.Errorf("invalid template name %q: use letters, digits, '-' and '_'", name)
+		}
+		if tmpl == nil || strings.TrimSpace(tmpl.Text) == "" {
+			return nil, fmt.Errorf("template %q has no text", name)
+		}
+
+		tmpl.Name = name
+		seen := make(map[string]bool)
+		for _, m := range placeholderPattern.FindAllStringSubmatch(tmpl.Text, -1) {
+			if !seen[m[1]] {
+				seen[m[1]] = true
+				tmpl.variables = append(tmpl.variables, m[1])
+			}
+		}
+		sort.Strings(tmpl.variables)
+
+		lib.templates[name] = tmpl
+	}
+	return lib, nil
+}
+
+// Get returns the named template, or false if there is none.
+func (l *Library) Get(name string) (*Template, bool) {
+	tmpl, ok := l.templates[name]
+	return tmpl, ok
+}
+
+// Templates returns all templates, sorted by name.
+func (l *Library) Templates() []*Template {
+	all := make([]*Template, 0, len(l.templates))
+	for _, tmpl := range l.templates {
+		all = append(all, tmpl)
+	}
+	sort.Slice(all, func(i, j int) bool { return all[i].Name < all[j].Name })
+	return all
+}
+
+// Render substitutes vars into the template's placeholders.
+//
+// Returns an error naming the missing variables if a placeholder has no value,
+// or the unknown variables if vars has entries the template does not use.
+func (t *Template) Render(vars map[string]string) (string, error) {
+	var missing []string
+	for _, name := range t.variables {
+		if _, ok := vars[name]; !ok {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) > 0 {
+		return "", fmt.Errorf("template %q is missing variables: %s", t.Name, strings.Join(missing, ", "))
+	}
+
+	var unknown []string
+	for name := range vars {
+		if i := sort.SearchStrings(t.variables, name); i == len(t.variables) || t.variables[i] != name {
+			unknown = append(unknown, name)
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		if len(t.variables) == 0 {
+			return "", fmt.Errorf("template %q takes no variables, got %s", t.Name, strings.Join(unknown, ", "))
+		}
+		return "", fmt.Errorf("template %q has no variables named %s (expected: %s)",
+			t.Name, strings.Join(unknown, ", "), strings.Join(t.variables, ", "))
+	}
+
+	return placeholderPattern.ReplaceAllStringFunc(t.Text, func(placeholder string) string {
+		name := placeholderPattern.FindStringSubmatch(placeholder)[1]
+		return escape(vars[name])
+	}), nil
+}
+
+// escape escapes &, <, and > as Slack requires in message text.
+func escape(text string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;").Replace(text)
+}
@@ -0,0 +1,126 @@
+// Package templates loads named message templates from a config file.
+package templates
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testTemplates = `{
+  "templates": {
+    "incident_update": {
+      "description": "Status update for an open incident",
+      "channel_id": "C01234567",
+      "text": "*{{title}}* is now *{{ status }}*\n{{summary}} ({{status}})"
+    },
+    "all_clear": {
+      "text": "All clear :white_check_mark:"
+    }
+  }
+}`
+
+func TestLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "templates.json")
+	if err := os.WriteFile(path, []byte(testTemplates), 0o600); err != nil {
+		t.Fatalf("failed to write templates file: %v", err)
+	}
+
+	lib, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	all := lib.Templates()
+	if len(all) != 2 || all[0].Name != "all_clear" || all[1].Name != "incident_update" {
+		t.Fatalf("Templates() = %v, want all_clear and incident_update", all)
+	}
+
+	tmpl, ok := lib.Get("incident_update")
+	if !ok {
+		t.Fatal("expected incident_update template")
+	}
+	if got := strings.Join(tmpl.Variables(), ","); got != "status,summary,title" {
+		t.Errorf("Variables() = %s, want status,summary,title", got)
+	}
+	if tmpl.ChannelID != "C01234567" {
+		t.Errorf("ChannelID = %q, want %q", tmpl.ChannelID, "C01234567")
+	}
+}
+
+func TestParse_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		wantErr string
+	}{
+		{name: "not JSON", data: `templates:`, wantErr: "parsing templates file"},
+		{name: "no templates", data: `{"templates":{}}`, wantErr: "defines no templates"},
+		{name: "bad name", data: `{"templates":{"incident update":{"text":"x"}}}`, wantErr: "invalid template name"},
+		{name: "no text", data: `{"templates":{"empty":{"description":"x"}}}`, wantErr: `template "empty" has no text`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse([]byte(tt.data))
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Parse() error = %v, want to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestTemplate_Render(t *testing.T) {
+	lib, err := Parse([]byte(testTemplates))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	tmpl, _ := lib.Get("incident_update")
+
+	tests := []struct {
+		name    string
+		vars    map[string]string
+		want    string
+		wantErr string
+	}{
+		{
+			name: "all variables",
+			vars: map[string]string{"title": "Login outage", "status": "resolved", "summary": "Fixed by rollback"},
+			want: "*Login outage* is now *resolved*\nFixed by rollback (resolved)",
+		},
+		{
+			name: "values are escaped",
+			vars: map[string]string{"title": "<!channel>", "status": "a & b", "summary": "x"},
+			want: "*&lt;!channel&gt;* is now *a &amp; b*\nx (a &amp; b)",
+		},
+		{
+			name:    "missing variable",
+			vars:    map[string]string{"title": "Login outage"},
+			wantErr: "missing variables: status, summary",
+		},
+		{
+			name:    "unknown variable",
+			vars:    map[string]string{"title": "t", "status": "s", "summary": "x", "sevrity": "1"},
+			wantErr: "no variables named sevrity",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tmpl.Render(tt.vars)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Render() error = %v, want to contain %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Render() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Render() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
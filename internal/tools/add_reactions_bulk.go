@@ -0,0 +1,260 @@
+// Package tools provides MCP tool handler implementations for the Slack MCP server.
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	slackclient "github.com/Bitovi/slack-mcp-server/internal/slack"
+	"github.com/Bitovi/slack-mcp-server/internal/urlparser"
+	"github.com/Bitovi/slack-mcp-server/pkg/types"
+)
+
+const (
+	// maxBulkReactionMessages is the maximum number of messages per add_reactions_bulk call.
+	maxBulkReactionMessages = 100
+	// bulkReactionInterval paces reactions.add calls. Slack allows about 50 per
+	// minute (Tier 3), so this stays just under the limit for a full batch.
+	bulkReactionInterval = 1200 * time.Millisecond
+)
+
+// AddReactionsBulkHandler handles the add_reactions_bulk MCP tool requests.
+// It applies one reaction to many messages, pacing the calls so that a batch
+// does not trip Slack's rate limit, and reports the outcome per message.
+// It is a write tool, registered only when write tools are enabled.
+type AddReactionsBulkHandler struct {
+	// slackClient is the Slack API client for adding reactions.
+	slackClient slackclient.ClientInterface
+	// config holds optional handler behavior.
+	config handlerConfig
+	// interval is the delay between reactions.add calls; it is shortened in tests.
+	interval time.Duration
+}
+
+// NewAddReactionsBulkHandler creates a new AddReactionsBulkHandler with the given Slack client and options.
+func NewAddReactionsBulkHandler(client slackclient.ClientInterface, opts ...HandlerOption) *AddReactionsBulkHandler {
+	return &AddReactionsBulkHandler{
+		slackClient: client,
+		config:      newHandlerConfig(opts),
+		interval:    bulkReactionInterval,
+	}
+}
+
+// messageRef identifies a message to react to.
+type messageRef struct {
+	channelID string
+	timestamp string
+	// threadTS is the parent message timestamp if the message is a thread reply.
+	threadTS string
+	// userID is set instead of channelID for DM links that reference a user.
+	userID string
+}
+
+// Handle processes an add_reactions_bulk tool call.
+//
+// All message references are validated before any reaction is added. Failures
+// on individual messages (e.g., message_not_found) are reported per message and
+// do not stop the batch. Failures that would affect every remaining message
+// (invalid token, missing scope, rate limiting that outlasts a retry, or
+// cancellation) stop the batch, and the remaining messages are reported as skipped.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeouts
+//   - request: The MCP tool call request containing reaction and messages
+//
+// Returns an MCP tool result with per-message outcomes, or an error result if
+// the arguments are invalid.
+func (h *AddReactionsBulkHandler) Handle(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	// Extract the reaction argument (required); surrounding colons are accepted
+	reactionArg, ok := request.Params.Arguments["reaction"]
+	if !ok {
+		return mcp.NewToolResultError("missing required argument 'reaction'"), nil
+	}
+
+	reaction, ok := reactionArg.(string)
+	if !ok {
+		return mcp.NewToolResultError("argument 'reaction' must be a string"), nil
+	}
+
+	reaction = strings.Trim(strings.TrimSpace(reaction), ":")
+	if reaction == "" {
+		return mcp.NewToolResultError("argument 'reaction' cannot be empty"), nil
+	}
+
+	// Extract the messages argument (required)
+	messagesArg, ok := request.Params.Arguments["messages"]
+	if !ok {
+		return mcp.NewToolResultError("missing required argument 'messages'"), nil
+	}
+
+	items, ok := messagesArg.([]interface{})
+	if !ok {
+		return mcp.NewToolResultError("argument 'messages' must be an array"), nil
+	}
+
+	if len(items) == 0 {
+		return mcp.NewToolResultError("argument 'messages' cannot be empty"), nil
+	}
+	if len(items) > maxBulkReactionMessages {
+		return mcp.NewToolResultError(fmt.Sprintf("argument 'messages' has %d entries; at most %d are allowed per call",
+			len(items), maxBulkReactionMessages)), nil
+	}
+
+	refs := make([]messageRef, 0, len(items))
+	for i, item := range items {
+		ref, err := parseMessageRef(item)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("messages[%d]: %s", i, err.Error())), nil
+		}
+		refs = append(refs, ref)
+	}
+
+	result := &types.AddReactionsBulkResult{
+		Reaction: reaction,
+		Results:  make([]types.ReactionResult, 0, len(refs)),
+	}
+
+	var stopErr error
+	for i, ref := range refs {
+		if stopErr != nil {
+			result.Results = append(result.Results, types.ReactionResult{
+				ChannelID: ref.channelID,
+				Timestamp: ref.timestamp,
+				Status:    types.ReactionStatusSkipped,
+			})
+			result.Skipped++
+			continue
+		}
+
+		// Pace the calls, stopping if the caller gives up
+		if i > 0 && h.interval > 0 {
+			select {
+			case <-ctx.Done():
+			case <-time.After(h.interval):
+			}
+		}
+
+		err := ctx.Err()
+		if err == nil && ref.userID != "" {
+			ref.channelID, err = h.slackClient.OpenDMChannel(ctx, ref.userID)
+		}
+		if err == nil {
+			err = h.slackClient.AddReaction(ctx, ref.channelID, ref.timestamp, ref.threadTS, reaction)
+		}
+
+		entry := types.ReactionResult{ChannelID: ref.channelID, Timestamp: ref.timestamp}
+		switch {
+		case err == nil:
+			entry.Status = types.ReactionStatusAdded
+			result.Added++
+		case slackclient.IsAlreadyReacted(err):
+			entry.Status = types.ReactionStatusAlreadyReacted
+			result.AlreadyReacted++
+		case batchFatal(ctx, err):
+			// Report this message as skipped too: the reaction was not added and it
+			// is safe to retry along with the rest
+			entry.Status = types.ReactionStatusSkipped
+			result.Skipped++
+			result.Warnings = append(result.Warnings, types.Warning{
+				Code: types.WarnCodeBatchStopped,
+				Message: fmt.Sprintf("stopped after %d of %d messages: %s; the skipped messages can be retried",
+					i, len(refs), err.Error()),
+			})
+			stopErr = err
+		default:
+			entry.Status = types.ReactionStatusFailed
+			entry.Error = partialError(err)
+			result.Failed++
+		}
+		result.Results = append(result.Results, entry)
+	}
+
+	// Nothing was attempted successfully before a batch-wide failure: report it as an error
+	if stopErr != nil && result.Added == 0 && result.AlreadyReacted == 0 && result.Failed == 0 {
+		return h.handleError(stopErr), nil
+	}
+
+	result.Workspace = workspaceFor(ctx, h.slackClient)
+
+	return h.successResult(result)
+}
+
+// batchFatal reports whether err will also affect every remaining message in the
+// batch, so the batch should stop rather than fail one message at a time.
+func batchFatal(ctx context.Context, err error) bool {
+	return ctx.Err() != nil ||
+		slackclient.IsRateLimited(err) ||
+		slackclient.IsInvalidToken(err) ||
+		slackclient.IsMissingScope(err)
+}
+
+// parseMessageRef parses one entry of the messages argument: either a Slack
+// message URL or an object with channel_id, timestamp, and optionally thread_ts.
+func parseMessageRef(item interface{}) (messageRef, error) {
+	switch v := item.(type) {
+	case string:
+		parsedURL, err := urlparser.Parse(v)
+		if err != nil {
+			return messageRef{}, err
+		}
+		return messageRef{
+			channelID: parsedURL.ChannelID,
+			timestamp: parsedURL.Timestamp,
+			threadTS:  parsedURL.ThreadTS,
+			userID:    parsedURL.UserID,
+		}, nil
+	case map[string]interface{}:
+		channelID, _ := v["channel_id"].(string)
+		timestamp, _ := v["timestamp"].(string)
+		threadTS, _ := v["thread_ts"].(string)
+		if channelID == "" || timestamp == "" {
+			return messageRef{}, fmt.Errorf("object entries need string 'channel_id' and 'timestamp' fields")
+		}
+		return messageRef{channelID: channelID, timestamp: timestamp, threadTS: threadTS}, nil
+	default:
+		return messageRef{}, fmt.Errorf("must be a Slack message URL or an object with 'channel_id' and 'timestamp'")
+	}
+}
+
+// handleError converts the error that stopped a batch before any message was
+// processed into an MCP error result.
+func (h *AddReactionsBulkHandler) handleError(err error) *mcp.CallToolResult {
+	// Check for known error types and provide appropriate messages
+	if slackclient.IsRateLimited(err) {
+		return mcp.NewToolResultError(
+			"Rate limit exceeded before any reaction was added. Please wait and try again.")
+	}
+
+	if slackclient.IsInvalidToken(err) {
+		return mcp.NewToolResultError(
+			"Authentication failed. Please check that SLACK_BOT_TOKEN is valid and not expired.")
+	}
+
+	if slackclient.IsMissingScope(err) {
+		return mcp.NewToolResultError(err.Error())
+	}
+
+	// Generic error handling (e.g., the call was canceled)
+	return mcp.NewToolResultError(fmt.Sprintf("Failed to add reactions: %s", err.Error()))
+}
+
+// successResult creates a successful MCP tool result with the given data.
+func (h *AddReactionsBulkHandler) successResult(result *types.AddReactionsBulkResult) (*mcp.CallToolResult, error) {
+	resultJSON, err := json.Marshal(result)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to encode result: %s", err.Error())), nil
+	}
+
+	return mcp.NewToolResultText(string(resultJSON)), nil
+}
+
+// HandleFunc returns a function that can be used directly as an MCP tool handler.
+// This is a convenience method for registering the handler with the MCP server.
+func (h *AddReactionsBulkHandler) HandleFunc() func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return h.Handle
+}
@@ -0,0 +1,127 @@
+// Package tools provides unit tests for the MCP tool handlers.
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/Bitovi/slack-mcp-server/pkg/types"
+)
+
+func TestAddReactionsBulkHandler_Handle(t *testing.T) {
+	messages := []interface{}{
+		"https://workspace.slack.com/archives/C01234567/p1355517523000008",
+		map[string]interface{}{"channel_id": "C01234567", "timestamp": "1355517524.000001", "thread_ts": "1355517523.000008"},
+		map[string]interface{}{"channel_id": "C01234567", "timestamp": "1355517525.000001"},
+		map[string]interface{}{"channel_id": "C01234567", "timestamp": "1355517526.000001"},
+	}
+
+	tests := []struct {
+		name         string
+		args         map[string]interface{}
+		reactErrs    map[string]error
+		wantStatuses []string
+		wantWarning  bool
+		wantError    string
+	}{
+		{
+			name:         "all added",
+			args:         map[string]interface{}{"reaction": ":white_check_mark:", "messages": messages},
+			wantStatuses: []string{"added", "added", "added", "added"},
+		},
+		{
+			name: "per-message failures continue",
+			args: map[string]interface{}{"reaction": "white_check_mark", "messages": messages},
+			reactErrs: map[string]error{
+				"1355517524.000001": types.NewSlackError(types.ErrCodeAlreadyReacted, "already reacted"),
+				"1355517525.000001": types.NewSlackError(types.ErrCodeMessageNotFound, "Message or thread not found."),
+			},
+			wantStatuses: []string{"added", "already_reacted", "failed", "added"},
+		},
+		{
+			name: "rate limit stops the batch",
+			args: map[string]interface{}{"reaction": "white_check_mark", "messages": messages},
+			reactErrs: map[string]error{
+				"1355517525.000001": types.NewSlackError(types.ErrCodeRateLimited, "Slack API rate limit exceeded."),
+			},
+			wantStatuses: []string{"added", "added", "skipped", "skipped"},
+			wantWarning:  true,
+		},
+		{
+			name: "failure before any reaction is an error",
+			args: map[string]interface{}{"reaction": "white_check_mark", "messages": messages},
+			reactErrs: map[string]error{
+				"1355517523.000008": types.NewSlackError(types.ErrCodeMissingScope, "Missing scope reactions:write."),
+			},
+			wantError: "Missing scope reactions:write",
+		},
+		{
+			name:      "invalid entry",
+			args:      map[string]interface{}{"reaction": "eyes", "messages": []interface{}{"C01234567"}},
+			wantError: "messages[0]:",
+		},
+		{
+			name:      "missing reaction",
+			args:      map[string]interface{}{"messages": messages},
+			wantError: "missing required argument 'reaction'",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var reacted []string
+			mock := &mockSlackClient{
+				addReaction: func(ctx context.Context, channelID, timestamp, threadTS, name string) error {
+					if name != "white_check_mark" {
+						t.Errorf("reaction = %q, want %q", name, "white_check_mark")
+					}
+					if timestamp == "1355517524.000001" && threadTS != "1355517523.000008" {
+						t.Errorf("thread_ts = %q, want the reply's parent %q", threadTS, "1355517523.000008")
+					}
+					reacted = append(reacted, timestamp)
+					return tt.reactErrs[timestamp]
+				},
+			}
+
+			handler := NewAddReactionsBulkHandler(mock)
+			handler.interval = 0
+			result, err := handler.Handle(context.Background(), createToolRequest(tt.args))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			text := result.Content[0].(mcp.TextContent).Text
+			if tt.wantError != "" {
+				if !result.IsError || !strings.Contains(text, tt.wantError) {
+					t.Errorf("expected error containing %q, got: %s", tt.wantError, text)
+				}
+				return
+			}
+			if result.IsError {
+				t.Fatalf("expected success, got error: %s", text)
+			}
+
+			var bulkResult types.AddReactionsBulkResult
+			if err := json.Unmarshal([]byte(text), &bulkResult); err != nil {
+				t.Fatalf("failed to parse result JSON: %v", err)
+			}
+			var statuses []string
+			for _, r := range bulkResult.Results {
+				statuses = append(statuses, r.Status)
+			}
+			if strings.Join(statuses, ",") != strings.Join(tt.wantStatuses, ",") {
+				t.Errorf("statuses = %v, want %v", statuses, tt.wantStatuses)
+			}
+			if got := len(bulkResult.Warnings) > 0; got != tt.wantWarning {
+				t.Errorf("warnings = %v, want warning: %v", bulkResult.Warnings, tt.wantWarning)
+			}
+			if bulkResult.Results[0].Timestamp != "1355517523.000008" {
+				t.Errorf("first timestamp = %q, want URL timestamp", bulkResult.Results[0].Timestamp)
+			}
+		})
+	}
+}
@@ -0,0 +1,44 @@
+// Package tools provides MCP tool handler implementations for the Slack MCP server.
+package tools
+
+import (
+	"context"
+	"fmt"
+
+	slackclient "github.com/Bitovi/slack-mcp-server/internal/slack"
+	"github.com/Bitovi/slack-mcp-server/pkg/types"
+)
+
+// workflowBuilderBotName is the bot name of Slack's Workflow Builder. Workflow posts
+// are made by this bot under the workflow's name (the message username).
+const workflowBuilderBotName = "Workflow Builder"
+
+// attributeBotMessage labels a message posted by a bot, so automated posts are
+// distinguishable from people's: PostedBy becomes "workflow 'New Joiner Intake'" for
+// Workflow Builder posts and "app 'PagerDuty'" for other bots, and Workflow is set
+// for workflow posts.
+//
+// The bot's name comes from the message's bot profile, or from bots.info (cached)
+// when the message has none. If the bot cannot be looked up, the label falls back
+// to the message username, and the message is left unlabeled if there is none.
+func attributeBotMessage(ctx context.Context, client slackclient.ClientInterface, msg *types.Message) {
+	if msg.BotID == "" {
+		return
+	}
+
+	if msg.BotName == "" {
+		if bot, err := client.GetBotInfo(ctx, msg.BotID); err == nil && bot != nil {
+			msg.BotName = bot.Name
+		}
+	}
+
+	switch {
+	case msg.BotName == workflowBuilderBotName && msg.Username != "":
+		msg.Workflow = msg.Username
+		msg.PostedBy = fmt.Sprintf("workflow '%s'", msg.Workflow)
+	case msg.Username != "":
+		msg.PostedBy = fmt.Sprintf("app '%s'", msg.Username)
+	case msg.BotName != "":
+		msg.PostedBy = fmt.Sprintf("app '%s'", msg.BotName)
+	}
+}
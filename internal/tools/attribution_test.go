@@ -0,0 +1,69 @@
+// Package tools provides unit tests for the MCP tool handlers.
+package tools
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Bitovi/slack-mcp-server/pkg/types"
+)
+
+func TestAttributeBotMessage(t *testing.T) {
+	tests := []struct {
+		name         string
+		msg          types.Message
+		wantPostedBy string
+		wantWorkflow string
+	}{
+		{
+			name:         "workflow post",
+			msg:          types.Message{BotID: "B01111111", BotName: "Workflow Builder", Username: "New Joiner Intake"},
+			wantPostedBy: "workflow 'New Joiner Intake'",
+			wantWorkflow: "New Joiner Intake",
+		},
+		{
+			name:         "workflow post without bot profile",
+			msg:          types.Message{BotID: "B02222222", Username: "Incident Intake"},
+			wantPostedBy: "workflow 'Incident Intake'",
+			wantWorkflow: "Incident Intake",
+		},
+		{
+			name:         "app post",
+			msg:          types.Message{BotID: "B03333333", BotName: "PagerDuty"},
+			wantPostedBy: "app 'PagerDuty'",
+		},
+		{
+			name:         "app post under a custom username",
+			msg:          types.Message{BotID: "B03333333", BotName: "PagerDuty", Username: "PD Alerts"},
+			wantPostedBy: "app 'PD Alerts'",
+		},
+		{
+			name:         "unknown bot",
+			msg:          types.Message{BotID: "B09999999"},
+			wantPostedBy: "",
+		},
+		{
+			name: "person",
+			msg:  types.Message{User: "U01234567"},
+		},
+	}
+
+	mock := &mockSlackClient{
+		getBotInfo: func(ctx context.Context, botID string) (*types.BotInfo, error) {
+			if botID == "B02222222" {
+				return &types.BotInfo{ID: botID, Name: "Workflow Builder"}, nil
+			}
+			return nil, types.NewSlackError(types.ErrCodeSlackError, "Slack API error: bot_not_found")
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msg := tt.msg
+			attributeBotMessage(context.Background(), mock, &msg)
+			if msg.PostedBy != tt.wantPostedBy || msg.Workflow != tt.wantWorkflow {
+				t.Errorf("posted_by = %q, workflow = %q; want %q, %q", msg.PostedBy, msg.Workflow, tt.wantPostedBy, tt.wantWorkflow)
+			}
+		})
+	}
+}
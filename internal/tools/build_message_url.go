@@ -0,0 +1,113 @@
+// Package tools provides MCP tool handler implementations for the Slack MCP server.
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	slackclient "github.com/Bitovi/slack-mcp-server/internal/slack"
+	"github.com/Bitovi/slack-mcp-server/internal/urlparser"
+	"github.com/Bitovi/slack-mcp-server/pkg/types"
+)
+
+// BuildMessageURLHandler handles the build_message_url MCP tool requests.
+// It turns a channel ID and timestamp from a tool result into a shareable Slack URL.
+type BuildMessageURLHandler struct {
+	// slackClient is the Slack API client for identifying the workspace URL.
+	slackClient slackclient.ClientInterface
+	// config holds optional handler behavior.
+	config handlerConfig
+}
+
+// NewBuildMessageURLHandler creates a new BuildMessageURLHandler with the given Slack client and options.
+func NewBuildMessageURLHandler(client slackclient.ClientInterface, opts ...HandlerOption) *BuildMessageURLHandler {
+	return &BuildMessageURLHandler{
+		slackClient: client,
+		config:      newHandlerConfig(opts),
+	}
+}
+
+// Handle processes a build_message_url tool call.
+// It builds the URL locally from the workspace URL, so it works even when
+// chat.getPermalink is unavailable to the bot.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeouts
+//   - request: The MCP tool call request containing channel_id, timestamp, and optional thread_ts
+//
+// Returns an MCP tool result containing the URL, or an error result if the
+// arguments are invalid or the workspace cannot be identified.
+func (h *BuildMessageURLHandler) Handle(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	// Extract the channel_id argument (required)
+	channelIDArg, ok := request.Params.Arguments["channel_id"]
+	if !ok {
+		return mcp.NewToolResultError("missing required argument 'channel_id'"), nil
+	}
+
+	channelID, ok := channelIDArg.(string)
+	if !ok {
+		return mcp.NewToolResultError("argument 'channel_id' must be a string"), nil
+	}
+
+	// Extract the timestamp argument (required)
+	timestampArg, ok := request.Params.Arguments["timestamp"]
+	if !ok {
+		return mcp.NewToolResultError("missing required argument 'timestamp'"), nil
+	}
+
+	timestamp, ok := timestampArg.(string)
+	if !ok {
+		return mcp.NewToolResultError("argument 'timestamp' must be a string"), nil
+	}
+
+	// Extract thread_ts parameter (optional)
+	threadTS := ""
+	if threadTSArg, exists := request.Params.Arguments["thread_ts"]; exists {
+		v, ok := threadTSArg.(string)
+		if !ok {
+			return mcp.NewToolResultError("argument 'thread_ts' must be a string"), nil
+		}
+		threadTS = v
+	}
+
+	// The workspace URL supplies the host for the link
+	workspace, err := h.slackClient.GetWorkspaceInfo(ctx)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to identify the workspace URL: %s", err.Error())), nil
+	}
+
+	messageURL, err := urlparser.Build(workspace.URL, channelID, timestamp, threadTS)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to build message URL: %s", err.Error())), nil
+	}
+
+	result := &types.BuildMessageURLResult{
+		URL:       messageURL,
+		ChannelID: channelID,
+		Timestamp: timestamp,
+	}
+	if threadTS != timestamp {
+		result.ThreadTS = threadTS
+	}
+
+	return h.successResult(result)
+}
+
+// successResult creates a successful MCP tool result with the given data.
+func (h *BuildMessageURLHandler) successResult(result *types.BuildMessageURLResult) (*mcp.CallToolResult, error) {
+	resultJSON, err := json.Marshal(result)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to encode result: %s", err.Error())), nil
+	}
+
+	return mcp.NewToolResultText(string(resultJSON)), nil
+}
+
+// HandleFunc returns a function that can be used directly as an MCP tool handler.
+// This is a convenience method for registering the handler with the MCP server.
+func (h *BuildMessageURLHandler) HandleFunc() func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return h.Handle
+}
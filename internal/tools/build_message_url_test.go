@@ -0,0 +1,112 @@
+// Package tools provides unit tests for the MCP tool handlers.
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/Bitovi/slack-mcp-server/pkg/types"
+)
+
+func TestBuildMessageURLHandler_Handle(t *testing.T) {
+	tests := []struct {
+		name      string
+		args      map[string]interface{}
+		wantURL   string
+		wantError string
+	}{
+		{
+			name: "top-level message",
+			args: map[string]interface{}{
+				"channel_id": "C01234567",
+				"timestamp":  "1355517523.000008",
+			},
+			wantURL: "https://workspace.slack.com/archives/C01234567/p1355517523000008",
+		},
+		{
+			name: "thread reply",
+			args: map[string]interface{}{
+				"channel_id": "C01234567",
+				"timestamp":  "1355517524.000001",
+				"thread_ts":  "1355517523.000008",
+			},
+			wantURL: "https://workspace.slack.com/archives/C01234567/p1355517524000001?thread_ts=1355517523.000008&cid=C01234567",
+		},
+		{
+			name: "missing channel_id",
+			args: map[string]interface{}{
+				"timestamp": "1355517523.000008",
+			},
+			wantError: "missing required argument 'channel_id'",
+		},
+		{
+			name: "missing timestamp",
+			args: map[string]interface{}{
+				"channel_id": "C01234567",
+			},
+			wantError: "missing required argument 'timestamp'",
+		},
+		{
+			name: "invalid timestamp",
+			args: map[string]interface{}{
+				"channel_id": "C01234567",
+				"timestamp":  "p1355517523000008",
+			},
+			wantError: "invalid timestamp",
+		},
+	}
+
+	handler := NewBuildMessageURLHandler(&mockSlackClient{})
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := handler.Handle(context.Background(), createToolRequest(tt.args))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			text := result.Content[0].(mcp.TextContent).Text
+			if tt.wantError != "" {
+				if !result.IsError || !strings.Contains(text, tt.wantError) {
+					t.Errorf("expected error containing %q, got: %s", tt.wantError, text)
+				}
+				return
+			}
+			if result.IsError {
+				t.Fatalf("expected success, got error: %s", text)
+			}
+
+			var urlResult types.BuildMessageURLResult
+			if err := json.Unmarshal([]byte(text), &urlResult); err != nil {
+				t.Fatalf("failed to parse result JSON: %v", err)
+			}
+			if urlResult.URL != tt.wantURL {
+				t.Errorf("url = %q, want %q", urlResult.URL, tt.wantURL)
+			}
+		})
+	}
+}
+
+func TestBuildMessageURLHandler_Handle_WorkspaceUnavailable(t *testing.T) {
+	mock := &mockSlackClient{
+		getWorkspaceInfo: func(ctx context.Context) (*types.WorkspaceInfo, error) {
+			return nil, types.NewSlackError(types.ErrCodeInvalidToken, "invalid_auth")
+		},
+	}
+
+	handler := NewBuildMessageURLHandler(mock)
+	result, err := handler.Handle(context.Background(), createToolRequest(map[string]interface{}{
+		"channel_id": "C01234567",
+		"timestamp":  "1355517523.000008",
+	}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !result.IsError {
+		t.Fatal("expected error result when the workspace cannot be identified")
+	}
+}
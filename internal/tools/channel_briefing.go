@@ -0,0 +1,220 @@
+// Package tools provides MCP tool handler implementations for the Slack MCP server.
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	slackclient "github.com/Bitovi/slack-mcp-server/internal/slack"
+	"github.com/Bitovi/slack-mcp-server/pkg/types"
+)
+
+const (
+	// defaultBriefingThreads is the number of active threads in a briefing by default.
+	defaultBriefingThreads = 5
+	// maxBriefingThreads is the maximum number of active threads in a briefing.
+	maxBriefingThreads = 20
+	// briefingHistoryLimit is the number of recent messages scanned for active threads.
+	briefingHistoryLimit = 100
+)
+
+// ChannelBriefingHandler handles the channel_briefing MCP tool requests.
+// It combines a channel's topic, purpose, pins, bookmarks, canvas preview, and
+// most recent active threads into one compact result, so an agent can orient
+// itself in a channel with a single call.
+type ChannelBriefingHandler struct {
+	// slackClient is the Slack API client for reading the channel.
+	slackClient slackclient.ClientInterface
+	// config holds optional handler behavior.
+	config handlerConfig
+}
+
+// NewChannelBriefingHandler creates a new ChannelBriefingHandler with the given Slack client and options.
+func NewChannelBriefingHandler(client slackclient.ClientInterface, opts ...HandlerOption) *ChannelBriefingHandler {
+	return &ChannelBriefingHandler{
+		slackClient: client,
+		config:      newHandlerConfig(opts),
+	}
+}
+
+// Handle processes a channel_briefing tool call. Only the channel lookup is
+// required; a part of the briefing that cannot be fetched (e.g., pins without the
+// pins:read scope) is omitted and reported in a warning.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeouts
+//   - request: The MCP tool call request containing channel_id and optional threads
+//
+// Returns an MCP tool result containing the briefing, or an error result if the
+// arguments are invalid or the channel cannot be looked up.
+func (h *ChannelBriefingHandler) Handle(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	// Extract the channel_id argument (required)
+	channelIDArg, ok := request.Params.Arguments["channel_id"]
+	if !ok {
+		return mcp.NewToolResultError("missing required argument 'channel_id'"), nil
+	}
+
+	channelID, ok := channelIDArg.(string)
+	if !ok {
+		return mcp.NewToolResultError("argument 'channel_id' must be a string"), nil
+	}
+
+	channelID = strings.TrimSpace(channelID)
+	if channelID == "" {
+		return mcp.NewToolResultError("argument 'channel_id' cannot be empty"), nil
+	}
+
+	channelID, errResult := resolveChannelRef(ctx, h.slackClient, channelID, h.handleError)
+	if errResult != nil {
+		return errResult, nil
+	}
+
+	// Extract threads (default 5, max 20)
+	threads := defaultBriefingThreads
+	if threadsArg, exists := request.Params.Arguments["threads"]; exists {
+		switch v := threadsArg.(type) {
+		case float64:
+			threads = int(v)
+		case int:
+			threads = v
+		default:
+			return mcp.NewToolResultError("argument 'threads' must be a number"), nil
+		}
+		if threads < 0 || threads > maxBriefingThreads {
+			return mcp.NewToolResultError(fmt.Sprintf("argument 'threads' must be between 0 and %d", maxBriefingThreads)), nil
+		}
+	}
+
+	channel, err := h.slackClient.GetChannelInfo(ctx, channelID)
+	if err != nil {
+		return h.handleError(err), nil
+	}
+
+	result := &types.ChannelBriefingResult{Channel: channel}
+	partFailed := func(part string, err error) {
+		result.Warnings = append(result.Warnings, types.Warning{
+			Code:    types.WarnCodeBriefingPartFailed,
+			Message: fmt.Sprintf("%s could not be fetched: %s", part, err.Error()),
+		})
+	}
+
+	// A refused DM read refuses the whole briefing, rather than every part in turn
+	if pins, err := h.slackClient.ListPins(ctx, channelID); slackclient.IsDMReadNotAllowed(err) {
+		return h.handleError(err), nil
+	} else if err != nil {
+		partFailed("pins", err)
+	} else {
+		result.Pins = h.summarize(ctx, pins)
+	}
+
+	if bookmarks, err := h.slackClient.ListBookmarks(ctx, channelID); err != nil {
+		partFailed("bookmarks", err)
+	} else if len(bookmarks) > 0 {
+		result.Bookmarks = bookmarks
+	}
+
+	if channel.CanvasID != "" {
+		if canvas, err := h.slackClient.GetCanvasSummary(ctx, channelID, channel.CanvasID); err != nil {
+			partFailed("canvas", err)
+		} else {
+			result.Canvas = canvas
+		}
+	}
+
+	// The most recent messages with replies stand in for the active threads
+	if threads > 0 {
+		messages, _, err := h.slackClient.GetChannelHistory(ctx, channelID, slackclient.HistoryOptions{
+			Limit: briefingHistoryLimit,
+		})
+		if err != nil {
+			partFailed("recent threads", err)
+		} else {
+			var active []types.Message
+			for _, msg := range messages {
+				if msg.ReplyCount > 0 {
+					active = append(active, msg)
+					if len(active) == threads {
+						break
+					}
+				}
+			}
+			result.ActiveThreads = h.summarize(ctx, active)
+		}
+	}
+
+	result.Workspace = workspaceFor(ctx, h.slackClient)
+
+	return h.successResult(result)
+}
+
+// summarize returns one summary line per message, with authors resolved where
+// possible. An author that cannot be resolved is shown by user ID.
+func (h *ChannelBriefingHandler) summarize(ctx context.Context, messages []types.Message) []string {
+	if len(messages) == 0 {
+		return nil
+	}
+
+	lines := make([]string, 0, len(messages))
+	for i := range messages {
+		msg := &messages[i]
+		attributeBotMessage(ctx, h.slackClient, msg)
+		if msg.User != "" {
+			if userInfo, err := h.slackClient.GetUserInfo(ctx, msg.User); err == nil && userInfo != nil {
+				msg.UserName = userInfo.Name
+			}
+		}
+		lines = append(lines, summarizeMessage(msg))
+	}
+	return lines
+}
+
+// handleError converts errors to appropriate MCP error results.
+func (h *ChannelBriefingHandler) handleError(err error) *mcp.CallToolResult {
+	// Check for known error types and provide appropriate messages
+	if slackclient.IsRateLimited(err) {
+		return mcp.NewToolResultError(
+			"Rate limit exceeded. Please wait and try again.")
+	}
+
+	if slackclient.IsInvalidToken(err) {
+		return mcp.NewToolResultError(
+			"Authentication failed. Please check that SLACK_BOT_TOKEN is valid and not expired.")
+	}
+
+	if slackclient.IsChannelNotFound(err) {
+		return mcp.NewToolResultError(
+			"Channel not found. The channel may have been deleted, or the channel_id is incorrect.")
+	}
+
+	if slackclient.IsMissingScope(err) {
+		return mcp.NewToolResultError(err.Error())
+	}
+
+	if slackclient.IsPermissionDenied(err) {
+		return mcp.NewToolResultError(
+			"Permission denied. The bot may lack required scopes.")
+	}
+
+	// Generic error handling
+	return mcp.NewToolResultError(fmt.Sprintf("Failed to brief channel: %s", err.Error()))
+}
+
+// successResult creates a successful MCP tool result with the given data.
+func (h *ChannelBriefingHandler) successResult(result *types.ChannelBriefingResult) (*mcp.CallToolResult, error) {
+	resultJSON, err := json.Marshal(result)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to encode result: %s", err.Error())), nil
+	}
+
+	return mcp.NewToolResultText(string(resultJSON)), nil
+}
+
+// HandleFunc returns a function that can be used directly as an MCP tool handler.
+// This is a convenience method for registering the handler with the MCP server.
+func (h *ChannelBriefingHandler) HandleFunc() func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return h.Handle
+}
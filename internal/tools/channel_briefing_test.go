@@ -0,0 +1,136 @@
+// Package tools provides unit tests for the MCP tool handlers.
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	slackclient "github.com/Bitovi/slack-mcp-server/internal/slack"
+	"github.com/Bitovi/slack-mcp-server/pkg/types"
+)
+
+func TestChannelBriefingHandler_Handle(t *testing.T) {
+	mock := &mockSlackClient{
+		getChannelInfo: func(ctx context.Context, channelID string) (*types.ChannelInfo, error) {
+			return &types.ChannelInfo{
+				ID:       channelID,
+				Name:     "proj-apollo",
+				Type:     types.ChannelTypePublic,
+				Topic:    "Launch on March 3",
+				Purpose:  "Coordinating the Apollo launch",
+				CanvasID: "F0CANVAS01",
+			}, nil
+		},
+		listPins: func(ctx context.Context, channelID string) ([]types.Message, error) {
+			return []types.Message{{User: "U00000001", Text: "Runbook: https://wiki/apollo", Timestamp: "1705314600.000001"}}, nil
+		},
+		listBookmarks: func(ctx context.Context, channelID string) ([]types.Bookmark, error) {
+			return nil, types.NewSlackError(types.ErrCodeMissingScope, "Missing scope bookmarks:read.")
+		},
+		getCanvasSummary: func(ctx context.Context, channelID, canvasID string) (*types.CanvasSummary, error) {
+			return &types.CanvasSummary{ID: canvasID, Title: "Apollo", Preview: "Goals and owners"}, nil
+		},
+		getChannelHistory: func(ctx context.Context, channelID string, opts slackclient.HistoryOptions) ([]types.Message, bool, error) {
+			return []types.Message{
+				{User: "U00000001", Text: "no replies", Timestamp: "1705314900.000004"},
+				{User: "U00000001", Text: "Newest thread", Timestamp: "1705314840.000003", ReplyCount: 2},
+				{User: "U00000001", Text: "Older thread", Timestamp: "1705314780.000002", ReplyCount: 5},
+			}, false, nil
+		},
+		getUserInfo: func(ctx context.Context, userID string) (*types.UserInfo, error) {
+			return &types.UserInfo{ID: userID, Name: "jsmith"}, nil
+		},
+	}
+
+	handler := NewChannelBriefingHandler(mock)
+	result, err := handler.Handle(context.Background(), createToolRequest(map[string]interface{}{
+		"channel_id": "C01234567",
+		"threads":    float64(1),
+	}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("expected success, got error: %+v", result.Content)
+	}
+
+	var briefing types.ChannelBriefingResult
+	if err := json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &briefing); err != nil {
+		t.Fatalf("failed to parse result JSON: %v", err)
+	}
+
+	if briefing.Channel == nil || briefing.Channel.Topic != "Launch on March 3" {
+		t.Errorf("channel = %+v, want the topic", briefing.Channel)
+	}
+	if len(briefing.Pins) != 1 || !strings.Contains(briefing.Pins[0], "jsmith: Runbook") {
+		t.Errorf("pins = %v, want one summary line", briefing.Pins)
+	}
+	if briefing.Canvas == nil || briefing.Canvas.Preview != "Goals and owners" {
+		t.Errorf("canvas = %+v, want the preview", briefing.Canvas)
+	}
+	if len(briefing.ActiveThreads) != 1 || !strings.Contains(briefing.ActiveThreads[0], "Newest thread") {
+		t.Errorf("active_threads = %v, want the newest thread only", briefing.ActiveThreads)
+	}
+
+	// The bookmarks failure degrades the briefing rather than failing it
+	if briefing.Bookmarks != nil {
+		t.Errorf("bookmarks = %v, want none", briefing.Bookmarks)
+	}
+	if len(briefing.Warnings) != 1 || briefing.Warnings[0].Code != types.WarnCodeBriefingPartFailed ||
+		!strings.Contains(briefing.Warnings[0].Message, "bookmarks") {
+		t.Errorf("warnings = %+v, want a bookmarks warning", briefing.Warnings)
+	}
+}
+
+func TestChannelBriefingHandler_Handle_ChannelNotFound(t *testing.T) {
+	mock := &mockSlackClient{
+		getChannelInfo: func(ctx context.Context, channelID string) (*types.ChannelInfo, error) {
+			return nil, types.NewSlackError(types.ErrCodeChannelNotFound, "channel not found")
+		},
+	}
+
+	handler := NewChannelBriefingHandler(mock)
+	result, err := handler.Handle(context.Background(), createToolRequest(map[string]interface{}{
+		"channel_id": "C99999999",
+	}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !result.IsError || !strings.Contains(result.Content[0].(mcp.TextContent).Text, "Channel not found") {
+		t.Errorf("expected channel not found error, got: %+v", result.Content)
+	}
+}
+
+func TestChannelBriefingHandler_Handle_ActAsUserDM(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/conversations.info":
+			_, _ = w.Write([]byte(`{"ok":true,"channel":{"id":"D01234567","is_im":true,"user":"U01234567"}}`))
+		default:
+			t.Errorf("unexpected request to %s", r.URL.Path)
+			_, _ = w.Write([]byte(`{"ok":false,"error":"unexpected"}`))
+		}
+	}))
+	defer srv.Close()
+	client := slackclient.NewClient("xoxb-test", "xoxp-test", slackclient.WithAPIURL(srv.URL+"/"),
+		slackclient.WithActAsUser())
+
+	// Without a DM read audit, the user's pins, bookmarks, and messages in the DM stay unread
+	handler := NewChannelBriefingHandler(client)
+	result, err := handler.Handle(context.Background(), createToolRequest(map[string]interface{}{
+		"channel_id": "D01234567",
+	}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !result.IsError || !strings.Contains(result.Content[0].(mcp.TextContent).Text, "SLACK_MCP_ALLOW_DM_READ") {
+		t.Errorf("expected the DM read to be refused, got: %+v", result.Content)
+	}
+}
@@ -0,0 +1,48 @@
+// Package tools provides MCP tool handler implementations for the Slack MCP server.
+package tools
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	slackclient "github.com/Bitovi/slack-mcp-server/internal/slack"
+	"github.com/Bitovi/slack-mcp-server/internal/urlparser"
+)
+
+// resolveChannelRef resolves a channel name such as "#general" passed as a
+// channel_id argument to the channel's ID. Any other reference (a channel ID,
+// user ID, or link) is returned unchanged for the tool to handle.
+//
+// Returns an error result if the name cannot be resolved. A name that matches no
+// channel is reported as such rather than through handleError, since the tool's own
+// "channel not found" message would blame the channel ID.
+func resolveChannelRef(ctx context.Context, client slackclient.ClientInterface, ref string,
+	handleError func(error) *mcp.CallToolResult) (string, *mcp.CallToolResult) {
+	name, ok := urlparser.ParseChannelName(ref)
+	if !ok {
+		return ref, nil
+	}
+
+	channelID, err := client.ResolveChannelName(ctx, name)
+	if err != nil {
+		if slackclient.IsChannelNotFound(err) {
+			return "", mcp.NewToolResultError(err.Error())
+		}
+		return "", handleError(err)
+	}
+	return channelID, nil
+}
+
+// userReferenceMessage explains why a read tool refuses a user ID or DM link by user
+// (e.g., https://workspace.slack.com/team/U01234567) in place of a conversation. The
+// link names the other person in someone's DM, not the DM itself: resolving it to
+// the bot's own DM with that user would read the wrong conversation, and opening
+// that DM with conversations.open could create one.
+func userReferenceMessage(userID string) string {
+	return fmt.Sprintf("%s is a user, not a conversation, and read tools do not resolve users to a direct "+
+		"message conversation. To read the direct messages between the user token's user and %s, use "+
+		"read_dm_history (requires SLACK_MCP_ALLOW_DM_READ=true); otherwise pass the DM's channel ID (D...).",
+		userID, userID)
+}
@@ -0,0 +1,174 @@
+// Package tools provides MCP tool handler implementations for the Slack MCP server.
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	slackclient "github.com/Bitovi/slack-mcp-server/internal/slack"
+	"github.com/Bitovi/slack-mcp-server/internal/urlparser"
+	"github.com/Bitovi/slack-mcp-server/pkg/types"
+)
+
+// CheckChannelAccessHandler handles the check_channel_access MCP tool requests.
+// It reports whether the bot and user token can read a channel and which read
+// tools will work, so that agents can fail fast with guidance instead of
+// hitting not_in_channel partway through a task.
+type CheckChannelAccessHandler struct {
+	// slackClient is the Slack API client for checking channel access.
+	slackClient slackclient.ClientInterface
+	// config holds optional handler behavior.
+	config handlerConfig
+}
+
+// NewCheckChannelAccessHandler creates a new CheckChannelAccessHandler with the given Slack client and options.
+func NewCheckChannelAccessHandler(client slackclient.ClientInterface, opts ...HandlerOption) *CheckChannelAccessHandler {
+	return &CheckChannelAccessHandler{
+		slackClient: client,
+		config:      newHandlerConfig(opts),
+	}
+}
+
+// Handle processes a check_channel_access tool call.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeouts
+//   - request: The MCP tool call request containing channel_id
+//
+// Returns an MCP tool result describing the channel's access, or an error result
+// if the arguments are invalid or the check itself fails (e.g., invalid token).
+// A channel the bot cannot see is reported as a result, not an error.
+func (h *CheckChannelAccessHandler) Handle(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	// Extract the channel_id argument (required)
+	channelIDArg, ok := request.Params.Arguments["channel_id"]
+	if !ok {
+		return mcp.NewToolResultError("missing required argument 'channel_id'"), nil
+	}
+
+	channelID, ok := channelIDArg.(string)
+	if !ok {
+		return mcp.NewToolResultError("argument 'channel_id' must be a string"), nil
+	}
+
+	channelID = strings.TrimSpace(channelID)
+	if channelID == "" {
+		return mcp.NewToolResultError("argument 'channel_id' cannot be empty"), nil
+	}
+
+	channelID, errResult := resolveChannelRef(ctx, h.slackClient, channelID, h.handleError)
+	if errResult != nil {
+		return errResult, nil
+	}
+
+	// A user ID or DM deep link names a person, not a conversation
+	if userID, ok := urlparser.ParseUserReference(channelID); ok {
+		return mcp.NewToolResultError(userReferenceMessage(userID)), nil
+	}
+
+	access, err := h.slackClient.GetChannelAccess(ctx, channelID)
+	if err != nil {
+		return h.handleError(err), nil
+	}
+
+	result := &types.CheckChannelAccessResult{
+		ChannelID:           channelID,
+		Channel:             access.Channel,
+		BotIsMember:         access.BotIsMember,
+		UserTokenConfigured: access.UserTokenConfigured,
+		UserIsMember:        access.UserIsMember,
+		Tools:               make(map[string]types.ToolAccess),
+	}
+	if access.UserMembershipError != "" {
+		result.Warnings = append(result.Warnings, types.Warning{
+			Code:    types.WarnCodeMembershipCheckFailed,
+			Message: fmt.Sprintf("could not check the user token's membership: %s", access.UserMembershipError),
+		})
+	}
+
+	// read_message and list_channel_messages read history with the bot token
+	botAccess := botReadAccess(access)
+	result.Tools["read_message"] = botAccess
+	result.Tools["list_channel_messages"] = botAccess
+	result.Tools["search_messages"] = searchAccess(access)
+
+	result.Workspace = workspaceFor(ctx, h.slackClient)
+
+	return h.successResult(result)
+}
+
+// botReadAccess reports whether the bot token can read a channel's history.
+func botReadAccess(access *types.ChannelAccess) types.ToolAccess {
+	switch {
+	case access.Channel == nil:
+		return types.ToolAccess{Reason: "The bot cannot see this channel: it does not exist, or it is a private " +
+			"channel the bot is not a member of. Check the channel ID, or invite the bot to the channel."}
+	case access.BotIsMember:
+		return types.ToolAccess{Available: true}
+	case access.Channel.IsArchived:
+		return types.ToolAccess{Reason: "The channel is archived and the bot is not a member. Archived channels " +
+			"cannot be joined; pass include_archived=true to read it with SLACK_USER_TOKEN."}
+	default:
+		return types.ToolAccess{Reason: "The bot is not a member of this channel. Invite the bot to the channel first."}
+	}
+}
+
+// searchAccess reports whether search_messages will find messages in a channel.
+// Search runs with the user token and covers public channels and the
+// conversations the token's user belongs to.
+func searchAccess(access *types.ChannelAccess) types.ToolAccess {
+	switch {
+	case !access.UserTokenConfigured:
+		return types.ToolAccess{Reason: "search_messages requires SLACK_USER_TOKEN, which is not configured."}
+	case access.UserIsMember != nil && *access.UserIsMember:
+		return types.ToolAccess{Available: true}
+	case access.Channel != nil && access.Channel.Type == types.ChannelTypePublic:
+		// Public channels are searchable without joining them
+		return types.ToolAccess{Available: true}
+	case access.UserIsMember == nil:
+		return types.ToolAccess{Reason: "Could not determine whether the user token's user is a member of this channel."}
+	default:
+		return types.ToolAccess{Reason: "The user token's user is not a member of this channel, " +
+			"so search results will not include it."}
+	}
+}
+
+// handleError converts errors to appropriate MCP error results.
+func (h *CheckChannelAccessHandler) handleError(err error) *mcp.CallToolResult {
+	// Check for known error types and provide appropriate messages
+	if slackclient.IsRateLimited(err) {
+		return mcp.NewToolResultError(
+			"Rate limit exceeded. Please wait and try again.")
+	}
+
+	if slackclient.IsInvalidToken(err) {
+		return mcp.NewToolResultError(
+			"Authentication failed. Please check that SLACK_BOT_TOKEN is valid and not expired.")
+	}
+
+	if slackclient.IsMissingScope(err) {
+		return mcp.NewToolResultError(err.Error())
+	}
+
+	// Generic error handling
+	return mcp.NewToolResultError(fmt.Sprintf("Failed to check channel access: %s", err.Error()))
+}
+
+// successResult creates a successful MCP tool result with the given data.
+func (h *CheckChannelAccessHandler) successResult(result *types.CheckChannelAccessResult) (*mcp.CallToolResult, error) {
+	resultJSON, err := json.Marshal(result)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to encode result: %s", err.Error())), nil
+	}
+
+	return mcp.NewToolResultText(string(resultJSON)), nil
+}
+
+// HandleFunc returns a function that can be used directly as an MCP tool handler.
+// This is a convenience method for registering the handler with the MCP server.
+func (h *CheckChannelAccessHandler) HandleFunc() func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return h.Handle
+}
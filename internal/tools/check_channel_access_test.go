@@ -0,0 +1,138 @@
+// Package tools provides unit tests for the MCP tool handlers.
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/Bitovi/slack-mcp-server/pkg/types"
+)
+
+func TestCheckChannelAccessHandler_Handle(t *testing.T) {
+	yes, no := true, false
+	tests := []struct {
+		name       string
+		args       map[string]interface{}
+		access     *types.ChannelAccess
+		accessErr  error
+		wantBot    bool
+		wantSearch bool
+		wantReason string
+		wantError  string
+	}{
+		{
+			name: "bot is member",
+			args: map[string]interface{}{"channel_id": "C01234567"},
+			access: &types.ChannelAccess{
+				Channel:     &types.ChannelInfo{ID: "C01234567", Type: types.ChannelTypePublic},
+				BotIsMember: true,
+			},
+			wantBot: true,
+		},
+		{
+			name: "bot not invited",
+			args: map[string]interface{}{"channel_id": "C01234567"},
+			access: &types.ChannelAccess{
+				Channel:             &types.ChannelInfo{ID: "C01234567", Type: types.ChannelTypePublic},
+				UserTokenConfigured: true,
+				UserIsMember:        &no,
+			},
+			wantSearch: true,
+			wantReason: "Invite the bot",
+		},
+		{
+			name: "archived",
+			args: map[string]interface{}{"channel_id": "C01234567"},
+			access: &types.ChannelAccess{
+				Channel: &types.ChannelInfo{ID: "C01234567", Type: types.ChannelTypePublic, IsArchived: true},
+			},
+			wantReason: "archived",
+		},
+		{
+			name: "private channel the bot cannot see",
+			args: map[string]interface{}{"channel_id": "C01234567"},
+			access: &types.ChannelAccess{
+				UserTokenConfigured: true,
+				UserIsMember:        &yes,
+			},
+			wantSearch: true,
+			wantReason: "cannot see this channel",
+		},
+		{
+			name:      "missing channel_id",
+			args:      map[string]interface{}{},
+			wantError: "missing required argument 'channel_id'",
+		},
+		{
+			name:      "invalid token",
+			args:      map[string]interface{}{"channel_id": "C01234567"},
+			accessErr: types.NewSlackError(types.ErrCodeInvalidToken, "invalid or expired token"),
+			wantError: "Authentication failed",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := &mockSlackClient{
+				getChannelAccess: func(ctx context.Context, channelID string) (*types.ChannelAccess, error) {
+					return tt.access, tt.accessErr
+				},
+			}
+
+			handler := NewCheckChannelAccessHandler(mock)
+			result, err := handler.Handle(context.Background(), createToolRequest(tt.args))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			text := result.Content[0].(mcp.TextContent).Text
+			if tt.wantError != "" {
+				if !result.IsError || !strings.Contains(text, tt.wantError) {
+					t.Errorf("expected error containing %q, got: %s", tt.wantError, text)
+				}
+				return
+			}
+			if result.IsError {
+				t.Fatalf("expected success, got error: %s", text)
+			}
+
+			var accessResult types.CheckChannelAccessResult
+			if err := json.Unmarshal([]byte(text), &accessResult); err != nil {
+				t.Fatalf("failed to parse result JSON: %v", err)
+			}
+			for _, tool := range []string{"read_message", "list_channel_messages"} {
+				if got := accessResult.Tools[tool]; got.Available != tt.wantBot || !strings.Contains(got.Reason, tt.wantReason) {
+					t.Errorf("%s = %+v, want available %v with reason containing %q", tool, got, tt.wantBot, tt.wantReason)
+				}
+			}
+			if got := accessResult.Tools["search_messages"]; got.Available != tt.wantSearch {
+				t.Errorf("search_messages = %+v, want available %v", got, tt.wantSearch)
+			}
+		})
+	}
+}
+
+func TestCheckChannelAccessHandler_Handle_UserReference(t *testing.T) {
+	mock := &mockSlackClient{
+		openDMChannel: func(ctx context.Context, userID string) (string, error) {
+			t.Error("expected no DM to be opened")
+			return "D99999999", nil
+		},
+	}
+
+	handler := NewCheckChannelAccessHandler(mock)
+	result, err := handler.Handle(context.Background(), createToolRequest(map[string]interface{}{
+		"channel_id": "https://workspace.slack.com/team/U01234567",
+	}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	text := result.Content[0].(mcp.TextContent).Text
+	if !result.IsError || !strings.Contains(text, "read_dm_history") {
+		t.Errorf("expected an error pointing to read_dm_history, got: %s", text)
+	}
+}
@@ -0,0 +1,75 @@
+// Package tools provides MCP tool handler implementations for the Slack MCP server.
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/Bitovi/slack-mcp-server/internal/blockkit"
+	"github.com/Bitovi/slack-mcp-server/pkg/types"
+)
+
+// ComposeBlocksHandler handles the compose_blocks MCP tool requests.
+// It converts Markdown into Block Kit JSON locally, without calling Slack.
+type ComposeBlocksHandler struct{}
+
+// NewComposeBlocksHandler creates a new ComposeBlocksHandler.
+func NewComposeBlocksHandler() *ComposeBlocksHandler {
+	return &ComposeBlocksHandler{}
+}
+
+// Handle processes a compose_blocks tool call.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeouts
+//   - request: The MCP tool call request containing the markdown argument
+//
+// Returns an MCP tool result containing the blocks and their mrkdwn fallback text,
+// or an error result if the Markdown is empty or converts to too many blocks.
+func (h *ComposeBlocksHandler) Handle(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	// Extract the markdown argument (required)
+	markdownArg, ok := request.Params.Arguments["markdown"]
+	if !ok {
+		return mcp.NewToolResultError("missing required argument 'markdown'"), nil
+	}
+
+	markdown, ok := markdownArg.(string)
+	if !ok {
+		return mcp.NewToolResultError("argument 'markdown' must be a string"), nil
+	}
+
+	composed, err := blockkit.FromMarkdown(markdown)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to compose blocks: %s", err.Error())), nil
+	}
+
+	blocksJSON, err := json.Marshal(composed.Blocks)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to encode blocks: %s", err.Error())), nil
+	}
+
+	return h.successResult(&types.ComposeBlocksResult{
+		Blocks:     blocksJSON,
+		Text:       composed.Text,
+		BlockCount: len(composed.Blocks),
+	})
+}
+
+// successResult creates a successful MCP tool result with the given data.
+func (h *ComposeBlocksHandler) successResult(result *types.ComposeBlocksResult) (*mcp.CallToolResult, error) {
+	resultJSON, err := json.Marshal(result)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to encode result: %s", err.Error())), nil
+	}
+
+	return mcp.NewToolResultText(string(resultJSON)), nil
+}
+
+// HandleFunc returns a function that can be used directly as an MCP tool handler.
+// This is a convenience method for registering the handler with the MCP server.
+func (h *ComposeBlocksHandler) HandleFunc() func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return h.Handle
+}
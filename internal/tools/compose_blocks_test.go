@@ -0,0 +1,83 @@
+// Package tools provides unit tests for the MCP tool handlers.
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/Bitovi/slack-mcp-server/pkg/types"
+)
+
+func TestComposeBlocksHandler_Handle(t *testing.T) {
+	tests := []struct {
+		name       string
+		args       map[string]interface{}
+		wantBlocks int
+		wantError  string
+	}{
+		{
+			name:       "heading, list and code",
+			args:       map[string]interface{}{"markdown": "# Status\n\n- api: ok\n- db: degraded\n\n```\ntail -f log\n```"},
+			wantBlocks: 3,
+		},
+		{
+			name:      "missing markdown",
+			args:      map[string]interface{}{},
+			wantError: "missing required argument 'markdown'",
+		},
+		{
+			name:      "markdown not a string",
+			args:      map[string]interface{}{"markdown": 42},
+			wantError: "argument 'markdown' must be a string",
+		},
+		{
+			name:      "empty markdown",
+			args:      map[string]interface{}{"markdown": "  "},
+			wantError: "markdown is empty",
+		},
+	}
+
+	handler := NewComposeBlocksHandler()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := handler.Handle(context.Background(), createToolRequest(tt.args))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			text := result.Content[0].(mcp.TextContent).Text
+			if tt.wantError != "" {
+				if !result.IsError || !strings.Contains(text, tt.wantError) {
+					t.Errorf("expected error containing %q, got: %s", tt.wantError, text)
+				}
+				return
+			}
+			if result.IsError {
+				t.Fatalf("expected success, got error: %s", text)
+			}
+
+			var composed types.ComposeBlocksResult
+			if err := json.Unmarshal([]byte(text), &composed); err != nil {
+				t.Fatalf("failed to parse result JSON: %v", err)
+			}
+			var blocks []map[string]interface{}
+			if err := json.Unmarshal(composed.Blocks, &blocks); err != nil {
+				t.Fatalf("blocks are not a JSON array: %v", err)
+			}
+			if len(blocks) != tt.wantBlocks || composed.BlockCount != tt.wantBlocks {
+				t.Errorf("got %d blocks (block_count %d), want %d", len(blocks), composed.BlockCount, tt.wantBlocks)
+			}
+			if blocks[0]["type"] != "header" {
+				t.Errorf("first block type = %v, want header", blocks[0]["type"])
+			}
+			if composed.Text == "" {
+				t.Error("expected fallback text")
+			}
+		})
+	}
+}
@@ -0,0 +1,136 @@
+// Package tools provides MCP tool handler implementations for the Slack MCP server.
+package tools
+
+import (
+	"context"
+
+	slackclient "github.com/Bitovi/slack-mcp-server/internal/slack"
+	"github.com/Bitovi/slack-mcp-server/pkg/types"
+)
+
+// entityMappings holds the users, channels, and user groups referred to by a
+// result's messages, resolved for the result's user_mapping, channel_mapping, and
+// usergroup_mapping. A map is nil if nothing of its kind was resolved, so it is
+// omitted from the JSON.
+type entityMappings struct {
+	users      map[string]types.UserInfo
+	channels   map[string]types.ChannelInfo
+	userGroups map[string]types.UserGroup
+}
+
+// entityRefs collects the IDs of the users, channels, and user groups that
+// messages refer to: the mentions and channel links in their text (see
+// slackclient.ClientInterface.ExtractEntities), and the users who reacted.
+type entityRefs struct {
+	client     slackclient.ClientInterface
+	users      []string
+	channels   []string
+	userGroups []string
+	seen       map[string]bool
+}
+
+// newEntityRefs creates an empty entityRefs that extracts entities with client.
+func newEntityRefs(client slackclient.ClientInterface) *entityRefs {
+	return &entityRefs{client: client, seen: make(map[string]bool)}
+}
+
+// addText collects the entities in text.
+func (r *entityRefs) addText(text string) {
+	entities := r.client.ExtractEntities(text)
+	r.users = r.add(r.users, "user", entities.Users)
+	r.channels = r.add(r.channels, "channel", entities.Channels)
+	r.userGroups = r.add(r.userGroups, "usergroup", entities.UserGroups)
+}
+
+// addMessage collects the entities in a message's text and the users who reacted to it.
+func (r *entityRefs) addMessage(msg *types.Message) {
+	r.addText(msg.Text)
+	for _, reaction := range msg.Reactions {
+		r.users = r.add(r.users, "user", reaction.Users)
+	}
+}
+
+// add appends the IDs of the given kind not collected yet to ids.
+func (r *entityRefs) add(ids []string, kind string, newIDs []string) []string {
+	for _, id := range newIDs {
+		if key := kind + ":" + id; id != "" && !r.seen[key] {
+			r.seen[key] = true
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// resolve looks up the collected users, channels, and user groups. Users whose
+// lookup fails are mapped to an unresolvedUser placeholder; channels and user
+// groups that cannot be resolved are left out. Every failure is recorded on resolution.
+// User groups are resolved from a single usergroups.list call, made only if a
+// user group was mentioned.
+func (r *entityRefs) resolve(ctx context.Context, resolution *resolutionTracker) entityMappings {
+	var mappings entityMappings
+
+	// Users are looked up in parallel; the mapping keeps the order they were collected in
+	users := lookupUsers(ctx, r.client, r.users)
+	for _, userID := range r.users {
+		userInfo, err := users[userID].info, users[userID].err
+		if err != nil {
+			// Graceful degradation: keep the user as a placeholder, so the mention stays visible
+			resolution.recordUser(userID, err)
+			userInfo = unresolvedUser(userID)
+		}
+		if userInfo != nil {
+			if mappings.users == nil {
+				mappings.users = make(map[string]types.UserInfo)
+			}
+			mappings.users[userID] = *userInfo
+		}
+	}
+
+	for _, channelID := range r.channels {
+		channelInfo, err := r.client.GetChannelInfo(ctx, channelID)
+		if err != nil {
+			resolution.recordChannel(channelID, err)
+			continue
+		}
+		if channelInfo != nil {
+			if mappings.channels == nil {
+				mappings.channels = make(map[string]types.ChannelInfo)
+			}
+			mappings.channels[channelID] = *channelInfo
+		}
+	}
+
+	if len(r.userGroups) > 0 {
+		groups, err := r.client.ListUserGroups(ctx)
+		if err != nil {
+			for _, groupID := range r.userGroups {
+				resolution.recordUserGroup(groupID, err)
+			}
+			return mappings
+		}
+
+		mentioned := make(map[string]bool, len(r.userGroups))
+		for _, groupID := range r.userGroups {
+			mentioned[groupID] = true
+		}
+		for _, group := range groups {
+			if !mentioned[group.ID] {
+				continue
+			}
+			if mappings.userGroups == nil {
+				mappings.userGroups = make(map[string]types.UserGroup)
+			}
+			// The mapping names the group; its members are not needed to read the message
+			group.Users = nil
+			mappings.userGroups[group.ID] = group
+		}
+	}
+
+	return mappings
+}
+
+// unresolvedUser returns the user mapping placeholder for a user whose lookup
+// failed, which tells agents a mention exists even though its profile is missing.
+func unresolvedUser(userID string) *types.UserInfo {
+	return &types.UserInfo{ID: userID, Unresolved: true}
+}
@@ -0,0 +1,124 @@
+// Package tools provides unit tests for the MCP tool handlers.
+package tools
+
+import (
+	"context"
+	"testing"
+
+	slackclient "github.com/Bitovi/slack-mcp-server/internal/slack"
+	"github.com/Bitovi/slack-mcp-server/pkg/types"
+)
+
+func TestEntityRefs_Resolve(t *testing.T) {
+	var userGroupCalls int
+	mock := &mockSlackClient{
+		extractEntities: func(text string) slackclient.Entities {
+			return (&slackclient.Client{}).ExtractEntities(text)
+		},
+		getUserInfo: func(ctx context.Context, userID string) (*types.UserInfo, error) {
+			return &types.UserInfo{ID: userID, Name: "jsmith"}, nil
+		},
+		getChannelInfo: func(ctx context.Context, channelID string) (*types.ChannelInfo, error) {
+			if channelID == "C99999999" {
+				return nil, types.NewSlackError(types.ErrCodeChannelNotFound, "channel not found")
+			}
+			return &types.ChannelInfo{ID: channelID, Name: "general"}, nil
+		},
+		listUserGroups: func(ctx context.Context) ([]types.UserGroup, error) {
+			userGroupCalls++
+			return []types.UserGroup{
+				{ID: "S01234567", Handle: "oncall", Name: "On-call", Users: []string{"U01234567"}},
+				{ID: "S07654321", Handle: "design", Name: "Design"},
+			}, nil
+		},
+	}
+
+	refs := newEntityRefs(mock)
+	refs.addMessage(&types.Message{
+		Text:      "<!subteam^S01234567|@oncall> see <#C01234567|general>",
+		Reactions: []types.Reaction{{Name: "eyes", Count: 1, Users: []string{"U07654321"}}},
+	})
+	refs.addText("<@U07654321> <#C99999999> <!subteam^S01234567> <https://example.com>")
+
+	resolution := newResolutionTracker()
+	mappings := refs.resolve(context.Background(), resolution)
+
+	if len(mappings.users) != 1 || mappings.users["U07654321"].Name != "jsmith" {
+		t.Errorf("users = %+v, want the reacting and mentioned user once", mappings.users)
+	}
+	if len(mappings.channels) != 1 || mappings.channels["C01234567"].Name != "general" {
+		t.Errorf("channels = %+v, want the resolvable channel only", mappings.channels)
+	}
+	group, ok := mappings.userGroups["S01234567"]
+	if len(mappings.userGroups) != 1 || !ok || group.Handle != "oncall" || group.Users != nil {
+		t.Errorf("userGroups = %+v, want the mentioned group without members", mappings.userGroups)
+	}
+	if userGroupCalls != 1 {
+		t.Errorf("usergroups.list calls = %d, want 1", userGroupCalls)
+	}
+
+	warnings, err := resolution.apply(ResolutionErrorWarn)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(warnings) != 1 || warnings[0].Code != types.WarnCodeChannelResolutionFailed {
+		t.Errorf("warnings = %+v, want a channel resolution warning", warnings)
+	}
+}
+
+func TestEntityRefs_Resolve_UserGroupsUnavailable(t *testing.T) {
+	mock := &mockSlackClient{
+		extractEntities: func(text string) slackclient.Entities {
+			return slackclient.Entities{UserGroups: []string{"S01234567"}}
+		},
+		listUserGroups: func(ctx context.Context) ([]types.UserGroup, error) {
+			return nil, types.NewSlackError(types.ErrCodeMissingScope, "Missing scope usergroups:read.")
+		},
+	}
+
+	refs := newEntityRefs(mock)
+	refs.addText("<!subteam^S01234567>")
+
+	resolution := newResolutionTracker()
+	if mappings := refs.resolve(context.Background(), resolution); mappings.userGroups != nil {
+		t.Errorf("userGroups = %+v, want none", mappings.userGroups)
+	}
+
+	warnings, _ := resolution.apply(ResolutionErrorWarn)
+	if len(warnings) != 1 || warnings[0].Code != types.WarnCodeUserGroupResolutionFailed {
+		t.Errorf("warnings = %+v, want a user group resolution warning", warnings)
+	}
+}
+
+func TestEntityRefs_Resolve_UserLookupFails(t *testing.T) {
+	mock := &mockSlackClient{
+		extractEntities: func(text string) slackclient.Entities {
+			return slackclient.Entities{Users: []string{"U01234567", "U07654321"}}
+		},
+		getUserInfo: func(ctx context.Context, userID string) (*types.UserInfo, error) {
+			if userID == "U07654321" {
+				return nil, types.NewSlackError(types.ErrCodeRateLimited, "rate limit exceeded")
+			}
+			return &types.UserInfo{ID: userID, Name: "jsmith"}, nil
+		},
+	}
+
+	refs := newEntityRefs(mock)
+	refs.addText("<@U01234567> <@U07654321>")
+
+	resolution := newResolutionTracker()
+	mappings := refs.resolve(context.Background(), resolution)
+
+	if len(mappings.users) != 2 || mappings.users["U01234567"].Unresolved {
+		t.Fatalf("users = %+v, want both users with the resolved one complete", mappings.users)
+	}
+	placeholder := mappings.users["U07654321"]
+	if !placeholder.Unresolved || placeholder.ID != "U07654321" || placeholder.Name != "" {
+		t.Errorf("placeholder = %+v, want an unresolved entry with only the ID", placeholder)
+	}
+
+	warnings, _ := resolution.apply(ResolutionErrorWarn)
+	if len(warnings) != 1 || warnings[0].Code != types.WarnCodeUserResolutionFailed {
+		t.Errorf("warnings = %+v, want a user resolution warning", warnings)
+	}
+}
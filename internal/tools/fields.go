@@ -0,0 +1,131 @@
+// Package tools provides MCP tool handler implementations for the Slack MCP server.
+package tools
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/Bitovi/slack-mcp-server/pkg/types"
+)
+
+var (
+	// messageFields is the set of field names that can be selected on message objects.
+	messageFields = jsonFieldNames(reflect.TypeOf(types.Message{}))
+	// searchMatchFields is the set of field names that can be selected on search match objects.
+	searchMatchFields = jsonFieldNames(reflect.TypeOf(types.SearchMatch{}))
+)
+
+// jsonFieldNames returns the JSON names of the exported fields of a struct type.
+func jsonFieldNames(t reflect.Type) map[string]bool {
+	names := make(map[string]bool, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		tag := t.Field(i).Tag.Get("json")
+		name, _, _ := strings.Cut(tag, ",")
+		if name != "" && name != "-" {
+			names[name] = true
+		}
+	}
+	return names
+}
+
+// parseFields parses the optional 'fields' argument, a list of field names to keep
+// on each returned message object.
+//
+// Parameters:
+//   - args: The tool call arguments
+//   - valid: The set of field names that may be selected
+//
+// Returns nil if the argument is absent or empty, meaning all fields are returned,
+// or an error naming the valid fields if an unknown field is requested.
+func parseFields(args map[string]interface{}, valid map[string]bool) ([]string, error) {
+	fieldsArg, exists := args["fields"]
+	if !exists || fieldsArg == nil {
+		return nil, nil
+	}
+
+	items, ok := fieldsArg.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("argument 'fields' must be an array of strings")
+	}
+
+	fields := make([]string, 0, len(items))
+	for _, item := range items {
+		field, ok := item.(string)
+		if !ok {
+			return nil, fmt.Errorf("argument 'fields' must be an array of strings")
+		}
+		if !valid[field] {
+			names := make([]string, 0, len(valid))
+			for name := range valid {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+			return nil, fmt.Errorf("unknown field %q in 'fields'; valid fields are: %s",
+				field, strings.Join(names, ", "))
+		}
+		fields = append(fields, field)
+	}
+
+	if len(fields) == 0 {
+		return nil, nil
+	}
+	return fields, nil
+}
+
+// marshalProjected encodes result as JSON, keeping only the selected fields on the
+// message objects found under the given top-level keys. A key may hold a single
+// object (e.g., "message") or an array of objects (e.g., "messages"). Other parts of
+// the result (channel_id, user_mapping, warnings, ...) are left intact.
+//
+// If fields is empty, result is encoded unchanged.
+func marshalProjected(result interface{}, fields []string, keys ...string) ([]byte, error) {
+	resultJSON, err := json.Marshal(result)
+	if err != nil || len(fields) == 0 {
+		return resultJSON, err
+	}
+
+	keep := make(map[string]bool, len(fields))
+	for _, field := range fields {
+		keep[field] = true
+	}
+
+	var generic map[string]interface{}
+	if err := json.Unmarshal(resultJSON, &generic); err != nil {
+		return nil, err
+	}
+
+	for _, key := range keys {
+		switch v := generic[key].(type) {
+		case map[string]interface{}:
+			projectObject(v, keep)
+		case []interface{}:
+			for _, item := range v {
+				if obj, ok := item.(map[string]interface{}); ok {
+					projectObject(obj, keep)
+				}
+			}
+		}
+	}
+
+	return json.Marshal(generic)
+}
+
+// projectObject removes all keys from obj that are not in keep.
+func projectObject(obj map[string]interface{}, keep map[string]bool) {
+	for key := range obj {
+		if !keep[key] {
+			delete(obj, key)
+		}
+	}
+}
+
+// clearRaw removes the Slack API payloads the client attaches to messages. They are
+// only returned when a tool's include_raw argument is set.
+func clearRaw(messages []types.Message) {
+	for i := range messages {
+		messages[i].Raw = nil
+	}
+}
@@ -0,0 +1,204 @@
+// Package tools provides MCP tool handler implementations for the Slack MCP server.
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	slackclient "github.com/Bitovi/slack-mcp-server/internal/slack"
+	"github.com/Bitovi/slack-mcp-server/internal/urlparser"
+	"github.com/Bitovi/slack-mcp-server/pkg/types"
+)
+
+// maxOriginScanMessages is the maximum number of messages scanned for a channel's
+// earliest message. At the maximum page size this bounds a lookup to 10
+// conversations.history calls.
+const maxOriginScanMessages = 10000
+
+// GetChannelOriginHandler handles the get_channel_origin MCP tool requests.
+// It reports when and by whom a channel was created and its earliest available
+// message, e.g., to answer how long a project channel has existed or to bound a backfill.
+type GetChannelOriginHandler struct {
+	// slackClient is the Slack API client for reading the channel.
+	slackClient slackclient.ClientInterface
+	// config holds optional handler behavior such as the resolution error policy.
+	config handlerConfig
+	// now returns the current time; replaced in tests.
+	now func() time.Time
+}
+
+// NewGetChannelOriginHandler creates a new GetChannelOriginHandler with the given Slack client and options.
+func NewGetChannelOriginHandler(client slackclient.ClientInterface, opts ...HandlerOption) *GetChannelOriginHandler {
+	return &GetChannelOriginHandler{
+		slackClient: client,
+		config:      newHandlerConfig(opts),
+		now:         time.Now,
+	}
+}
+
+// Handle processes a get_channel_origin tool call.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeouts
+//   - request: The MCP tool call request containing channel_id
+//
+// Returns an MCP tool result containing the channel's creation date, creator, and
+// earliest available message, or an error result if the channel cannot be read.
+func (h *GetChannelOriginHandler) Handle(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	// Extract the channel_id argument (required)
+	channelIDArg, ok := request.Params.Arguments["channel_id"]
+	if !ok {
+		return mcp.NewToolResultError("missing required argument 'channel_id'"), nil
+	}
+
+	channelID, ok := channelIDArg.(string)
+	if !ok {
+		return mcp.NewToolResultError("argument 'channel_id' must be a string"), nil
+	}
+
+	channelID = strings.TrimSpace(channelID)
+	if channelID == "" {
+		return mcp.NewToolResultError("argument 'channel_id' cannot be empty"), nil
+	}
+
+	channelID, errResult := resolveChannelRef(ctx, h.slackClient, channelID, h.handleError)
+	if errResult != nil {
+		return errResult, nil
+	}
+
+	// A user ID or DM deep link names a person, not a conversation
+	if userID, ok := urlparser.ParseUserReference(channelID); ok {
+		return mcp.NewToolResultError(userReferenceMessage(userID)), nil
+	}
+
+	channel, err := h.slackClient.GetChannelInfo(ctx, channelID)
+	if err != nil {
+		return h.handleError(err), nil
+	}
+
+	earliest, hasOlder, err := h.slackClient.GetEarliestMessage(ctx, channelID, maxOriginScanMessages)
+	if err != nil {
+		return h.handleError(err), nil
+	}
+
+	result := &types.ChannelOriginResult{
+		ChannelID: channelID,
+		Channel:   channel,
+		HasOlder:  hasOlder,
+	}
+
+	if channel.Created > 0 {
+		created := time.Unix(channel.Created, 0).UTC()
+		result.CreatedAt = created.Format(time.RFC3339)
+		result.AgeDays = int(h.now().Sub(created).Hours() / 24)
+	}
+
+	if hasOlder {
+		result.Warnings = append(result.Warnings, types.Warning{
+			Code: types.WarnCodeResultsTruncated,
+			Message: fmt.Sprintf("scanned the %d most recent messages; messages older than earliest_message exist",
+				maxOriginScanMessages),
+		})
+	}
+
+	// Track user resolution failures so they can be reported according to the configured policy
+	resolution := newResolutionTracker()
+
+	if channel.Creator != "" {
+		creator, err := h.slackClient.GetUserInfo(ctx, channel.Creator)
+		if err != nil {
+			resolution.recordUser(channel.Creator, err)
+		} else {
+			result.Creator = creator
+		}
+	}
+
+	if earliest != nil {
+		attributeBotMessage(ctx, h.slackClient, earliest)
+		if earliest.User != "" {
+			if userInfo, err := h.slackClient.GetUserInfo(ctx, earliest.User); err != nil {
+				resolution.recordUser(earliest.User, err)
+			} else if userInfo != nil {
+				earliest.UserName = userInfo.Name
+				earliest.DisplayName = userInfo.DisplayName
+				earliest.RealName = userInfo.RealName
+			}
+		}
+		// Drop the Slack API payload, as list_channel_messages does by default
+		earliest.Raw = nil
+		result.EarliestMessage = earliest
+	}
+
+	result.Workspace = workspaceFor(ctx, h.slackClient)
+
+	// Flag or reject degraded results according to the resolution error policy
+	resolutionWarnings, err := resolution.apply(h.config.onResolutionError)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to resolve users: %s", err.Error())), nil
+	}
+	result.Warnings = append(result.Warnings, resolutionWarnings...)
+
+	return h.successResult(result)
+}
+
+// handleError converts errors to appropriate MCP error results.
+func (h *GetChannelOriginHandler) handleError(err error) *mcp.CallToolResult {
+	// Check for known error types and provide appropriate messages
+	if slackclient.IsRateLimited(err) {
+		return mcp.NewToolResultError(
+			"Rate limit exceeded. Please wait and try again.")
+	}
+
+	if slackclient.IsInvalidToken(err) {
+		return mcp.NewToolResultError(
+			"Authentication failed. Please check that SLACK_BOT_TOKEN is valid and not expired.")
+	}
+
+	if slackclient.IsChannelNotFound(err) {
+		return mcp.NewToolResultError(
+			"Channel not found. The channel may have been deleted, or the channel_id is incorrect.")
+	}
+
+	if slackclient.IsNotInChannel(err) {
+		return mcp.NewToolResultError(
+			"The bot is not a member of this channel. Please invite the bot to the channel first.")
+	}
+
+	if slackclient.IsChannelArchived(err) {
+		return mcp.NewToolResultError(
+			"The channel is archived and the bot is not a member. Archived channels cannot be joined.")
+	}
+
+	if slackclient.IsMissingScope(err) {
+		return mcp.NewToolResultError(err.Error())
+	}
+
+	if slackclient.IsPermissionDenied(err) {
+		return mcp.NewToolResultError(
+			"Permission denied. The bot may lack required scopes.")
+	}
+
+	// Generic error handling
+	return mcp.NewToolResultError(fmt.Sprintf("Failed to look up channel origin: %s", err.Error()))
+}
+
+// successResult creates a successful MCP tool result with the given data.
+func (h *GetChannelOriginHandler) successResult(result *types.ChannelOriginResult) (*mcp.CallToolResult, error) {
+	resultJSON, err := json.Marshal(result)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to encode result: %s", err.Error())), nil
+	}
+
+	return mcp.NewToolResultText(string(resultJSON)), nil
+}
+
+// HandleFunc returns a function that can be used directly as an MCP tool handler.
+// This is a convenience method for registering the handler with the MCP server.
+func (h *GetChannelOriginHandler) HandleFunc() func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return h.Handle
+}
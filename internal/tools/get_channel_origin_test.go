@@ -0,0 +1,73 @@
+// Package tools provides unit tests for the MCP tool handlers.
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/Bitovi/slack-mcp-server/pkg/types"
+)
+
+func TestGetChannelOriginHandler_Handle(t *testing.T) {
+	tests := []struct {
+		name         string
+		hasOlder     bool
+		wantWarnings int
+	}{
+		{name: "full history scanned"},
+		{name: "scan capped", hasOlder: true, wantWarnings: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := &mockSlackClient{
+				getChannelInfo: func(ctx context.Context, channelID string) (*types.ChannelInfo, error) {
+					return &types.ChannelInfo{ID: channelID, Name: "proj-apollo", Type: types.ChannelTypePublic,
+						Created: 1704067200, Creator: "U00000001"}, nil
+				},
+				getEarliestMessage: func(ctx context.Context, channelID string, maxScan int) (*types.Message, bool, error) {
+					if maxScan != maxOriginScanMessages {
+						t.Errorf("maxScan = %d, want %d", maxScan, maxOriginScanMessages)
+					}
+					return &types.Message{User: "U00000002", Text: "Kickoff notes", Timestamp: "1704070800.000100"}, tt.hasOlder, nil
+				},
+				getUserInfo: func(ctx context.Context, userID string) (*types.UserInfo, error) {
+					return &types.UserInfo{ID: userID, Name: "user-" + userID}, nil
+				},
+			}
+
+			handler := NewGetChannelOriginHandler(mock)
+			handler.now = func() time.Time { return time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC) }
+			result, err := handler.Handle(context.Background(), createToolRequest(map[string]interface{}{
+				"channel_id": "C01234567",
+			}))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if result.IsError {
+				t.Fatalf("expected success, got error: %+v", result.Content)
+			}
+
+			var origin types.ChannelOriginResult
+			if err := json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &origin); err != nil {
+				t.Fatalf("failed to parse result JSON: %v", err)
+			}
+			if origin.CreatedAt != "2024-01-01T00:00:00Z" || origin.AgeDays != 60 {
+				t.Errorf("created_at = %q, age_days = %d, want 2024-01-01T00:00:00Z and 60", origin.CreatedAt, origin.AgeDays)
+			}
+			if origin.Creator == nil || origin.Creator.Name != "user-U00000001" {
+				t.Errorf("creator = %+v, want user-U00000001", origin.Creator)
+			}
+			if origin.EarliestMessage == nil || origin.EarliestMessage.UserName != "user-U00000002" {
+				t.Errorf("earliest_message = %+v, want a resolved message", origin.EarliestMessage)
+			}
+			if origin.HasOlder != tt.hasOlder || len(origin.Warnings) != tt.wantWarnings {
+				t.Errorf("has_older = %v, warnings = %+v", origin.HasOlder, origin.Warnings)
+			}
+		})
+	}
+}
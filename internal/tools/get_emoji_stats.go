@@ -0,0 +1,355 @@
+// Package tools provides MCP tool handler implementations for the Slack MCP server.
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	slackclient "github.com/Bitovi/slack-mcp-server/internal/slack"
+	"github.com/Bitovi/slack-mcp-server/pkg/types"
+)
+
+const (
+	// defaultEmojiStatsWindow is how far back reactions are counted when no oldest is given.
+	defaultEmojiStatsWindow = 30 * 24 * time.Hour
+	// defaultEmojiStatsTop is the number of emoji returned when no top is given.
+	defaultEmojiStatsTop = 10
+	// maxEmojiStatsTop caps the emoji returned in one call.
+	maxEmojiStatsTop = 100
+	// defaultEmojiScanMessages is the number of messages scanned per channel when no limit is given.
+	defaultEmojiScanMessages = 200
+	// maxEmojiScanMessages caps the messages scanned per channel in one call.
+	maxEmojiScanMessages = 1000
+	// maxEmojiStatsChannels caps the channels scanned for workspace-wide stats.
+	maxEmojiStatsChannels = 25
+)
+
+// GetEmojiStatsHandler handles the get_emoji_stats MCP tool requests.
+// It counts the emoji used in reactions over a time range, in one channel or
+// across the channels the bot is a member of, and returns the most used.
+type GetEmojiStatsHandler struct {
+	// slackClient is the Slack API client for listing channels and reading history.
+	slackClient slackclient.ClientInterface
+	// config holds optional handler behavior.
+	config handlerConfig
+}
+
+// NewGetEmojiStatsHandler creates a new GetEmojiStatsHandler with the given Slack client and options.
+func NewGetEmojiStatsHandler(client slackclient.ClientInterface, opts ...HandlerOption) *GetEmojiStatsHandler {
+	return &GetEmojiStatsHandler{
+		slackClient: client,
+		config:      newHandlerConfig(opts),
+	}
+}
+
+// emojiTally accumulates the reaction usage of one emoji.
+type emojiTally struct {
+	count    int
+	messages int
+	users    map[string]bool
+	channels map[string]int
+}
+
+// Handle processes a get_emoji_stats tool call.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeouts
+//   - request: The MCP tool call request containing optional channel_id, oldest,
+//     latest, top, and limit
+//
+// Returns an MCP tool result containing the most used reaction emoji, or an error
+// result if the arguments are invalid or no channel could be read.
+func (h *GetEmojiStatsHandler) Handle(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	// Extract channel_id parameter (optional; workspace-wide if omitted)
+	channelID := ""
+	if channelIDArg, exists := request.Params.Arguments["channel_id"]; exists {
+		v, ok := channelIDArg.(string)
+		if !ok {
+			return mcp.NewToolResultError("argument 'channel_id' must be a string"), nil
+		}
+		channelID = strings.TrimSpace(v)
+		if channelID == "" {
+			return mcp.NewToolResultError("argument 'channel_id' cannot be empty"), nil
+		}
+
+		var errResult *mcp.CallToolResult
+		channelID, errResult = resolveChannelRef(ctx, h.slackClient, channelID, h.handleError)
+		if errResult != nil {
+			return errResult, nil
+		}
+	}
+
+	// Extract top and limit parameters (optional)
+	top := defaultEmojiStatsTop
+	limit := defaultEmojiScanMessages
+	for _, optional := range []struct {
+		name   string
+		max    int
+		target *int
+	}{
+		{"top", maxEmojiStatsTop, &top},
+		{"limit", maxEmojiScanMessages, &limit},
+	} {
+		arg, exists := request.Params.Arguments[optional.name]
+		if !exists {
+			continue
+		}
+		v, ok := arg.(float64)
+		if !ok {
+			return mcp.NewToolResultError(fmt.Sprintf("argument '%s' must be a number", optional.name)), nil
+		}
+		if v < 1 || v > float64(optional.max) {
+			return mcp.NewToolResultError(fmt.Sprintf("argument '%s' must be between 1 and %d", optional.name, optional.max)), nil
+		}
+		*optional.target = int(v)
+	}
+
+	// Extract oldest and latest parameters (optional Unix timestamps)
+	var oldest, latest string
+	for _, optional := range []struct {
+		name   string
+		target *string
+	}{
+		{"oldest", &oldest},
+		{"latest", &latest},
+	} {
+		name, target := optional.name, optional.target
+		arg, exists := request.Params.Arguments[name]
+		if !exists {
+			continue
+		}
+		v, ok := arg.(string)
+		if !ok {
+			return mcp.NewToolResultError(fmt.Sprintf("argument '%s' must be a string (Unix timestamp)", name)), nil
+		}
+		if _, ok := parseSlackTime(v); !ok {
+			return mcp.NewToolResultError(fmt.Sprintf("argument '%s' must be a Unix timestamp, got %q", name, v)), nil
+		}
+		*target = v
+	}
+	if oldest == "" {
+		end := time.Now()
+		if t, ok := parseSlackTime(latest); ok {
+			end = t
+		}
+		oldest = strconv.FormatInt(end.Add(-defaultEmojiStatsWindow).Unix(), 10)
+	}
+
+	result := &types.EmojiStatsResult{
+		ChannelID: channelID,
+		Oldest:    oldest,
+		Latest:    latest,
+		Emoji:     []types.EmojiUsage{},
+	}
+	tallies := make(map[string]*emojiTally)
+	opts := slackclient.HistoryOptions{Limit: limit, Oldest: oldest, Latest: latest}
+
+	if channelID != "" {
+		messages, hasMore, err := h.slackClient.GetChannelHistory(ctx, channelID, opts)
+		if err != nil {
+			return h.handleError(err), nil
+		}
+		result.HasMore = hasMore
+		tallyReactions(tallies, channelID, messages, result)
+	} else {
+		if errResult := h.scanWorkspace(ctx, opts, tallies, result); errResult != nil {
+			return errResult, nil
+		}
+	}
+
+	result.DistinctEmoji = len(tallies)
+	result.Emoji = topEmoji(tallies, top, channelID == "")
+	if result.HasMore {
+		result.Warnings = append(result.Warnings, types.Warning{
+			Code: types.WarnCodeResultsTruncated,
+			Message: fmt.Sprintf("only the %d most recent messages per channel were scanned; "+
+				"raise the limit or narrow the time range for complete counts", limit),
+		})
+	}
+
+	result.Workspace = workspaceFor(ctx, h.slackClient)
+
+	return h.successResult(result)
+}
+
+// scanWorkspace tallies the reactions in the channels the bot is a member of, up to
+// maxEmojiStatsChannels. A channel that cannot be read is skipped with a warning; if
+// Slack rate limits the scan, it stops early with the channels scanned so far.
+//
+// Returns an error result if the channels cannot be listed.
+func (h *GetEmojiStatsHandler) scanWorkspace(ctx context.Context, opts slackclient.HistoryOptions, tallies map[string]*emojiTally, result *types.EmojiStatsResult) *mcp.CallToolResult {
+	channels, nextCursor, err := h.slackClient.ListChannels(ctx, slackclient.ListChannelsOptions{
+		Types:           []string{types.ChannelTypePublic, types.ChannelTypePrivate},
+		ExcludeArchived: true,
+		MemberOnly:      true,
+		Limit:           maxEmojiStatsChannels,
+	})
+	if err != nil {
+		return h.handleError(err)
+	}
+	if nextCursor != "" {
+		result.Warnings = append(result.Warnings, types.Warning{
+			Code: types.WarnCodeResultsTruncated,
+			Message: fmt.Sprintf("only the first %d channels the bot is a member of were scanned; "+
+				"pass channel_id for the stats of another channel", maxEmojiStatsChannels),
+		})
+	}
+
+	result.Channels = []types.EmojiStatsChannel{}
+	for _, access := range channels {
+		if access.Channel == nil {
+			continue
+		}
+		channel := access.Channel
+
+		messages, hasMore, err := h.slackClient.GetChannelHistory(ctx, channel.ID, opts)
+		if slackclient.IsRateLimited(err) {
+			if len(result.Channels) == 0 {
+				return h.handleError(err)
+			}
+			result.Warnings = append(result.Warnings, types.Warning{
+				Code: types.WarnCodeRateLimited,
+				Message: fmt.Sprintf("Slack rate limited the scan after %d of %d channels; "+
+					"try again later or pass channel_id", len(result.Channels), len(channels)),
+			})
+			break
+		}
+		if err != nil {
+			result.Warnings = append(result.Warnings, types.Warning{
+				Code:    types.WarnCodeChannelScanFailed,
+				Message: fmt.Sprintf("could not scan #%s (%s): %s", channel.Name, channel.ID, err.Error()),
+			})
+			continue
+		}
+
+		result.Channels = append(result.Channels, types.EmojiStatsChannel{
+			ID:              channel.ID,
+			Name:            channel.Name,
+			MessagesScanned: len(messages),
+			HasMore:         hasMore,
+		})
+		result.HasMore = result.HasMore || hasMore
+		tallyReactions(tallies, channel.ID, messages, result)
+	}
+
+	if len(result.Channels) == 0 && len(channels) > 0 {
+		return mcp.NewToolResultError("Failed to get emoji stats: none of the bot's channels could be read. " +
+			"Pass channel_id to see the error for a channel.")
+	}
+	return nil
+}
+
+// tallyReactions adds the reactions on messages, posted in channelID, to tallies and
+// the result's totals. Skin tone variants (e.g., "+1::skin-tone-2") are counted under
+// the base emoji.
+func tallyReactions(tallies map[string]*emojiTally, channelID string, messages []types.Message, result *types.EmojiStatsResult) {
+	result.MessagesScanned += len(messages)
+	for _, message := range messages {
+		if len(message.Reactions) > 0 {
+			result.MessagesWithReactions++
+		}
+
+		counted := make(map[string]bool)
+		for _, reaction := range message.Reactions {
+			name, _, _ := strings.Cut(reaction.Name, "::")
+			tally, ok := tallies[name]
+			if !ok {
+				tally = &emojiTally{users: make(map[string]bool), channels: make(map[string]int)}
+				tallies[name] = tally
+			}
+			tally.count += reaction.Count
+			tally.channels[channelID] += reaction.Count
+			for _, userID := range reaction.Users {
+				tally.users[userID] = true
+			}
+			// Skin tone variants on one message are one message for the base emoji
+			if !counted[name] {
+				counted[name] = true
+				tally.messages++
+			}
+			result.TotalReactions += reaction.Count
+		}
+	}
+}
+
+// topEmoji returns the top most used emoji in tallies, by count and then by name.
+// The per-channel breakdown is included if withChannels is set.
+func topEmoji(tallies map[string]*emojiTally, top int, withChannels bool) []types.EmojiUsage {
+	usage := make([]types.EmojiUsage, 0, len(tallies))
+	for name, tally := range tallies {
+		emoji := types.EmojiUsage{
+			Name:     name,
+			Count:    tally.count,
+			Messages: tally.messages,
+			Users:    len(tally.users),
+		}
+		if withChannels {
+			emoji.Channels = tally.channels
+		}
+		usage = append(usage, emoji)
+	}
+	sort.Slice(usage, func(i, j int) bool {
+		if usage[i].Count != usage[j].Count {
+			return usage[i].Count > usage[j].Count
+		}
+		return usage[i].Name < usage[j].Name
+	})
+	if len(usage) > top {
+		usage = usage[:top]
+	}
+	return usage
+}
+
+// handleError converts errors to appropriate MCP error results.
+func (h *GetEmojiStatsHandler) handleError(err error) *mcp.CallToolResult {
+	// Check for known error types and provide appropriate messages
+	if slackclient.IsRateLimited(err) {
+		return mcp.NewToolResultError(
+			"Rate limit exceeded. Please wait and try again.")
+	}
+
+	if slackclient.IsInvalidToken(err) {
+		return mcp.NewToolResultError(
+			"Authentication failed. Please check that SLACK_BOT_TOKEN is valid and not expired.")
+	}
+
+	if slackclient.IsChannelNotFound(err) {
+		return mcp.NewToolResultError(
+			"Channel not found. The channel may have been deleted, or the channel_id is incorrect.")
+	}
+
+	if slackclient.IsNotInChannel(err) {
+		return mcp.NewToolResultError(
+			"The bot is not a member of this channel. Please invite the bot to the channel first.")
+	}
+
+	if slackclient.IsMissingScope(err) {
+		return mcp.NewToolResultError(err.Error())
+	}
+
+	// Generic error handling
+	return mcp.NewToolResultError(fmt.Sprintf("Failed to get emoji stats: %s", err.Error()))
+}
+
+// successResult creates a successful MCP tool result with the given data.
+func (h *GetEmojiStatsHandler) successResult(result *types.EmojiStatsResult) (*mcp.CallToolResult, error) {
+	resultJSON, err := json.Marshal(result)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to encode result: %s", err.Error())), nil
+	}
+
+	return mcp.NewToolResultText(string(resultJSON)), nil
+}
+
+// HandleFunc returns a function that can be used directly as an MCP tool handler.
+// This is a convenience method for registering the handler with the MCP server.
+func (h *GetEmojiStatsHandler) HandleFunc() func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return h.Handle
+}
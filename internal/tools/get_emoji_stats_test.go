@@ -0,0 +1,199 @@
+// Package tools provides unit tests for the MCP tool handlers.
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	slackclient "github.com/Bitovi/slack-mcp-server/internal/slack"
+	"github.com/Bitovi/slack-mcp-server/pkg/types"
+)
+
+func TestGetEmojiStatsHandler_Handle(t *testing.T) {
+	history := map[string][]types.Message{
+		"C01": {
+			{Timestamp: "1700000002.000000", Reactions: []types.Reaction{
+				{Name: "+1", Count: 2, Users: []string{"U01", "U02"}},
+				{Name: "+1::skin-tone-3", Count: 1, Users: []string{"U03"}},
+				{Name: "tada", Count: 1, Users: []string{"U01"}},
+			}},
+			{Timestamp: "1700000001.000000"},
+		},
+		"C02": {
+			{Timestamp: "1700000003.000000", Reactions: []types.Reaction{
+				{Name: "tada", Count: 3, Users: []string{"U01", "U04", "U05"}},
+				{Name: "eyes", Count: 1, Users: []string{"U02"}},
+			}},
+		},
+	}
+
+	tests := []struct {
+		name         string
+		args         map[string]interface{}
+		failChannel  string
+		wantEmoji    []string
+		wantCounts   []int
+		wantChannels int
+		wantWarning  string
+		wantError    string
+	}{
+		{
+			name:       "one channel merges skin tones",
+			args:       map[string]interface{}{"channel_id": "C01"},
+			wantEmoji:  []string{"+1", "tada"},
+			wantCounts: []int{3, 1},
+		},
+		{
+			name:         "workspace-wide",
+			args:         map[string]interface{}{},
+			wantEmoji:    []string{"tada", "+1", "eyes"},
+			wantCounts:   []int{4, 3, 1},
+			wantChannels: 2,
+		},
+		{
+			name:         "top limits the emoji returned",
+			args:         map[string]interface{}{"top": float64(1)},
+			wantEmoji:    []string{"tada"},
+			wantCounts:   []int{4},
+			wantChannels: 2,
+		},
+		{
+			name:         "unreadable channel is skipped",
+			args:         map[string]interface{}{},
+			failChannel:  "C02",
+			wantEmoji:    []string{"+1", "tada"},
+			wantCounts:   []int{3, 1},
+			wantChannels: 1,
+			wantWarning:  types.WarnCodeChannelScanFailed,
+		},
+		{
+			name:      "invalid top",
+			args:      map[string]interface{}{"top": float64(0)},
+			wantError: "argument 'top' must be between 1 and 100",
+		},
+		{
+			name:      "invalid oldest",
+			args:      map[string]interface{}{"oldest": "last week"},
+			wantError: "argument 'oldest' must be a Unix timestamp",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := &mockSlackClient{
+				listChannels: func(ctx context.Context, opts slackclient.ListChannelsOptions) ([]types.ChannelAccess, string, error) {
+					if !opts.MemberOnly || !opts.ExcludeArchived {
+						t.Errorf("expected only the bot's unarchived channels to be listed, got %+v", opts)
+					}
+					return []types.ChannelAccess{
+						{Channel: &types.ChannelInfo{ID: "C01", Name: "general"}, BotIsMember: true},
+						{Channel: &types.ChannelInfo{ID: "C02", Name: "random"}, BotIsMember: true},
+					}, "", nil
+				},
+				getChannelHistory: func(ctx context.Context, channelID string, opts slackclient.HistoryOptions) ([]types.Message, bool, error) {
+					if opts.Oldest == "" {
+						t.Error("expected a default oldest")
+					}
+					if channelID == tt.failChannel {
+						return nil, false, types.NewSlackError(types.ErrCodeNotInChannel, "not_in_channel")
+					}
+					return history[channelID], false, nil
+				},
+			}
+
+			handler := NewGetEmojiStatsHandler(mock)
+			result, err := handler.Handle(context.Background(), createToolRequest(tt.args))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			text := result.Content[0].(mcp.TextContent).Text
+			if tt.wantError != "" {
+				if !result.IsError || !strings.Contains(text, tt.wantError) {
+					t.Errorf("expected error containing %q, got: %s", tt.wantError, text)
+				}
+				return
+			}
+			if result.IsError {
+				t.Fatalf("expected success, got error: %s", text)
+			}
+
+			var stats types.EmojiStatsResult
+			if err := json.Unmarshal([]byte(text), &stats); err != nil {
+				t.Fatalf("failed to parse result JSON: %v", err)
+			}
+			var names []string
+			var counts []int
+			for _, emoji := range stats.Emoji {
+				names = append(names, emoji.Name)
+				counts = append(counts, emoji.Count)
+			}
+			if strings.Join(names, ",") != strings.Join(tt.wantEmoji, ",") {
+				t.Errorf("emoji = %v, want %v", names, tt.wantEmoji)
+			}
+			for i := range counts {
+				if i < len(tt.wantCounts) && counts[i] != tt.wantCounts[i] {
+					t.Errorf("counts = %v, want %v", counts, tt.wantCounts)
+					break
+				}
+			}
+			if len(stats.Channels) != tt.wantChannels {
+				t.Errorf("scanned %d channels, want %d", len(stats.Channels), tt.wantChannels)
+			}
+			var codes []string
+			for _, warning := range stats.Warnings {
+				codes = append(codes, warning.Code)
+			}
+			if strings.Join(codes, ",") != tt.wantWarning {
+				t.Errorf("warnings = %v, want %q", codes, tt.wantWarning)
+			}
+		})
+	}
+}
+
+func TestGetEmojiStatsHandler_Handle_UsageDetails(t *testing.T) {
+	mock := &mockSlackClient{
+		getChannelHistory: func(ctx context.Context, channelID string, opts slackclient.HistoryOptions) ([]types.Message, bool, error) {
+			return []types.Message{
+				{Timestamp: "1700000002.000000", Reactions: []types.Reaction{
+					{Name: "+1", Count: 1, Users: []string{"U01"}},
+					{Name: "+1::skin-tone-2", Count: 1, Users: []string{"U02"}},
+				}},
+				{Timestamp: "1700000001.000000", Reactions: []types.Reaction{
+					{Name: "+1", Count: 1, Users: []string{"U01"}},
+				}},
+			}, true, nil
+		},
+	}
+
+	handler := NewGetEmojiStatsHandler(mock)
+	result, err := handler.Handle(context.Background(), createToolRequest(map[string]interface{}{"channel_id": "C01"}))
+	if err != nil || result.IsError {
+		t.Fatalf("unexpected failure: %v %+v", err, result)
+	}
+
+	var stats types.EmojiStatsResult
+	if err := json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &stats); err != nil {
+		t.Fatalf("failed to parse result JSON: %v", err)
+	}
+	if len(stats.Emoji) != 1 {
+		t.Fatalf("expected one emoji, got %+v", stats.Emoji)
+	}
+	emoji := stats.Emoji[0]
+	if emoji.Count != 3 || emoji.Messages != 2 || emoji.Users != 2 {
+		t.Errorf("usage = %+v, want count 3 on 2 messages by 2 users", emoji)
+	}
+	if emoji.Channels != nil {
+		t.Errorf("expected no channel breakdown for one channel, got %v", emoji.Channels)
+	}
+	if stats.TotalReactions != 3 || stats.MessagesScanned != 2 || stats.MessagesWithReactions != 2 {
+		t.Errorf("unexpected totals: %+v", stats)
+	}
+	if !stats.HasMore || len(stats.Warnings) != 1 || stats.Warnings[0].Code != types.WarnCodeResultsTruncated {
+		t.Errorf("expected a truncation warning, got has_more=%v warnings=%+v", stats.HasMore, stats.Warnings)
+	}
+}
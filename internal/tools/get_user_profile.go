@@ -0,0 +1,259 @@
+// Package tools provides MCP tool handler implementations for the Slack MCP server.
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	slackclient "github.com/Bitovi/slack-mcp-server/internal/slack"
+	"github.com/Bitovi/slack-mcp-server/internal/urlparser"
+	"github.com/Bitovi/slack-mcp-server/pkg/types"
+)
+
+// maxProfileCandidates caps the candidates returned when a name matches several users.
+const maxProfileCandidates = 10
+
+// Name match strengths, strongest last. A user's strength is that of the best
+// match among their username, display name, and real name.
+const (
+	nameMatchNone = iota
+	// nameMatchSubstring matches the query anywhere in a name.
+	nameMatchSubstring
+	// nameMatchWordPrefix matches the start of a word in a name (e.g., "smi" in "Jane Smith").
+	nameMatchWordPrefix
+	// nameMatchExact matches a whole name.
+	nameMatchExact
+)
+
+// GetUserProfileHandler handles the get_user_profile MCP tool requests.
+// It returns a user's full profile (title, email, phone, timezone, status, and
+// avatar), looked up by user ID, email address, or name.
+type GetUserProfileHandler struct {
+	// slackClient is the Slack API client for looking up users.
+	slackClient slackclient.ClientInterface
+	// config holds optional handler behavior.
+	config handlerConfig
+}
+
+// NewGetUserProfileHandler creates a new GetUserProfileHandler with the given Slack client and options.
+func NewGetUserProfileHandler(client slackclient.ClientInterface, opts ...HandlerOption) *GetUserProfileHandler {
+	return &GetUserProfileHandler{
+		slackClient: client,
+		config:      newHandlerConfig(opts),
+	}
+}
+
+// Handle processes a get_user_profile tool call.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeouts
+//   - request: The MCP tool call request containing exactly one of user_id, email, or name
+//
+// Returns an MCP tool result containing the user's profile, or the candidates if a
+// name matches several users equally well. Returns an error result if the
+// arguments are invalid, no user matches, or the lookup fails.
+func (h *GetUserProfileHandler) Handle(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	// Extract the lookup arguments; exactly one is required
+	var lookupBy, value string
+	for _, name := range []string{"user_id", "email", "name"} {
+		arg, exists := request.Params.Arguments[name]
+		if !exists {
+			continue
+		}
+		v, ok := arg.(string)
+		if !ok {
+			return mcp.NewToolResultError(fmt.Sprintf("argument '%s' must be a string", name)), nil
+		}
+		v = strings.TrimSpace(v)
+		if v == "" {
+			return mcp.NewToolResultError(fmt.Sprintf("argument '%s' cannot be empty", name)), nil
+		}
+		if lookupBy != "" {
+			return mcp.NewToolResultError(fmt.Sprintf(
+				"arguments '%s' and '%s' cannot be combined; pass exactly one of user_id, email, or name", lookupBy, name)), nil
+		}
+		lookupBy, value = name, v
+	}
+
+	var result *types.GetUserProfileResult
+	switch lookupBy {
+	case "user_id":
+		userID, ok := urlparser.ParseUserReference(value)
+		if !ok {
+			return mcp.NewToolResultError(fmt.Sprintf("argument 'user_id' must be a Slack user ID (e.g., U01234567) "+
+				"or profile link, got %q", value)), nil
+		}
+		user, err := h.slackClient.GetUserProfile(ctx, userID)
+		if err != nil {
+			return h.handleError(err), nil
+		}
+		result = &types.GetUserProfileResult{User: user, MatchedBy: "id"}
+
+	case "email":
+		if !strings.Contains(value, "@") {
+			return mcp.NewToolResultError(fmt.Sprintf("argument 'email' must be an email address, got %q", value)), nil
+		}
+		user, err := h.slackClient.LookupUserByEmail(ctx, value)
+		if err != nil {
+			return h.handleError(err), nil
+		}
+		if user == nil {
+			return mcp.NewToolResultError(fmt.Sprintf("No user has the email address %q.", value)), nil
+		}
+		result = &types.GetUserProfileResult{User: user, MatchedBy: "email"}
+
+	case "name":
+		var errResult *mcp.CallToolResult
+		result, errResult = h.lookupByName(ctx, value)
+		if errResult != nil {
+			return errResult, nil
+		}
+
+	default:
+		return mcp.NewToolResultError("missing required argument: pass one of 'user_id', 'email', or 'name'"), nil
+	}
+
+	result.Workspace = workspaceFor(ctx, h.slackClient)
+
+	return h.successResult(result)
+}
+
+// lookupByName finds the user whose username, display name, or real name best
+// matches name, ignoring case and a leading "@". Deactivated users are skipped.
+// If several users match equally well, they are returned as candidates instead.
+func (h *GetUserProfileHandler) lookupByName(ctx context.Context, name string) (*types.GetUserProfileResult, *mcp.CallToolResult) {
+	query := strings.ToLower(strings.TrimPrefix(name, "@"))
+
+	users, truncated, err := h.slackClient.ListUsers(ctx)
+	if err != nil {
+		return nil, h.handleError(err)
+	}
+
+	type match struct {
+		user     types.UserInfo
+		strength int
+	}
+	var matches []match
+	for _, user := range users {
+		if user.IsDeleted {
+			continue
+		}
+		if strength := nameMatchStrength(user, query); strength != nameMatchNone {
+			matches = append(matches, match{user: user, strength: strength})
+		}
+	}
+
+	result := &types.GetUserProfileResult{MatchedBy: "name"}
+	if truncated {
+		result.Warnings = append(result.Warnings, types.Warning{
+			Code:    types.WarnCodeResultsTruncated,
+			Message: fmt.Sprintf("only the first %d users in the workspace were searched", len(users)),
+		})
+	}
+	if len(matches) == 0 {
+		return nil, mcp.NewToolResultError(fmt.Sprintf("No user matches the name %q.", name))
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].strength > matches[j].strength
+	})
+	if len(matches) == 1 || matches[0].strength > matches[1].strength {
+		result.User = &matches[0].user
+		return result, nil
+	}
+
+	for _, m := range matches {
+		if len(result.Candidates) == maxProfileCandidates {
+			break
+		}
+		// Candidates are listed by their identity only; look one up by ID for the full profile
+		result.Candidates = append(result.Candidates, types.UserInfo{
+			ID:          m.user.ID,
+			Name:        m.user.Name,
+			DisplayName: m.user.DisplayName,
+			RealName:    m.user.RealName,
+			IsBot:       m.user.IsBot,
+			Title:       m.user.Title,
+		})
+	}
+	return result, nil
+}
+
+// nameMatchStrength returns how strongly query (lowercase) matches the user's
+// username, display name, or real name, as one of the nameMatch* constants.
+func nameMatchStrength(user types.UserInfo, query string) int {
+	best := nameMatchNone
+	for _, name := range []string{user.Name, user.DisplayName, user.RealName} {
+		name = strings.ToLower(name)
+		switch {
+		case name == "":
+			continue
+		case name == query:
+			return nameMatchExact
+		case hasWordPrefix(name, query):
+			best = max(best, nameMatchWordPrefix)
+		case strings.Contains(name, query):
+			best = max(best, nameMatchSubstring)
+		}
+	}
+	return best
+}
+
+// hasWordPrefix reports whether a word in name starts with prefix. Words are
+// separated by spaces, dots, dashes, and underscores, as in "jane.smith".
+func hasWordPrefix(name, prefix string) bool {
+	if strings.HasPrefix(name, prefix) {
+		return true
+	}
+	words := strings.FieldsFunc(name, func(r rune) bool {
+		return r == ' ' || r == '.' || r == '-' || r == '_'
+	})
+	for _, word := range words {
+		if strings.HasPrefix(word, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// handleError converts errors to appropriate MCP error results.
+func (h *GetUserProfileHandler) handleError(err error) *mcp.CallToolResult {
+	// Check for known error types and provide appropriate messages
+	if slackclient.IsRateLimited(err) {
+		return mcp.NewToolResultError(
+			"Rate limit exceeded. Please wait and try again.")
+	}
+
+	if slackclient.IsInvalidToken(err) {
+		return mcp.NewToolResultError(
+			"Authentication failed. Please check that SLACK_BOT_TOKEN is valid and not expired.")
+	}
+
+	if slackclient.IsMissingScope(err) {
+		return mcp.NewToolResultError(err.Error())
+	}
+
+	// Generic error handling
+	return mcp.NewToolResultError(fmt.Sprintf("Failed to look up user: %s", err.Error()))
+}
+
+// successResult creates a successful MCP tool result with the given data.
+func (h *GetUserProfileHandler) successResult(result *types.GetUserProfileResult) (*mcp.CallToolResult, error) {
+	resultJSON, err := json.Marshal(result)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to encode result: %s", err.Error())), nil
+	}
+
+	return mcp.NewToolResultText(string(resultJSON)), nil
+}
+
+// HandleFunc returns a function that can be used directly as an MCP tool handler.
+// This is a convenience method for registering the handler with the MCP server.
+func (h *GetUserProfileHandler) HandleFunc() func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return h.Handle
+}
@@ -0,0 +1,154 @@
+// Package tools provides unit tests for the MCP tool handlers.
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/Bitovi/slack-mcp-server/pkg/types"
+)
+
+func TestGetUserProfileHandler_Handle(t *testing.T) {
+	directory := []types.UserInfo{
+		{ID: "U01", Name: "jane.smith", DisplayName: "Jane", RealName: "Jane Smith", Title: "Engineer"},
+		{ID: "U02", Name: "john.smith", DisplayName: "John", RealName: "John Smith"},
+		{ID: "U03", Name: "janet", DisplayName: "Janet", RealName: "Janet Doe"},
+		{ID: "U04", Name: "smithy", DisplayName: "Old Smithy", RealName: "Old Smithy", IsDeleted: true},
+	}
+
+	tests := []struct {
+		name           string
+		args           map[string]interface{}
+		truncated      bool
+		wantUser       string
+		wantMatchedBy  string
+		wantCandidates []string
+		wantWarning    bool
+		wantError      string
+	}{
+		{
+			name:          "by user ID",
+			args:          map[string]interface{}{"user_id": "https://acme.slack.com/team/U01234567"},
+			wantUser:      "U01234567",
+			wantMatchedBy: "id",
+		},
+		{
+			name:          "by email",
+			args:          map[string]interface{}{"email": "jane@example.com"},
+			wantUser:      "U01",
+			wantMatchedBy: "email",
+		},
+		{
+			name:      "unknown email",
+			args:      map[string]interface{}{"email": "nobody@example.com"},
+			wantError: "No user has the email address",
+		},
+		{
+			name:      "invalid email",
+			args:      map[string]interface{}{"email": "jane"},
+			wantError: "must be an email address",
+		},
+		{
+			name:          "exact handle beats prefixes",
+			args:          map[string]interface{}{"name": "@Janet"},
+			wantUser:      "U03",
+			wantMatchedBy: "name",
+		},
+		{
+			name:          "unique word prefix",
+			args:          map[string]interface{}{"name": "jane smi"},
+			wantUser:      "U01",
+			wantMatchedBy: "name",
+		},
+		{
+			name:           "ambiguous name returns candidates",
+			args:           map[string]interface{}{"name": "smith"},
+			wantMatchedBy:  "name",
+			wantCandidates: []string{"U01", "U02"},
+		},
+		{
+			name:          "truncated directory warns",
+			args:          map[string]interface{}{"name": "janet"},
+			truncated:     true,
+			wantUser:      "U03",
+			wantMatchedBy: "name",
+			wantWarning:   true,
+		},
+		{
+			name:      "no match",
+			args:      map[string]interface{}{"name": "zed"},
+			wantError: "No user matches the name",
+		},
+		{
+			name:      "combined arguments",
+			args:      map[string]interface{}{"user_id": "U01", "name": "jane"},
+			wantError: "cannot be combined",
+		},
+		{
+			name:      "no arguments",
+			args:      map[string]interface{}{},
+			wantError: "missing required argument",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := &mockSlackClient{
+				getUserProfile: func(ctx context.Context, userID string) (*types.UserInfo, error) {
+					return &types.UserInfo{ID: userID, Email: "jane@example.com"}, nil
+				},
+				lookupUserByEmail: func(ctx context.Context, email string) (*types.UserInfo, error) {
+					if email != "jane@example.com" {
+						return nil, nil
+					}
+					return &types.UserInfo{ID: "U01", Email: email}, nil
+				},
+				listUsers: func(ctx context.Context) ([]types.UserInfo, bool, error) {
+					return directory, tt.truncated, nil
+				},
+			}
+
+			handler := NewGetUserProfileHandler(mock)
+			result, err := handler.Handle(context.Background(), createToolRequest(tt.args))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			text := result.Content[0].(mcp.TextContent).Text
+			if tt.wantError != "" {
+				if !result.IsError || !strings.Contains(text, tt.wantError) {
+					t.Errorf("expected error containing %q, got: %s", tt.wantError, text)
+				}
+				return
+			}
+			if result.IsError {
+				t.Fatalf("expected success, got error: %s", text)
+			}
+
+			var profile types.GetUserProfileResult
+			if err := json.Unmarshal([]byte(text), &profile); err != nil {
+				t.Fatalf("failed to parse result JSON: %v", err)
+			}
+			if profile.MatchedBy != tt.wantMatchedBy {
+				t.Errorf("matched_by = %q, want %q", profile.MatchedBy, tt.wantMatchedBy)
+			}
+			if tt.wantUser != "" && (profile.User == nil || profile.User.ID != tt.wantUser) {
+				t.Errorf("user = %+v, want %s", profile.User, tt.wantUser)
+			}
+			var candidates []string
+			for _, candidate := range profile.Candidates {
+				candidates = append(candidates, candidate.ID)
+			}
+			if strings.Join(candidates, ",") != strings.Join(tt.wantCandidates, ",") {
+				t.Errorf("candidates = %v, want %v", candidates, tt.wantCandidates)
+			}
+			if tt.wantWarning != (len(profile.Warnings) > 0) {
+				t.Errorf("warnings = %+v, want warning: %v", profile.Warnings, tt.wantWarning)
+			}
+		})
+	}
+}
@@ -0,0 +1,197 @@
+// Package tools provides MCP tool handler implementations for the Slack MCP server.
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	slackclient "github.com/Bitovi/slack-mcp-server/internal/slack"
+	"github.com/Bitovi/slack-mcp-server/pkg/types"
+)
+
+const (
+	// defaultAnalyticsRows is the number of export rows returned when no limit is given.
+	defaultAnalyticsRows = 50
+	// maxAnalyticsRows caps the export rows returned in one result; totals cover every row.
+	maxAnalyticsRows = 500
+	// analyticsDelay is how far back the default date is. Slack publishes each day's
+	// export after the day ends, so yesterday's file is often not available yet.
+	analyticsDelay = 2 * 24 * time.Hour
+)
+
+// GetWorkspaceAnalyticsHandler handles the get_workspace_analytics MCP tool requests.
+// It downloads an Enterprise Grid admin analytics export for a day and summarizes it,
+// so that workspace health agents can reason over adoption metrics.
+// It is registered only when an org admin token is configured.
+type GetWorkspaceAnalyticsHandler struct {
+	// slackClient is the Slack API client for downloading analytics exports.
+	slackClient slackclient.ClientInterface
+	// config holds optional handler behavior.
+	config handlerConfig
+}
+
+// NewGetWorkspaceAnalyticsHandler creates a new GetWorkspaceAnalyticsHandler with the given Slack client and options.
+func NewGetWorkspaceAnalyticsHandler(client slackclient.ClientInterface, opts ...HandlerOption) *GetWorkspaceAnalyticsHandler {
+	return &GetWorkspaceAnalyticsHandler{
+		slackClient: client,
+		config:      newHandlerConfig(opts),
+	}
+}
+
+// Handle processes a get_workspace_analytics tool call.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeouts
+//   - request: The MCP tool call request containing type and optional date and limit
+//
+// Returns an MCP tool result with the export's totals and first rows, or an error
+// result if the arguments are invalid or the export cannot be downloaded.
+func (h *GetWorkspaceAnalyticsHandler) Handle(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	// Extract the type argument (required)
+	typeArg, ok := request.Params.Arguments["type"]
+	if !ok {
+		return mcp.NewToolResultError("missing required argument 'type'"), nil
+	}
+
+	fileType, ok := typeArg.(string)
+	if !ok {
+		return mcp.NewToolResultError("argument 'type' must be a string"), nil
+	}
+
+	if fileType != slackclient.AnalyticsTypeMember && fileType != slackclient.AnalyticsTypePublicChannel {
+		return mcp.NewToolResultError(fmt.Sprintf("argument 'type' must be '%s' or '%s', got %q",
+			slackclient.AnalyticsTypeMember, slackclient.AnalyticsTypePublicChannel, fileType)), nil
+	}
+
+	// Extract date parameter (optional, defaults to the most recent day usually available)
+	date := time.Now().UTC().Add(-analyticsDelay).Format("2006-01-02")
+	if dateArg, exists := request.Params.Arguments["date"]; exists {
+		v, ok := dateArg.(string)
+		if !ok {
+			return mcp.NewToolResultError("argument 'date' must be a string"), nil
+		}
+		if _, err := time.Parse("2006-01-02", v); err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("argument 'date' must be a date in YYYY-MM-DD format, got %q", v)), nil
+		}
+		date = v
+	}
+
+	// Extract limit parameter (optional)
+	limit := defaultAnalyticsRows
+	if limitArg, exists := request.Params.Arguments["limit"]; exists {
+		v, ok := limitArg.(float64)
+		if !ok {
+			return mcp.NewToolResultError("argument 'limit' must be a number"), nil
+		}
+		if v < 0 {
+			return mcp.NewToolResultError("argument 'limit' cannot be negative"), nil
+		}
+		limit = int(v)
+	}
+
+	result := &types.WorkspaceAnalyticsResult{
+		Type:   fileType,
+		Date:   date,
+		Totals: make(map[string]float64),
+		Rows:   []map[string]interface{}{},
+	}
+	rowLimit := min(limit, maxAnalyticsRows)
+
+	err := h.slackClient.ReadAnalyticsFile(ctx, fileType, date, func(row map[string]interface{}) {
+		result.RowCount++
+		addAnalyticsTotals(result.Totals, row)
+		if len(result.Rows) < rowLimit {
+			result.Rows = append(result.Rows, row)
+		}
+	})
+	if err != nil {
+		return h.handleError(err, date), nil
+	}
+
+	if limit > maxAnalyticsRows && result.RowCount > maxAnalyticsRows {
+		result.Warnings = append(result.Warnings, types.Warning{
+			Code: types.WarnCodeResultsTruncated,
+			Message: fmt.Sprintf("returned %d of %d rows; at most %d rows are returned per call, and totals cover every row",
+				len(result.Rows), result.RowCount, maxAnalyticsRows),
+		})
+	}
+
+	result.Workspace = workspaceFor(ctx, h.slackClient)
+
+	return h.successResult(result)
+}
+
+// addAnalyticsTotals adds a row's numeric fields to totals, and counts its true
+// boolean fields. Other fields (IDs, names, dates) are not totaled.
+func addAnalyticsTotals(totals map[string]float64, row map[string]interface{}) {
+	for field, value := range row {
+		switch v := value.(type) {
+		case float64:
+			// date_claimed and similar fields are timestamps, not counts
+			if !strings.HasPrefix(field, "date_") {
+				totals[field] += v
+			}
+		case bool:
+			if v {
+				totals[field]++
+			} else if _, ok := totals[field]; !ok {
+				totals[field] = 0
+			}
+		}
+	}
+}
+
+// handleError converts errors to appropriate MCP error results.
+func (h *GetWorkspaceAnalyticsHandler) handleError(err error, date string) *mcp.CallToolResult {
+	// Check for known error types and provide appropriate messages
+	if slackclient.IsRateLimited(err) {
+		return mcp.NewToolResultError(
+			"Rate limit exceeded. Please wait and try again.")
+	}
+
+	if slackclient.IsInvalidToken(err) {
+		return mcp.NewToolResultError(
+			"Authentication failed. Please check that SLACK_ADMIN_TOKEN is valid and not expired.")
+	}
+
+	if slackclient.IsAdminTokenNotConfigured(err) || slackclient.IsMissingScope(err) {
+		return mcp.NewToolResultError(err.Error())
+	}
+
+	// Slack reports these only as error codes, which wrapSlackError does not classify
+	switch msg := err.Error(); {
+	case strings.Contains(msg, "file_not_yet_available"):
+		return mcp.NewToolResultError(fmt.Sprintf("Analytics for %s are not available yet. Slack publishes each "+
+			"day's export after the day ends, usually within a day or two; try an earlier date.", date))
+	case strings.Contains(msg, "file_not_found"):
+		return mcp.NewToolResultError(fmt.Sprintf("No analytics export exists for %s. Exports are kept for a "+
+			"limited time and start from when the organization enabled analytics.", date))
+	case strings.Contains(msg, "not_allowed_token_type") || strings.Contains(msg, "feature_not_enabled"):
+		return mcp.NewToolResultError("Admin analytics require an Enterprise Grid org admin or owner token " +
+			"(SLACK_ADMIN_TOKEN) with the admin.analytics:read scope.")
+	}
+
+	// Generic error handling
+	return mcp.NewToolResultError(fmt.Sprintf("Failed to get workspace analytics: %s", err.Error()))
+}
+
+// successResult creates a successful MCP tool result with the given data.
+func (h *GetWorkspaceAnalyticsHandler) successResult(result *types.WorkspaceAnalyticsResult) (*mcp.CallToolResult, error) {
+	resultJSON, err := json.Marshal(result)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to encode result: %s", err.Error())), nil
+	}
+
+	return mcp.NewToolResultText(string(resultJSON)), nil
+}
+
+// HandleFunc returns a function that can be used directly as an MCP tool handler.
+// This is a convenience method for registering the handler with the MCP server.
+func (h *GetWorkspaceAnalyticsHandler) HandleFunc() func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return h.Handle
+}
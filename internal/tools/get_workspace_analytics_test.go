@@ -0,0 +1,123 @@
+// Package tools provides unit tests for the MCP tool handlers.
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/Bitovi/slack-mcp-server/pkg/types"
+)
+
+func TestGetWorkspaceAnalyticsHandler_Handle(t *testing.T) {
+	rows := []map[string]interface{}{
+		{"user_id": "U01111111", "is_active": true, "messages_posted": float64(3), "date_claimed": float64(1700000000)},
+		{"user_id": "U02222222", "is_active": false, "messages_posted": float64(0), "date_claimed": float64(1700000000)},
+		{"user_id": "U03333333", "is_active": true, "messages_posted": float64(5), "date_claimed": float64(1700000000)},
+	}
+
+	tests := []struct {
+		name       string
+		args       map[string]interface{}
+		readErr    error
+		wantDate   string
+		wantRows   int
+		wantTotals map[string]float64
+		wantError  string
+	}{
+		{
+			name:       "member totals",
+			args:       map[string]interface{}{"type": "member", "date": "2024-03-01"},
+			wantDate:   "2024-03-01",
+			wantRows:   3,
+			wantTotals: map[string]float64{"is_active": 2, "messages_posted": 8},
+		},
+		{
+			name:       "limited rows",
+			args:       map[string]interface{}{"type": "member", "date": "2024-03-01", "limit": float64(1)},
+			wantDate:   "2024-03-01",
+			wantRows:   1,
+			wantTotals: map[string]float64{"is_active": 2, "messages_posted": 8},
+		},
+		{
+			name:      "invalid type",
+			args:      map[string]interface{}{"type": "channels"},
+			wantError: "argument 'type' must be 'member' or 'public_channel'",
+		},
+		{
+			name:      "invalid date",
+			args:      map[string]interface{}{"type": "member", "date": "03/01/2024"},
+			wantError: "YYYY-MM-DD",
+		},
+		{
+			name:      "not yet available",
+			args:      map[string]interface{}{"type": "member", "date": "2024-03-01"},
+			readErr:   types.NewSlackError(types.ErrCodeSlackError, "Slack API error: file_not_yet_available"),
+			wantError: "not available yet",
+		},
+		{
+			name:      "generic failure",
+			args:      map[string]interface{}{"type": "public_channel", "date": "2024-03-01"},
+			readErr:   errors.New("connection reset"),
+			wantError: "Failed to get workspace analytics",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var readDate string
+			mock := &mockSlackClient{
+				readAnalyticsFile: func(ctx context.Context, fileType, date string, fn func(row map[string]interface{})) error {
+					readDate = date
+					if tt.readErr != nil {
+						return tt.readErr
+					}
+					for _, row := range rows {
+						fn(row)
+					}
+					return nil
+				},
+			}
+
+			handler := NewGetWorkspaceAnalyticsHandler(mock)
+			result, err := handler.Handle(context.Background(), createToolRequest(tt.args))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			text := result.Content[0].(mcp.TextContent).Text
+			if tt.wantError != "" {
+				if !result.IsError || !strings.Contains(text, tt.wantError) {
+					t.Errorf("expected error containing %q, got: %s", tt.wantError, text)
+				}
+				return
+			}
+			if result.IsError {
+				t.Fatalf("expected success, got error: %s", text)
+			}
+
+			var analytics types.WorkspaceAnalyticsResult
+			if err := json.Unmarshal([]byte(text), &analytics); err != nil {
+				t.Fatalf("failed to parse result JSON: %v", err)
+			}
+			if readDate != tt.wantDate || analytics.Date != tt.wantDate {
+				t.Errorf("date = %q (read %q), want %q", analytics.Date, readDate, tt.wantDate)
+			}
+			if analytics.RowCount != len(rows) || len(analytics.Rows) != tt.wantRows {
+				t.Errorf("row_count = %d with %d rows, want %d with %d", analytics.RowCount, len(analytics.Rows), len(rows), tt.wantRows)
+			}
+			for field, want := range tt.wantTotals {
+				if got := analytics.Totals[field]; got != want {
+					t.Errorf("totals[%s] = %v, want %v", field, got, want)
+				}
+			}
+			if _, ok := analytics.Totals["date_claimed"]; ok {
+				t.Error("expected date fields to be excluded from totals")
+			}
+		})
+	}
+}
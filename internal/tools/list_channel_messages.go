@@ -5,24 +5,41 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/mark3labs/mcp-go/mcp"
 
+	"github.com/Bitovi/slack-mcp-server/internal/langdetect"
+	"github.com/Bitovi/slack-mcp-server/internal/references"
 	slackclient "github.com/Bitovi/slack-mcp-server/internal/slack"
+	"github.com/Bitovi/slack-mcp-server/internal/urlparser"
 	"github.com/Bitovi/slack-mcp-server/pkg/types"
 )
 
+const (
+	// maxCountOnlyMessages is the maximum number of messages counted in count_only mode.
+	// At the maximum page size this bounds a count to 10 conversations.history calls.
+	maxCountOnlyMessages = 10000
+	// summaryTextLength is the maximum number of characters of message text in a summary line.
+	summaryTextLength = 120
+)
+
 // ListChannelMessagesHandler handles the list_channel_messages MCP tool requests.
 // It retrieves messages from a Slack channel and resolves user information.
 type ListChannelMessagesHandler struct {
 	// slackClient is the Slack API client for retrieving channel history.
 	slackClient slackclient.ClientInterface
+	// config holds optional handler behavior such as the resolution error policy.
+	config handlerConfig
 }
 
-// NewListChannelMessagesHandler creates a new ListChannelMessagesHandler with the given Slack client.
-func NewListChannelMessagesHandler(client slackclient.ClientInterface) *ListChannelMessagesHandler {
+// NewListChannelMessagesHandler creates a new ListChannelMessagesHandler with the given Slack client and options.
+func NewListChannelMessagesHandler(client slackclient.ClientInterface, opts ...HandlerOption) *ListChannelMessagesHandler {
 	return &ListChannelMessagesHandler{
 		slackClient: client,
+		config:      newHandlerConfig(opts),
 	}
 }
 
@@ -52,6 +69,11 @@ func (h *ListChannelMessagesHandler) Handle(ctx context.Context, request mcp.Cal
 		return mcp.NewToolResultError("argument 'channel_id' cannot be empty"), nil
 	}
 
+	channelID, errResult := resolveChannelRef(ctx, h.slackClient, channelID, h.handleError)
+	if errResult != nil {
+		return errResult, nil
+	}
+
 	// Extract limit (default 100, max 200)
 	limit := 100
 	if limitArg, exists := request.Params.Arguments["limit"]; exists {
@@ -66,10 +88,15 @@ func (h *ListChannelMessagesHandler) Handle(ctx context.Context, request mcp.Cal
 	}
 
 	// Validate limit range
+	var warnings []types.Warning
 	if limit < 1 {
 		limit = 1
 	}
 	if limit > 200 {
+		warnings = append(warnings, types.Warning{
+			Code:    types.WarnCodeResultsTruncated,
+			Message: fmt.Sprintf("limit %d exceeds the maximum of 200; at most 200 messages are returned", limit),
+		})
 		limit = 200
 	}
 
@@ -93,15 +120,211 @@ func (h *ListChannelMessagesHandler) Handle(ctx context.Context, request mcp.Cal
 		}
 	}
 
+	// Extract inclusive parameter (optional, default false)
+	inclusive := false
+	if inclusiveArg, exists := request.Params.Arguments["inclusive"]; exists {
+		v, ok := inclusiveArg.(bool)
+		if !ok {
+			return mcp.NewToolResultError("argument 'inclusive' must be a boolean"), nil
+		}
+		inclusive = v
+	}
+
+	// Extract count_only parameter (optional, default false)
+	countOnly := false
+	if countOnlyArg, exists := request.Params.Arguments["count_only"]; exists {
+		v, ok := countOnlyArg.(bool)
+		if !ok {
+			return mcp.NewToolResultError("argument 'count_only' must be a boolean"), nil
+		}
+		countOnly = v
+	}
+
+	// Extract include_archived parameter (optional, default false). When true, an
+	// archived channel the bot is not a member of is read with the user token.
+	if archivedArg, exists := request.Params.Arguments["include_archived"]; exists {
+		v, ok := archivedArg.(bool)
+		if !ok {
+			return mcp.NewToolResultError("argument 'include_archived' must be a boolean"), nil
+		}
+		if v {
+			ctx = slackclient.WithArchivedReads(ctx)
+		}
+	}
+
+	// Extract detect_language parameter (optional, default false)
+	detectLanguage := false
+	if detectArg, exists := request.Params.Arguments["detect_language"]; exists {
+		v, ok := detectArg.(bool)
+		if !ok {
+			return mcp.NewToolResultError("argument 'detect_language' must be a boolean"), nil
+		}
+		detectLanguage = v
+	}
+
+	// Extract extract_references parameter (optional, default false)
+	extractReferences := false
+	if extractArg, exists := request.Params.Arguments["extract_references"]; exists {
+		v, ok := extractArg.(bool)
+		if !ok {
+			return mcp.NewToolResultError("argument 'extract_references' must be a boolean"), nil
+		}
+		extractReferences = v
+	}
+
+	// Extract include_raw parameter (optional, default false)
+	includeRaw := false
+	if rawArg, exists := request.Params.Arguments["include_raw"]; exists {
+		v, ok := rawArg.(bool)
+		if !ok {
+			return mcp.NewToolResultError("argument 'include_raw' must be a boolean"), nil
+		}
+		includeRaw = v
+	}
+
+	// Extract fields parameter (optional, default all fields)
+	fields, err := parseFields(request.Params.Arguments, messageFields)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	// Extract summary parameter (optional, default false)
+	summary := false
+	if summaryArg, exists := request.Params.Arguments["summary"]; exists {
+		v, ok := summaryArg.(bool)
+		if !ok {
+			return mcp.NewToolResultError("argument 'summary' must be a boolean"), nil
+		}
+		summary = v
+	}
+	if summary && len(fields) > 0 {
+		return mcp.NewToolResultError("argument 'fields' cannot be combined with 'summary'"), nil
+	}
+	if summary && extractReferences {
+		return mcp.NewToolResultError("argument 'extract_references' cannot be combined with 'summary'"), nil
+	}
+	if summary && includeRaw {
+		return mcp.NewToolResultError("argument 'include_raw' cannot be combined with 'summary'"), nil
+	}
+
+	// Extract score parameter (optional, default false)
+	score := false
+	if scoreArg, exists := request.Params.Arguments["score"]; exists {
+		v, ok := scoreArg.(bool)
+		if !ok {
+			return mcp.NewToolResultError("argument 'score' must be a boolean"), nil
+		}
+		score = v
+	}
+
+	// Extract min_score parameter (optional). Filtering by score implies scoring.
+	minScore := 0
+	if minScoreArg, exists := request.Params.Arguments["min_score"]; exists {
+		switch v := minScoreArg.(type) {
+		case float64:
+			minScore = int(v)
+		case int:
+			minScore = v
+		default:
+			return mcp.NewToolResultError("argument 'min_score' must be a number"), nil
+		}
+		score = true
+	}
+
+	// A user ID or DM deep link (https://workspace.slack.com/team/U01234567) names a
+	// person, not a conversation
+	if userID, ok := urlparser.ParseUserReference(channelID); ok {
+		return mcp.NewToolResultError(userReferenceMessage(userID)), nil
+	}
+
+	// In count_only mode, count the messages in the window without returning content
+	if countOnly {
+		count, capped, err := h.slackClient.CountChannelMessages(ctx, channelID, slackclient.HistoryOptions{
+			Limit:     maxCountOnlyMessages,
+			Oldest:    oldest,
+			Latest:    latest,
+			Inclusive: inclusive,
+		})
+		if err != nil {
+			return h.handleError(err), nil
+		}
+
+		return h.countResult(ctx, &types.MessageCountResult{
+			ChannelID: channelID,
+			Count:     count,
+			HasMore:   capped,
+		})
+	}
+
 	// Call GetChannelHistory to retrieve messages
-	messages, hasMore, err := h.slackClient.GetChannelHistory(ctx, channelID, limit, oldest, latest)
+	messages, hasMore, err := h.slackClient.GetChannelHistory(ctx, channelID, slackclient.HistoryOptions{
+		Limit:              limit,
+		Oldest:             oldest,
+		Latest:             latest,
+		Inclusive:          inclusive,
+		IncludeAllMetadata: true,
+	})
 	if err != nil {
 		return h.handleError(err), nil
 	}
 
-	// Resolve user info for each message
+	// Explain a window that is empty because older history was removed by retention limits
+	if warning := retentionGapWarning(ctx, h.slackClient, h.config.retentionWindow,
+		channelID, oldest, latest, messages, hasMore); warning != nil {
+		warnings = append(warnings, *warning)
+	}
+
+	// Track user resolution failures so they can be reported according to the configured policy
+	resolution := newResolutionTracker()
+
+	// Resolve user info for each message, looking up the distinct authors in parallel
+	users := lookupUsers(ctx, h.slackClient, messageAuthors(messages))
 	for i := range messages {
-		h.resolveUserForMessage(ctx, &messages[i])
+		h.resolveUserForMessage(ctx, &messages[i], users, resolution)
+	}
+
+	// Score messages by importance and drop those below min_score if requested
+	if score {
+		messages = h.scoreMessages(ctx, messages, minScore)
+	}
+
+	// In summary mode, return one line per message instead of full message objects
+	if summary {
+		resolutionWarnings, err := resolution.apply(h.config.onResolutionError)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to resolve users: %s", err.Error())), nil
+		}
+
+		lines := make([]string, 0, len(messages))
+		for i := range messages {
+			lines = append(lines, summarizeMessage(&messages[i]))
+		}
+
+		return h.summaryResult(ctx, &types.ListChannelMessagesSummaryResult{
+			ChannelID: channelID,
+			Messages:  lines,
+			HasMore:   hasMore,
+			Warnings:  append(warnings, resolutionWarnings...),
+		})
+	}
+
+	// Tag messages with their detected language if requested
+	if detectLanguage {
+		for i := range messages {
+			messages[i].Lang = langdetect.Detect(messages[i].Text)
+		}
+	}
+
+	// Extract issue and ticket references if requested
+	if extractReferences {
+		for i := range messages {
+			messages[i].References = references.Extract(messages[i].Text, h.config.referenceTrackers)
+		}
+	}
+
+	// Drop the Slack API payloads unless requested
+	if !includeRaw {
+		clearRaw(messages)
 	}
 
 	// Build the result
@@ -109,20 +332,36 @@ func (h *ListChannelMessagesHandler) Handle(ctx context.Context, request mcp.Cal
 		Messages:  messages,
 		ChannelID: channelID,
 		HasMore:   hasMore,
+		Warnings:  warnings,
 	}
 
-	// Extract mentioned users from all messages and build user mapping
-	result.UserMapping = h.buildUserMapping(ctx, messages)
+	// Name the participants of a group DM (graceful degradation if the channel lookup fails)
+	if channelInfo, err := h.slackClient.GetChannelInfo(ctx, channelID); err == nil {
+		result.Participants = mpimParticipants(ctx, h.slackClient, channelInfo, resolution)
+	}
+
+	// Resolve the users, channels, and user groups the messages refer to
+	h.buildMappings(ctx, result, resolution)
 
 	// Fetch the authenticated user's identity (graceful degradation on failure)
 	currentUser, err := h.slackClient.GetCurrentUser(ctx)
 	if err == nil && currentUser != nil {
 		result.CurrentUser = currentUser
 	}
-	// Note: If GetCurrentUser fails, we continue without current_user rather than failing
+	// Note: If GetCurrentUser fails, we continue without current_user unless the policy says otherwise
+	resolution.recordCurrentUser(err)
+
+	result.Workspace = workspaceFor(ctx, h.slackClient)
+
+	// Flag or reject degraded results according to the resolution error policy
+	resolutionWarnings, err := resolution.apply(h.config.onResolutionError)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to resolve users: %s", err.Error())), nil
+	}
+	result.Warnings = append(result.Warnings, resolutionWarnings...)
 
 	// Return the successful result as JSON content
-	return h.successResult(result)
+	return h.successResult(result, fields)
 }
 
 // handleError converts an error into an MCP tool error result.
@@ -150,9 +389,19 @@ func (h *ListChannelMessagesHandler) handleError(err error) *mcp.CallToolResult
 			"The bot is not a member of this channel. Please invite the bot to the channel first.")
 	}
 
+	if slackclient.IsChannelArchived(err) {
+		return mcp.NewToolResultError(
+			"The channel is archived and the bot is not a member. Archived channels cannot be joined; " +
+				"pass include_archived=true to read it with SLACK_USER_TOKEN.")
+	}
+
+	if slackclient.IsMissingScope(err) {
+		return mcp.NewToolResultError(err.Error())
+	}
+
 	if slackclient.IsPermissionDenied(err) {
 		return mcp.NewToolResultError(
-			"Permission denied. The bot may lack required scopes or the channel is archived.")
+			"Permission denied. The bot may lack required scopes.")
 	}
 
 	// Generic error handling
@@ -160,7 +409,32 @@ func (h *ListChannelMessagesHandler) handleError(err error) *mcp.CallToolResult
 }
 
 // successResult creates a successful MCP tool result with the given data.
-func (h *ListChannelMessagesHandler) successResult(result *types.ListChannelMessagesResult) (*mcp.CallToolResult, error) {
+// If fields is non-empty, the message objects are trimmed to those fields.
+func (h *ListChannelMessagesHandler) successResult(result *types.ListChannelMessagesResult, fields []string) (*mcp.CallToolResult, error) {
+	resultJSON, err := marshalProjected(result, fields, "messages")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to encode result: %s", err.Error())), nil
+	}
+
+	return mcp.NewToolResultText(string(resultJSON)), nil
+}
+
+// countResult creates a successful MCP tool result for count_only mode.
+func (h *ListChannelMessagesHandler) countResult(ctx context.Context, result *types.MessageCountResult) (*mcp.CallToolResult, error) {
+	result.Workspace = workspaceFor(ctx, h.slackClient)
+
+	resultJSON, err := json.Marshal(result)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to encode result: %s", err.Error())), nil
+	}
+
+	return mcp.NewToolResultText(string(resultJSON)), nil
+}
+
+// summaryResult creates a successful MCP tool result for summary mode.
+func (h *ListChannelMessagesHandler) summaryResult(ctx context.Context, result *types.ListChannelMessagesSummaryResult) (*mcp.CallToolResult, error) {
+	result.Workspace = workspaceFor(ctx, h.slackClient)
+
 	resultJSON, err := json.Marshal(result)
 	if err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to encode result: %s", err.Error())), nil
@@ -169,29 +443,91 @@ func (h *ListChannelMessagesHandler) successResult(result *types.ListChannelMess
 	return mcp.NewToolResultText(string(resultJSON)), nil
 }
 
-// resolveUserForMessage populates user name fields on a message by fetching user info.
+// summarizeMessage formats a message as a single summary line:
 //
-// This method fetches user information for the message author and populates
-// the UserName, DisplayName, and RealName fields on the message. If the user
-// lookup fails, the message is left unchanged (graceful degradation).
+//	2024-01-15 10:30 jsmith: Deploy is done, please verify… [3 replies, 2 reactions] (ts 1705314600.123456)
+//
+// The time is in UTC, the author is the username if resolved (otherwise the user ID),
+// and the text is flattened to one line and truncated to summaryTextLength characters.
+// Reply and reaction counts are omitted when zero.
+func summarizeMessage(msg *types.Message) string {
+	var b strings.Builder
+
+	if sec, err := strconv.ParseFloat(msg.Timestamp, 64); err == nil {
+		b.WriteString(time.Unix(int64(sec), 0).UTC().Format("2006-01-02 15:04"))
+		b.WriteString(" ")
+	}
+
+	author := msg.UserName
+	if author == "" {
+		author = msg.User
+	}
+	if author == "" {
+		author = "unknown"
+	}
+	b.WriteString(author)
+	b.WriteString(": ")
+
+	text := []rune(strings.Join(strings.Fields(msg.Text), " "))
+	if len(text) > summaryTextLength {
+		text = append(text[:summaryTextLength], '…')
+	}
+	b.WriteString(string(text))
+
+	var counts []string
+	if msg.ReplyCount > 0 {
+		counts = append(counts, pluralize(msg.ReplyCount, "reply", "replies"))
+	}
+	if msg.ReactionCount > 0 {
+		counts = append(counts, pluralize(msg.ReactionCount, "reaction", "reactions"))
+	}
+	if len(counts) > 0 {
+		fmt.Fprintf(&b, " [%s]", strings.Join(counts, ", "))
+	}
+
+	fmt.Fprintf(&b, " (ts %s)", msg.Timestamp)
+	return b.String()
+}
+
+// pluralize formats a count with the singular or plural form of a noun.
+func pluralize(n int, singular, plural string) string {
+	if n == 1 {
+		return fmt.Sprintf("%d %s", n, singular)
+	}
+	return fmt.Sprintf("%d %s", n, plural)
+}
+
+// resolveUserForMessage populates user name fields on a message from its author's
+// lookup in users (see lookupUsers).
+//
+// This method populates the UserName, DisplayName, and RealName fields on the
+// message from the author's user information. If the user lookup failed, the
+// message is left unchanged (graceful degradation) and the failure is recorded
+// on the resolution tracker.
 //
 // Parameters:
 //   - ctx: Context for cancellation and timeouts
 //   - msg: Pointer to the message to populate with user info
+//   - users: The lookups of the messages' authors, by user ID
+//   - resolution: Tracker that collects user resolution failures
 //
 // This method does not return an error. If user resolution fails, the message
 // will simply not have user name fields populated.
-func (h *ListChannelMessagesHandler) resolveUserForMessage(ctx context.Context, msg *types.Message) {
+func (h *ListChannelMessagesHandler) resolveUserForMessage(ctx context.Context, msg *types.Message, users map[string]userLookup, resolution *resolutionTracker) {
+	// Label posts by bots and workflows, which often have no user ID
+	attributeBotMessage(ctx, h.slackClient, msg)
+
 	// Skip if message has no user ID (e.g., system messages)
 	if msg.User == "" {
 		return
 	}
 
-	// Fetch user info from Slack (or cache)
-	userInfo, err := h.slackClient.GetUserInfo(ctx, msg.User)
+	// Take the user info looked up from Slack (or cache)
+	userInfo, err := users[msg.User].info, users[msg.User].err
 	if err != nil {
-		// Graceful degradation: log the error but don't fail
+		// Graceful degradation: record the error but don't fail
 		// The message will be returned without user name fields
+		resolution.recordUser(msg.User, err)
 		return
 	}
 
@@ -206,52 +542,47 @@ func (h *ListChannelMessagesHandler) resolveUserForMessage(ctx context.Context,
 	msg.RealName = userInfo.RealName
 }
 
-// buildUserMapping extracts mentioned user IDs from all messages and resolves them to UserInfo.
-//
-// This method scans all messages for Slack mentions (e.g., <@U06025G6B28>) and builds
-// a mapping of user IDs to their UserInfo. If a user lookup fails, that user is
-// simply omitted from the mapping.
-//
-// Parameters:
-//   - ctx: Context for cancellation and timeouts
-//   - messages: The messages to scan for mentions
-//
-// Returns a map of user IDs to UserInfo for all mentioned users, or nil if no mentions found.
-func (h *ListChannelMessagesHandler) buildUserMapping(ctx context.Context, messages []types.Message) map[string]types.UserInfo {
-	// Collect all unique mentioned user IDs
-	mentionedUserIDs := make(map[string]bool)
-
-	// Extract mentions from all messages
-	for _, msg := range messages {
-		for _, userID := range h.slackClient.ExtractMentions(msg.Text) {
-			mentionedUserIDs[userID] = true
-		}
+// scoreMessages sets the importance score of each message and returns the messages
+// scoring at least minScore, in their original order. The current user and message
+// authors are looked up for the mention and role signals; a failed lookup skips
+// that signal rather than failing the call.
+func (h *ListChannelMessagesHandler) scoreMessages(ctx context.Context, messages []types.Message, minScore int) []types.Message {
+	currentUserID := ""
+	if currentUser, err := h.slackClient.GetCurrentUser(ctx); err == nil && currentUser != nil {
+		currentUserID = currentUser.ID
 	}
 
-	// If no mentions found, return nil
-	if len(mentionedUserIDs) == 0 {
-		return nil
-	}
+	kept := messages[:0]
+	for i := range messages {
+		var author *types.UserInfo
+		if messages[i].User != "" {
+			author, _ = h.slackClient.GetUserInfo(ctx, messages[i].User)
+		}
 
-	// Build the user mapping by resolving each mentioned user
-	userMapping := make(map[string]types.UserInfo)
-	for userID := range mentionedUserIDs {
-		userInfo, err := h.slackClient.GetUserInfo(ctx, userID)
-		if err != nil {
-			// Graceful degradation: skip users we can't resolve
+		messageScore := scoreMessage(&messages[i], currentUserID, author)
+		if messageScore < minScore {
 			continue
 		}
-		if userInfo != nil {
-			userMapping[userID] = *userInfo
-		}
+		messages[i].Score = &messageScore
+		kept = append(kept, messages[i])
 	}
+	return kept
+}
 
-	// Return nil if no users were resolved (to avoid empty map in JSON)
-	if len(userMapping) == 0 {
-		return nil
+// buildMappings resolves the users, channels, and user groups that messages refer
+// to, by mention, channel link, or reaction, into the result's user_mapping,
+// channel_mapping, and usergroup_mapping. Entities that cannot be resolved are
+// omitted and recorded on resolution.
+func (h *ListChannelMessagesHandler) buildMappings(ctx context.Context, result *types.ListChannelMessagesResult, resolution *resolutionTracker) {
+	refs := newEntityRefs(h.slackClient)
+	for i := range result.Messages {
+		refs.addMessage(&result.Messages[i])
 	}
 
-	return userMapping
+	mappings := refs.resolve(ctx, resolution)
+	result.UserMapping = mappings.users
+	result.ChannelMapping = mappings.channels
+	result.UserGroupMapping = mappings.userGroups
 }
 
 // HandleFunc returns a function that can be used directly as an MCP tool handler.
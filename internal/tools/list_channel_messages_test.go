@@ -4,11 +4,14 @@ package tools
 import (
 	"context"
 	"encoding/json"
+	"reflect"
 	"strings"
 	"testing"
 
 	"github.com/mark3labs/mcp-go/mcp"
 
+	"github.com/Bitovi/slack-mcp-server/internal/references"
+	slackclient "github.com/Bitovi/slack-mcp-server/internal/slack"
 	"github.com/Bitovi/slack-mcp-server/pkg/types"
 )
 
@@ -223,15 +226,15 @@ func TestListChannelMessagesHandler_Handle_Success(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			mock := &mockSlackClient{
-				getChannelHistory: func(ctx context.Context, channelID string, limit int, oldest, latest string) ([]types.Message, bool, error) {
+				getChannelHistory: func(ctx context.Context, channelID string, opts slackclient.HistoryOptions) ([]types.Message, bool, error) {
 					if channelID != tt.channelID {
 						t.Errorf("GetChannelHistory channelID = %q, want %q", channelID, tt.channelID)
 					}
-					if tt.oldest != "" && oldest != tt.oldest {
-						t.Errorf("GetChannelHistory oldest = %q, want %q", oldest, tt.oldest)
+					if tt.oldest != "" && opts.Oldest != tt.oldest {
+						t.Errorf("GetChannelHistory oldest = %q, want %q", opts.Oldest, tt.oldest)
 					}
-					if tt.latest != "" && latest != tt.latest {
-						t.Errorf("GetChannelHistory latest = %q, want %q", latest, tt.latest)
+					if tt.latest != "" && opts.Latest != tt.latest {
+						t.Errorf("GetChannelHistory latest = %q, want %q", opts.Latest, tt.latest)
 					}
 					return tt.mockMessages, tt.mockHasMore, nil
 				},
@@ -392,7 +395,7 @@ func TestNewListChannelMessagesHandler(t *testing.T) {
 func TestListChannelMessagesHandler_HandleFunc(t *testing.T) {
 	// Test that HandleFunc returns a usable function
 	mock := &mockSlackClient{
-		getChannelHistory: func(ctx context.Context, channelID string, limit int, oldest, latest string) ([]types.Message, bool, error) {
+		getChannelHistory: func(ctx context.Context, channelID string, opts slackclient.HistoryOptions) ([]types.Message, bool, error) {
 			return []types.Message{
 				{
 					User:      "U12345678",
@@ -459,8 +462,8 @@ func TestListChannelMessagesHandler_Handle_InvalidLimitType(t *testing.T) {
 func TestListChannelMessagesHandler_Handle_ZeroLimitUsesMinimum(t *testing.T) {
 	var capturedLimit int
 	mock := &mockSlackClient{
-		getChannelHistory: func(ctx context.Context, channelID string, limit int, oldest, latest string) ([]types.Message, bool, error) {
-			capturedLimit = limit
+		getChannelHistory: func(ctx context.Context, channelID string, opts slackclient.HistoryOptions) ([]types.Message, bool, error) {
+			capturedLimit = opts.Limit
 			return []types.Message{}, false, nil
 		},
 	}
@@ -491,8 +494,8 @@ func TestListChannelMessagesHandler_Handle_ZeroLimitUsesMinimum(t *testing.T) {
 func TestListChannelMessagesHandler_Handle_NegativeLimitUsesMinimum(t *testing.T) {
 	var capturedLimit int
 	mock := &mockSlackClient{
-		getChannelHistory: func(ctx context.Context, channelID string, limit int, oldest, latest string) ([]types.Message, bool, error) {
-			capturedLimit = limit
+		getChannelHistory: func(ctx context.Context, channelID string, opts slackclient.HistoryOptions) ([]types.Message, bool, error) {
+			capturedLimit = opts.Limit
 			return []types.Message{}, false, nil
 		},
 	}
@@ -523,8 +526,8 @@ func TestListChannelMessagesHandler_Handle_NegativeLimitUsesMinimum(t *testing.T
 func TestListChannelMessagesHandler_Handle_LimitExceedsMaximum(t *testing.T) {
 	var capturedLimit int
 	mock := &mockSlackClient{
-		getChannelHistory: func(ctx context.Context, channelID string, limit int, oldest, latest string) ([]types.Message, bool, error) {
-			capturedLimit = limit
+		getChannelHistory: func(ctx context.Context, channelID string, opts slackclient.HistoryOptions) ([]types.Message, bool, error) {
+			capturedLimit = opts.Limit
 			return []types.Message{}, false, nil
 		},
 	}
@@ -555,8 +558,8 @@ func TestListChannelMessagesHandler_Handle_LimitExceedsMaximum(t *testing.T) {
 func TestListChannelMessagesHandler_Handle_DefaultLimit(t *testing.T) {
 	var capturedLimit int
 	mock := &mockSlackClient{
-		getChannelHistory: func(ctx context.Context, channelID string, limit int, oldest, latest string) ([]types.Message, bool, error) {
-			capturedLimit = limit
+		getChannelHistory: func(ctx context.Context, channelID string, opts slackclient.HistoryOptions) ([]types.Message, bool, error) {
+			capturedLimit = opts.Limit
 			return []types.Message{}, false, nil
 		},
 	}
@@ -609,6 +612,11 @@ func TestListChannelMessagesHandler_Handle_SlackErrors(t *testing.T) {
 			errorCode:      types.ErrCodeNotInChannel,
 			wantErrContain: "not a member of this channel",
 		},
+		{
+			name:           "archived channel",
+			errorCode:      types.ErrCodeChannelArchived,
+			wantErrContain: "include_archived=true",
+		},
 		{
 			name:           "permission denied",
 			errorCode:      types.ErrCodePermissionDenied,
@@ -619,7 +627,7 @@ func TestListChannelMessagesHandler_Handle_SlackErrors(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			mock := &mockSlackClient{
-				getChannelHistory: func(ctx context.Context, channelID string, limit int, oldest, latest string) ([]types.Message, bool, error) {
+				getChannelHistory: func(ctx context.Context, channelID string, opts slackclient.HistoryOptions) ([]types.Message, bool, error) {
 					return nil, false, types.NewSlackError(tt.errorCode, "mock error")
 				},
 			}
@@ -774,8 +782,8 @@ func TestListChannelMessagesHandler_Handle_Pagination(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			var capturedLimit int
 			mock := &mockSlackClient{
-				getChannelHistory: func(ctx context.Context, channelID string, limit int, oldest, latest string) ([]types.Message, bool, error) {
-					capturedLimit = limit
+				getChannelHistory: func(ctx context.Context, channelID string, opts slackclient.HistoryOptions) ([]types.Message, bool, error) {
+					capturedLimit = opts.Limit
 					if channelID != tt.channelID {
 						t.Errorf("GetChannelHistory channelID = %q, want %q", channelID, tt.channelID)
 					}
@@ -864,6 +872,29 @@ func TestListChannelMessagesHandler_Handle_UserMapping(t *testing.T) {
 			wantMappingCount: 1,
 			wantMappedUsers:  []string{"U87654321"},
 		},
+		{
+			name:      "reacting users are mapped",
+			channelID: "C01234567",
+			mockMessages: []types.Message{
+				{
+					User:      "U12345678",
+					Text:      "Ship it?",
+					Timestamp: "1355517523.000008",
+					Reactions: []types.Reaction{
+						{Name: "+1", Count: 2, Users: []string{"U87654321", "UAAAAAAAA"}},
+						{Name: "eyes", Count: 1, Users: []string{"U87654321"}},
+					},
+				},
+			},
+			extractedIDs: map[string][]string{},
+			userInfoMap: map[string]*types.UserInfo{
+				"U12345678": {ID: "U12345678", Name: "alice"},
+				"U87654321": {ID: "U87654321", Name: "bob"},
+				"UAAAAAAAA": {ID: "UAAAAAAAA", Name: "charlie"},
+			},
+			wantMappingCount: 2,
+			wantMappedUsers:  []string{"U87654321", "UAAAAAAAA"},
+		},
 		{
 			name:      "multiple mentions in message",
 			channelID: "C01234567",
@@ -1037,7 +1068,7 @@ func TestListChannelMessagesHandler_Handle_UserMapping(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			mock := &mockSlackClient{
-				getChannelHistory: func(ctx context.Context, channelID string, limit int, oldest, latest string) ([]types.Message, bool, error) {
+				getChannelHistory: func(ctx context.Context, channelID string, opts slackclient.HistoryOptions) ([]types.Message, bool, error) {
 					return tt.mockMessages, false, nil
 				},
 				getUserInfo: func(ctx context.Context, userID string) (*types.UserInfo, error) {
@@ -1046,11 +1077,8 @@ func TestListChannelMessagesHandler_Handle_UserMapping(t *testing.T) {
 					}
 					return nil, nil // User not found
 				},
-				extractMentions: func(text string) []string {
-					if ids, ok := tt.extractedIDs[text]; ok {
-						return ids
-					}
-					return []string{}
+				extractEntities: func(text string) slackclient.Entities {
+					return slackclient.Entities{Users: tt.extractedIDs[text]}
 				},
 			}
 
@@ -1109,3 +1137,670 @@ func TestListChannelMessagesHandler_Handle_UserMapping(t *testing.T) {
 		})
 	}
 }
+
+// TestListChannelMessagesHandler_Handle_Inclusive tests that the inclusive flag is propagated to the client.
+func TestListChannelMessagesHandler_Handle_Inclusive(t *testing.T) {
+	tests := []struct {
+		name          string
+		args          map[string]interface{}
+		wantInclusive bool
+	}{
+		{
+			name: "default is exclusive",
+			args: map[string]interface{}{
+				"channel_id": "C01234567",
+				"latest":     "1355517523.000008",
+			},
+			wantInclusive: false,
+		},
+		{
+			name: "inclusive true",
+			args: map[string]interface{}{
+				"channel_id": "C01234567",
+				"oldest":     "1355517500.000000",
+				"latest":     "1355517523.000008",
+				"inclusive":  true,
+			},
+			wantInclusive: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var capturedInclusive bool
+			mock := &mockSlackClient{
+				getChannelHistory: func(ctx context.Context, channelID string, opts slackclient.HistoryOptions) ([]types.Message, bool, error) {
+					capturedInclusive = opts.Inclusive
+					return []types.Message{}, false, nil
+				},
+			}
+
+			handler := NewListChannelMessagesHandler(mock)
+			result, err := handler.Handle(context.Background(), createListChannelMessagesRequest(tt.args))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if result.IsError {
+				t.Fatalf("expected success, got error: %+v", result.Content)
+			}
+
+			if capturedInclusive != tt.wantInclusive {
+				t.Errorf("inclusive = %v, want %v", capturedInclusive, tt.wantInclusive)
+			}
+		})
+	}
+}
+
+func TestListChannelMessagesHandler_Handle_InvalidInclusiveType(t *testing.T) {
+	handler := NewListChannelMessagesHandler(&mockSlackClient{})
+	request := createListChannelMessagesRequest(map[string]interface{}{
+		"channel_id": "C01234567",
+		"inclusive":  "true",
+	})
+
+	result, err := handler.Handle(context.Background(), request)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !result.IsError {
+		t.Error("expected error result for non-boolean inclusive")
+	}
+}
+
+// TestListChannelMessagesHandler_Handle_CountOnly tests that count_only returns a count without messages.
+func TestListChannelMessagesHandler_Handle_CountOnly(t *testing.T) {
+	historyCalled := false
+	var capturedOldest, capturedLatest string
+	var capturedMax int
+
+	mock := &mockSlackClient{
+		getChannelHistory: func(ctx context.Context, channelID string, opts slackclient.HistoryOptions) ([]types.Message, bool, error) {
+			historyCalled = true
+			return []types.Message{}, false, nil
+		},
+		countChannelMessages: func(ctx context.Context, channelID string, opts slackclient.HistoryOptions) (int, bool, error) {
+			capturedOldest = opts.Oldest
+			capturedLatest = opts.Latest
+			capturedMax = opts.Limit
+			return 42, false, nil
+		},
+	}
+
+	handler := NewListChannelMessagesHandler(mock)
+	request := createListChannelMessagesRequest(map[string]interface{}{
+		"channel_id": "C01234567",
+		"oldest":     "1700000000",
+		"latest":     "1700086400",
+		"count_only": true,
+	})
+
+	result, err := handler.Handle(context.Background(), request)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("expected success, got error: %+v", result.Content)
+	}
+
+	if historyCalled {
+		t.Error("GetChannelHistory should not be called in count_only mode")
+	}
+	if capturedOldest != "1700000000" || capturedLatest != "1700086400" {
+		t.Errorf("unexpected window: oldest=%q latest=%q", capturedOldest, capturedLatest)
+	}
+	if capturedMax != maxCountOnlyMessages {
+		t.Errorf("expected max count %d, got %d", maxCountOnlyMessages, capturedMax)
+	}
+
+	textContent := result.Content[0].(mcp.TextContent)
+	var countResult types.MessageCountResult
+	if err := json.Unmarshal([]byte(textContent.Text), &countResult); err != nil {
+		t.Fatalf("failed to parse result JSON: %v", err)
+	}
+
+	if countResult.Count != 42 {
+		t.Errorf("expected count 42, got %d", countResult.Count)
+	}
+	if countResult.ChannelID != "C01234567" {
+		t.Errorf("expected channel_id C01234567, got %q", countResult.ChannelID)
+	}
+	if strings.Contains(textContent.Text, "messages") {
+		t.Errorf("count_only result should not contain messages: %s", textContent.Text)
+	}
+}
+
+func TestListChannelMessagesHandler_Handle_CountOnlyError(t *testing.T) {
+	mock := &mockSlackClient{
+		countChannelMessages: func(ctx context.Context, channelID string, opts slackclient.HistoryOptions) (int, bool, error) {
+			return 0, false, types.NewSlackError(types.ErrCodeNotInChannel, "not in channel")
+		},
+	}
+
+	handler := NewListChannelMessagesHandler(mock)
+	request := createListChannelMessagesRequest(map[string]interface{}{
+		"channel_id": "C01234567",
+		"count_only": true,
+	})
+
+	result, err := handler.Handle(context.Background(), request)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !result.IsError {
+		t.Error("expected error result")
+	}
+}
+
+// TestListChannelMessagesHandler_Handle_DetectLanguage tests that messages are tagged with a language when requested.
+func TestListChannelMessagesHandler_Handle_DetectLanguage(t *testing.T) {
+	mock := &mockSlackClient{
+		getChannelHistory: func(ctx context.Context, channelID string, opts slackclient.HistoryOptions) ([]types.Message, bool, error) {
+			return []types.Message{
+				{User: "U12345678", Text: "Gracias por la ayuda, el despliegue está listo", Timestamp: "1355517524.000001"},
+				{User: "U87654321", Text: "ok", Timestamp: "1355517523.000008"},
+			}, false, nil
+		},
+	}
+
+	handler := NewListChannelMessagesHandler(mock)
+	request := createListChannelMessagesRequest(map[string]interface{}{
+		"channel_id":      "C01234567",
+		"detect_language": true,
+	})
+
+	result, err := handler.Handle(context.Background(), request)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("expected success, got error: %+v", result.Content)
+	}
+
+	textContent := result.Content[0].(mcp.TextContent)
+	var listResult types.ListChannelMessagesResult
+	if err := json.Unmarshal([]byte(textContent.Text), &listResult); err != nil {
+		t.Fatalf("failed to parse result JSON: %v", err)
+	}
+
+	if listResult.Messages[0].Lang != "es" {
+		t.Errorf("expected lang 'es', got %q", listResult.Messages[0].Lang)
+	}
+	// Too short to detect reliably
+	if listResult.Messages[1].Lang != "" {
+		t.Errorf("expected no lang for short message, got %q", listResult.Messages[1].Lang)
+	}
+}
+
+// TestListChannelMessagesHandler_Handle_ExtractReferences tests that issue references are listed when requested.
+func TestListChannelMessagesHandler_Handle_ExtractReferences(t *testing.T) {
+	mock := &mockSlackClient{
+		getChannelHistory: func(ctx context.Context, channelID string, opts slackclient.HistoryOptions) ([]types.Message, bool, error) {
+			return []types.Message{
+				{User: "U12345678", Text: "OPS-42 is fixed by <https://github.com/acme/api/pull/7>", Timestamp: "1355517524.000001"},
+				{User: "U87654321", Text: "nothing to see", Timestamp: "1355517523.000008"},
+			}, false, nil
+		},
+	}
+
+	trackers, err := references.ParseTrackers(`ops=\bOPS-[0-9]+\b;pr=github\.com/([\w-]+/[\w-]+/pull/[0-9]+)`)
+	if err != nil {
+		t.Fatalf("ParseTrackers() error = %v", err)
+	}
+	handler := NewListChannelMessagesHandler(mock, WithReferenceTrackers(trackers))
+
+	result, err := handler.Handle(context.Background(), createListChannelMessagesRequest(map[string]interface{}{
+		"channel_id":         "C01234567",
+		"extract_references": true,
+	}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("expected success, got error: %+v", result.Content)
+	}
+
+	var listResult types.ListChannelMessagesResult
+	if err := json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &listResult); err != nil {
+		t.Fatalf("failed to parse result JSON: %v", err)
+	}
+
+	want := []types.Reference{{Tracker: "ops", ID: "OPS-42"}, {Tracker: "pr", ID: "acme/api/pull/7"}}
+	if !reflect.DeepEqual(listResult.Messages[0].References, want) {
+		t.Errorf("references = %+v, want %+v", listResult.Messages[0].References, want)
+	}
+	if listResult.Messages[1].References != nil {
+		t.Errorf("expected no references, got %+v", listResult.Messages[1].References)
+	}
+
+	// Combining with summary mode is rejected, since summary lines have no references
+	result, err = handler.Handle(context.Background(), createListChannelMessagesRequest(map[string]interface{}{
+		"channel_id":         "C01234567",
+		"extract_references": true,
+		"summary":            true,
+	}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !result.IsError {
+		t.Error("expected error combining extract_references with summary")
+	}
+}
+
+func TestListChannelMessagesHandler_Handle_IncludeRaw(t *testing.T) {
+	raw := json.RawMessage(`{"type":"message","user":"U12345678","text":"a &amp; b","ts":"1355517523.000008","client_msg_id":"abc"}`)
+	mock := &mockSlackClient{
+		getChannelHistory: func(ctx context.Context, channelID string, opts slackclient.HistoryOptions) ([]types.Message, bool, error) {
+			return []types.Message{{User: "U12345678", Text: "a & b", Timestamp: "1355517523.000008", Raw: raw}}, false, nil
+		},
+	}
+	handler := NewListChannelMessagesHandler(mock)
+
+	for _, includeRaw := range []bool{false, true} {
+		result, err := handler.Handle(context.Background(), createListChannelMessagesRequest(map[string]interface{}{
+			"channel_id":  "C01234567",
+			"include_raw": includeRaw,
+		}))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if result.IsError {
+			t.Fatalf("expected success, got error: %+v", result.Content)
+		}
+
+		var listResult types.ListChannelMessagesResult
+		if err := json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &listResult); err != nil {
+			t.Fatalf("failed to parse result JSON: %v", err)
+		}
+		got := listResult.Messages[0].Raw
+		if includeRaw {
+			var payload map[string]interface{}
+			if err := json.Unmarshal(got, &payload); err != nil || payload["client_msg_id"] != "abc" || payload["text"] != "a &amp; b" {
+				t.Errorf("include_raw: raw = %s (%v), want the Slack payload", got, err)
+			}
+		}
+		if !includeRaw && got != nil {
+			t.Errorf("expected no raw payload by default, got %s", got)
+		}
+	}
+
+	// Combining with summary mode is rejected, since summary lines have no payload
+	result, err := handler.Handle(context.Background(), createListChannelMessagesRequest(map[string]interface{}{
+		"channel_id":  "C01234567",
+		"include_raw": true,
+		"summary":     true,
+	}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !result.IsError {
+		t.Error("expected error combining include_raw with summary")
+	}
+}
+
+func TestListChannelMessagesHandler_Handle_Fields(t *testing.T) {
+	mock := &mockSlackClient{
+		getChannelHistory: func(ctx context.Context, channelID string, opts slackclient.HistoryOptions) ([]types.Message, bool, error) {
+			return []types.Message{
+				{User: "U12345678", Text: "Hello world", Timestamp: "1355517523.000008", ReplyCount: 2},
+			}, false, nil
+		},
+		getUserInfo: func(ctx context.Context, userID string) (*types.UserInfo, error) {
+			return &types.UserInfo{ID: userID, Name: "jsmith", DisplayName: "John", RealName: "John Smith"}, nil
+		},
+	}
+
+	handler := NewListChannelMessagesHandler(mock)
+
+	t.Run("trims message objects to the requested fields", func(t *testing.T) {
+		result, err := handler.Handle(context.Background(), createListChannelMessagesRequest(map[string]interface{}{
+			"channel_id": "C01234567",
+			"fields":     []interface{}{"user_name", "text"},
+		}))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if result.IsError {
+			t.Fatalf("expected success, got error: %+v", result.Content)
+		}
+
+		var generic struct {
+			ChannelID string                   `json:"channel_id"`
+			Messages  []map[string]interface{} `json:"messages"`
+		}
+		if err := json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &generic); err != nil {
+			t.Fatalf("failed to parse result JSON: %v", err)
+		}
+
+		if generic.ChannelID != "C01234567" {
+			t.Errorf("top-level fields should be kept, got channel_id %q", generic.ChannelID)
+		}
+		if len(generic.Messages) != 1 {
+			t.Fatalf("expected 1 message, got %d", len(generic.Messages))
+		}
+		msg := generic.Messages[0]
+		if len(msg) != 2 || msg["user_name"] != "jsmith" || msg["text"] != "Hello world" {
+			t.Errorf("expected only user_name and text, got %v", msg)
+		}
+	})
+
+	t.Run("unknown field is rejected", func(t *testing.T) {
+		result, err := handler.Handle(context.Background(), createListChannelMessagesRequest(map[string]interface{}{
+			"channel_id": "C01234567",
+			"fields":     []interface{}{"author"},
+		}))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !result.IsError {
+			t.Fatal("expected error result for unknown field")
+		}
+		text := result.Content[0].(mcp.TextContent).Text
+		if !strings.Contains(text, `unknown field "author"`) || !strings.Contains(text, "user_name") {
+			t.Errorf("error should name the unknown field and list valid fields, got: %s", text)
+		}
+	})
+
+	t.Run("non-array fields is rejected", func(t *testing.T) {
+		result, err := handler.Handle(context.Background(), createListChannelMessagesRequest(map[string]interface{}{
+			"channel_id": "C01234567",
+			"fields":     "text",
+		}))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !result.IsError {
+			t.Fatal("expected error result for non-array fields")
+		}
+	})
+}
+
+func TestSummarizeMessage(t *testing.T) {
+	tests := []struct {
+		name string
+		msg  types.Message
+		want string
+	}{
+		{
+			name: "resolved author with counts",
+			msg: types.Message{
+				User:          "U12345678",
+				UserName:      "jsmith",
+				Text:          "Deploy is done,\nplease verify",
+				Timestamp:     "1705314600.123456",
+				ReplyCount:    3,
+				ReactionCount: 1,
+			},
+			want: "2024-01-15 10:30 jsmith: Deploy is done, please verify [3 replies, 1 reaction] (ts 1705314600.123456)",
+		},
+		{
+			name: "unresolved author without counts",
+			msg: types.Message{
+				User:      "U12345678",
+				Text:      "Hello",
+				Timestamp: "1705314600.123456",
+			},
+			want: "2024-01-15 10:30 U12345678: Hello (ts 1705314600.123456)",
+		},
+		{
+			name: "long text is truncated",
+			msg: types.Message{
+				UserName:  "jsmith",
+				Text:      strings.Repeat("a", 130),
+				Timestamp: "1705314600.123456",
+			},
+			want: "2024-01-15 10:30 jsmith: " + strings.Repeat("a", 120) + "… (ts 1705314600.123456)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := summarizeMessage(&tt.msg); got != tt.want {
+				t.Errorf("summarizeMessage() =\n%q\nwant\n%q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestListChannelMessagesHandler_Handle_Summary(t *testing.T) {
+	mock := &mockSlackClient{
+		getChannelHistory: func(ctx context.Context, channelID string, opts slackclient.HistoryOptions) ([]types.Message, bool, error) {
+			return []types.Message{
+				{User: "U12345678", Text: "Second", Timestamp: "1705314660.000002"},
+				{User: "U12345678", Text: "First", Timestamp: "1705314600.000001", ReplyCount: 1},
+			}, true, nil
+		},
+		getUserInfo: func(ctx context.Context, userID string) (*types.UserInfo, error) {
+			return &types.UserInfo{ID: userID, Name: "jsmith"}, nil
+		},
+	}
+
+	handler := NewListChannelMessagesHandler(mock)
+	result, err := handler.Handle(context.Background(), createListChannelMessagesRequest(map[string]interface{}{
+		"channel_id": "C01234567",
+		"summary":    true,
+	}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("expected success, got error: %+v", result.Content)
+	}
+
+	var summaryResult types.ListChannelMessagesSummaryResult
+	if err := json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &summaryResult); err != nil {
+		t.Fatalf("failed to parse result JSON: %v", err)
+	}
+
+	want := []string{
+		"2024-01-15 10:31 jsmith: Second (ts 1705314660.000002)",
+		"2024-01-15 10:30 jsmith: First [1 reply] (ts 1705314600.000001)",
+	}
+	if len(summaryResult.Messages) != len(want) {
+		t.Fatalf("expected %d lines, got %v", len(want), summaryResult.Messages)
+	}
+	for i := range want {
+		if summaryResult.Messages[i] != want[i] {
+			t.Errorf("line %d = %q, want %q", i, summaryResult.Messages[i], want[i])
+		}
+	}
+	if !summaryResult.HasMore {
+		t.Error("expected has_more to be preserved")
+	}
+}
+
+func TestListChannelMessagesHandler_Handle_MinScore(t *testing.T) {
+	mock := &mockSlackClient{
+		getChannelHistory: func(ctx context.Context, channelID string, opts slackclient.HistoryOptions) ([]types.Message, bool, error) {
+			return []types.Message{
+				{User: "U00000001", Text: "lunch?", Timestamp: "1705314720.000003"},
+				{User: "U00000002", Text: "<@UBOT00001> can you review the rollout plan?", Timestamp: "1705314660.000002"},
+				{User: "U00000003", Text: "Release is blocked", Timestamp: "1705314600.000001", ReplyCount: 4, ReactionCount: 3},
+				{BotID: "B00000001", Text: "Build passed", Timestamp: "1705314540.000000", ReactionCount: 1},
+			}, false, nil
+		},
+		getUserInfo: func(ctx context.Context, userID string) (*types.UserInfo, error) {
+			return &types.UserInfo{ID: userID, Name: "user", IsOwner: userID == "U00000003"}, nil
+		},
+		getCurrentUser: func(ctx context.Context) (*types.UserInfo, error) {
+			return &types.UserInfo{ID: "UBOT00001", Name: "bot", IsBot: true}, nil
+		},
+	}
+
+	handler := NewListChannelMessagesHandler(mock)
+	result, err := handler.Handle(context.Background(), createListChannelMessagesRequest(map[string]interface{}{
+		"channel_id": "C01234567",
+		"min_score":  float64(10),
+	}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("expected success, got error: %+v", result.Content)
+	}
+
+	var listResult types.ListChannelMessagesResult
+	if err := json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &listResult); err != nil {
+		t.Fatalf("failed to parse result JSON: %v", err)
+	}
+
+	// The mention scores 25; the owner's discussed message scores 6 + 12 + 10
+	want := map[string]int{"1705314660.000002": 25, "1705314600.000001": 28}
+	if len(listResult.Messages) != len(want) {
+		t.Fatalf("expected %d messages, got %+v", len(want), listResult.Messages)
+	}
+	for _, msg := range listResult.Messages {
+		if msg.Score == nil || *msg.Score != want[msg.Timestamp] {
+			t.Errorf("message %s score = %v, want %d", msg.Timestamp, msg.Score, want[msg.Timestamp])
+		}
+	}
+}
+
+func TestListChannelMessagesHandler_Handle_UserReference(t *testing.T) {
+	tests := []struct {
+		name      string
+		channelID string
+	}{
+		{name: "user ID", channelID: "U01234567"},
+		{name: "team URL", channelID: "https://workspace.slack.com/team/U01234567"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// A user names a person, not a conversation: no DM is opened or read
+			mock := &mockSlackClient{
+				openDMChannel: func(ctx context.Context, userID string) (string, error) {
+					t.Error("expected no DM to be opened")
+					return "D99999999", nil
+				},
+				getChannelHistory: func(ctx context.Context, channelID string, opts slackclient.HistoryOptions) ([]types.Message, bool, error) {
+					t.Errorf("expected no history read, got one of %s", channelID)
+					return []types.Message{}, false, nil
+				},
+			}
+			handler := NewListChannelMessagesHandler(mock)
+
+			result, err := handler.Handle(context.Background(), createListChannelMessagesRequest(map[string]interface{}{
+				"channel_id": tt.channelID,
+			}))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			text := result.Content[0].(mcp.TextContent).Text
+			if !result.IsError || !strings.Contains(text, "read_dm_history") {
+				t.Errorf("expected an error pointing to read_dm_history, got: %s", text)
+			}
+		})
+	}
+}
+
+func TestListChannelMessagesHandler_Handle_ChannelName(t *testing.T) {
+	tests := []struct {
+		name      string
+		channelID string
+		wantError string
+	}{
+		{name: "with hash", channelID: "#General"},
+		{name: "without hash", channelID: "general"},
+		{name: "unknown name", channelID: "#nonexistent", wantError: "no channel named #nonexistent"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var historyChannel string
+			mock := &mockSlackClient{
+				resolveChannelName: func(ctx context.Context, name string) (string, error) {
+					if name != "general" {
+						return "", types.NewSlackError(types.ErrCodeChannelNotFound, "no channel named #"+name+" is visible to the bot")
+					}
+					return "C01234567", nil
+				},
+				getChannelHistory: func(ctx context.Context, channelID string, opts slackclient.HistoryOptions) ([]types.Message, bool, error) {
+					historyChannel = channelID
+					return []types.Message{}, false, nil
+				},
+			}
+			handler := NewListChannelMessagesHandler(mock)
+
+			result, err := handler.Handle(context.Background(), createListChannelMessagesRequest(map[string]interface{}{
+				"channel_id": tt.channelID,
+			}))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			text := result.Content[0].(mcp.TextContent).Text
+			if tt.wantError != "" {
+				if !result.IsError || !strings.Contains(text, tt.wantError) {
+					t.Errorf("expected error containing %q, got: %s", tt.wantError, text)
+				}
+				return
+			}
+			if result.IsError {
+				t.Fatalf("unexpected error result: %s", text)
+			}
+			if historyChannel != "C01234567" {
+				t.Errorf("history fetched for %q, want %q", historyChannel, "C01234567")
+			}
+		})
+	}
+}
+
+func TestListChannelMessagesHandler_Handle_GroupDMParticipants(t *testing.T) {
+	tests := []struct {
+		name       string
+		membersErr error
+		want       []string
+	}{
+		{name: "members resolved", want: []string{"U01111111:alice", "U02222222:bob", "U03333333:"}},
+		{name: "members unavailable", membersErr: types.NewSlackError(types.ErrCodeMissingScope, "missing mpim:read"),
+			want: []string{":alice", ":bob", ":carol"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := &mockSlackClient{
+				getChannelHistory: func(ctx context.Context, channelID string, opts slackclient.HistoryOptions) ([]types.Message, bool, error) {
+					return []types.Message{{User: "U01111111", Text: "lunch?", Timestamp: "1700000000.000100"}}, false, nil
+				},
+				getChannelInfo: func(ctx context.Context, channelID string) (*types.ChannelInfo, error) {
+					return &types.ChannelInfo{ID: channelID, Name: "mpdm-alice--bob--carol-1", IsDM: true, Type: types.ChannelTypeMPIM}, nil
+				},
+				getConversationMembers: func(ctx context.Context, channelID string) ([]string, error) {
+					return []string{"U01111111", "U02222222", "U03333333"}, tt.membersErr
+				},
+				getUserInfo: func(ctx context.Context, userID string) (*types.UserInfo, error) {
+					names := map[string]string{"U01111111": "alice", "U02222222": "bob"}
+					if name, ok := names[userID]; ok {
+						return &types.UserInfo{ID: userID, Name: name}, nil
+					}
+					return nil, types.NewSlackError(types.ErrCodeSlackError, "Slack API error: user_not_found")
+				},
+			}
+
+			handler := NewListChannelMessagesHandler(mock)
+			result, err := handler.Handle(context.Background(), createListChannelMessagesRequest(map[string]interface{}{
+				"channel_id": "G01234567",
+			}))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			text := result.Content[0].(mcp.TextContent).Text
+			if result.IsError {
+				t.Fatalf("expected success, got error: %s", text)
+			}
+
+			var listing types.ListChannelMessagesResult
+			if err := json.Unmarshal([]byte(text), &listing); err != nil {
+				t.Fatalf("failed to parse result JSON: %v", err)
+			}
+			var got []string
+			for _, participant := range listing.Participants {
+				got = append(got, participant.ID+":"+participant.Name)
+			}
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("participants = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,203 @@
+// Package tools provides MCP tool handler implementations for the Slack MCP server.
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	slackclient "github.com/Bitovi/slack-mcp-server/internal/slack"
+	"github.com/Bitovi/slack-mcp-server/pkg/types"
+)
+
+const (
+	// defaultListChannelsLimit is the number of channels returned when no limit is given.
+	defaultListChannelsLimit = 100
+	// maxListChannelsLimit caps the channels returned in one call.
+	maxListChannelsLimit = 1000
+)
+
+// channelTypeNames maps the values accepted by the list_channels 'types' argument
+// to conversation types. The Slack API names are accepted too.
+var channelTypeNames = map[string]string{
+	"public":                 types.ChannelTypePublic,
+	"private":                types.ChannelTypePrivate,
+	"im":                     types.ChannelTypeIM,
+	"mpim":                   types.ChannelTypeMPIM,
+	types.ChannelTypePublic:  types.ChannelTypePublic,
+	types.ChannelTypePrivate: types.ChannelTypePrivate,
+}
+
+// ListChannelsHandler handles the list_channels MCP tool requests.
+// It lists the conversations visible to the bot, so that agents can discover
+// channel IDs to pass to the other tools.
+type ListChannelsHandler struct {
+	// slackClient is the Slack API client for listing channels.
+	slackClient slackclient.ClientInterface
+	// config holds optional handler behavior.
+	config handlerConfig
+}
+
+// NewListChannelsHandler creates a new ListChannelsHandler with the given Slack client and options.
+func NewListChannelsHandler(client slackclient.ClientInterface, opts ...HandlerOption) *ListChannelsHandler {
+	return &ListChannelsHandler{
+		slackClient: client,
+		config:      newHandlerConfig(opts),
+	}
+}
+
+// Handle processes a list_channels tool call.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeouts
+//   - request: The MCP tool call request containing optional types,
+//     exclude_archived, member_only, name, limit, and cursor
+//
+// Returns an MCP tool result listing the matching channels and a cursor for the
+// next page, or an error result if the arguments are invalid or the listing fails.
+func (h *ListChannelsHandler) Handle(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := request.Params.Arguments
+	opts := slackclient.ListChannelsOptions{
+		ExcludeArchived: true,
+		Limit:           defaultListChannelsLimit,
+	}
+
+	// Extract types parameter (optional, default public and private channels)
+	if typesArg, exists := args["types"]; exists {
+		items, ok := typesArg.([]interface{})
+		if !ok {
+			return mcp.NewToolResultError("argument 'types' must be an array of strings"), nil
+		}
+		for i, item := range items {
+			name, ok := item.(string)
+			if !ok {
+				return mcp.NewToolResultError(fmt.Sprintf("argument 'types' item %d must be a string", i)), nil
+			}
+			conversationType, ok := channelTypeNames[strings.ToLower(strings.TrimSpace(name))]
+			if !ok {
+				return mcp.NewToolResultError(fmt.Sprintf(
+					"unknown channel type %q in 'types'; valid types are: public, private, im, mpim", name)), nil
+			}
+			if !containsString(opts.Types, conversationType) {
+				opts.Types = append(opts.Types, conversationType)
+			}
+		}
+	}
+
+	// Extract exclude_archived and member_only parameters (optional booleans)
+	for _, optional := range []struct {
+		name   string
+		target *bool
+	}{
+		{"exclude_archived", &opts.ExcludeArchived},
+		{"member_only", &opts.MemberOnly},
+	} {
+		arg, exists := args[optional.name]
+		if !exists {
+			continue
+		}
+		v, ok := arg.(bool)
+		if !ok {
+			return mcp.NewToolResultError(fmt.Sprintf("argument '%s' must be a boolean", optional.name)), nil
+		}
+		*optional.target = v
+	}
+
+	// Extract name and cursor parameters (optional strings)
+	for _, optional := range []struct {
+		name   string
+		target *string
+	}{
+		{"name", &opts.NameContains},
+		{"cursor", &opts.Cursor},
+	} {
+		arg, exists := args[optional.name]
+		if !exists {
+			continue
+		}
+		v, ok := arg.(string)
+		if !ok {
+			return mcp.NewToolResultError(fmt.Sprintf("argument '%s' must be a string", optional.name)), nil
+		}
+		*optional.target = strings.TrimSpace(v)
+	}
+	// Channel names are written without the # in conversations.list
+	opts.NameContains = strings.TrimPrefix(opts.NameContains, "#")
+
+	// Extract limit parameter (optional)
+	if limitArg, exists := args["limit"]; exists {
+		v, ok := limitArg.(float64)
+		if !ok {
+			return mcp.NewToolResultError("argument 'limit' must be a number"), nil
+		}
+		if v < 1 || v > maxListChannelsLimit {
+			return mcp.NewToolResultError(fmt.Sprintf("argument 'limit' must be between 1 and %d", maxListChannelsLimit)), nil
+		}
+		opts.Limit = int(v)
+	}
+
+	channels, nextCursor, err := h.slackClient.ListChannels(ctx, opts)
+	if err != nil {
+		return h.handleError(err), nil
+	}
+
+	result := &types.ListChannelsResult{
+		Channels:   make([]types.ListedChannel, 0, len(channels)),
+		NextCursor: nextCursor,
+	}
+	for _, channel := range channels {
+		result.Channels = append(result.Channels, types.ListedChannel{
+			ChannelInfo: *channel.Channel,
+			IsMember:    channel.BotIsMember,
+		})
+	}
+
+	result.Workspace = workspaceFor(ctx, h.slackClient)
+
+	return h.successResult(result)
+}
+
+// handleError converts errors to appropriate MCP error results.
+func (h *ListChannelsHandler) handleError(err error) *mcp.CallToolResult {
+	// Check for known error types and provide appropriate messages
+	if slackclient.IsRateLimited(err) {
+		return mcp.NewToolResultError(
+			"Rate limit exceeded. Please wait and try again.")
+	}
+
+	if slackclient.IsInvalidToken(err) {
+		return mcp.NewToolResultError(
+			"Authentication failed. Please check that SLACK_BOT_TOKEN is valid and not expired.")
+	}
+
+	if slackclient.IsMissingScope(err) {
+		return mcp.NewToolResultError(err.Error())
+	}
+
+	if strings.Contains(err.Error(), "invalid_cursor") {
+		return mcp.NewToolResultError(
+			"Invalid cursor. Pass the next_cursor returned by the previous list_channels call, with the same filters.")
+	}
+
+	// Generic error handling
+	return mcp.NewToolResultError(fmt.Sprintf("Failed to list channels: %s", err.Error()))
+}
+
+// successResult creates a successful MCP tool result with the given data.
+func (h *ListChannelsHandler) successResult(result *types.ListChannelsResult) (*mcp.CallToolResult, error) {
+	resultJSON, err := json.Marshal(result)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to encode result: %s", err.Error())), nil
+	}
+
+	return mcp.NewToolResultText(string(resultJSON)), nil
+}
+
+// HandleFunc returns a function that can be used directly as an MCP tool handler.
+// This is a convenience method for registering the handler with the MCP server.
+func (h *ListChannelsHandler) HandleFunc() func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return h.Handle
+}
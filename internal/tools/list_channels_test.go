@@ -0,0 +1,127 @@
+// Package tools provides unit tests for the MCP tool handlers.
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	slackclient "github.com/Bitovi/slack-mcp-server/internal/slack"
+	"github.com/Bitovi/slack-mcp-server/pkg/types"
+)
+
+func TestListChannelsHandler_Handle(t *testing.T) {
+	general := types.ChannelAccess{
+		Channel:     &types.ChannelInfo{ID: "C01234567", Name: "general", Type: types.ChannelTypePublic},
+		BotIsMember: true,
+	}
+
+	tests := []struct {
+		name       string
+		args       map[string]interface{}
+		listErr    error
+		wantOpts   slackclient.ListChannelsOptions
+		wantCursor string
+		wantError  string
+	}{
+		{
+			name:     "defaults",
+			args:     map[string]interface{}{},
+			wantOpts: slackclient.ListChannelsOptions{ExcludeArchived: true, Limit: defaultListChannelsLimit},
+		},
+		{
+			name: "all filters",
+			args: map[string]interface{}{
+				"types":            []interface{}{"private", "mpim", "private"},
+				"exclude_archived": false,
+				"member_only":      true,
+				"name":             " #Gen ",
+				"limit":            float64(20),
+				"cursor":           "dGVhbTpDMDE=",
+			},
+			wantOpts: slackclient.ListChannelsOptions{
+				Types:        []string{types.ChannelTypePrivate, types.ChannelTypeMPIM},
+				MemberOnly:   true,
+				NameContains: "Gen",
+				Limit:        20,
+				Cursor:       "dGVhbTpDMDE=",
+			},
+			wantCursor: "next",
+		},
+		{
+			name:      "unknown type",
+			args:      map[string]interface{}{"types": []interface{}{"shared"}},
+			wantError: "unknown channel type",
+		},
+		{
+			name:      "limit out of range",
+			args:      map[string]interface{}{"limit": float64(5000)},
+			wantError: "argument 'limit' must be between 1 and 1000",
+		},
+		{
+			name:      "missing scope",
+			args:      map[string]interface{}{},
+			listErr:   types.NewSlackError(types.ErrCodeMissingScope, "missing_scope (needed: channels:read)"),
+			wantError: "channels:read",
+		},
+		{
+			name:      "invalid cursor",
+			args:      map[string]interface{}{"cursor": "bogus"},
+			listErr:   types.NewSlackError(types.ErrCodeSlackError, "Slack API error: invalid_cursor"),
+			wantError: "Invalid cursor",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotOpts slackclient.ListChannelsOptions
+			mock := &mockSlackClient{
+				listChannels: func(ctx context.Context, opts slackclient.ListChannelsOptions) ([]types.ChannelAccess, string, error) {
+					gotOpts = opts
+					if tt.listErr != nil {
+						return nil, "", tt.listErr
+					}
+					return []types.ChannelAccess{general}, tt.wantCursor, nil
+				},
+			}
+
+			handler := NewListChannelsHandler(mock)
+			result, err := handler.Handle(context.Background(), createToolRequest(tt.args))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			text := result.Content[0].(mcp.TextContent).Text
+			if tt.wantError != "" {
+				if !result.IsError || !strings.Contains(text, tt.wantError) {
+					t.Errorf("expected error containing %q, got: %s", tt.wantError, text)
+				}
+				return
+			}
+			if result.IsError {
+				t.Fatalf("expected success, got error: %s", text)
+			}
+			if !reflect.DeepEqual(gotOpts, tt.wantOpts) {
+				t.Errorf("options = %+v, want %+v", gotOpts, tt.wantOpts)
+			}
+
+			var listResult types.ListChannelsResult
+			if err := json.Unmarshal([]byte(text), &listResult); err != nil {
+				t.Fatalf("failed to parse result JSON: %v", err)
+			}
+			if len(listResult.Channels) != 1 || listResult.Channels[0].ID != "C01234567" || !listResult.Channels[0].IsMember {
+				t.Errorf("unexpected channels: %+v", listResult.Channels)
+			}
+			if listResult.NextCursor != tt.wantCursor {
+				t.Errorf("next_cursor = %q, want %q", listResult.NextCursor, tt.wantCursor)
+			}
+			if listResult.Workspace == nil || listResult.Workspace.TeamID != "T01234567" {
+				t.Errorf("expected workspace info, got %+v", listResult.Workspace)
+			}
+		})
+	}
+}
@@ -0,0 +1,349 @@
+// Package tools provides MCP tool handler implementations for the Slack MCP server.
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	slackclient "github.com/Bitovi/slack-mcp-server/internal/slack"
+	"github.com/Bitovi/slack-mcp-server/internal/urlparser"
+	"github.com/Bitovi/slack-mcp-server/pkg/types"
+)
+
+const (
+	// defaultLinkScanMessages is the number of channel messages scanned when no limit is given.
+	defaultLinkScanMessages = 500
+	// maxLinkScanMessages caps the channel messages scanned in one call.
+	maxLinkScanMessages = 1000
+	// maxLinkScanThreads caps the threads whose replies are scanned when include_threads is set.
+	maxLinkScanThreads = 20
+)
+
+// sharedLinkPattern matches a web link in Slack message text, as <url> or <url|label>.
+var sharedLinkPattern = regexp.MustCompile(`<(https?://[^|>\s]+)(?:\|[^>]*)?>`)
+
+// ListSharedLinksHandler handles the list_shared_links MCP tool requests.
+// It scans a channel's messages in a time range and returns every URL shared in
+// them, deduplicated, with how often and by whom each was shared and when it
+// first appeared.
+type ListSharedLinksHandler struct {
+	// slackClient is the Slack API client for reading channel history.
+	slackClient slackclient.ClientInterface
+	// config holds optional handler behavior.
+	config handlerConfig
+}
+
+// NewListSharedLinksHandler creates a new ListSharedLinksHandler with the given Slack client and options.
+func NewListSharedLinksHandler(client slackclient.ClientInterface, opts ...HandlerOption) *ListSharedLinksHandler {
+	return &ListSharedLinksHandler{
+		slackClient: client,
+		config:      newHandlerConfig(opts),
+	}
+}
+
+// Handle processes a list_shared_links tool call.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeouts
+//   - request: The MCP tool call request containing channel_id and optional
+//     oldest, latest, limit, and include_threads
+//
+// Returns an MCP tool result containing the shared links, most shared first, or an
+// error result if the arguments are invalid or the channel cannot be read.
+func (h *ListSharedLinksHandler) Handle(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	// Extract channel_id (required)
+	channelIDArg, ok := request.Params.Arguments["channel_id"]
+	if !ok {
+		return mcp.NewToolResultError("missing required argument 'channel_id'"), nil
+	}
+
+	channelID, ok := channelIDArg.(string)
+	if !ok {
+		return mcp.NewToolResultError("argument 'channel_id' must be a string"), nil
+	}
+	channelID = strings.TrimSpace(channelID)
+	if channelID == "" {
+		return mcp.NewToolResultError("argument 'channel_id' cannot be empty"), nil
+	}
+
+	channelID, errResult := resolveChannelRef(ctx, h.slackClient, channelID, h.handleError)
+	if errResult != nil {
+		return errResult, nil
+	}
+
+	// Extract limit parameter (optional)
+	limit := defaultLinkScanMessages
+	if limitArg, exists := request.Params.Arguments["limit"]; exists {
+		v, ok := limitArg.(float64)
+		if !ok {
+			return mcp.NewToolResultError("argument 'limit' must be a number"), nil
+		}
+		if v < 1 || v > maxLinkScanMessages {
+			return mcp.NewToolResultError(fmt.Sprintf("argument 'limit' must be between 1 and %d", maxLinkScanMessages)), nil
+		}
+		limit = int(v)
+	}
+
+	// Extract oldest and latest parameters (optional Unix timestamps)
+	var oldest, latest string
+	for _, optional := range []struct {
+		name   string
+		target *string
+	}{
+		{"oldest", &oldest},
+		{"latest", &latest},
+	} {
+		name, target := optional.name, optional.target
+		arg, exists := request.Params.Arguments[name]
+		if !exists {
+			continue
+		}
+		v, ok := arg.(string)
+		if !ok {
+			return mcp.NewToolResultError(fmt.Sprintf("argument '%s' must be a string (Unix timestamp)", name)), nil
+		}
+		if _, ok := parseSlackTime(v); !ok {
+			return mcp.NewToolResultError(fmt.Sprintf("argument '%s' must be a Unix timestamp, got %q", name, v)), nil
+		}
+		*target = v
+	}
+
+	// Extract include_threads parameter (optional, default false)
+	includeThreads := false
+	if threadsArg, exists := request.Params.Arguments["include_threads"]; exists {
+		v, ok := threadsArg.(bool)
+		if !ok {
+			return mcp.NewToolResultError("argument 'include_threads' must be a boolean"), nil
+		}
+		includeThreads = v
+	}
+
+	messages, hasMore, err := h.slackClient.GetChannelHistory(ctx, channelID, slackclient.HistoryOptions{
+		Limit:  limit,
+		Oldest: oldest,
+		Latest: latest,
+	})
+	if err != nil {
+		return h.handleError(err), nil
+	}
+
+	result := &types.ListSharedLinksResult{
+		ChannelID: channelID,
+		HasMore:   hasMore,
+	}
+
+	// Scan thread replies too if requested, for the first threads only
+	scanned := messages
+	if includeThreads {
+		threads := 0
+		for _, message := range messages {
+			if message.ReplyCount == 0 {
+				continue
+			}
+			if threads == maxLinkScanThreads {
+				result.Warnings = append(result.Warnings, types.Warning{
+					Code: types.WarnCodeResultsTruncated,
+					Message: fmt.Sprintf("replies were scanned in the %d most recent threads only; "+
+						"narrow the time range to scan the rest", maxLinkScanThreads),
+				})
+				break
+			}
+			threads++
+
+			thread, err := h.slackClient.GetThread(ctx, channelID, message.Timestamp)
+			if err != nil {
+				result.Warnings = append(result.Warnings, types.Warning{
+					Code:    types.WarnCodeThreadFetchFailed,
+					Message: fmt.Sprintf("could not scan replies to %s: %s", message.Timestamp, err.Error()),
+				})
+				continue
+			}
+			for _, reply := range thread {
+				// The thread includes its parent, which was already scanned
+				if reply.Timestamp != message.Timestamp {
+					scanned = append(scanned, reply)
+				}
+			}
+		}
+	}
+	result.MessagesScanned = len(scanned)
+
+	result.Workspace = workspaceFor(ctx, h.slackClient)
+	workspaceURL := ""
+	if result.Workspace != nil {
+		workspaceURL = result.Workspace.URL
+	}
+
+	links, firstThreads := collectSharedLinks(scanned)
+	result.Links = links
+	for i := range result.Links {
+		link := &result.Links[i]
+		if permalink, err := urlparser.Build(workspaceURL, channelID, link.FirstSeen, firstThreads[link.URL]); err == nil {
+			link.FirstSeenPermalink = permalink
+		}
+	}
+
+	// Resolve the links' authors (graceful degradation on failure)
+	resolution := newResolutionTracker()
+	result.UserMapping = h.buildUserMapping(ctx, result.Links, resolution)
+
+	// Flag or reject degraded results according to the resolution error policy
+	resolutionWarnings, err := resolution.apply(h.config.onResolutionError)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to resolve users: %s", err.Error())), nil
+	}
+	result.Warnings = append(result.Warnings, resolutionWarnings...)
+
+	return h.successResult(result)
+}
+
+// collectSharedLinks returns the distinct links in messages, most shared first and then
+// by first appearance. A link shared several times in one message is counted once.
+//
+// Also returns, by URL, the thread timestamp of links first shared in a thread reply,
+// for building the first share's permalink.
+func collectSharedLinks(messages []types.Message) ([]types.SharedLink, map[string]string) {
+	links := make(map[string]*types.SharedLink)
+	firstThreads := make(map[string]string)
+	authors := make(map[string]map[string]bool)
+
+	// Scan oldest first, so authors are listed in order of first share
+	ordered := make([]types.Message, len(messages))
+	copy(ordered, messages)
+	sort.SliceStable(ordered, func(i, j int) bool { return ordered[i].Timestamp < ordered[j].Timestamp })
+
+	for _, message := range ordered {
+		author := message.User
+		if author == "" {
+			author = message.BotID
+		}
+
+		seen := make(map[string]bool)
+		for _, match := range sharedLinkPattern.FindAllStringSubmatch(message.Text, -1) {
+			url := match[1]
+			if seen[url] {
+				continue
+			}
+			seen[url] = true
+
+			link, ok := links[url]
+			if !ok {
+				link = &types.SharedLink{URL: url, Authors: []string{}, FirstSeen: message.Timestamp}
+				links[url] = link
+				authors[url] = make(map[string]bool)
+				if message.ThreadTS != "" && message.ThreadTS != message.Timestamp {
+					firstThreads[url] = message.ThreadTS
+				}
+			}
+			link.Count++
+			if author != "" && !authors[url][author] {
+				authors[url][author] = true
+				link.Authors = append(link.Authors, author)
+			}
+		}
+	}
+
+	result := make([]types.SharedLink, 0, len(links))
+	for _, link := range links {
+		result = append(result, *link)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Count != result[j].Count {
+			return result[i].Count > result[j].Count
+		}
+		if result[i].FirstSeen != result[j].FirstSeen {
+			return result[i].FirstSeen < result[j].FirstSeen
+		}
+		return result[i].URL < result[j].URL
+	})
+	return result, firstThreads
+}
+
+// buildUserMapping resolves the user IDs among the links' authors. Bot IDs are skipped,
+// and users that cannot be resolved are mapped to an unresolvedUser placeholder.
+func (h *ListSharedLinksHandler) buildUserMapping(ctx context.Context, links []types.SharedLink, resolution *resolutionTracker) map[string]types.UserInfo {
+	var userIDs []string
+	for _, link := range links {
+		for _, userID := range link.Authors {
+			if strings.HasPrefix(userID, "U") || strings.HasPrefix(userID, "W") {
+				userIDs = append(userIDs, userID)
+			}
+		}
+	}
+	users := lookupUsers(ctx, h.slackClient, userIDs)
+
+	userMapping := make(map[string]types.UserInfo)
+	for _, userID := range userIDs {
+		if _, done := userMapping[userID]; done {
+			continue
+		}
+		userInfo, err := users[userID].info, users[userID].err
+		if err != nil {
+			// Graceful degradation: keep the user as a placeholder
+			resolution.recordUser(userID, err)
+			userInfo = unresolvedUser(userID)
+		}
+		if userInfo != nil {
+			userMapping[userID] = *userInfo
+		}
+	}
+
+	// Return nil if no users were resolved (to avoid empty map in JSON)
+	if len(userMapping) == 0 {
+		return nil
+	}
+	return userMapping
+}
+
+// handleError converts errors to appropriate MCP error results.
+func (h *ListSharedLinksHandler) handleError(err error) *mcp.CallToolResult {
+	// Check for known error types and provide appropriate messages
+	if slackclient.IsRateLimited(err) {
+		return mcp.NewToolResultError(
+			"Rate limit exceeded. Please wait and try again.")
+	}
+
+	if slackclient.IsInvalidToken(err) {
+		return mcp.NewToolResultError(
+			"Authentication failed. Please check that SLACK_BOT_TOKEN is valid and not expired.")
+	}
+
+	if slackclient.IsChannelNotFound(err) {
+		return mcp.NewToolResultError(
+			"Channel not found. The channel may have been deleted, or the channel_id is incorrect.")
+	}
+
+	if slackclient.IsNotInChannel(err) {
+		return mcp.NewToolResultError(
+			"The bot is not a member of this channel. Please invite the bot to the channel first.")
+	}
+
+	if slackclient.IsMissingScope(err) {
+		return mcp.NewToolResultError(err.Error())
+	}
+
+	// Generic error handling
+	return mcp.NewToolResultError(fmt.Sprintf("Failed to list shared links: %s", err.Error()))
+}
+
+// successResult creates a successful MCP tool result with the given data.
+func (h *ListSharedLinksHandler) successResult(result *types.ListSharedLinksResult) (*mcp.CallToolResult, error) {
+	resultJSON, err := json.Marshal(result)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to encode result: %s", err.Error())), nil
+	}
+
+	return mcp.NewToolResultText(string(resultJSON)), nil
+}
+
+// HandleFunc returns a function that can be used directly as an MCP tool handler.
+// This is a convenience method for registering the handler with the MCP server.
+func (h *ListSharedLinksHandler) HandleFunc() func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return h.Handle
+}
@@ -0,0 +1,141 @@
+// Package tools provides unit tests for the MCP tool handlers.
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	slackclient "github.com/Bitovi/slack-mcp-server/internal/slack"
+	"github.com/Bitovi/slack-mcp-server/pkg/types"
+)
+
+func TestListSharedLinksHandler_Handle(t *testing.T) {
+	mock := &mockSlackClient{
+		getChannelHistory: func(ctx context.Context, channelID string, opts slackclient.HistoryOptions) ([]types.Message, bool, error) {
+			if opts.Limit != defaultLinkScanMessages || opts.Oldest != "1700000000" {
+				t.Errorf("scanned limit %d from %q, want %d from 1700000000", opts.Limit, opts.Oldest, defaultLinkScanMessages)
+			}
+			// Newest first, as Slack returns them
+			return []types.Message{
+				{User: "U02222222", Text: "again <https://example.com/doc?a=1&b=2|the doc> and <https://example.com/doc?a=1&b=2>",
+					Timestamp: "1700000300.000100"},
+				{BotID: "B01111111", Text: "deploy log <https://ci.example.com/run/9>", Timestamp: "1700000200.000100", ReplyCount: 1},
+				{User: "U01111111", Text: "see <https://example.com/doc?a=1&b=2|the doc> <mailto:a@example.com|a@example.com>",
+					Timestamp: "1700000100.000100"},
+			}, false, nil
+		},
+		getThread: func(ctx context.Context, channelID, threadTS string) ([]types.Message, error) {
+			return []types.Message{
+				{BotID: "B01111111", Text: "deploy log <https://ci.example.com/run/9>", Timestamp: threadTS, ThreadTS: threadTS},
+				{User: "U01111111", Text: "retry: <https://ci.example.com/run/10>", Timestamp: "1700000250.000100", ThreadTS: threadTS},
+			}, nil
+		},
+		getUserInfo: func(ctx context.Context, userID string) (*types.UserInfo, error) {
+			return &types.UserInfo{ID: userID, Name: strings.ToLower(userID)}, nil
+		},
+		getWorkspaceInfo: func(ctx context.Context) (*types.WorkspaceInfo, error) {
+			return &types.WorkspaceInfo{TeamID: "T01234567", URL: "https://acme.slack.com/"}, nil
+		},
+	}
+
+	handler := NewListSharedLinksHandler(mock)
+	result, err := handler.Handle(context.Background(), createToolRequest(map[string]interface{}{
+		"channel_id":      "C01234567",
+		"oldest":          "1700000000",
+		"include_threads": true,
+	}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	text := result.Content[0].(mcp.TextContent).Text
+	if result.IsError {
+		t.Fatalf("expected success, got error: %s", text)
+	}
+
+	var links types.ListSharedLinksResult
+	if err := json.Unmarshal([]byte(text), &links); err != nil {
+		t.Fatalf("failed to parse result JSON: %v", err)
+	}
+
+	want := []types.SharedLink{
+		{
+			URL:                "https://example.com/doc?a=1&b=2",
+			Count:              2,
+			Authors:            []string{"U01111111", "U02222222"},
+			FirstSeen:          "1700000100.000100",
+			FirstSeenPermalink: "https://acme.slack.com/archives/C01234567/p1700000100000100",
+		},
+		{
+			URL:                "https://ci.example.com/run/9",
+			Count:              1,
+			Authors:            []string{"B01111111"},
+			FirstSeen:          "1700000200.000100",
+			FirstSeenPermalink: "https://acme.slack.com/archives/C01234567/p1700000200000100",
+		},
+		{
+			URL:       "https://ci.example.com/run/10",
+			Count:     1,
+			Authors:   []string{"U01111111"},
+			FirstSeen: "1700000250.000100",
+			FirstSeenPermalink: "https://acme.slack.com/archives/C01234567/p1700000250000100" +
+				"?thread_ts=1700000200.000100&cid=C01234567",
+		},
+	}
+	if !reflect.DeepEqual(links.Links, want) {
+		t.Errorf("links = %+v\nwant %+v", links.Links, want)
+	}
+	if links.MessagesScanned != 4 {
+		t.Errorf("messages_scanned = %d, want 4", links.MessagesScanned)
+	}
+	// Bot IDs are not looked up as users
+	if len(links.UserMapping) != 2 || links.UserMapping["U01111111"].Name != "u01111111" {
+		t.Errorf("unexpected user mapping %+v", links.UserMapping)
+	}
+}
+
+func TestListSharedLinksHandler_Handle_Errors(t *testing.T) {
+	tests := []struct {
+		name       string
+		args       map[string]interface{}
+		historyErr error
+		wantError  string
+	}{
+		{name: "missing channel", args: map[string]interface{}{}, wantError: "missing required argument 'channel_id'"},
+		{name: "limit too large", args: map[string]interface{}{"channel_id": "C01234567", "limit": float64(5000)}, wantError: "between 1 and 1000"},
+		{name: "invalid latest", args: map[string]interface{}{"channel_id": "C01234567", "latest": "now"}, wantError: "must be a Unix timestamp"},
+		{name: "invalid include_threads", args: map[string]interface{}{"channel_id": "C01234567", "include_threads": "yes"}, wantError: "must be a boolean"},
+		{
+			name:       "not in channel",
+			args:       map[string]interface{}{"channel_id": "C01234567"},
+			historyErr: types.NewSlackError(types.ErrCodeNotInChannel, "not_in_channel"),
+			wantError:  "not a member of this channel",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := &mockSlackClient{
+				getChannelHistory: func(ctx context.Context, channelID string, opts slackclient.HistoryOptions) ([]types.Message, bool, error) {
+					if tt.historyErr != nil {
+						return nil, false, tt.historyErr
+					}
+					return nil, false, errors.New("unexpected history read")
+				},
+			}
+
+			result, err := NewListSharedLinksHandler(mock).Handle(context.Background(), createToolRequest(tt.args))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if text := result.Content[0].(mcp.TextContent).Text; !result.IsError || !strings.Contains(text, tt.wantError) {
+				t.Errorf("expected error containing %q, got: %s", tt.wantError, text)
+			}
+		})
+	}
+}
@@ -0,0 +1,118 @@
+// Package tools provides MCP tool handler implementations for the Slack MCP server.
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	slackclient "github.com/Bitovi/slack-mcp-server/internal/slack"
+	"github.com/Bitovi/slack-mcp-server/pkg/types"
+)
+
+// ListWorkspacesHandler handles the list_workspaces MCP tool requests.
+// It lists the workspaces the bot token can access, and which one the other
+// tools act on, so that agents on Enterprise Grid or with several servers
+// configured can pick the right target explicitly.
+type ListWorkspacesHandler struct {
+	// slackClient is the Slack API client for listing workspaces.
+	slackClient slackclient.ClientInterface
+	// config holds optional handler behavior.
+	config handlerConfig
+}
+
+// NewListWorkspacesHandler creates a new ListWorkspacesHandler with the given Slack client and options.
+func NewListWorkspacesHandler(client slackclient.ClientInterface, opts ...HandlerOption) *ListWorkspacesHandler {
+	return &ListWorkspacesHandler{
+		slackClient: client,
+		config:      newHandlerConfig(opts),
+	}
+}
+
+// Handle processes a list_workspaces tool call. It takes no arguments.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeouts
+//   - request: The MCP tool call request
+//
+// Returns an MCP tool result listing the accessible workspaces, or an error result
+// if the connected workspace cannot be identified. If only the listing fails, the
+// connected workspace is returned with a workspace_list_failed warning.
+func (h *ListWorkspacesHandler) Handle(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	current, err := h.slackClient.GetWorkspaceInfo(ctx)
+	if err != nil {
+		return h.handleError(err), nil
+	}
+
+	result := &types.ListWorkspacesResult{Current: current}
+
+	workspaces, truncated, err := h.slackClient.ListWorkspaces(ctx)
+	if err != nil {
+		if slackclient.IsInvalidToken(err) {
+			return h.handleError(err), nil
+		}
+		result.Warnings = append(result.Warnings, types.Warning{
+			Code:    types.WarnCodeWorkspaceListFailed,
+			Message: fmt.Sprintf("could not list accessible workspaces, returning only the current one: %s", err.Error()),
+		})
+	}
+	if truncated {
+		result.Warnings = append(result.Warnings, types.Warning{
+			Code:    types.WarnCodeResultsTruncated,
+			Message: fmt.Sprintf("returned the first %d accessible workspaces", len(workspaces)),
+		})
+	}
+
+	// The current workspace is always listed, even if auth.teams.list omits it
+	result.Workspaces = workspaces
+	if !containsWorkspace(workspaces, current.TeamID) {
+		result.Workspaces = append([]types.WorkspaceInfo{*current}, workspaces...)
+	}
+
+	return h.successResult(result)
+}
+
+// containsWorkspace reports whether workspaces includes the workspace with teamID.
+func containsWorkspace(workspaces []types.WorkspaceInfo, teamID string) bool {
+	for _, workspace := range workspaces {
+		if workspace.TeamID == teamID {
+			return true
+		}
+	}
+	return false
+}
+
+// handleError converts errors to appropriate MCP error results.
+func (h *ListWorkspacesHandler) handleError(err error) *mcp.CallToolResult {
+	// Check for known error types and provide appropriate messages
+	if slackclient.IsRateLimited(err) {
+		return mcp.NewToolResultError(
+			"Rate limit exceeded. Please wait and try again.")
+	}
+
+	if slackclient.IsInvalidToken(err) {
+		return mcp.NewToolResultError(
+			"Authentication failed. Please check that SLACK_BOT_TOKEN is valid and not expired.")
+	}
+
+	// Generic error handling
+	return mcp.NewToolResultError(fmt.Sprintf("Failed to list workspaces: %s", err.Error()))
+}
+
+// successResult creates a successful MCP tool result with the given data.
+func (h *ListWorkspacesHandler) successResult(result *types.ListWorkspacesResult) (*mcp.CallToolResult, error) {
+	resultJSON, err := json.Marshal(result)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to encode result: %s", err.Error())), nil
+	}
+
+	return mcp.NewToolResultText(string(resultJSON)), nil
+}
+
+// HandleFunc returns a function that can be used directly as an MCP tool handler.
+// This is a convenience method for registering the handler with the MCP server.
+func (h *ListWorkspacesHandler) HandleFunc() func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return h.Handle
+}
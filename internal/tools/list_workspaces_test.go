@@ -0,0 +1,118 @@
+// Package tools provides unit tests for the MCP tool handlers.
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/Bitovi/slack-mcp-server/pkg/types"
+)
+
+func TestListWorkspacesHandler_Handle(t *testing.T) {
+	current := types.WorkspaceInfo{TeamID: "T01234567", Name: "Test Workspace", Domain: "workspace"}
+	other := types.WorkspaceInfo{TeamID: "T07654321", Name: "Other Workspace", Domain: "other"}
+
+	tests := []struct {
+		name         string
+		listed       []types.WorkspaceInfo
+		truncated    bool
+		listErr      error
+		workspaceErr error
+		wantTeams    []string
+		wantWarning  string
+		wantError    string
+	}{
+		{
+			name:      "org-wide app",
+			listed:    []types.WorkspaceInfo{current, other},
+			wantTeams: []string{"T01234567", "T07654321"},
+		},
+		{
+			name:      "current workspace missing from listing",
+			listed:    []types.WorkspaceInfo{other},
+			wantTeams: []string{"T01234567", "T07654321"},
+		},
+		{
+			name:        "truncated listing",
+			listed:      []types.WorkspaceInfo{current, other},
+			truncated:   true,
+			wantTeams:   []string{"T01234567", "T07654321"},
+			wantWarning: types.WarnCodeResultsTruncated,
+		},
+		{
+			name:        "listing fails",
+			listErr:     types.NewSlackError(types.ErrCodeSlackError, "Slack API error: internal_error"),
+			wantTeams:   []string{"T01234567"},
+			wantWarning: types.WarnCodeWorkspaceListFailed,
+		},
+		{
+			name:      "invalid token",
+			listErr:   types.NewSlackError(types.ErrCodeInvalidToken, "invalid token"),
+			wantError: "Authentication failed",
+		},
+		{
+			name:         "workspace lookup fails",
+			workspaceErr: types.NewSlackError(types.ErrCodeRateLimited, "rate limited"),
+			wantError:    "Rate limit exceeded",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := &mockSlackClient{
+				getWorkspaceInfo: func(ctx context.Context) (*types.WorkspaceInfo, error) {
+					if tt.workspaceErr != nil {
+						return nil, tt.workspaceErr
+					}
+					workspace := current
+					return &workspace, nil
+				},
+				listWorkspaces: func(ctx context.Context) ([]types.WorkspaceInfo, bool, error) {
+					return tt.listed, tt.truncated, tt.listErr
+				},
+			}
+
+			handler := NewListWorkspacesHandler(mock)
+			result, err := handler.Handle(context.Background(), createToolRequest(map[string]interface{}{}))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			text := result.Content[0].(mcp.TextContent).Text
+			if tt.wantError != "" {
+				if !result.IsError || !strings.Contains(text, tt.wantError) {
+					t.Errorf("expected error containing %q, got: %s", tt.wantError, text)
+				}
+				return
+			}
+			if result.IsError {
+				t.Fatalf("expected success, got error: %s", text)
+			}
+
+			var listing types.ListWorkspacesResult
+			if err := json.Unmarshal([]byte(text), &listing); err != nil {
+				t.Fatalf("failed to parse result JSON: %v", err)
+			}
+			if listing.Current == nil || listing.Current.TeamID != current.TeamID {
+				t.Errorf("current = %v, want %s", listing.Current, current.TeamID)
+			}
+			var teams []string
+			for _, workspace := range listing.Workspaces {
+				teams = append(teams, workspace.TeamID)
+			}
+			if strings.Join(teams, ",") != strings.Join(tt.wantTeams, ",") {
+				t.Errorf("workspaces = %v, want %v", teams, tt.wantTeams)
+			}
+			if tt.wantWarning == "" && len(listing.Warnings) > 0 {
+				t.Errorf("unexpected warnings %v", listing.Warnings)
+			}
+			if tt.wantWarning != "" && (len(listing.Warnings) != 1 || listing.Warnings[0].Code != tt.wantWarning) {
+				t.Errorf("warnings = %v, want one %s warning", listing.Warnings, tt.wantWarning)
+			}
+		})
+	}
+}
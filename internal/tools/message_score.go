@@ -0,0 +1,56 @@
+// Package tools provides the importance scoring heuristic for messages.
+package tools
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/Bitovi/slack-mcp-server/pkg/types"
+)
+
+// Weights and caps of the message importance score. Each signal is capped so that a
+// single popular reaction or a very long message cannot dominate the score.
+const (
+	// scorePerReaction is added for each reaction, up to scoreReactionsMax.
+	scorePerReaction  = 2
+	scoreReactionsMax = 20
+	// scorePerReply is added for each thread reply, up to scoreRepliesMax.
+	scorePerReply   = 3
+	scoreRepliesMax = 30
+	// scoreMentionsCurrentUser is added when the message mentions the current user.
+	scoreMentionsCurrentUser = 25
+	// scoreLengthUnit is the number of characters of text worth one point, up to scoreLengthMax.
+	scoreLengthUnit = 100
+	scoreLengthMax  = 10
+	// scoreAdminAuthor is added for messages by workspace admins and owners.
+	scoreAdminAuthor = 10
+	// scoreBotAuthor is subtracted for messages posted by bots and workflows.
+	scoreBotAuthor = 10
+)
+
+// scoreMessage returns the importance score of msg, a heuristic from 0 upward that
+// favors reacted, discussed, and long messages, messages that mention the current
+// user, and messages by admins and owners, over bot posts.
+//
+// Parameters:
+//   - msg: The message to score
+//   - currentUserID: The current user's ID; empty if unknown, which skips the mention signal
+//   - author: The message author's user info; nil if unknown, which skips the role signal
+func scoreMessage(msg *types.Message, currentUserID string, author *types.UserInfo) int {
+	score := min(msg.ReactionCount*scorePerReaction, scoreReactionsMax)
+	score += min(msg.ReplyCount*scorePerReply, scoreRepliesMax)
+	score += min(utf8.RuneCountInString(msg.Text)/scoreLengthUnit, scoreLengthMax)
+
+	if currentUserID != "" && strings.Contains(msg.Text, "<@"+currentUserID) {
+		score += scoreMentionsCurrentUser
+	}
+
+	switch {
+	case msg.BotID != "" || (author != nil && author.IsBot):
+		score -= scoreBotAuthor
+	case author != nil && (author.IsAdmin || author.IsOwner):
+		score += scoreAdminAuthor
+	}
+
+	return max(score, 0)
+}
@@ -0,0 +1,195 @@
+// Package tools provides MCP tool handler implementations for the Slack MCP server.
+package tools
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Bitovi/slack-mcp-server/internal/references"
+	"github.com/Bitovi/slack-mcp-server/pkg/types"
+)
+
+// ResolutionErrorPolicy controls how tool handlers react when user or channel resolution
+// fails (e.g., users.info, auth.test, or conversations.info returns an error).
+type ResolutionErrorPolicy string
+
+const (
+	// ResolutionErrorIgnore returns results without the unresolved user fields and no indication of the failure.
+	ResolutionErrorIgnore ResolutionErrorPolicy = "ignore"
+	// ResolutionErrorWarn returns results with a warning entry for each user or channel that could not be resolved.
+	ResolutionErrorWarn ResolutionErrorPolicy = "warn"
+	// ResolutionErrorFail rejects the tool call if any user or channel could not be resolved.
+	ResolutionErrorFail ResolutionErrorPolicy = "fail"
+)
+
+// ParseResolutionErrorPolicy parses a resolution error policy name.
+// An empty string selects ResolutionErrorIgnore.
+func ParseResolutionErrorPolicy(s string) (ResolutionErrorPolicy, error) {
+	switch policy := ResolutionErrorPolicy(strings.ToLower(strings.TrimSpace(s))); policy {
+	case "":
+		return ResolutionErrorIgnore, nil
+	case ResolutionErrorIgnore, ResolutionErrorWarn, ResolutionErrorFail:
+		return policy, nil
+	default:
+		return "", fmt.Errorf("unknown resolution error policy %q: must be 'ignore', 'warn', or 'fail'", s)
+	}
+}
+
+// FreePlanRetentionWindow is the message history kept by workspaces on Slack's free
+// plan. Retention gap warnings are off unless a window is set with WithRetentionWindow,
+// since paid workspaces keep longer or unlimited history.
+const FreePlanRetentionWindow = 90 * 24 * time.Hour
+
+// handlerConfig holds optional configuration shared by the tool handlers.
+type handlerConfig struct {
+	// onResolutionError controls how user and channel resolution failures are reported.
+	onResolutionError ResolutionErrorPolicy
+	// retentionWindow is how much message history the workspace is assumed to keep,
+	// used to explain empty history windows. Zero disables retention gap warnings.
+	retentionWindow time.Duration
+	// auditLog records changes made by write tools that alter workspace state
+	// (e.g., user group membership) and reads of direct messages. Nil disables
+	// audit logging, and with it DM reads.
+	auditLog *log.Logger
+	// referenceTrackers are the patterns used when a tool call asks for references to be extracted.
+	referenceTrackers []references.Tracker
+}
+
+// defaultHandlerConfig returns the handler configuration used when no options are given.
+func defaultHandlerConfig() handlerConfig {
+	return handlerConfig{
+		onResolutionError: ResolutionErrorIgnore,
+		referenceTrackers: references.DefaultTrackers(),
+	}
+}
+
+// HandlerOption configures optional tool handler behavior.
+type HandlerOption func(*handlerConfig)
+
+// WithResolutionErrorPolicy sets how user and channel resolution failures are reported.
+func WithResolutionErrorPolicy(policy ResolutionErrorPolicy) HandlerOption {
+	return func(c *handlerConfig) {
+		c.onResolutionError = policy
+	}
+}
+
+// WithRetentionWindow sets how much message history the workspace is assumed to keep.
+// list_channel_messages warns when a history window reaches back past it and no older
+// messages are returned. Zero disables the warning.
+func WithRetentionWindow(window time.Duration) HandlerOption {
+	return func(c *handlerConfig) {
+		c.retentionWindow = window
+	}
+}
+
+// WithAuditLog sets the logger that records changes made by write tools that alter
+// workspace state, such as user group membership updates, and reads of direct messages.
+func WithAuditLog(logger *log.Logger) HandlerOption {
+	return func(c *handlerConfig) {
+		c.auditLog = logger
+	}
+}
+
+// WithReferenceTrackers sets the patterns used to extract issue, pull request, and
+// ticket references when a tool call passes extract_references. Without it, the
+// default Jira and GitHub patterns are used.
+func WithReferenceTrackers(trackers []references.Tracker) HandlerOption {
+	return func(c *handlerConfig) {
+		c.referenceTrackers = trackers
+	}
+}
+
+// newHandlerConfig applies the given options to the default handler configuration.
+func newHandlerConfig(opts []HandlerOption) handlerConfig {
+	config := defaultHandlerConfig()
+	for _, opt := range opts {
+		opt(&config)
+	}
+	return config
+}
+
+// resolutionFailure is a single failed user or channel lookup.
+type resolutionFailure struct {
+	code string
+	err  error
+}
+
+// resolutionTracker collects user, channel, and user group resolution failures during a single tool call.
+// A nil tracker discards failures.
+type resolutionTracker struct {
+	failures map[string]resolutionFailure
+}
+
+// newResolutionTracker creates an empty resolutionTracker.
+func newResolutionTracker() *resolutionTracker {
+	return &resolutionTracker{failures: make(map[string]resolutionFailure)}
+}
+
+// recordUser notes that the given user ID could not be resolved.
+func (t *resolutionTracker) recordUser(userID string, err error) {
+	t.record("user "+userID, types.WarnCodeUserResolutionFailed, err)
+}
+
+// recordCurrentUser notes that the authenticated user could not be resolved.
+func (t *resolutionTracker) recordCurrentUser(err error) {
+	t.record("current user", types.WarnCodeUserResolutionFailed, err)
+}
+
+// recordChannel notes that the given channel ID could not be resolved.
+func (t *resolutionTracker) recordChannel(channelID string, err error) {
+	t.record("channel "+channelID, types.WarnCodeChannelResolutionFailed, err)
+}
+
+// recordUserGroup notes that the given user group ID could not be resolved.
+func (t *resolutionTracker) recordUserGroup(userGroupID string, err error) {
+	t.record("user group "+userGroupID, types.WarnCodeUserGroupResolutionFailed, err)
+}
+
+// record notes a resolution failure for subject. Only the first failure per subject is kept.
+func (t *resolutionTracker) record(subject, code string, err error) {
+	if t == nil || err == nil {
+		return
+	}
+	if _, exists := t.failures[subject]; !exists {
+		t.failures[subject] = resolutionFailure{code: code, err: err}
+	}
+}
+
+// apply reports the recorded failures according to policy.
+//
+// Returns warnings to attach to the result under ResolutionErrorWarn, or an error
+// describing the failures under ResolutionErrorFail. Returns nil, nil if no failures
+// were recorded or the policy is ResolutionErrorIgnore.
+func (t *resolutionTracker) apply(policy ResolutionErrorPolicy) ([]types.Warning, error) {
+	if t == nil || len(t.failures) == 0 {
+		return nil, nil
+	}
+
+	// Sort subjects so warnings are deterministic
+	subjects := make([]string, 0, len(t.failures))
+	for subject := range t.failures {
+		subjects = append(subjects, subject)
+	}
+	sort.Strings(subjects)
+
+	switch policy {
+	case ResolutionErrorWarn:
+		warnings := make([]types.Warning, 0, len(subjects))
+		for _, subject := range subjects {
+			failure := t.failures[subject]
+			warnings = append(warnings, types.Warning{
+				Code:    failure.code,
+				Message: fmt.Sprintf("could not resolve %s: %s", subject, failure.err.Error()),
+			})
+		}
+		return warnings, nil
+	case ResolutionErrorFail:
+		return nil, fmt.Errorf("could not resolve %s: %s",
+			strings.Join(subjects, ", "), t.failures[subjects[0]].err.Error())
+	default:
+		return nil, nil
+	}
+}
@@ -0,0 +1,37 @@
+// Package tools provides unit tests for the MCP tool handlers.
+package tools
+
+import "testing"
+
+func TestParseResolutionErrorPolicy(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    ResolutionErrorPolicy
+		wantErr bool
+	}{
+		{input: "", want: ResolutionErrorIgnore},
+		{input: "ignore", want: ResolutionErrorIgnore},
+		{input: "warn", want: ResolutionErrorWarn},
+		{input: " FAIL ", want: ResolutionErrorFail},
+		{input: "strict", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseResolutionErrorPolicy(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseResolutionErrorPolicy(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseResolutionErrorPolicy(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDefaultHandlerConfig_RetentionWarningDisabled(t *testing.T) {
+	// Paid workspaces keep more than the free plan's history, so the warning is opt-in
+	if window := defaultHandlerConfig().retentionWindow; window != 0 {
+		t.Errorf("default retention window = %v, want 0", window)
+	}
+}
@@ -0,0 +1,25 @@
+// Package tools provides MCP tool handler implementations for the Slack MCP server.
+package tools
+
+import (
+	slackclient "github.com/Bitovi/slack-mcp-server/internal/slack"
+	"github.com/Bitovi/slack-mcp-server/pkg/types"
+)
+
+// partialError converts an error from an optional fetch (e.g., thread replies or
+// context messages) into a SlackError that can be embedded in a tool result, so
+// partial failures stay inside the JSON payload rather than failing the call.
+//
+// Returns nil if err is nil.
+func partialError(err error) *types.SlackError {
+	if err == nil {
+		return nil
+	}
+
+	code := slackclient.GetErrorCode(err)
+	if code == "" {
+		code = types.ErrCodeSlackError
+	}
+
+	return types.NewSlackError(code, err.Error())
+}
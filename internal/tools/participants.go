@@ -0,0 +1,46 @@
+// Package tools provides MCP tool handler implementations for the Slack MCP server.
+package tools
+
+import (
+	"context"
+
+	slackclient "github.com/Bitovi/slack-mcp-server/internal/slack"
+	"github.com/Bitovi/slack-mcp-server/internal/urlparser"
+	"github.com/Bitovi/slack-mcp-server/pkg/types"
+)
+
+// mpimParticipants resolves the participants of a group DM, so results from an
+// "mpdm-alice--bob--carol-1" conversation say who was in it. Members are listed with
+// conversations.members and resolved to user info; users that cannot be resolved are
+// returned with only their ID and recorded in resolution.
+//
+// If the members cannot be listed, the participants' usernames are taken from the
+// group DM's name instead. Returns nil if channel is not a group DM.
+func mpimParticipants(ctx context.Context, client slackclient.ClientInterface, channel *types.ChannelInfo,
+	resolution *resolutionTracker) []types.UserInfo {
+	if channel == nil || (channel.Type != types.ChannelTypeMPIM && !urlparser.IsMPIMName(channel.Name)) {
+		return nil
+	}
+
+	memberIDs, err := client.GetConversationMembers(ctx, channel.ID)
+	if err != nil {
+		var participants []types.UserInfo
+		for _, name := range urlparser.MPIMParticipantNames(channel.Name) {
+			participants = append(participants, types.UserInfo{Name: name})
+		}
+		return participants
+	}
+
+	users := lookupUsers(ctx, client, memberIDs)
+	participants := make([]types.UserInfo, 0, len(memberIDs))
+	for _, userID := range memberIDs {
+		userInfo, err := users[userID].info, users[userID].err
+		if err != nil || userInfo == nil {
+			resolution.recordUser(userID, err)
+			participants = append(participants, types.UserInfo{ID: userID})
+			continue
+		}
+		participants = append(participants, *userInfo)
+	}
+	return participants
+}
@@ -0,0 +1,181 @@
+// Package tools provides MCP tool handler implementations for the Slack MCP server.
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	slackclient "github.com/Bitovi/slack-mcp-server/internal/slack"
+	"github.com/Bitovi/slack-mcp-server/internal/urlparser"
+	"github.com/Bitovi/slack-mcp-server/pkg/types"
+)
+
+// PostEphemeralHandler handles the post_ephemeral MCP tool requests.
+// It shows a message to a single user in a channel without notifying anyone else.
+// It is a write tool, registered only when write tools are enabled.
+type PostEphemeralHandler struct {
+	// slackClient is the Slack API client for posting messages.
+	slackClient slackclient.ClientInterface
+	// config holds optional handler behavior.
+	config handlerConfig
+}
+
+// NewPostEphemeralHandler creates a new PostEphemeralHandler with the given Slack client and options.
+func NewPostEphemeralHandler(client slackclient.ClientInterface, opts ...HandlerOption) *PostEphemeralHandler {
+	return &PostEphemeralHandler{
+		slackClient: client,
+		config:      newHandlerConfig(opts),
+	}
+}
+
+// Handle processes a post_ephemeral tool call.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeouts
+//   - request: The MCP tool call request containing channel_id, user_id, text, and optional thread_ts
+//
+// Returns an MCP tool result containing the ephemeral message timestamp,
+// or an error result if the arguments are invalid or Slack rejects the message.
+func (h *PostEphemeralHandler) Handle(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	// Extract the channel_id argument (required)
+	channelIDArg, ok := request.Params.Arguments["channel_id"]
+	if !ok {
+		return mcp.NewToolResultError("missing required argument 'channel_id'"), nil
+	}
+
+	channelID, ok := channelIDArg.(string)
+	if !ok {
+		return mcp.NewToolResultError("argument 'channel_id' must be a string"), nil
+	}
+
+	if channelID == "" {
+		return mcp.NewToolResultError("argument 'channel_id' cannot be empty"), nil
+	}
+
+	channelID, errResult := resolveChannelRef(ctx, h.slackClient, channelID, h.handleError)
+	if errResult != nil {
+		return errResult, nil
+	}
+
+	// Extract the user_id argument (required); a user profile link is also accepted
+	userIDArg, ok := request.Params.Arguments["user_id"]
+	if !ok {
+		return mcp.NewToolResultError("missing required argument 'user_id'"), nil
+	}
+
+	userRef, ok := userIDArg.(string)
+	if !ok {
+		return mcp.NewToolResultError("argument 'user_id' must be a string"), nil
+	}
+
+	userID, ok := urlparser.ParseUserReference(userRef)
+	if !ok {
+		return mcp.NewToolResultError(fmt.Sprintf("argument 'user_id' must be a Slack user ID (e.g., U01234567), got %q", userRef)), nil
+	}
+
+	// Extract the text argument (required)
+	textArg, ok := request.Params.Arguments["text"]
+	if !ok {
+		return mcp.NewToolResultError("missing required argument 'text'"), nil
+	}
+
+	text, ok := textArg.(string)
+	if !ok {
+		return mcp.NewToolResultError("argument 'text' must be a string"), nil
+	}
+
+	if text == "" {
+		return mcp.NewToolResultError("argument 'text' cannot be empty"), nil
+	}
+
+	// Extract thread_ts parameter (optional)
+	threadTS := ""
+	if threadTSArg, exists := request.Params.Arguments["thread_ts"]; exists {
+		v, ok := threadTSArg.(string)
+		if !ok {
+			return mcp.NewToolResultError("argument 'thread_ts' must be a string"), nil
+		}
+		threadTS = v
+	}
+
+	messageTS, err := h.slackClient.PostEphemeral(ctx, channelID, userID, text, threadTS)
+	if err != nil {
+		return h.handleError(err), nil
+	}
+
+	result := &types.PostEphemeralResult{
+		ChannelID: channelID,
+		UserID:    userID,
+		MessageTS: messageTS,
+		ThreadTS:  threadTS,
+	}
+
+	result.Workspace = workspaceFor(ctx, h.slackClient)
+
+	return h.successResult(result)
+}
+
+// handleError converts errors to appropriate MCP error results.
+func (h *PostEphemeralHandler) handleError(err error) *mcp.CallToolResult {
+	// Check for known error types and provide appropriate messages
+	if slackclient.IsRateLimited(err) {
+		return mcp.NewToolResultError(
+			"Rate limit exceeded. Please wait and try again.")
+	}
+
+	if slackclient.IsInvalidToken(err) {
+		return mcp.NewToolResultError(
+			"Authentication failed. Please check that SLACK_BOT_TOKEN is valid and not expired.")
+	}
+
+	if slackclient.IsChannelNotFound(err) {
+		return mcp.NewToolResultError(
+			"Channel not found. The channel may have been deleted, or the channel_id is incorrect.")
+	}
+
+	if slackclient.IsUserNotInChannel(err) {
+		return mcp.NewToolResultError(
+			"The user is not a member of this channel. Ephemeral messages can only be shown to channel members.")
+	}
+
+	if slackclient.IsNotInChannel(err) {
+		return mcp.NewToolResultError(
+			"The bot is not a member of this channel. Please invite the bot to the channel first.")
+	}
+
+	if slackclient.IsMissingScope(err) {
+		return mcp.NewToolResultError(err.Error())
+	}
+
+	if slackclient.IsChannelArchived(err) {
+		return mcp.NewToolResultError(
+			"The channel is archived. Messages cannot be posted to archived channels.")
+	}
+
+	if slackclient.IsPermissionDenied(err) {
+		return mcp.NewToolResultError(
+			"Permission denied. The bot lacks permission to post in this channel.")
+	}
+
+	// Generic error handling
+	return mcp.NewToolResultError(fmt.Sprintf("Failed to post ephemeral message: %s", err.Error()))
+}
+
+// successResult creates a successful MCP tool result with the given data.
+func (h *PostEphemeralHandler) successResult(result *types.PostEphemeralResult) (*mcp.CallToolResult, error) {
+	resultJSON, err := json.Marshal(result)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to encode result: %s", err.Error())), nil
+	}
+
+	return mcp.NewToolResultText(string(resultJSON)), nil
+}
+
+// HandleFunc returns a function that can be used directly as an MCP tool handler.
+// This is a convenience method for registering the handler with the MCP server.
+func (h *PostEphemeralHandler) HandleFunc() func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return h.Handle
+}
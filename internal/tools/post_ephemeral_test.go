@@ -0,0 +1,116 @@
+// Package tools provides unit tests for the MCP tool handlers.
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/Bitovi/slack-mcp-server/pkg/types"
+)
+
+func TestPostEphemeralHandler_Handle(t *testing.T) {
+	tests := []struct {
+		name      string
+		args      map[string]interface{}
+		postErr   error
+		wantUser  string
+		wantError string
+	}{
+		{
+			name: "posts to user",
+			args: map[string]interface{}{
+				"channel_id": "C01234567",
+				"user_id":    "U01234567",
+				"text":       "I summarized this thread here",
+				"thread_ts":  "1355517523.000008",
+			},
+			wantUser: "U01234567",
+		},
+		{
+			name: "user profile link",
+			args: map[string]interface{}{
+				"channel_id": "C01234567",
+				"user_id":    "https://workspace.slack.com/team/U01234567",
+				"text":       "hi",
+			},
+			wantUser: "U01234567",
+		},
+		{
+			name: "channel ID as user",
+			args: map[string]interface{}{
+				"channel_id": "C01234567",
+				"user_id":    "C01234567",
+				"text":       "hi",
+			},
+			wantError: "must be a Slack user ID",
+		},
+		{
+			name: "missing text",
+			args: map[string]interface{}{
+				"channel_id": "C01234567",
+				"user_id":    "U01234567",
+			},
+			wantError: "missing required argument 'text'",
+		},
+		{
+			name: "user not in channel",
+			args: map[string]interface{}{
+				"channel_id": "C01234567",
+				"user_id":    "U01234567",
+				"text":       "hi",
+			},
+			postErr:   types.NewSlackError(types.ErrCodeUserNotInChannel, "User is not a member of this channel."),
+			wantError: "user is not a member of this channel",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var posted struct{ channelID, userID, text, threadTS string }
+			mock := &mockSlackClient{
+				postEphemeral: func(ctx context.Context, channelID, userID, text, threadTS string) (string, error) {
+					posted.channelID, posted.userID, posted.text, posted.threadTS = channelID, userID, text, threadTS
+					if tt.postErr != nil {
+						return "", tt.postErr
+					}
+					return "1355517524.000001", nil
+				},
+			}
+
+			handler := NewPostEphemeralHandler(mock)
+			result, err := handler.Handle(context.Background(), createToolRequest(tt.args))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			text := result.Content[0].(mcp.TextContent).Text
+			if tt.wantError != "" {
+				if !result.IsError || !strings.Contains(text, tt.wantError) {
+					t.Errorf("expected error containing %q, got: %s", tt.wantError, text)
+				}
+				return
+			}
+			if result.IsError {
+				t.Fatalf("expected success, got error: %s", text)
+			}
+
+			var postResult types.PostEphemeralResult
+			if err := json.Unmarshal([]byte(text), &postResult); err != nil {
+				t.Fatalf("failed to parse result JSON: %v", err)
+			}
+			if posted.userID != tt.wantUser || postResult.UserID != tt.wantUser {
+				t.Errorf("posted to %q (result %q), want %q", posted.userID, postResult.UserID, tt.wantUser)
+			}
+			if postResult.MessageTS != "1355517524.000001" {
+				t.Errorf("message_ts = %q, want %q", postResult.MessageTS, "1355517524.000001")
+			}
+			if posted.threadTS != postResult.ThreadTS {
+				t.Errorf("thread_ts = %q, posted %q", postResult.ThreadTS, posted.threadTS)
+			}
+		})
+	}
+}
@@ -0,0 +1,288 @@
+// Package tools provides MCP tool handler implementations for the Slack MCP server.
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	slackclient "github.com/Bitovi/slack-mcp-server/internal/slack"
+	"github.com/Bitovi/slack-mcp-server/internal/templates"
+	"github.com/Bitovi/slack-mcp-server/internal/urlparser"
+	"github.com/Bitovi/slack-mcp-server/pkg/types"
+)
+
+// PostFromTemplateHandler handles the post_from_template MCP tool requests.
+// It renders a named template from the configured library and posts it.
+// It is a write tool, registered only when write tools are enabled and a
+// templates file is configured.
+type PostFromTemplateHandler struct {
+	// slackClient is the Slack API client for posting messages.
+	slackClient slackclient.ClientInterface
+	// library holds the configured templates.
+	library *templates.Library
+	// config holds optional handler behavior.
+	config handlerConfig
+}
+
+// NewPostFromTemplateHandler creates a new PostFromTemplateHandler with the given Slack client,
+// template library, and options.
+func NewPostFromTemplateHandler(client slackclient.ClientInterface, library *templates.Library, opts ...HandlerOption) *PostFromTemplateHandler {
+	return &PostFromTemplateHandler{
+		slackClient: client,
+		library:     library,
+		config:      newHandlerConfig(opts),
+	}
+}
+
+// Handle processes a post_from_template tool call.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeouts
+//   - request: The MCP tool call request containing name, vars, and optional
+//     channel_id, thread_ts, reply_broadcast, unfurl_links, and unfurl_media
+//
+// Returns an MCP tool result describing the posted message, or an error result
+// if the template does not exist, a variable is missing or unknown, or Slack
+// rejects the message.
+func (h *PostFromTemplateHandler) Handle(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	// Extract the name argument (required)
+	nameArg, ok := request.Params.Arguments["name"]
+	if !ok {
+		return mcp.NewToolResultError("missing required argument 'name'"), nil
+	}
+
+	name, ok := nameArg.(string)
+	if !ok {
+		return mcp.NewToolResultError("argument 'name' must be a string"), nil
+	}
+
+	tmpl, ok := h.library.Get(name)
+	if !ok {
+		var names []string
+		for _, t := range h.library.Templates() {
+			names = append(names, t.Name)
+		}
+		return mcp.NewToolResultError(fmt.Sprintf("unknown template %q (available: %s)", name, strings.Join(names, ", "))), nil
+	}
+
+	// Extract vars (optional object of strings; numbers and booleans are formatted)
+	vars := make(map[string]string)
+	if varsArg, exists := request.Params.Arguments["vars"]; exists {
+		varsMap, ok := varsArg.(map[string]interface{})
+		if !ok {
+			return mcp.NewToolResultError("argument 'vars' must be an object"), nil
+		}
+		for key, value := range varsMap {
+			switch v := value.(type) {
+			case string:
+				vars[key] = v
+			case float64, bool:
+				vars[key] = fmt.Sprint(v)
+			default:
+				return mcp.NewToolResultError(fmt.Sprintf("variable '%s' must be a string", key)), nil
+			}
+		}
+	}
+
+	text, err := tmpl.Render(vars)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	// Extract channel_id (optional if the template has a default channel)
+	channelID := tmpl.ChannelID
+	if channelIDArg, exists := request.Params.Arguments["channel_id"]; exists {
+		v, ok := channelIDArg.(string)
+		if !ok {
+			return mcp.NewToolResultError("argument 'channel_id' must be a string"), nil
+		}
+		if v != "" {
+			channelID = v
+		}
+	}
+	if channelID == "" {
+		return mcp.NewToolResultError(fmt.Sprintf("template %q has no default channel; 'channel_id' is required", name)), nil
+	}
+
+	channelID, errResult := resolveChannelRef(ctx, h.slackClient, channelID, h.handleError)
+	if errResult != nil {
+		return errResult, nil
+	}
+
+	// Extract posting options
+	var opts slackclient.PostMessageOptions
+	if threadTSArg, exists := request.Params.Arguments["thread_ts"]; exists {
+		v, ok := threadTSArg.(string)
+		if !ok {
+			return mcp.NewToolResultError("argument 'thread_ts' must be a string"), nil
+		}
+		opts.ThreadTS = v
+	}
+
+	if broadcastArg, exists := request.Params.Arguments["reply_broadcast"]; exists {
+		v, ok := broadcastArg.(bool)
+		if !ok {
+			return mcp.NewToolResultError("argument 'reply_broadcast' must be a boolean"), nil
+		}
+		if v && opts.ThreadTS == "" {
+			return mcp.NewToolResultError("argument 'reply_broadcast' requires 'thread_ts'"), nil
+		}
+		opts.ReplyBroadcast = v
+	}
+
+	if unfurlArg, exists := request.Params.Arguments["unfurl_links"]; exists {
+		v, ok := unfurlArg.(bool)
+		if !ok {
+			return mcp.NewToolResultError("argument 'unfurl_links' must be a boolean"), nil
+		}
+		opts.UnfurlLinks = &v
+	}
+
+	if unfurlArg, exists := request.Params.Arguments["unfurl_media"]; exists {
+		v, ok := unfurlArg.(bool)
+		if !ok {
+			return mcp.NewToolResultError("argument 'unfurl_media' must be a boolean"), nil
+		}
+		opts.UnfurlMedia = &v
+	}
+
+	// Text too long for one message is split into threaded continuations, unless
+	// on_long_text asks for a snippet or an error
+	longTextMode, err := parseLongTextMode(request.Params.Arguments)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	chunks := []string{text}
+	asSnippet := false
+	if length := utf8.RuneCountInString(text); length > maxMessageLength {
+		switch longTextMode {
+		case longTextError:
+			return mcp.NewToolResultError(fmt.Sprintf("rendered message is %d characters; Slack posts at most %d "+
+				"per message (use on_long_text '%s' to post it as threaded continuations)",
+				length, maxMessageLength, longTextSplit)), nil
+		case longTextSnippet:
+			if err := checkSnippetLength(text); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			asSnippet = true
+		default:
+			chunks = splitMessage(text, maxMessageLength)
+		}
+	}
+
+	if asSnippet {
+		result, errResult := uploadLongText(ctx, h.slackClient, channelID, opts.ThreadTS, text, h.handleError)
+		if errResult != nil {
+			return errResult, nil
+		}
+		result.Template = name
+		return h.successResult(result)
+	}
+
+	messageTS, err := h.slackClient.PostMessage(ctx, channelID, chunks[0], opts)
+	if err != nil {
+		return h.handleError(err), nil
+	}
+
+	result := &types.PostMessageResult{
+		ChannelID: channelID,
+		MessageTS: messageTS,
+		ThreadTS:  opts.ThreadTS,
+		Template:  name,
+		Text:      text,
+	}
+
+	// Post the continuations in the message's thread (or the thread it was posted in)
+	continuation := slackclient.PostMessageOptions{
+		ThreadTS:    opts.ThreadTS,
+		UnfurlLinks: opts.UnfurlLinks,
+		UnfurlMedia: opts.UnfurlMedia,
+	}
+	if continuation.ThreadTS == "" {
+		continuation.ThreadTS = messageTS
+	}
+	for i, chunk := range chunks[1:] {
+		ts, err := h.slackClient.PostMessage(ctx, channelID, chunk, continuation)
+		if err != nil {
+			// The first parts are already posted, so report the rest rather than failing
+			result.Warnings = append(result.Warnings, types.Warning{
+				Code:    types.WarnCodePostIncomplete,
+				Message: fmt.Sprintf("posted %d of %d parts of the message: %s", i+1, len(chunks), err.Error()),
+			})
+			break
+		}
+		result.ContinuationTS = append(result.ContinuationTS, ts)
+	}
+
+	result.Workspace = workspaceFor(ctx, h.slackClient)
+	// Link to the posted message so it can be reviewed
+	if result.Workspace != nil {
+		if permalink, err := urlparser.Build(result.Workspace.URL, channelID, messageTS, opts.ThreadTS); err == nil {
+			result.Permalink = permalink
+		}
+	}
+
+	return h.successResult(result)
+}
+
+// handleError converts errors to appropriate MCP error results.
+func (h *PostFromTemplateHandler) handleError(err error) *mcp.CallToolResult {
+	// Check for known error types and provide appropriate messages
+	if slackclient.IsRateLimited(err) {
+		return mcp.NewToolResultError(
+			"Rate limit exceeded. Please wait and try again.")
+	}
+
+	if slackclient.IsInvalidToken(err) {
+		return mcp.NewToolResultError(
+			"Authentication failed. Please check that SLACK_BOT_TOKEN is valid and not expired.")
+	}
+
+	if slackclient.IsChannelNotFound(err) {
+		return mcp.NewToolResultError(
+			"Channel not found. The channel may have been deleted, or the channel_id is incorrect.")
+	}
+
+	if slackclient.IsNotInChannel(err) {
+		return mcp.NewToolResultError(
+			"The bot is not a member of this channel. Please invite the bot to the channel first.")
+	}
+
+	if slackclient.IsMissingScope(err) {
+		return mcp.NewToolResultError(err.Error())
+	}
+
+	if slackclient.IsChannelArchived(err) {
+		return mcp.NewToolResultError(
+			"The channel is archived. Messages cannot be posted to archived channels.")
+	}
+
+	if slackclient.IsPermissionDenied(err) {
+		return mcp.NewToolResultError(
+			"Permission denied. The bot lacks permission to post in this channel.")
+	}
+
+	// Generic error handling
+	return mcp.NewToolResultError(fmt.Sprintf("Failed to post message: %s", err.Error()))
+}
+
+// successResult creates a successful MCP tool result with the given data.
+func (h *PostFromTemplateHandler) successResult(result *types.PostMessageResult) (*mcp.CallToolResult, error) {
+	resultJSON, err := json.Marshal(result)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to encode result: %s", err.Error())), nil
+	}
+
+	return mcp.NewToolResultText(string(resultJSON)), nil
+}
+
+// HandleFunc returns a function that can be used directly as an MCP tool handler.
+// This is a convenience method for registering the handler with the MCP server.
+func (h *PostFromTemplateHandler) HandleFunc() func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return h.Handle
+}
@@ -0,0 +1,236 @@
+// Package tools provides unit tests for the MCP tool handlers.
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	slackclient "github.com/Bitovi/slack-mcp-server/internal/slack"
+	"github.com/Bitovi/slack-mcp-server/internal/templates"
+	"github.com/Bitovi/slack-mcp-server/pkg/types"
+)
+
+func TestPostFromTemplateHandler_Handle(t *testing.T) {
+	library, err := templates.Parse([]byte(`{"templates":{
+		"incident_update":{"channel_id":"C01234567","text":"*{{title}}* is now *{{status}}*"},
+		"standup":{"text":"Standup notes for {{date}}"}
+	}}`))
+	if err != nil {
+		t.Fatalf("failed to parse templates: %v", err)
+	}
+
+	tests := []struct {
+		name        string
+		args        map[string]interface{}
+		wantChannel string
+		wantText    string
+		wantOpts    slackclient.PostMessageOptions
+		wantError   string
+	}{
+		{
+			name: "default channel",
+			args: map[string]interface{}{
+				"name": "incident_update",
+				"vars": map[string]interface{}{"title": "Login outage", "status": "resolved"},
+			},
+			wantChannel: "C01234567",
+			wantText:    "*Login outage* is now *resolved*",
+		},
+		{
+			name: "thread reply with broadcast",
+			args: map[string]interface{}{
+				"name":            "standup",
+				"vars":            map[string]interface{}{"date": "2024-01-15"},
+				"channel_id":      "C07654321",
+				"thread_ts":       "1355517523.000008",
+				"reply_broadcast": true,
+			},
+			wantChannel: "C07654321",
+			wantText:    "Standup notes for 2024-01-15",
+			wantOpts:    slackclient.PostMessageOptions{ThreadTS: "1355517523.000008", ReplyBroadcast: true},
+		},
+		{
+			name:      "unknown template",
+			args:      map[string]interface{}{"name": "retro"},
+			wantError: `unknown template "retro" (available: incident_update, standup)`,
+		},
+		{
+			name: "missing variable",
+			args: map[string]interface{}{
+				"name": "incident_update",
+				"vars": map[string]interface{}{"title": "Login outage"},
+			},
+			wantError: "missing variables: status",
+		},
+		{
+			name: "no channel",
+			args: map[string]interface{}{
+				"name": "standup",
+				"vars": map[string]interface{}{"date": "2024-01-15"},
+			},
+			wantError: "'channel_id' is required",
+		},
+		{
+			name: "broadcast without thread",
+			args: map[string]interface{}{
+				"name":            "incident_update",
+				"vars":            map[string]interface{}{"title": "t", "status": "s"},
+				"reply_broadcast": true,
+			},
+			wantError: "'reply_broadcast' requires 'thread_ts'",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var postedChannel, postedText string
+			var postedOpts slackclient.PostMessageOptions
+			mock := &mockSlackClient{
+				postMessage: func(ctx context.Context, channelID, text string, opts slackclient.PostMessageOptions) (string, error) {
+					postedChannel, postedText, postedOpts = channelID, text, opts
+					return "1355517524.000001", nil
+				},
+			}
+
+			handler := NewPostFromTemplateHandler(mock, library)
+			result, err := handler.Handle(context.Background(), createToolRequest(tt.args))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			text := result.Content[0].(mcp.TextContent).Text
+			if tt.wantError != "" {
+				if !result.IsError || !strings.Contains(text, tt.wantError) {
+					t.Errorf("expected error containing %q, got: %s", tt.wantError, text)
+				}
+				if postedChannel != "" {
+					t.Error("expected no message to be posted")
+				}
+				return
+			}
+			if result.IsError {
+				t.Fatalf("expected success, got error: %s", text)
+			}
+
+			if postedChannel != tt.wantChannel || postedText != tt.wantText {
+				t.Errorf("posted %q to %s, want %q to %s", postedText, postedChannel, tt.wantText, tt.wantChannel)
+			}
+			if !reflect.DeepEqual(postedOpts, tt.wantOpts) {
+				t.Errorf("options = %+v, want %+v", postedOpts, tt.wantOpts)
+			}
+
+			var postResult types.PostMessageResult
+			if err := json.Unmarshal([]byte(text), &postResult); err != nil {
+				t.Fatalf("failed to parse result JSON: %v", err)
+			}
+			if postResult.Permalink == "" || postResult.Text != tt.wantText {
+				t.Errorf("unexpected result: %+v", postResult)
+			}
+		})
+	}
+}
+
+func TestPostFromTemplateHandler_Handle_LongText(t *testing.T) {
+	library, err := templates.Parse([]byte(`{"templates":{
+		"analysis":{"channel_id":"C01234567","text":"Analysis:\n{{body}}"}
+	}}`))
+	if err != nil {
+		t.Fatalf("failed to parse templates: %v", err)
+	}
+	body := strings.Repeat(strings.Repeat("word ", 1000)+"\n\n", 12) // about 60,000 characters
+
+	t.Run("split into thread", func(t *testing.T) {
+		type post struct {
+			text     string
+			threadTS string
+		}
+		var posts []post
+		mock := &mockSlackClient{
+			postMessage: func(ctx context.Context, channelID, text string, opts slackclient.PostMessageOptions) (string, error) {
+				posts = append(posts, post{text: text, threadTS: opts.ThreadTS})
+				return []string{"1355517524.000001", "1355517524.000002", "1355517524.000003"}[len(posts)-1], nil
+			},
+		}
+
+		handler := NewPostFromTemplateHandler(mock, library)
+		result, err := handler.Handle(context.Background(), createToolRequest(map[string]interface{}{
+			"name": "analysis",
+			"vars": map[string]interface{}{"body": body},
+		}))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		text := result.Content[0].(mcp.TextContent).Text
+		if result.IsError {
+			t.Fatalf("expected success, got error: %s", text)
+		}
+
+		if len(posts) != 2 {
+			t.Fatalf("expected 2 posts, got %d", len(posts))
+		}
+		if posts[0].threadTS != "" || posts[1].threadTS != "1355517524.000001" {
+			t.Errorf("thread_ts = %q, %q; want continuation in the first message's thread", posts[0].threadTS, posts[1].threadTS)
+		}
+		var postResult types.PostMessageResult
+		if err := json.Unmarshal([]byte(text), &postResult); err != nil {
+			t.Fatalf("failed to parse result JSON: %v", err)
+		}
+		if len(postResult.ContinuationTS) != 1 || postResult.ContinuationTS[0] != "1355517524.000002" {
+			t.Errorf("continuation_ts = %v, want [1355517524.000002]", postResult.ContinuationTS)
+		}
+	})
+
+	t.Run("snippet mode", func(t *testing.T) {
+		var uploaded string
+		mock := &mockSlackClient{
+			uploadSnippet: func(ctx context.Context, channelID, filename, content string, opts slackclient.SnippetOptions) (string, error) {
+				uploaded = content
+				return "F01234567", nil
+			},
+		}
+
+		handler := NewPostFromTemplateHandler(mock, library)
+		result, err := handler.Handle(context.Background(), createToolRequest(map[string]interface{}{
+			"name":         "analysis",
+			"vars":         map[string]interface{}{"body": body},
+			"on_long_text": "snippet",
+		}))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		text := result.Content[0].(mcp.TextContent).Text
+		if result.IsError {
+			t.Fatalf("expected success, got error: %s", text)
+		}
+		if uploaded != "Analysis:\n"+body {
+			t.Errorf("uploaded %d characters, want the rendered message", len(uploaded))
+		}
+		var postResult types.PostMessageResult
+		if err := json.Unmarshal([]byte(text), &postResult); err != nil {
+			t.Fatalf("failed to parse result JSON: %v", err)
+		}
+		if postResult.FileID != "F01234567" || postResult.Template != "analysis" {
+			t.Errorf("unexpected result: file_id = %q, template = %q", postResult.FileID, postResult.Template)
+		}
+	})
+
+	t.Run("error mode", func(t *testing.T) {
+		handler := NewPostFromTemplateHandler(&mockSlackClient{}, library)
+		result, err := handler.Handle(context.Background(), createToolRequest(map[string]interface{}{
+			"name":         "analysis",
+			"vars":         map[string]interface{}{"body": body},
+			"on_long_text": "error",
+		}))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if text := result.Content[0].(mcp.TextContent).Text; !result.IsError || !strings.Contains(text, "at most 40000") {
+			t.Errorf("expected length error, got: %s", text)
+		}
+	})
+}
@@ -0,0 +1,286 @@
+// Package tools provides MCP tool handler implementations for the Slack MCP server.
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/slack-go/slack"
+
+	"github.com/Bitovi/slack-mcp-server/internal/blockkit"
+	slackclient "github.com/Bitovi/slack-mcp-server/internal/slack"
+	"github.com/Bitovi/slack-mcp-server/internal/urlparser"
+	"github.com/Bitovi/slack-mcp-server/pkg/types"
+)
+
+// PostMessageHandler handles the post_message MCP tool requests.
+// It posts a message to a channel, or as a reply in a thread.
+// It is a write tool, registered only when write tools are enabled.
+type PostMessageHandler struct {
+	// slackClient is the Slack API client for posting messages.
+	slackClient slackclient.ClientInterface
+	// config holds optional handler behavior.
+	config handlerConfig
+}
+
+// NewPostMessageHandler creates a new PostMessageHandler with the given Slack client and options.
+func NewPostMessageHandler(client slackclient.ClientInterface, opts ...HandlerOption) *PostMessageHandler {
+	return &PostMessageHandler{
+		slackClient: client,
+		config:      newHandlerConfig(opts),
+	}
+}
+
+// Handle processes a post_message tool call.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeouts
+//   - request: The MCP tool call request containing channel_id, text, and optional
+//     blocks, thread_ts, reply_broadcast, unfurl_links, unfurl_media, and on_long_text
+//
+// Returns an MCP tool result with the posted message's timestamp and permalink,
+// or an error result if the arguments are invalid or Slack rejects the message.
+func (h *PostMessageHandler) Handle(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	// Extract the channel_id argument (required)
+	channelIDArg, ok := request.Params.Arguments["channel_id"]
+	if !ok {
+		return mcp.NewToolResultError("missing required argument 'channel_id'"), nil
+	}
+
+	channelID, ok := channelIDArg.(string)
+	if !ok {
+		return mcp.NewToolResultError("argument 'channel_id' must be a string"), nil
+	}
+
+	if channelID == "" {
+		return mcp.NewToolResultError("argument 'channel_id' cannot be empty"), nil
+	}
+
+	channelID, errResult := resolveChannelRef(ctx, h.slackClient, channelID, h.handleError)
+	if errResult != nil {
+		return errResult, nil
+	}
+
+	// Extract the text argument (required)
+	textArg, ok := request.Params.Arguments["text"]
+	if !ok {
+		return mcp.NewToolResultError("missing required argument 'text'"), nil
+	}
+
+	text, ok := textArg.(string)
+	if !ok {
+		return mcp.NewToolResultError("argument 'text' must be a string"), nil
+	}
+
+	if text == "" {
+		return mcp.NewToolResultError("argument 'text' cannot be empty"), nil
+	}
+
+	// Extract posting options
+	var opts slackclient.PostMessageOptions
+	if threadTSArg, exists := request.Params.Arguments["thread_ts"]; exists {
+		v, ok := threadTSArg.(string)
+		if !ok {
+			return mcp.NewToolResultError("argument 'thread_ts' must be a string"), nil
+		}
+		opts.ThreadTS = v
+	}
+
+	if broadcastArg, exists := request.Params.Arguments["reply_broadcast"]; exists {
+		v, ok := broadcastArg.(bool)
+		if !ok {
+			return mcp.NewToolResultError("argument 'reply_broadcast' must be a boolean"), nil
+		}
+		if v && opts.ThreadTS == "" {
+			return mcp.NewToolResultError("argument 'reply_broadcast' requires 'thread_ts'"), nil
+		}
+		opts.ReplyBroadcast = v
+	}
+
+	if unfurlArg, exists := request.Params.Arguments["unfurl_links"]; exists {
+		v, ok := unfurlArg.(bool)
+		if !ok {
+			return mcp.NewToolResultError("argument 'unfurl_links' must be a boolean"), nil
+		}
+		opts.UnfurlLinks = &v
+	}
+
+	if unfurlArg, exists := request.Params.Arguments["unfurl_media"]; exists {
+		v, ok := unfurlArg.(bool)
+		if !ok {
+			return mcp.NewToolResultError("argument 'unfurl_media' must be a boolean"), nil
+		}
+		opts.UnfurlMedia = &v
+	}
+
+	// Blocks (e.g., from compose_blocks) are posted with the text as their fallback
+	if blocksArg, exists := request.Params.Arguments["blocks"]; exists {
+		blocks, err := parseBlocksArgument(blocksArg)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("argument 'blocks' is invalid: %s", err.Error())), nil
+		}
+		opts.Blocks = blocks
+	}
+
+	// Text too long for one message is split into threaded continuations, unless
+	// on_long_text asks for a snippet or an error
+	longTextMode, err := parseLongTextMode(request.Params.Arguments)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	chunks := []string{text}
+	asSnippet := false
+	if length := utf8.RuneCountInString(text); length > maxMessageLength {
+		if opts.Blocks != nil {
+			// Continuations would repeat none of the blocks, so the fallback is not split
+			return mcp.NewToolResultError(fmt.Sprintf("message text is %d characters; Slack posts at most %d "+
+				"per message, and the fallback text of blocks cannot be split", length, maxMessageLength)), nil
+		}
+		switch longTextMode {
+		case longTextError:
+			return mcp.NewToolResultError(fmt.Sprintf("message is %d characters; Slack posts at most %d "+
+				"per message (use on_long_text '%s' to post it as threaded continuations)",
+				length, maxMessageLength, longTextSplit)), nil
+		case longTextSnippet:
+			if err := checkSnippetLength(text); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			asSnippet = true
+		default:
+			chunks = splitMessage(text, maxMessageLength)
+		}
+	}
+
+	if asSnippet {
+		result, errResult := uploadLongText(ctx, h.slackClient, channelID, opts.ThreadTS, text, h.handleError)
+		if errResult != nil {
+			return errResult, nil
+		}
+		return h.successResult(result)
+	}
+
+	messageTS, err := h.slackClient.PostMessage(ctx, channelID, chunks[0], opts)
+	if err != nil {
+		return h.handleError(err), nil
+	}
+
+	result := &types.PostMessageResult{
+		ChannelID: channelID,
+		MessageTS: messageTS,
+		ThreadTS:  opts.ThreadTS,
+		Text:      text,
+	}
+
+	// Post the continuations in the message's thread (or the thread it was posted in)
+	continuation := slackclient.PostMessageOptions{
+		ThreadTS:    opts.ThreadTS,
+		UnfurlLinks: opts.UnfurlLinks,
+		UnfurlMedia: opts.UnfurlMedia,
+	}
+	if continuation.ThreadTS == "" {
+		continuation.ThreadTS = messageTS
+	}
+	for i, chunk := range chunks[1:] {
+		ts, err := h.slackClient.PostMessage(ctx, channelID, chunk, continuation)
+		if err != nil {
+			// The first parts are already posted, so report the rest rather than failing
+			result.Warnings = append(result.Warnings, types.Warning{
+				Code:    types.WarnCodePostIncomplete,
+				Message: fmt.Sprintf("posted %d of %d parts of the message: %s", i+1, len(chunks), err.Error()),
+			})
+			break
+		}
+		result.ContinuationTS = append(result.ContinuationTS, ts)
+	}
+
+	result.Workspace = workspaceFor(ctx, h.slackClient)
+	// Link to the posted message so it can be reviewed
+	if result.Workspace != nil {
+		if permalink, err := urlparser.Build(result.Workspace.URL, channelID, messageTS, opts.ThreadTS); err == nil {
+			result.Permalink = permalink
+		}
+	}
+
+	return h.successResult(result)
+}
+
+// handleError converts errors to appropriate MCP error results.
+func (h *PostMessageHandler) handleError(err error) *mcp.CallToolResult {
+	// Check for known error types and provide appropriate messages
+	if slackclient.IsRateLimited(err) {
+		return mcp.NewToolResultError(
+			"Rate limit exceeded. Please wait and try again.")
+	}
+
+	if slackclient.IsInvalidToken(err) {
+		return mcp.NewToolResultError(
+			"Authentication failed. Please check that SLACK_BOT_TOKEN is valid and not expired.")
+	}
+
+	if slackclient.IsChannelNotFound(err) {
+		return mcp.NewToolResultError(
+			"Channel not found. The channel may have been deleted, or the channel_id is incorrect.")
+	}
+
+	if slackclient.IsNotInChannel(err) {
+		return mcp.NewToolResultError(
+			"The bot is not a member of this channel. Please invite the bot to the channel first.")
+	}
+
+	// Posting requires chat:write, which read-only installs of the app do not have
+	if slackclient.IsMissingScope(err) {
+		return mcp.NewToolResultError(fmt.Sprintf(
+			"Posting messages requires the chat:write bot scope. %s", err.Error()))
+	}
+
+	if slackclient.IsChannelArchived(err) {
+		return mcp.NewToolResultError(
+			"The channel is archived. Messages cannot be posted to archived channels.")
+	}
+
+	if slackclient.IsPermissionDenied(err) {
+		return mcp.NewToolResultError(
+			"Permission denied. The bot lacks permission to post in this channel.")
+	}
+
+	// Generic error handling
+	return mcp.NewToolResultError(fmt.Sprintf("Failed to post message: %s", err.Error()))
+}
+
+// successResult creates a successful MCP tool result with the given data.
+func (h *PostMessageHandler) successResult(result *types.PostMessageResult) (*mcp.CallToolResult, error) {
+	resultJSON, err := json.Marshal(result)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to encode result: %s", err.Error())), nil
+	}
+
+	return mcp.NewToolResultText(string(resultJSON)), nil
+}
+
+// HandleFunc returns a function that can be used directly as an MCP tool handler.
+// This is a convenience method for registering the handler with the MCP server.
+func (h *PostMessageHandler) HandleFunc() func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return h.Handle
+}
+
+// parseBlocksArgument parses a blocks argument: a Block Kit JSON array, given as an
+// array or as a string of JSON (e.g., the blocks returned by compose_blocks).
+func parseBlocksArgument(arg interface{}) ([]slack.Block, error) {
+	var data []byte
+	switch v := arg.(type) {
+	case string:
+		data = []byte(v)
+	case []interface{}:
+		encoded, err := json.Marshal(v)
+		if err != nil {
+			return nil, err
+		}
+		data = encoded
+	default:
+		return nil, fmt.Errorf("must be an array of Block Kit blocks")
+	}
+	return blockkit.Parse(data)
+}
@@ -0,0 +1,267 @@
+// Package tools provides unit tests for the MCP tool handlers.
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	slackclient "github.com/Bitovi/slack-mcp-server/internal/slack"
+	"github.com/Bitovi/slack-mcp-server/pkg/types"
+)
+
+func TestPostMessageHandler_Handle(t *testing.T) {
+	tests := []struct {
+		name          string
+		args          map[string]interface{}
+		postErr       error
+		wantOpts      slackclient.PostMessageOptions
+		wantPermalink string
+		wantError     string
+	}{
+		{
+			name:          "channel message",
+			args:          map[string]interface{}{"channel_id": "C01234567", "text": "Deploy finished"},
+			wantPermalink: "https://workspace.slack.com/archives/C01234567/p1355517524000001",
+		},
+		{
+			name: "thread reply",
+			args: map[string]interface{}{
+				"channel_id":      "C01234567",
+				"text":            "Deploy finished",
+				"thread_ts":       "1355517523.000008",
+				"reply_broadcast": true,
+			},
+			wantOpts:      slackclient.PostMessageOptions{ThreadTS: "1355517523.000008", ReplyBroadcast: true},
+			wantPermalink: "https://workspace.slack.com/archives/C01234567/p1355517524000001?thread_ts=1355517523.000008&cid=C01234567",
+		},
+		{
+			name:      "missing channel",
+			args:      map[string]interface{}{"text": "Deploy finished"},
+			wantError: "missing required argument 'channel_id'",
+		},
+		{
+			name:      "empty text",
+			args:      map[string]interface{}{"channel_id": "C01234567", "text": ""},
+			wantError: "argument 'text' cannot be empty",
+		},
+		{
+			name:      "broadcast without thread",
+			args:      map[string]interface{}{"channel_id": "C01234567", "text": "t", "reply_broadcast": true},
+			wantError: "'reply_broadcast' requires 'thread_ts'",
+		},
+		{
+			name:      "blocks of the wrong type",
+			args:      map[string]interface{}{"channel_id": "C01234567", "text": "t", "blocks": float64(1)},
+			wantError: "argument 'blocks' is invalid: must be an array",
+		},
+		{
+			name: "blocks that cannot be posted",
+			args: map[string]interface{}{"channel_id": "C01234567", "text": "t",
+				"blocks": []interface{}{map[string]interface{}{"type": "input"}}},
+			wantError: `argument 'blocks' is invalid: block 0 has type "input"`,
+		},
+		{
+			name: "blocks with fallback text over the limit",
+			args: map[string]interface{}{"channel_id": "C01234567", "text": strings.Repeat("a", maxMessageLength+1),
+				"blocks": `[{"type":"divider"}]`},
+			wantError: "the fallback text of blocks cannot be split",
+		},
+		{
+			name: "missing chat:write",
+			args: map[string]interface{}{"channel_id": "C01234567", "text": "Deploy finished"},
+			postErr: types.NewSlackError(types.ErrCodeMissingScope,
+				"Missing scope chat:write. Add it under OAuth & Permissions in the Slack app settings and reinstall the app."),
+			wantError: "Posting messages requires the chat:write bot scope. Missing scope chat:write.",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var postedOpts slackclient.PostMessageOptions
+			posted := false
+			mock := &mockSlackClient{
+				postMessage: func(ctx context.Context, channelID, text string, opts slackclient.PostMessageOptions) (string, error) {
+					if tt.postErr != nil {
+						return "", tt.postErr
+					}
+					posted, postedOpts = true, opts
+					return "1355517524.000001", nil
+				},
+			}
+
+			handler := NewPostMessageHandler(mock)
+			result, err := handler.Handle(context.Background(), createToolRequest(tt.args))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			text := result.Content[0].(mcp.TextContent).Text
+			if tt.wantError != "" {
+				if !result.IsError || !strings.Contains(text, tt.wantError) {
+					t.Errorf("expected error containing %q, got: %s", tt.wantError, text)
+				}
+				if posted {
+					t.Error("expected no message to be posted")
+				}
+				return
+			}
+			if result.IsError {
+				t.Fatalf("expected success, got error: %s", text)
+			}
+			if !reflect.DeepEqual(postedOpts, tt.wantOpts) {
+				t.Errorf("options = %+v, want %+v", postedOpts, tt.wantOpts)
+			}
+
+			var postResult types.PostMessageResult
+			if err := json.Unmarshal([]byte(text), &postResult); err != nil {
+				t.Fatalf("failed to parse result JSON: %v", err)
+			}
+			if postResult.MessageTS != "1355517524.000001" || postResult.Permalink != tt.wantPermalink {
+				t.Errorf("message_ts = %q, permalink = %q, want %q", postResult.MessageTS, postResult.Permalink, tt.wantPermalink)
+			}
+		})
+	}
+}
+
+func TestPostMessageHandler_Handle_Blocks(t *testing.T) {
+	// The output of compose_blocks is posted as is, given as an array or a string
+	composed, err := NewComposeBlocksHandler().Handle(context.Background(), createToolRequest(map[string]interface{}{
+		"markdown": "# Deploy finished\n\nAll checks *passed*.",
+	}))
+	if err != nil || composed.IsError {
+		t.Fatalf("compose_blocks failed: %v, %+v", err, composed)
+	}
+	var blocks types.ComposeBlocksResult
+	if err := json.Unmarshal([]byte(composed.Content[0].(mcp.TextContent).Text), &blocks); err != nil {
+		t.Fatalf("failed to parse compose_blocks result: %v", err)
+	}
+	var blocksArray []interface{}
+	if err := json.Unmarshal(blocks.Blocks, &blocksArray); err != nil {
+		t.Fatalf("failed to parse blocks: %v", err)
+	}
+	blocksJSON := string(blocks.Blocks)
+
+	for name, arg := range map[string]interface{}{"array": blocksArray, "string": blocksJSON} {
+		t.Run(name, func(t *testing.T) {
+			var postedText string
+			var postedOpts slackclient.PostMessageOptions
+			mock := &mockSlackClient{
+				postMessage: func(ctx context.Context, channelID, text string, opts slackclient.PostMessageOptions) (string, error) {
+					postedText, postedOpts = text, opts
+					return "1355517524.000001", nil
+				},
+			}
+
+			handler := NewPostMessageHandler(mock)
+			result, err := handler.Handle(context.Background(), createToolRequest(map[string]interface{}{
+				"channel_id": "C01234567",
+				"text":       blocks.Text,
+				"blocks":     arg,
+			}))
+			if err != nil || result.IsError {
+				t.Fatalf("expected success, got %v, %+v", err, result.Content)
+			}
+			if postedText != blocks.Text {
+				t.Errorf("text = %q, want the fallback %q", postedText, blocks.Text)
+			}
+			if got, _ := json.Marshal(postedOpts.Blocks); string(got) != blocksJSON {
+				t.Errorf("blocks = %s, want %s", got, blocksJSON)
+			}
+		})
+	}
+}
+
+func TestPostMessageHandler_Handle_LongTextSnippet(t *testing.T) {
+	longText := strings.Repeat("word ", maxMessageLength/5+1)
+
+	t.Run("uploads the text as a snippet", func(t *testing.T) {
+		var uploaded, uploadedThread string
+		mock := &mockSlackClient{
+			postMessage: func(ctx context.Context, channelID, text string, opts slackclient.PostMessageOptions) (string, error) {
+				t.Error("expected no message to be posted")
+				return "", nil
+			},
+			uploadSnippet: func(ctx context.Context, channelID, filename, content string, opts slackclient.SnippetOptions) (string, error) {
+				uploaded, uploadedThread = content, opts.ThreadTS
+				return "F01234567", nil
+			},
+		}
+
+		handler := NewPostMessageHandler(mock)
+		result, err := handler.Handle(context.Background(), createToolRequest(map[string]interface{}{
+			"channel_id":   "C01234567",
+			"text":         longText,
+			"thread_ts":    "1355517523.000008",
+			"on_long_text": "snippet",
+		}))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		text := result.Content[0].(mcp.TextContent).Text
+		if result.IsError {
+			t.Fatalf("expected success, got error: %s", text)
+		}
+		if uploaded != longText || uploadedThread != "1355517523.000008" {
+			t.Errorf("uploaded %d characters in thread %q, want %d in 1355517523.000008",
+				len(uploaded), uploadedThread, len(longText))
+		}
+
+		var postResult types.PostMessageResult
+		if err := json.Unmarshal([]byte(text), &postResult); err != nil {
+			t.Fatalf("failed to parse result JSON: %v", err)
+		}
+		if postResult.FileID != "F01234567" || postResult.MessageTS != "" || postResult.Permalink != "" {
+			t.Errorf("unexpected result: file_id = %q, message_ts = %q, permalink = %q",
+				postResult.FileID, postResult.MessageTS, postResult.Permalink)
+		}
+	})
+
+	t.Run("short text is posted as a message", func(t *testing.T) {
+		mock := &mockSlackClient{
+			uploadSnippet: func(ctx context.Context, channelID, filename, content string, opts slackclient.SnippetOptions) (string, error) {
+				t.Error("expected no snippet upload")
+				return "", nil
+			},
+		}
+
+		handler := NewPostMessageHandler(mock)
+		result, err := handler.Handle(context.Background(), createToolRequest(map[string]interface{}{
+			"channel_id":   "C01234567",
+			"text":         "Deploy finished",
+			"on_long_text": "snippet",
+		}))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if result.IsError {
+			t.Fatalf("expected success, got error: %s", result.Content[0].(mcp.TextContent).Text)
+		}
+	})
+
+	t.Run("missing files:write scope", func(t *testing.T) {
+		mock := &mockSlackClient{
+			uploadSnippet: func(ctx context.Context, channelID, filename, content string, opts slackclient.SnippetOptions) (string, error) {
+				return "", types.NewSlackError(types.ErrCodeMissingScope, "Missing scope files:write.")
+			},
+		}
+
+		handler := NewPostMessageHandler(mock)
+		result, err := handler.Handle(context.Background(), createToolRequest(map[string]interface{}{
+			"channel_id":   "C01234567",
+			"text":         longText,
+			"on_long_text": "snippet",
+		}))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if text := result.Content[0].(mcp.TextContent).Text; !result.IsError || !strings.Contains(text, "files:write") ||
+			strings.Contains(text, "chat:write") {
+			t.Errorf("expected files:write scope error, got: %s", text)
+		}
+	})
+}
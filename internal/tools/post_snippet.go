@@ -0,0 +1,254 @@
+// Package tools provides MCP tool handler implementations for the Slack MCP server.
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	slackclient "github.com/Bitovi/slack-mcp-server/internal/slack"
+	"github.com/Bitovi/slack-mcp-server/pkg/types"
+)
+
+// maxSnippetLength is the largest snippet content, in bytes, that post_snippet uploads.
+// Slack only previews the start of larger files, which defeats the point of a snippet.
+const maxSnippetLength = 1 << 20
+
+// snippetTypes maps filename extensions to Slack snippet types, so that a snippet
+// named "query.sql" is highlighted as SQL without the caller naming the type.
+var snippetTypes = map[string]string{
+	".c":     "c",
+	".cpp":   "cpp",
+	".cs":    "csharp",
+	".css":   "css",
+	".csv":   "csv",
+	".diff":  "diff",
+	".go":    "go",
+	".html":  "html",
+	".java":  "java",
+	".js":    "javascript",
+	".json":  "json",
+	".kt":    "kotlin",
+	".log":   "text",
+	".md":    "markdown",
+	".patch": "diff",
+	".php":   "php",
+	".py":    "python",
+	".rb":    "ruby",
+	".rs":    "rust",
+	".sh":    "shell",
+	".sql":   "sql",
+	".swift": "swift",
+	".ts":    "typescript",
+	".txt":   "text",
+	".xml":   "xml",
+	".yaml":  "yaml",
+	".yml":   "yaml",
+}
+
+// PostSnippetHandler handles the post_snippet MCP tool requests.
+// It shares text as a snippet file with syntax highlighting, for logs, diffs,
+// or queries that would be unreadable as a plain message.
+// It is a write tool, registered only when write tools are enabled.
+type PostSnippetHandler struct {
+	// slackClient is the Slack API client for uploading snippets.
+	slackClient slackclient.ClientInterface
+	// config holds optional handler behavior.
+	config handlerConfig
+}
+
+// NewPostSnippetHandler creates a new PostSnippetHandler with the given Slack client and options.
+func NewPostSnippetHandler(client slackclient.ClientInterface, opts ...HandlerOption) *PostSnippetHandler {
+	return &PostSnippetHandler{
+		slackClient: client,
+		config:      newHandlerConfig(opts),
+	}
+}
+
+// Handle processes a post_snippet tool call.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeouts
+//   - request: The MCP tool call request containing channel_id, content, and optional
+//     filename, title, snippet_type, initial_comment, and thread_ts
+//
+// Returns an MCP tool result containing the uploaded file's ID,
+// or an error result if the arguments are invalid or Slack rejects the upload.
+func (h *PostSnippetHandler) Handle(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	// Extract the channel_id argument (required)
+	channelIDArg, ok := request.Params.Arguments["channel_id"]
+	if !ok {
+		return mcp.NewToolResultError("missing required argument 'channel_id'"), nil
+	}
+
+	channelID, ok := channelIDArg.(string)
+	if !ok {
+		return mcp.NewToolResultError("argument 'channel_id' must be a string"), nil
+	}
+
+	if channelID == "" {
+		return mcp.NewToolResultError("argument 'channel_id' cannot be empty"), nil
+	}
+
+	channelID, errResult := resolveChannelRef(ctx, h.slackClient, channelID, h.handleError)
+	if errResult != nil {
+		return errResult, nil
+	}
+
+	// Extract the content argument (required)
+	contentArg, ok := request.Params.Arguments["content"]
+	if !ok {
+		return mcp.NewToolResultError("missing required argument 'content'"), nil
+	}
+
+	content, ok := contentArg.(string)
+	if !ok {
+		return mcp.NewToolResultError("argument 'content' must be a string"), nil
+	}
+
+	if strings.TrimSpace(content) == "" {
+		return mcp.NewToolResultError("argument 'content' cannot be empty"), nil
+	}
+
+	if len(content) > maxSnippetLength {
+		return mcp.NewToolResultError(fmt.Sprintf("argument 'content' is %d bytes; snippets are limited to %d",
+			len(content), maxSnippetLength)), nil
+	}
+
+	// Extract the optional string arguments
+	var filename, snippetType string
+	var opts slackclient.SnippetOptions
+	for _, optional := range []struct {
+		name   string
+		target *string
+	}{
+		{"filename", &filename},
+		{"title", &opts.Title},
+		{"snippet_type", &snippetType},
+		{"initial_comment", &opts.InitialComment},
+		{"thread_ts", &opts.ThreadTS},
+	} {
+		name, target := optional.name, optional.target
+		arg, exists := request.Params.Arguments[name]
+		if !exists {
+			continue
+		}
+		v, ok := arg.(string)
+		if !ok {
+			return mcp.NewToolResultError(fmt.Sprintf("argument '%s' must be a string", name)), nil
+		}
+		*target = strings.TrimSpace(v)
+	}
+
+	if strings.ContainsAny(filename, "/\\") {
+		return mcp.NewToolResultError(fmt.Sprintf("argument 'filename' must be a file name without a path, got %q", filename)), nil
+	}
+
+	// Infer the syntax highlighting from the filename, or name the file after the type
+	snippetType = strings.ToLower(snippetType)
+	if snippetType == "" {
+		snippetType = snippetTypes[strings.ToLower(path.Ext(filename))]
+	}
+	if filename == "" {
+		filename = "snippet" + snippetExtension(snippetType)
+	}
+	opts.SnippetType = snippetType
+
+	fileID, err := h.slackClient.UploadSnippet(ctx, channelID, filename, content, opts)
+	if err != nil {
+		return h.handleError(err), nil
+	}
+
+	result := &types.PostSnippetResult{
+		ChannelID:   channelID,
+		FileID:      fileID,
+		Filename:    filename,
+		Title:       opts.Title,
+		SnippetType: snippetType,
+		ThreadTS:    opts.ThreadTS,
+	}
+
+	result.Workspace = workspaceFor(ctx, h.slackClient)
+
+	return h.successResult(result)
+}
+
+// snippetExtension returns the filename extension for a snippet type, or ".txt"
+// if the type has none in snippetTypes.
+func snippetExtension(snippetType string) string {
+	if snippetType == "" || snippetType == "text" {
+		return ".txt"
+	}
+	best := ""
+	for ext, t := range snippetTypes {
+		// Prefer the shortest extension (".yml" over ".yaml") for a stable choice
+		if t == snippetType && (best == "" || len(ext) < len(best) || (len(ext) == len(best) && ext < best)) {
+			best = ext
+		}
+	}
+	if best == "" {
+		return ".txt"
+	}
+	return best
+}
+
+// handleError converts errors to appropriate MCP error results.
+func (h *PostSnippetHandler) handleError(err error) *mcp.CallToolResult {
+	// Check for known error types and provide appropriate messages
+	if slackclient.IsRateLimited(err) {
+		return mcp.NewToolResultError(
+			"Rate limit exceeded. Please wait and try again.")
+	}
+
+	if slackclient.IsInvalidToken(err) {
+		return mcp.NewToolResultError(
+			"Authentication failed. Please check that SLACK_BOT_TOKEN is valid and not expired.")
+	}
+
+	if slackclient.IsChannelNotFound(err) {
+		return mcp.NewToolResultError(
+			"Channel not found. The channel may have been deleted, or the channel_id is incorrect.")
+	}
+
+	if slackclient.IsNotInChannel(err) {
+		return mcp.NewToolResultError(
+			"The bot is not a member of this channel. Please invite the bot to the channel first.")
+	}
+
+	if slackclient.IsMissingScope(err) {
+		return mcp.NewToolResultError(err.Error())
+	}
+
+	if slackclient.IsChannelArchived(err) {
+		return mcp.NewToolResultError(
+			"The channel is archived. Snippets cannot be shared in archived channels.")
+	}
+
+	if slackclient.IsPermissionDenied(err) {
+		return mcp.NewToolResultError(
+			"Permission denied. The bot lacks permission to share files in this channel.")
+	}
+
+	// Generic error handling
+	return mcp.NewToolResultError(fmt.Sprintf("Failed to post snippet: %s", err.Error()))
+}
+
+// successResult creates a successful MCP tool result with the given data.
+func (h *PostSnippetHandler) successResult(result *types.PostSnippetResult) (*mcp.CallToolResult, error) {
+	resultJSON, err := json.Marshal(result)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to encode result: %s", err.Error())), nil
+	}
+
+	return mcp.NewToolResultText(string(resultJSON)), nil
+}
+
+// HandleFunc returns a function that can be used directly as an MCP tool handler.
+// This is a convenience method for registering the handler with the MCP server.
+func (h *PostSnippetHandler) HandleFunc() func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return h.Handle
+}
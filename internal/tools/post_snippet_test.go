@@ -0,0 +1,164 @@
+// Package tools provides unit tests for the MCP tool handlers.
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	slackclient "github.com/Bitovi/slack-mcp-server/internal/slack"
+	"github.com/Bitovi/slack-mcp-server/pkg/types"
+)
+
+func TestPostSnippetHandler_Handle(t *testing.T) {
+	tests := []struct {
+		name         string
+		args         map[string]interface{}
+		uploadErr    error
+		wantFilename string
+		wantType     string
+		wantError    string
+	}{
+		{
+			name: "type from filename",
+			args: map[string]interface{}{
+				"channel_id": "C01234567",
+				"content":    "SELECT 1;",
+				"filename":   "query.SQL",
+				"thread_ts":  "1355517523.000008",
+			},
+			wantFilename: "query.SQL",
+			wantType:     "sql",
+		},
+		{
+			name: "filename from type",
+			args: map[string]interface{}{
+				"channel_id":   "C01234567",
+				"content":      "- old\n+ new",
+				"snippet_type": "diff",
+			},
+			wantFilename: "snippet.diff",
+			wantType:     "diff",
+		},
+		{
+			name: "explicit type overrides filename",
+			args: map[string]interface{}{
+				"channel_id":   "C01234567",
+				"content":      "panic: oops",
+				"filename":     "crash.txt",
+				"snippet_type": "go",
+			},
+			wantFilename: "crash.txt",
+			wantType:     "go",
+		},
+		{
+			name: "no filename or type",
+			args: map[string]interface{}{
+				"channel_id": "C01234567",
+				"content":    "some output",
+			},
+			wantFilename: "snippet.txt",
+		},
+		{
+			name: "empty content",
+			args: map[string]interface{}{
+				"channel_id": "C01234567",
+				"content":    "  \n",
+			},
+			wantError: "argument 'content' cannot be empty",
+		},
+		{
+			name: "content too long",
+			args: map[string]interface{}{
+				"channel_id": "C01234567",
+				"content":    strings.Repeat("x", maxSnippetLength+1),
+			},
+			wantError: "snippets are limited to",
+		},
+		{
+			name: "filename with path",
+			args: map[string]interface{}{
+				"channel_id": "C01234567",
+				"content":    "x",
+				"filename":   "../etc/passwd",
+			},
+			wantError: "without a path",
+		},
+		{
+			name: "non-string title",
+			args: map[string]interface{}{
+				"channel_id": "C01234567",
+				"content":    "x",
+				"title":      42,
+			},
+			wantError: "argument 'title' must be a string",
+		},
+		{
+			name: "bot not in channel",
+			args: map[string]interface{}{
+				"channel_id": "C01234567",
+				"content":    "x",
+			},
+			uploadErr: types.NewSlackError(types.ErrCodeNotInChannel, "Bot is not a member of this channel."),
+			wantError: "not a member of this channel",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var uploaded struct {
+				channelID, filename, content string
+				opts                         slackclient.SnippetOptions
+			}
+			mock := &mockSlackClient{
+				uploadSnippet: func(ctx context.Context, channelID, filename, content string, opts slackclient.SnippetOptions) (string, error) {
+					uploaded.channelID, uploaded.filename, uploaded.content, uploaded.opts = channelID, filename, content, opts
+					if tt.uploadErr != nil {
+						return "", tt.uploadErr
+					}
+					return "F01234567", nil
+				},
+			}
+
+			handler := NewPostSnippetHandler(mock)
+			result, err := handler.Handle(context.Background(), createToolRequest(tt.args))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			text := result.Content[0].(mcp.TextContent).Text
+			if tt.wantError != "" {
+				if !result.IsError || !strings.Contains(text, tt.wantError) {
+					t.Errorf("expected error containing %q, got: %s", tt.wantError, text)
+				}
+				return
+			}
+			if result.IsError {
+				t.Fatalf("expected success, got error: %s", text)
+			}
+
+			var snippetResult types.PostSnippetResult
+			if err := json.Unmarshal([]byte(text), &snippetResult); err != nil {
+				t.Fatalf("failed to parse result JSON: %v", err)
+			}
+			if uploaded.filename != tt.wantFilename || snippetResult.Filename != tt.wantFilename {
+				t.Errorf("uploaded %q (result %q), want %q", uploaded.filename, snippetResult.Filename, tt.wantFilename)
+			}
+			if uploaded.opts.SnippetType != tt.wantType || snippetResult.SnippetType != tt.wantType {
+				t.Errorf("snippet type = %q (result %q), want %q", uploaded.opts.SnippetType, snippetResult.SnippetType, tt.wantType)
+			}
+			if uploaded.content != tt.args["content"] {
+				t.Errorf("uploaded content = %q, want %q", uploaded.content, tt.args["content"])
+			}
+			if snippetResult.FileID != "F01234567" {
+				t.Errorf("file_id = %q, want %q", snippetResult.FileID, "F01234567")
+			}
+			if uploaded.opts.ThreadTS != snippetResult.ThreadTS {
+				t.Errorf("thread_ts = %q, uploaded %q", snippetResult.ThreadTS, uploaded.opts.ThreadTS)
+			}
+		})
+	}
+}
@@ -0,0 +1,179 @@
+// Package tools provides MCP tool handler implementations for the Slack MCP server.
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	slackclient "github.com/Bitovi/slack-mcp-server/internal/slack"
+	"github.com/Bitovi/slack-mcp-server/pkg/types"
+)
+
+const (
+	// defaultAuditLogEntries is the number of audit log entries returned when no limit is given.
+	defaultAuditLogEntries = 50
+	// maxAuditLogEntries caps the audit log entries returned in one call.
+	maxAuditLogEntries = 1000
+)
+
+// ReadAuditLogsHandler handles the read_audit_logs MCP tool requests.
+// It reads Enterprise Grid Audit Logs API entries, so that security and compliance
+// agents can answer questions such as "who archived #x" or "when was the retention
+// policy changed". It is registered only when an audit token is configured.
+type ReadAuditLogsHandler struct {
+	// slackClient is the Slack API client for reading audit logs.
+	slackClient slackclient.ClientInterface
+	// config holds optional handler behavior.
+	config handlerConfig
+}
+
+// NewReadAuditLogsHandler creates a new ReadAuditLogsHandler with the given Slack client and options.
+func NewReadAuditLogsHandler(client slackclient.ClientInterface, opts ...HandlerOption) *ReadAuditLogsHandler {
+	return &ReadAuditLogsHandler{
+		slackClient: client,
+		config:      newHandlerConfig(opts),
+	}
+}
+
+// Handle processes a read_audit_logs tool call.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeouts
+//   - request: The MCP tool call request containing optional action, actor, entity,
+//     oldest, latest, limit, and cursor filters
+//
+// Returns an MCP tool result containing the matching entries, newest first, or an
+// error result if the arguments are invalid or the Audit Logs API rejects the read.
+func (h *ReadAuditLogsHandler) Handle(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var query slackclient.AuditLogQuery
+
+	// Extract the optional string filters
+	for _, optional := range []struct {
+		name   string
+		target *string
+	}{
+		{"action", &query.Action},
+		{"actor", &query.Actor},
+		{"entity", &query.Entity},
+		{"cursor", &query.Cursor},
+	} {
+		name, target := optional.name, optional.target
+		arg, exists := request.Params.Arguments[name]
+		if !exists {
+			continue
+		}
+		v, ok := arg.(string)
+		if !ok {
+			return mcp.NewToolResultError(fmt.Sprintf("argument '%s' must be a string", name)), nil
+		}
+		*target = strings.TrimSpace(v)
+	}
+
+	// Extract oldest and latest parameters (optional Unix timestamps)
+	for _, optional := range []struct {
+		name   string
+		target *int64
+	}{
+		{"oldest", &query.Oldest},
+		{"latest", &query.Latest},
+	} {
+		name, target := optional.name, optional.target
+		arg, exists := request.Params.Arguments[name]
+		if !exists {
+			continue
+		}
+		v, ok := arg.(string)
+		if !ok {
+			return mcp.NewToolResultError(fmt.Sprintf("argument '%s' must be a string (Unix timestamp)", name)), nil
+		}
+		t, ok := parseSlackTime(v)
+		if !ok {
+			return mcp.NewToolResultError(fmt.Sprintf("argument '%s' must be a Unix timestamp, got %q", name, v)), nil
+		}
+		*target = t.Unix()
+	}
+
+	if query.Oldest != 0 && query.Latest != 0 && query.Oldest > query.Latest {
+		return mcp.NewToolResultError("argument 'oldest' must not be after 'latest'"), nil
+	}
+
+	// Extract limit parameter (optional)
+	query.Limit = defaultAuditLogEntries
+	if limitArg, exists := request.Params.Arguments["limit"]; exists {
+		v, ok := limitArg.(float64)
+		if !ok {
+			return mcp.NewToolResultError("argument 'limit' must be a number"), nil
+		}
+		if v < 1 || v > maxAuditLogEntries {
+			return mcp.NewToolResultError(fmt.Sprintf("argument 'limit' must be between 1 and %d", maxAuditLogEntries)), nil
+		}
+		query.Limit = int(v)
+	}
+
+	entries, cursor, err := h.slackClient.GetAuditLogs(ctx, query)
+	if err != nil {
+		return h.handleError(err), nil
+	}
+
+	result := &types.ReadAuditLogsResult{
+		Entries:    entries,
+		NextCursor: cursor,
+	}
+	if result.Entries == nil {
+		result.Entries = []types.AuditEntry{}
+	}
+
+	return h.successResult(result)
+}
+
+// handleError converts errors to appropriate MCP error results.
+func (h *ReadAuditLogsHandler) handleError(err error) *mcp.CallToolResult {
+	// Check for known error types and provide appropriate messages
+	if slackclient.IsRateLimited(err) {
+		return mcp.NewToolResultError(
+			"Rate limit exceeded. Please wait and try again.")
+	}
+
+	if slackclient.IsInvalidToken(err) {
+		return mcp.NewToolResultError(
+			"Authentication failed. Please check that SLACK_AUDIT_TOKEN is valid and not expired.")
+	}
+
+	if slackclient.IsAuditTokenNotConfigured(err) || slackclient.IsMissingScope(err) {
+		return mcp.NewToolResultError(err.Error())
+	}
+
+	// The Audit Logs API reports these only as error codes, which wrapSlackError does not classify
+	switch msg := err.Error(); {
+	case strings.Contains(msg, "feature_not_enabled"):
+		return mcp.NewToolResultError("The Audit Logs API is only available to Enterprise Grid organizations.")
+	case strings.Contains(msg, "not_allowed_token_type") || strings.Contains(msg, "not_an_enterprise"):
+		return mcp.NewToolResultError("The Audit Logs API requires an Enterprise Grid org owner's user token " +
+			"(SLACK_AUDIT_TOKEN), from an app installed at the org level with the auditlogs:read scope.")
+	case strings.Contains(msg, "invalid_cursor"):
+		return mcp.NewToolResultError("The cursor is invalid or expired. Repeat the read without a cursor.")
+	}
+
+	// Generic error handling
+	return mcp.NewToolResultError(fmt.Sprintf("Failed to read audit logs: %s", err.Error()))
+}
+
+// successResult creates a successful MCP tool result with the given data.
+func (h *ReadAuditLogsHandler) successResult(result *types.ReadAuditLogsResult) (*mcp.CallToolResult, error) {
+	resultJSON, err := json.Marshal(result)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to encode result: %s", err.Error())), nil
+	}
+
+	return mcp.NewToolResultText(string(resultJSON)), nil
+}
+
+// HandleFunc returns a function that can be used directly as an MCP tool handler.
+// This is a convenience method for registering the handler with the MCP server.
+func (h *ReadAuditLogsHandler) HandleFunc() func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return h.Handle
+}
@@ -0,0 +1,111 @@
+// Package tools provides unit tests for the MCP tool handlers.
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	slackclient "github.com/Bitovi/slack-mcp-server/internal/slack"
+	"github.com/Bitovi/slack-mcp-server/pkg/types"
+)
+
+func TestReadAuditLogsHandler_Handle(t *testing.T) {
+	tests := []struct {
+		name      string
+		args      map[string]interface{}
+		readErr   error
+		wantQuery slackclient.AuditLogQuery
+		wantError string
+	}{
+		{
+			name: "filters",
+			args: map[string]interface{}{
+				"action": "channel_archive",
+				"entity": "C01234567",
+				"oldest": "1709251200",
+				"latest": "1709337600.000100",
+				"limit":  float64(10),
+			},
+			wantQuery: slackclient.AuditLogQuery{
+				Action: "channel_archive",
+				Entity: "C01234567",
+				Oldest: 1709251200,
+				Latest: 1709337600,
+				Limit:  10,
+			},
+		},
+		{
+			name:      "defaults",
+			args:      map[string]interface{}{},
+			wantQuery: slackclient.AuditLogQuery{Limit: defaultAuditLogEntries},
+		},
+		{
+			name:      "invalid oldest",
+			args:      map[string]interface{}{"oldest": "yesterday"},
+			wantError: "must be a Unix timestamp",
+		},
+		{
+			name:      "oldest after latest",
+			args:      map[string]interface{}{"oldest": "1709337600", "latest": "1709251200"},
+			wantError: "must not be after",
+		},
+		{
+			name:      "limit too high",
+			args:      map[string]interface{}{"limit": float64(5000)},
+			wantError: "between 1 and 1000",
+		},
+		{
+			name:      "not enterprise",
+			args:      map[string]interface{}{},
+			readErr:   types.NewSlackError(types.ErrCodeSlackError, "Slack API error: feature_not_enabled"),
+			wantError: "only available to Enterprise Grid",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotQuery slackclient.AuditLogQuery
+			mock := &mockSlackClient{
+				getAuditLogs: func(ctx context.Context, query slackclient.AuditLogQuery) ([]types.AuditEntry, string, error) {
+					gotQuery = query
+					if tt.readErr != nil {
+						return nil, "", tt.readErr
+					}
+					return []types.AuditEntry{{ID: "0123a45b", Action: "channel_archive"}}, "next", nil
+				},
+			}
+
+			handler := NewReadAuditLogsHandler(mock)
+			result, err := handler.Handle(context.Background(), createToolRequest(tt.args))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			text := result.Content[0].(mcp.TextContent).Text
+			if tt.wantError != "" {
+				if !result.IsError || !strings.Contains(text, tt.wantError) {
+					t.Errorf("expected error containing %q, got: %s", tt.wantError, text)
+				}
+				return
+			}
+			if result.IsError {
+				t.Fatalf("expected success, got error: %s", text)
+			}
+
+			if gotQuery != tt.wantQuery {
+				t.Errorf("query = %+v, want %+v", gotQuery, tt.wantQuery)
+			}
+			var auditResult types.ReadAuditLogsResult
+			if err := json.Unmarshal([]byte(text), &auditResult); err != nil {
+				t.Fatalf("failed to parse result JSON: %v", err)
+			}
+			if len(auditResult.Entries) != 1 || auditResult.NextCursor != "next" {
+				t.Errorf("unexpected result %+v", auditResult)
+			}
+		})
+	}
+}
@@ -0,0 +1,204 @@
+// Package tools provides MCP tool handler implementations for the Slack MCP server.
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/Bitovi/slack-mcp-server/internal/requestid"
+	slackclient "github.com/Bitovi/slack-mcp-server/internal/slack"
+	"github.com/Bitovi/slack-mcp-server/internal/urlparser"
+	"github.com/Bitovi/slack-mcp-server/pkg/types"
+)
+
+const (
+	// defaultDMMessages is the number of DM messages returned when no limit is given.
+	defaultDMMessages = 50
+	// maxDMMessages caps the DM messages returned in one call.
+	maxDMMessages = 200
+)
+
+// ReadDMHistoryHandler handles the read_dm_history MCP tool requests.
+// It reads the direct messages between the user token's user and another user.
+// DMs carry a very different privacy expectation than channels, so the tool is
+// registered only when DM reads are explicitly allowed, and every read is recorded
+// in the audit log; without an audit log, reads are refused.
+type ReadDMHistoryHandler struct {
+	// slackClient is the Slack API client for reading DM history.
+	slackClient slackclient.ClientInterface
+	// config holds optional handler behavior, including the required audit log.
+	config handlerConfig
+}
+
+// NewReadDMHistoryHandler creates a new ReadDMHistoryHandler with the given Slack client and options.
+// Pass WithAuditLog; without it, every call is rejected.
+func NewReadDMHistoryHandler(client slackclient.ClientInterface, opts ...HandlerOption) *ReadDMHistoryHandler {
+	return &ReadDMHistoryHandler{
+		slackClient: client,
+		config:      newHandlerConfig(opts),
+	}
+}
+
+// Handle processes a read_dm_history tool call.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeouts
+//   - request: The MCP tool call request containing user and optional limit, oldest, and latest
+//
+// Returns an MCP tool result containing the DM messages, newest first, or an error
+// result if the arguments are invalid, no audit log is configured, or the read fails.
+func (h *ReadDMHistoryHandler) Handle(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	if h.config.auditLog == nil {
+		return mcp.NewToolResultError("read_dm_history requires an audit log, and none is configured. DM reads are refused."), nil
+	}
+
+	// Extract the user argument (required)
+	userArg, ok := request.Params.Arguments["user"]
+	if !ok {
+		return mcp.NewToolResultError("missing required argument 'user'"), nil
+	}
+
+	userRef, ok := userArg.(string)
+	if !ok {
+		return mcp.NewToolResultError("argument 'user' must be a string"), nil
+	}
+
+	userID, ok := urlparser.ParseUserReference(strings.TrimSpace(userRef))
+	if !ok {
+		return mcp.NewToolResultError(fmt.Sprintf("argument 'user' must be a Slack user ID (e.g., U01234567) "+
+			"or profile link, got %q", userRef)), nil
+	}
+
+	// Extract limit parameter (optional)
+	limit := defaultDMMessages
+	if limitArg, exists := request.Params.Arguments["limit"]; exists {
+		v, ok := limitArg.(float64)
+		if !ok {
+			return mcp.NewToolResultError("argument 'limit' must be a number"), nil
+		}
+		if v < 1 || v > maxDMMessages {
+			return mcp.NewToolResultError(fmt.Sprintf("argument 'limit' must be between 1 and %d", maxDMMessages)), nil
+		}
+		limit = int(v)
+	}
+
+	// Extract oldest and latest parameters (optional Unix timestamps)
+	var oldest, latest string
+	for _, optional := range []struct {
+		name   string
+		target *string
+	}{
+		{"oldest", &oldest},
+		{"latest", &latest},
+	} {
+		name, target := optional.name, optional.target
+		arg, exists := request.Params.Arguments[name]
+		if !exists {
+			continue
+		}
+		v, ok := arg.(string)
+		if !ok {
+			return mcp.NewToolResultError(fmt.Sprintf("argument '%s' must be a string (Unix timestamp)", name)), nil
+		}
+		if _, ok := parseSlackTime(v); !ok {
+			return mcp.NewToolResultError(fmt.Sprintf("argument '%s' must be a Unix timestamp, got %q", name, v)), nil
+		}
+		*target = v
+	}
+
+	channelID, messages, hasMore, err := h.slackClient.GetDMHistory(ctx, userID, slackclient.HistoryOptions{
+		Limit:              limit,
+		Oldest:             oldest,
+		Latest:             latest,
+		IncludeAllMetadata: true,
+	})
+	h.audit(ctx, userID, channelID, len(messages), err)
+	if err != nil {
+		return h.handleError(err), nil
+	}
+
+	// read_dm_history does not offer include_raw
+	clearRaw(messages)
+
+	result := &types.ReadDMHistoryResult{
+		UserID:    userID,
+		ChannelID: channelID,
+		Messages:  messages,
+		HasMore:   hasMore,
+	}
+	if result.Messages == nil {
+		result.Messages = []types.Message{}
+	}
+
+	// Resolve the other user (graceful degradation on failure)
+	if user, err := h.slackClient.GetUserInfo(ctx, userID); err == nil {
+		result.User = user
+	}
+
+	// Resolve the users, channels, and user groups the messages refer to; read_dm_history
+	// has no warnings, so entities that cannot be resolved are simply omitted
+	refs := newEntityRefs(h.slackClient)
+	for i := range result.Messages {
+		refs.addMessage(&result.Messages[i])
+	}
+	mappings := refs.resolve(ctx, nil)
+	result.UserMapping = mappings.users
+	result.ChannelMapping = mappings.channels
+	result.UserGroupMapping = mappings.userGroups
+
+	result.Workspace = workspaceFor(ctx, h.slackClient)
+
+	return h.successResult(result)
+}
+
+// audit records a DM read in the audit log, whether or not it succeeded.
+// Only who was read and how much is recorded, never message content.
+func (h *ReadDMHistoryHandler) audit(ctx context.Context, userID, channelID string, count int, err error) {
+	status := "ok"
+	if err != nil {
+		status = fmt.Sprintf("failed error=%q", err.Error())
+	}
+	h.config.auditLog.Printf("audit request_id=%s tool=read_dm_history user=%s channel=%s messages=%d status=%s",
+		requestid.FromContext(ctx), userID, channelID, count, status)
+}
+
+// handleError converts errors to appropriate MCP error results.
+func (h *ReadDMHistoryHandler) handleError(err error) *mcp.CallToolResult {
+	// Check for known error types and provide appropriate messages
+	if slackclient.IsRateLimited(err) {
+		return mcp.NewToolResultError(
+			"Rate limit exceeded. Please wait and try again.")
+	}
+
+	if slackclient.IsInvalidToken(err) {
+		return mcp.NewToolResultError(
+			"Authentication failed. Please check that SLACK_USER_TOKEN is valid and not expired.")
+	}
+
+	if slackclient.IsUserTokenNotConfigured(err) || slackclient.IsMissingScope(err) || slackclient.IsChannelNotFound(err) {
+		return mcp.NewToolResultError(err.Error())
+	}
+
+	// Generic error handling
+	return mcp.NewToolResultError(fmt.Sprintf("Failed to read DM history: %s", err.Error()))
+}
+
+// successResult creates a successful MCP tool result with the given data.
+func (h *ReadDMHistoryHandler) successResult(result *types.ReadDMHistoryResult) (*mcp.CallToolResult, error) {
+	resultJSON, err := json.Marshal(result)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to encode result: %s", err.Error())), nil
+	}
+
+	return mcp.NewToolResultText(string(resultJSON)), nil
+}
+
+// HandleFunc returns a function that can be used directly as an MCP tool handler.
+// This is a convenience method for registering the handler with the MCP server.
+func (h *ReadDMHistoryHandler) HandleFunc() func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return h.Handle
+}
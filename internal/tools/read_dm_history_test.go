@@ -0,0 +1,142 @@
+// Package tools provides unit tests for the MCP tool handlers.
+package tools
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	slackclient "github.com/Bitovi/slack-mcp-server/internal/slack"
+	"github.com/Bitovi/slack-mcp-server/pkg/types"
+)
+
+func TestReadDMHistoryHandler_Handle(t *testing.T) {
+	tests := []struct {
+		name       string
+		args       map[string]interface{}
+		historyErr error
+		wantUser   string
+		wantLimit  int
+		wantError  string
+		wantAudit  string
+	}{
+		{
+			name:      "user ID",
+			args:      map[string]interface{}{"user": "U07654321"},
+			wantUser:  "U07654321",
+			wantLimit: defaultDMMessages,
+			wantAudit: "user=U07654321 channel=D01234567 messages=2 status=ok",
+		},
+		{
+			name:      "profile link with limit",
+			args:      map[string]interface{}{"user": "https://workspace.slack.com/team/U07654321", "limit": float64(10)},
+			wantUser:  "U07654321",
+			wantLimit: 10,
+			wantAudit: "user=U07654321",
+		},
+		{
+			name:      "missing user",
+			args:      map[string]interface{}{},
+			wantError: "missing required argument 'user'",
+		},
+		{
+			name:      "channel instead of user",
+			args:      map[string]interface{}{"user": "C01234567"},
+			wantError: "must be a Slack user ID",
+		},
+		{
+			name:      "limit too large",
+			args:      map[string]interface{}{"user": "U07654321", "limit": float64(500)},
+			wantError: "between 1 and 200",
+		},
+		{
+			name:      "invalid oldest",
+			args:      map[string]interface{}{"user": "U07654321", "oldest": "yesterday"},
+			wantError: "must be a Unix timestamp",
+		},
+		{
+			name:       "no DM with user",
+			args:       map[string]interface{}{"user": "U07654321"},
+			historyErr: types.NewSlackError(types.ErrCodeChannelNotFound, "no direct message conversation with user U07654321"),
+			wantError:  "no direct message conversation",
+			wantAudit:  "status=failed",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotUser string
+			var gotLimit int
+			mock := &mockSlackClient{
+				getDMHistory: func(ctx context.Context, userID string, opts slackclient.HistoryOptions) (string, []types.Message, bool, error) {
+					gotUser, gotLimit = userID, opts.Limit
+					if tt.historyErr != nil {
+						return "", nil, false, tt.historyErr
+					}
+					return "D01234567", []types.Message{
+						{User: userID, Text: "see you tomorrow", Timestamp: "1700000001.000200"},
+						{User: "U01234567", Text: "thanks!", Timestamp: "1700000000.000100"},
+					}, false, nil
+				},
+			}
+
+			var auditLog bytes.Buffer
+			handler := NewReadDMHistoryHandler(mock, WithAuditLog(log.New(&auditLog, "", 0)))
+			result, err := handler.Handle(context.Background(), createToolRequest(tt.args))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			text := result.Content[0].(mcp.TextContent).Text
+			if !strings.Contains(auditLog.String(), tt.wantAudit) {
+				t.Errorf("audit log %q does not contain %q", auditLog.String(), tt.wantAudit)
+			}
+			if tt.wantError != "" {
+				if !result.IsError || !strings.Contains(text, tt.wantError) {
+					t.Errorf("expected error containing %q, got: %s", tt.wantError, text)
+				}
+				if tt.wantAudit == "" && auditLog.Len() > 0 {
+					t.Errorf("expected no audit entry for rejected arguments, got %q", auditLog.String())
+				}
+				return
+			}
+			if result.IsError {
+				t.Fatalf("expected success, got error: %s", text)
+			}
+
+			var dm types.ReadDMHistoryResult
+			if err := json.Unmarshal([]byte(text), &dm); err != nil {
+				t.Fatalf("failed to parse result JSON: %v", err)
+			}
+			if gotUser != tt.wantUser || gotLimit != tt.wantLimit {
+				t.Errorf("read user %s with limit %d, want %s with %d", gotUser, gotLimit, tt.wantUser, tt.wantLimit)
+			}
+			if dm.ChannelID != "D01234567" || len(dm.Messages) != 2 {
+				t.Errorf("unexpected result %+v", dm)
+			}
+		})
+	}
+}
+
+func TestReadDMHistoryHandler_RequiresAuditLog(t *testing.T) {
+	mock := &mockSlackClient{
+		getDMHistory: func(ctx context.Context, userID string, opts slackclient.HistoryOptions) (string, []types.Message, bool, error) {
+			t.Error("expected no DM read without an audit log")
+			return "", nil, false, nil
+		},
+	}
+
+	handler := NewReadDMHistoryHandler(mock)
+	result, err := handler.Handle(context.Background(), createToolRequest(map[string]interface{}{"user": "U07654321"}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if text := result.Content[0].(mcp.TextContent).Text; !result.IsError || !strings.Contains(text, "requires an audit log") {
+		t.Errorf("expected audit log error, got: %s", text)
+	}
+}
@@ -4,10 +4,14 @@ package tools
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/mark3labs/mcp-go/mcp"
 
+	"github.com/Bitovi/slack-mcp-server/internal/langdetect"
+	"github.com/Bitovi/slack-mcp-server/internal/references"
 	slackclient "github.com/Bitovi/slack-mcp-server/internal/slack"
 	"github.com/Bitovi/slack-mcp-server/internal/urlparser"
 	"github.com/Bitovi/slack-mcp-server/pkg/types"
@@ -18,12 +22,15 @@ import (
 type ReadMessageHandler struct {
 	// slackClient is the Slack API client for retrieving messages and threads.
 	slackClient slackclient.ClientInterface
+	// config holds optional handler behavior such as the resolution error policy.
+	config handlerConfig
 }
 
-// NewReadMessageHandler creates a new ReadMessageHandler with the given Slack client.
-func NewReadMessageHandler(client slackclient.ClientInterface) *ReadMessageHandler {
+// NewReadMessageHandler creates a new ReadMessageHandler with the given Slack client and options.
+func NewReadMessageHandler(client slackclient.ClientInterface, opts ...HandlerOption) *ReadMessageHandler {
 	return &ReadMessageHandler{
 		slackClient: client,
+		config:      newHandlerConfig(opts),
 	}
 }
 
@@ -54,26 +61,157 @@ func (h *ReadMessageHandler) Handle(ctx context.Context, request mcp.CallToolReq
 		return mcp.NewToolResultError("missing required argument 'url'"), nil
 	}
 
+	// Extract include_archived parameter (optional, default false). When true, an
+	// archived channel the bot is not a member of is read with the user token.
+	if archivedArg, exists := request.Params.Arguments["include_archived"]; exists {
+		v, ok := archivedArg.(bool)
+		if !ok {
+			return mcp.NewToolResultError("argument 'include_archived' must be a boolean"), nil
+		}
+		if v {
+			ctx = slackclient.WithArchivedReads(ctx)
+		}
+	}
+
+	// Extract detect_language parameter (optional, default false)
+	detectLanguage := false
+	if detectArg, exists := request.Params.Arguments["detect_language"]; exists {
+		v, ok := detectArg.(bool)
+		if !ok {
+			return mcp.NewToolResultError("argument 'detect_language' must be a boolean"), nil
+		}
+		detectLanguage = v
+	}
+
+	// Extract extract_references parameter (optional, default false)
+	extractReferences := false
+	if extractArg, exists := request.Params.Arguments["extract_references"]; exists {
+		v, ok := extractArg.(bool)
+		if !ok {
+			return mcp.NewToolResultError("argument 'extract_references' must be a boolean"), nil
+		}
+		extractReferences = v
+	}
+
+	// Extract include_raw parameter (optional, default false)
+	includeRaw := false
+	if rawArg, exists := request.Params.Arguments["include_raw"]; exists {
+		v, ok := rawArg.(bool)
+		if !ok {
+			return mcp.NewToolResultError("argument 'include_raw' must be a boolean"), nil
+		}
+		includeRaw = v
+	}
+
+	// Extract max_tokens_estimate parameter (optional, default no budget)
+	maxTokens := 0
+	if maxTokensArg, exists := request.Params.Arguments["max_tokens_estimate"]; exists {
+		switch v := maxTokensArg.(type) {
+		case float64:
+			maxTokens = int(v)
+		case int:
+			maxTokens = v
+		default:
+			return mcp.NewToolResultError("argument 'max_tokens_estimate' must be a number"), nil
+		}
+		if maxTokens < 1 {
+			return mcp.NewToolResultError("argument 'max_tokens_estimate' must be a positive number"), nil
+		}
+	}
+
+	// Extract known_reply_count parameter (optional, -1 if not given)
+	knownReplyCount := -1
+	if knownArg, exists := request.Params.Arguments["known_reply_count"]; exists {
+		switch v := knownArg.(type) {
+		case float64:
+			knownReplyCount = int(v)
+		case int:
+			knownReplyCount = v
+		default:
+			return mcp.NewToolResultError("argument 'known_reply_count' must be a number"), nil
+		}
+		if knownReplyCount < 0 {
+			return mcp.NewToolResultError("argument 'known_reply_count' cannot be negative"), nil
+		}
+	}
+
+	// Extract if_changed_since parameter (optional): the latest reply timestamp already seen
+	ifChangedSince := ""
+	if sinceArg, exists := request.Params.Arguments["if_changed_since"]; exists {
+		v, ok := sinceArg.(string)
+		if !ok {
+			return mcp.NewToolResultError("argument 'if_changed_since' must be a string (Slack timestamp)"), nil
+		}
+		if _, ok := parseSlackTime(v); !ok {
+			return mcp.NewToolResultError(fmt.Sprintf("argument 'if_changed_since' must be a Slack timestamp, got %q", v)), nil
+		}
+		ifChangedSince = v
+	}
+
+	// Extract fields parameter (optional, default all fields)
+	fields, err := parseFields(request.Params.Arguments, messageFields)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
 	// Parse the Slack URL to extract channel ID and timestamps
 	parsedURL, err := urlparser.Parse(url)
 	if err != nil {
 		return h.handleError(err), nil
 	}
 
-	// Fetch the primary message
+	// DM deep links that reference a user name a person, not a conversation
+	if parsedURL.UserID != "" {
+		return mcp.NewToolResultError(userReferenceMessage(parsedURL.UserID)), nil
+	}
+
+	// Answer cheaply if the caller already has the whole thread
+	if knownReplyCount >= 0 || ifChangedSince != "" {
+		if unchanged := h.checkUnchanged(ctx, parsedURL, knownReplyCount, ifChangedSince); unchanged != nil {
+			return h.unchangedResult(unchanged)
+		}
+	}
+
+	// Fetch the primary message. If it is not in the channel history, look for it in
+	// its thread, which also reveals whether it was deleted.
 	message, err := h.slackClient.GetMessage(ctx, parsedURL.ChannelID, parsedURL.Timestamp)
+	var thread []types.Message
+	if slackclient.IsMessageNotFound(err) {
+		message, thread, err = h.findInThread(ctx, parsedURL, err)
+	}
 	if err != nil {
 		return h.handleError(err), nil
 	}
 
+	// Track user resolution failures so they can be reported according to the configured policy
+	resolution := newResolutionTracker()
+
 	// Resolve user info for the primary message (populates UserName, DisplayName, RealName)
-	h.resolveUserForMessage(ctx, message)
+	h.resolveUserForMessage(ctx, message, lookupUsers(ctx, h.slackClient, []string{message.User}), resolution)
 
 	// Build the result
 	result := &types.ReadMessageResult{
-		Message:   *message,
-		ChannelID: parsedURL.ChannelID,
+		Message:     *message,
+		ChannelID:   parsedURL.ChannelID,
+		ChannelType: parsedURL.ChannelType,
+		Deleted:     message.Deleted,
+	}
+
+	// Describe where the message was posted (graceful degradation on failure)
+	channelInfo, err := h.slackClient.GetChannelInfo(ctx, parsedURL.ChannelID)
+	if err == nil && channelInfo != nil {
+		result.ChannelName = channelInfo.Name
+		result.IsPrivate = channelInfo.IsPrivate
+		result.IsDM = channelInfo.IsDM
+		result.IsArchived = channelInfo.IsArchived
+		if channelInfo.Type != "" {
+			result.ChannelType = channelInfo.Type
+		}
 	}
+	resolution.recordChannel(parsedURL.ChannelID, err)
+
+	// Name the participants of a group DM, whose channel name only lists usernames
+	result.Participants = mpimParticipants(ctx, h.slackClient, channelInfo, resolution)
 
 	// Determine if we need to fetch thread replies
 	// We fetch the thread if:
@@ -81,7 +219,14 @@ func (h *ReadMessageHandler) Handle(ctx context.Context, request mcp.CallToolReq
 	// 2. The message has replies (ReplyCount > 0)
 	shouldFetchThread := parsedURL.IsThread || h.slackClient.HasThread(message)
 
-	if shouldFetchThread {
+	if thread != nil {
+		// Already fetched while looking for the message
+		users := lookupUsers(ctx, h.slackClient, messageAuthors(thread))
+		for i := range thread {
+			h.resolveUserForMessage(ctx, &thread[i], users, resolution)
+		}
+		result.Thread = thread
+	} else if shouldFetchThread {
 		// Determine which timestamp to use for fetching the thread
 		// If it's a thread URL, use the thread_ts from the URL
 		// Otherwise, use the message's timestamp (it's the parent of the thread)
@@ -96,29 +241,119 @@ func (h *ReadMessageHandler) Handle(ctx context.Context, request mcp.CallToolReq
 		if err != nil {
 			// If thread fetch fails, still return the message but note the error
 			// This provides partial results rather than complete failure
-			return h.handlePartialResult(result, err), nil
+			result.ThreadError = partialError(err)
+			result.Warnings = append(result.Warnings, types.Warning{
+				Code:    types.WarnCodeThreadFetchFailed,
+				Message: fmt.Sprintf("failed to fetch thread replies: %s", err.Error()),
+			})
+		} else {
+			// Resolve user info for each message in the thread, looking up the distinct authors in parallel
+			users := lookupUsers(ctx, h.slackClient, messageAuthors(thread))
+			for i := range thread {
+				h.resolveUserForMessage(ctx, &thread[i], users, resolution)
+			}
+
+			result.Thread = thread
 		}
+	}
 
-		// Resolve user info for each message in the thread
-		for i := range thread {
-			h.resolveUserForMessage(ctx, &thread[i])
+	// Tag messages with their detected language if requested
+	if detectLanguage {
+		result.Message.Lang = langdetect.Detect(result.Message.Text)
+		for i := range result.Thread {
+			result.Thread[i].Lang = langdetect.Detect(result.Thread[i].Text)
 		}
+	}
 
-		result.Thread = thread
+	// Extract issue and ticket references if requested
+	if extractReferences {
+		trackers := h.config.referenceTrackers
+		result.Message.References = references.Extract(result.Message.Text, trackers)
+		for i := range result.Thread {
+			result.Thread[i].References = references.Extract(result.Thread[i].Text, trackers)
+		}
+	}
+
+	// Drop the Slack API payloads unless requested
+	if !includeRaw {
+		result.Message.Raw = nil
+		clearRaw(result.Thread)
+	}
+
+	// Trim the thread to the token budget, before the user mapping so it only covers kept replies
+	if maxTokens > 0 && len(result.Thread) > 0 {
+		rest := *result
+		rest.Thread = nil
+		replies := len(result.Thread) - 1
+		result.Thread, result.ElidedReplies = trimThread(result.Thread, result.Message.Timestamp, maxTokens-estimateTokens(rest))
+		if len(result.ElidedReplies) > 0 {
+			result.Warnings = append(result.Warnings, types.Warning{
+				Code: types.WarnCodeThreadTrimmed,
+				Message: fmt.Sprintf("%d of %d thread replies were elided to fit max_tokens_estimate %d; "+
+					"kept the parent, the first and latest replies, and the most-reacted and latest replies that fit. "+
+					"elided_replies lists the timestamps of the elided replies", len(result.ElidedReplies), replies, maxTokens),
+			})
+		}
 	}
 
-	// Extract mentioned users from all messages and build user mapping
-	result.UserMapping = h.buildUserMapping(ctx, result)
+	// Resolve the users, channels, and user groups the messages refer to
+	h.buildMappings(ctx, result, resolution)
 
 	// Fetch the authenticated user's identity (graceful degradation on failure)
 	currentUser, err := h.slackClient.GetCurrentUser(ctx)
 	if err == nil && currentUser != nil {
 		result.CurrentUser = currentUser
 	}
-	// Note: If GetCurrentUser fails, we continue without current_user rather than failing
+	// Note: If GetCurrentUser fails, we continue without current_user unless the policy says otherwise
+	resolution.recordCurrentUser(err)
+
+	result.Workspace = workspaceFor(ctx, h.slackClient)
+
+	// Flag or reject degraded results according to the resolution error policy
+	resolutionWarnings, err := resolution.apply(h.config.onResolutionError)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to resolve users: %s", err.Error())), nil
+	}
+	result.Warnings = append(result.Warnings, resolutionWarnings...)
 
 	// Return the successful result as JSON content
-	return h.successResult(result)
+	return h.successResult(result, fields)
+}
+
+// findInThread looks for a message that is not in the channel history in its
+// thread: thread replies are only returned by conversations.replies, and a deleted
+// message is recognized by its thread existing without it.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeouts
+//   - parsedURL: The parsed message URL
+//   - notFound: The message_not_found error from the history lookup
+//
+// Returns the message (a placeholder with Deleted set if it was deleted) and its
+// thread, or notFound if the message has no thread to look in.
+func (h *ReadMessageHandler) findInThread(ctx context.Context, parsedURL *types.ParsedURL, notFound error) (*types.Message, []types.Message, error) {
+	threadTS := parsedURL.ThreadTS
+	if threadTS == "" {
+		threadTS = parsedURL.Timestamp
+	}
+
+	thread, err := h.slackClient.GetThread(ctx, parsedURL.ChannelID, threadTS)
+	if err != nil {
+		return nil, nil, notFound
+	}
+
+	for i := range thread {
+		if thread[i].Timestamp == parsedURL.Timestamp {
+			message := thread[i]
+			return &message, thread, nil
+		}
+	}
+
+	deleted := &types.Message{Timestamp: parsedURL.Timestamp, Deleted: true}
+	if threadTS != parsedURL.Timestamp {
+		deleted.ThreadTS = threadTS
+	}
+	return deleted, thread, nil
 }
 
 // handleError converts an error into an MCP tool error result.
@@ -151,9 +386,19 @@ func (h *ReadMessageHandler) handleError(err error) *mcp.CallToolResult {
 			"Message not found. The message may have been deleted, or the timestamp in the URL is incorrect.")
 	}
 
+	if slackclient.IsChannelArchived(err) {
+		return mcp.NewToolResultError(
+			"The channel is archived and the bot is not a member. Archived channels cannot be joined; " +
+				"pass include_archived=true to read it with SLACK_USER_TOKEN.")
+	}
+
+	if slackclient.IsMissingScope(err) {
+		return mcp.NewToolResultError(err.Error())
+	}
+
 	if slackclient.IsPermissionDenied(err) {
 		return mcp.NewToolResultError(
-			"Permission denied. The bot may lack required scopes or the channel is archived.")
+			"Permission denied. The bot may lack required scopes.")
 	}
 
 	// Check for URL parsing errors
@@ -168,23 +413,77 @@ func (h *ReadMessageHandler) handleError(err error) *mcp.CallToolResult {
 	return mcp.NewToolResultError(fmt.Sprintf("Failed to read message: %s", err.Error()))
 }
 
-// handlePartialResult creates a result that includes the message but notes a thread fetch failure.
-func (h *ReadMessageHandler) handlePartialResult(result *types.ReadMessageResult, threadErr error) *mcp.CallToolResult {
-	// Create a modified result that indicates partial success
-	resultJSON, err := json.Marshal(result)
+// successResult creates a successful MCP tool result with the given data.
+// If fields is non-empty, the message and thread objects are trimmed to those fields.
+func (h *ReadMessageHandler) successResult(result *types.ReadMessageResult, fields []string) (*mcp.CallToolResult, error) {
+	resultJSON, err := marshalProjected(result, fields, "message", "thread")
 	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to encode partial result: %s", err.Error()))
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to encode result: %s", err.Error())), nil
 	}
 
-	// Return both the partial result and a warning about the thread
-	return mcp.NewToolResultText(fmt.Sprintf(
-		"%s\n\nNote: Failed to fetch thread replies: %s",
-		string(resultJSON),
-		threadErr.Error()))
+	return mcp.NewToolResultText(string(resultJSON)), nil
 }
 
-// successResult creates a successful MCP tool result with the given data.
-func (h *ReadMessageHandler) successResult(result *types.ReadMessageResult) (*mcp.CallToolResult, error) {
+// checkUnchanged reports whether the thread of the URL's message has not grown past
+// what the caller has seen: no more than knownReplyCount replies (if not negative)
+// and no reply after ifChangedSince (if set). Only the thread parent is fetched,
+// bypassing the message cache, whose copy may predate the latest replies.
+//
+// Returns the thread's current state if it is unchanged, or nil if it changed or
+// the parent cannot be read, in which case the message is read in full.
+func (h *ReadMessageHandler) checkUnchanged(ctx context.Context, parsedURL *types.ParsedURL, knownReplyCount int, ifChangedSince string) *types.ReadMessageUnchangedResult {
+	threadTS := parsedURL.ThreadTS
+	if threadTS == "" {
+		threadTS = parsedURL.Timestamp
+	}
+
+	parent, err := h.slackClient.GetMessage(slackclient.WithFreshReads(ctx), parsedURL.ChannelID, threadTS)
+	if err != nil {
+		return nil
+	}
+	if knownReplyCount >= 0 && parent.ReplyCount > knownReplyCount {
+		return nil
+	}
+	if ifChangedSince != "" && slackTimestampAfter(parent.LatestReply, ifChangedSince) {
+		return nil
+	}
+
+	return &types.ReadMessageUnchangedResult{
+		Unchanged:   true,
+		ChannelID:   parsedURL.ChannelID,
+		ThreadTS:    threadTS,
+		ReplyCount:  parent.ReplyCount,
+		LatestReply: parent.LatestReply,
+	}
+}
+
+// slackTimestampAfter reports whether Slack timestamp a is later than b. Timestamps
+// are compared as seconds, then microseconds, since float64 cannot hold both exactly.
+// An empty a is not after anything.
+func slackTimestampAfter(a, b string) bool {
+	if a == "" {
+		return false
+	}
+	aSec, aMicro, _ := strings.Cut(a, ".")
+	bSec, bMicro, _ := strings.Cut(b, ".")
+	if len(aSec) != len(bSec) {
+		return len(aSec) > len(bSec)
+	}
+	if aSec != bSec {
+		return aSec > bSec
+	}
+	// Pad to the same length so the fractions compare as numbers
+	for len(aMicro) < len(bMicro) {
+		aMicro += "0"
+	}
+	for len(bMicro) < len(aMicro) {
+		bMicro += "0"
+	}
+	return aMicro > bMicro
+}
+
+// unchangedResult creates a successful MCP tool result for an unchanged thread.
+func (h *ReadMessageHandler) unchangedResult(result *types.ReadMessageUnchangedResult) (*mcp.CallToolResult, error) {
 	resultJSON, err := json.Marshal(result)
 	if err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to encode result: %s", err.Error())), nil
@@ -199,29 +498,37 @@ func (h *ReadMessageHandler) HandleFunc() func(ctx context.Context, request mcp.
 	return h.Handle
 }
 
-// resolveUserForMessage populates user name fields on a message by fetching user info.
+// resolveUserForMessage populates user name fields on a message from its author's
+// lookup in users (see lookupUsers).
 //
-// This method fetches user information for the message author and populates
-// the UserName, DisplayName, and RealName fields on the message. If the user
-// lookup fails, the message is left unchanged (graceful degradation).
+// This method populates the UserName, DisplayName, and RealName fields on the
+// message from the author's user information. If the user lookup failed, the
+// message is left unchanged (graceful degradation) and the failure is recorded
+// on the resolution tracker.
 //
 // Parameters:
 //   - ctx: Context for cancellation and timeouts
 //   - msg: Pointer to the message to populate with user info
+//   - users: The lookups of the messages' authors, by user ID
+//   - resolution: Tracker that collects user resolution failures
 //
 // This method does not return an error. If user resolution fails, the message
 // will simply not have user name fields populated.
-func (h *ReadMessageHandler) resolveUserForMessage(ctx context.Context, msg *types.Message) {
+func (h *ReadMessageHandler) resolveUserForMessage(ctx context.Context, msg *types.Message, users map[string]userLookup, resolution *resolutionTracker) {
+	// Label posts by bots and workflows, which often have no user ID
+	attributeBotMessage(ctx, h.slackClient, msg)
+
 	// Skip if message has no user ID (e.g., system messages)
 	if msg.User == "" {
 		return
 	}
 
-	// Fetch user info from Slack (or cache)
-	userInfo, err := h.slackClient.GetUserInfo(ctx, msg.User)
+	// Take the user info looked up from Slack (or cache)
+	userInfo, err := users[msg.User].info, users[msg.User].err
 	if err != nil {
-		// Graceful degradation: log the error but don't fail
+		// Graceful degradation: record the error but don't fail
 		// The message will be returned without user name fields
+		resolution.recordUser(msg.User, err)
 		return
 	}
 
@@ -236,57 +543,21 @@ func (h *ReadMessageHandler) resolveUserForMessage(ctx context.Context, msg *typ
 	msg.RealName = userInfo.RealName
 }
 
-// buildUserMapping extracts mentioned user IDs from all messages and resolves them to UserInfo.
-//
-// This method scans the primary message and all thread messages for Slack mentions
-// (e.g., <@U06025G6B28>) and builds a mapping of user IDs to their UserInfo.
-// If a user lookup fails, that user is simply omitted from the mapping.
-//
-// Parameters:
-//   - ctx: Context for cancellation and timeouts
-//   - result: The ReadMessageResult containing the message and optional thread
-//
-// Returns a map of user IDs to UserInfo for all mentioned users, or nil if no mentions found.
-func (h *ReadMessageHandler) buildUserMapping(ctx context.Context, result *types.ReadMessageResult) map[string]types.UserInfo {
-	// Collect all unique mentioned user IDs
-	mentionedUserIDs := make(map[string]bool)
-
-	// Extract mentions from the primary message
-	for _, userID := range h.slackClient.ExtractMentions(result.Message.Text) {
-		mentionedUserIDs[userID] = true
+// buildMappings resolves the users, channels, and user groups that the primary
+// message and all thread messages refer to, by mention, channel link, or reaction,
+// into the result's user_mapping, channel_mapping, and usergroup_mapping. Entities
+// that cannot be resolved are omitted and recorded on resolution.
+func (h *ReadMessageHandler) buildMappings(ctx context.Context, result *types.ReadMessageResult, resolution *resolutionTracker) {
+	refs := newEntityRefs(h.slackClient)
+	refs.addMessage(&result.Message)
+	for i := range result.Thread {
+		refs.addMessage(&result.Thread[i])
 	}
 
-	// Extract mentions from all thread messages
-	for _, msg := range result.Thread {
-		for _, userID := range h.slackClient.ExtractMentions(msg.Text) {
-			mentionedUserIDs[userID] = true
-		}
-	}
-
-	// If no mentions found, return nil
-	if len(mentionedUserIDs) == 0 {
-		return nil
-	}
-
-	// Build the user mapping by resolving each mentioned user
-	userMapping := make(map[string]types.UserInfo)
-	for userID := range mentionedUserIDs {
-		userInfo, err := h.slackClient.GetUserInfo(ctx, userID)
-		if err != nil {
-			// Graceful degradation: skip users we can't resolve
-			continue
-		}
-		if userInfo != nil {
-			userMapping[userID] = *userInfo
-		}
-	}
-
-	// Return nil if no users were resolved (to avoid empty map in JSON)
-	if len(userMapping) == 0 {
-		return nil
-	}
-
-	return userMapping
+	mappings := refs.resolve(ctx, resolution)
+	result.UserMapping = mappings.users
+	result.ChannelMapping = mappings.channels
+	result.UserGroupMapping = mappings.userGroups
 }
 
 // ReadMessage is a standalone function that processes a read_message request.
@@ -305,6 +576,10 @@ func ReadMessage(ctx context.Context, client slackclient.ClientInterface, url st
 		return nil, err
 	}
 
+	if parsedURL.UserID != "" {
+		return nil, errors.New(userReferenceMessage(parsedURL.UserID))
+	}
+
 	// Fetch the primary message
 	message, err := client.GetMessage(ctx, parsedURL.ChannelID, parsedURL.Timestamp)
 	if err != nil {
@@ -4,8 +4,13 @@ package tools
 import (
 	"context"
 	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/mark3labs/mcp-go/mcp"
 
@@ -15,14 +20,40 @@ import (
 
 // mockSlackClient is a test double for the Slack client interface.
 type mockSlackClient struct {
-	getMessage        func(ctx context.Context, channelID, timestamp string) (*types.Message, error)
-	getThread         func(ctx context.Context, channelID, threadTS string) ([]types.Message, error)
-	getChannelHistory func(ctx context.Context, channelID string, limit int, oldest, latest string) ([]types.Message, bool, error)
-	hasThread         func(message *types.Message) bool
-	getUserInfo       func(ctx context.Context, userID string) (*types.UserInfo, error)
-	getCurrentUser    func(ctx context.Context) (*types.UserInfo, error)
-	extractMentions   func(text string) []string
-	searchMessages    func(ctx context.Context, query string, count int, sort string) ([]types.SearchMatch, int, error)
+	getMessage             func(ctx context.Context, channelID, timestamp string) (*types.Message, error)
+	getThread              func(ctx context.Context, channelID, threadTS string) ([]types.Message, error)
+	getChannelHistory      func(ctx context.Context, channelID string, opts slackclient.HistoryOptions) ([]types.Message, bool, error)
+	countChannelMessages   func(ctx context.Context, channelID string, opts slackclient.HistoryOptions) (int, bool, error)
+	getEarliestMessage     func(ctx context.Context, channelID string, maxScan int) (*types.Message, bool, error)
+	hasThread              func(message *types.Message) bool
+	getUserInfo            func(ctx context.Context, userID string) (*types.UserInfo, error)
+	getUserProfile         func(ctx context.Context, userID string) (*types.UserInfo, error)
+	lookupUserByEmail      func(ctx context.Context, email string) (*types.UserInfo, error)
+	listUsers              func(ctx context.Context) ([]types.UserInfo, bool, error)
+	getChannelInfo         func(ctx context.Context, channelID string) (*types.ChannelInfo, error)
+	openDMChannel          func(ctx context.Context, userID string) (string, error)
+	postEphemeral          func(ctx context.Context, channelID, userID, text, threadTS string) (string, error)
+	postMessage            func(ctx context.Context, channelID, text string, opts slackclient.PostMessageOptions) (string, error)
+	addReaction            func(ctx context.Context, channelID, timestamp, threadTS, name string) error
+	uploadSnippet          func(ctx context.Context, channelID, filename, content string, opts slackclient.SnippetOptions) (string, error)
+	listUserGroups         func(ctx context.Context) ([]types.UserGroup, error)
+	updateUserGroupMembers func(ctx context.Context, userGroupID string, userIDs []string) (*types.UserGroup, error)
+	readAnalyticsFile      func(ctx context.Context, fileType, date string, fn func(row map[string]interface{})) error
+	getAuditLogs           func(ctx context.Context, query slackclient.AuditLogQuery) ([]types.AuditEntry, string, error)
+	getChannelAccess       func(ctx context.Context, channelID string) (*types.ChannelAccess, error)
+	getCurrentUser         func(ctx context.Context) (*types.UserInfo, error)
+	getWorkspaceInfo       func(ctx context.Context) (*types.WorkspaceInfo, error)
+	listWorkspaces         func(ctx context.Context) ([]types.WorkspaceInfo, bool, error)
+	resolveChannelName     func(ctx context.Context, name string) (string, error)
+	listChannels           func(ctx context.Context, opts slackclient.ListChannelsOptions) ([]types.ChannelAccess, string, error)
+	getBotInfo             func(ctx context.Context, botID string) (*types.BotInfo, error)
+	getConversationMembers func(ctx context.Context, channelID string) ([]string, error)
+	getDMHistory           func(ctx context.Context, userID string, opts slackclient.HistoryOptions) (string, []types.Message, bool, error)
+	extractEntities        func(text string) slackclient.Entities
+	searchMessages         func(ctx context.Context, query string, count int, sort string) ([]types.SearchMatch, int, error)
+	listPins               func(ctx context.Context, channelID string) ([]types.Message, error)
+	listBookmarks          func(ctx context.Context, channelID string) ([]types.Bookmark, error)
+	getCanvasSummary       func(ctx context.Context, channelID, canvasID string) (*types.CanvasSummary, error)
 }
 
 // GetMessage implements slackclient.ClientInterface.
@@ -42,13 +73,21 @@ func (m *mockSlackClient) GetThread(ctx context.Context, channelID, threadTS str
 }
 
 // GetChannelHistory implements slackclient.ClientInterface.
-func (m *mockSlackClient) GetChannelHistory(ctx context.Context, channelID string, limit int, oldest, latest string) ([]types.Message, bool, error) {
+func (m *mockSlackClient) GetChannelHistory(ctx context.Context, channelID string, opts slackclient.HistoryOptions) ([]types.Message, bool, error) {
 	if m.getChannelHistory != nil {
-		return m.getChannelHistory(ctx, channelID, limit, oldest, latest)
+		return m.getChannelHistory(ctx, channelID, opts)
 	}
 	return nil, false, types.NewSlackError(types.ErrCodeChannelNotFound, "mock: GetChannelHistory not configured")
 }
 
+// CountChannelMessages implements slackclient.ClientInterface.
+func (m *mockSlackClient) CountChannelMessages(ctx context.Context, channelID string, opts slackclient.HistoryOptions) (int, bool, error) {
+	if m.countChannelMessages != nil {
+		return m.countChannelMessages(ctx, channelID, opts)
+	}
+	return 0, false, types.NewSlackError(types.ErrCodeChannelNotFound, "mock: CountChannelMessages not configured")
+}
+
 // HasThread implements slackclient.ClientInterface.
 func (m *mockSlackClient) HasThread(message *types.Message) bool {
 	if m.hasThread != nil {
@@ -67,6 +106,128 @@ func (m *mockSlackClient) GetUserInfo(ctx context.Context, userID string) (*type
 	return nil, nil
 }
 
+// GetUserProfile implements slackclient.ClientInterface.
+func (m *mockSlackClient) GetUserProfile(ctx context.Context, userID string) (*types.UserInfo, error) {
+	if m.getUserProfile != nil {
+		return m.getUserProfile(ctx, userID)
+	}
+	return nil, types.NewSlackError(types.ErrCodeSlackError, "mock: GetUserProfile not configured")
+}
+
+// LookupUserByEmail implements slackclient.ClientInterface.
+func (m *mockSlackClient) LookupUserByEmail(ctx context.Context, email string) (*types.UserInfo, error) {
+	if m.lookupUserByEmail != nil {
+		return m.lookupUserByEmail(ctx, email)
+	}
+	return nil, types.NewSlackError(types.ErrCodeSlackError, "mock: LookupUserByEmail not configured")
+}
+
+// ListUsers implements slackclient.ClientInterface.
+func (m *mockSlackClient) ListUsers(ctx context.Context) ([]types.UserInfo, bool, error) {
+	if m.listUsers != nil {
+		return m.listUsers(ctx)
+	}
+	return nil, false, types.NewSlackError(types.ErrCodeSlackError, "mock: ListUsers not configured")
+}
+
+// GetChannelInfo implements slackclient.ClientInterface.
+func (m *mockSlackClient) GetChannelInfo(ctx context.Context, channelID string) (*types.ChannelInfo, error) {
+	if m.getChannelInfo != nil {
+		return m.getChannelInfo(ctx, channelID)
+	}
+	// Default: return a public channel
+	return &types.ChannelInfo{ID: channelID, Name: "general", Type: types.ChannelTypePublic}, nil
+}
+
+// OpenDMChannel implements slackclient.ClientInterface.
+func (m *mockSlackClient) OpenDMChannel(ctx context.Context, userID string) (string, error) {
+	if m.openDMChannel != nil {
+		return m.openDMChannel(ctx, userID)
+	}
+	// Default: derive a DM channel ID from the user ID
+	return "D" + userID[1:], nil
+}
+
+// PostEphemeral implements slackclient.ClientInterface.
+func (m *mockSlackClient) PostEphemeral(ctx context.Context, channelID, userID, text, threadTS string) (string, error) {
+	if m.postEphemeral != nil {
+		return m.postEphemeral(ctx, channelID, userID, text, threadTS)
+	}
+	// Default: return a fixed message timestamp
+	return "1355517523.000008", nil
+}
+
+// PostMessage implements slackclient.ClientInterface.
+func (m *mockSlackClient) PostMessage(ctx context.Context, channelID, text string, opts slackclient.PostMessageOptions) (string, error) {
+	if m.postMessage != nil {
+		return m.postMessage(ctx, channelID, text, opts)
+	}
+	// Default: return a fixed message timestamp
+	return "1355517524.000001", nil
+}
+
+// AddReaction implements slackclient.ClientInterface.
+func (m *mockSlackClient) AddReaction(ctx context.Context, channelID, timestamp, threadTS, name string) error {
+	if m.addReaction != nil {
+		return m.addReaction(ctx, channelID, timestamp, threadTS, name)
+	}
+	return nil
+}
+
+// UploadSnippet implements slackclient.ClientInterface.
+func (m *mockSlackClient) UploadSnippet(ctx context.Context, channelID, filename, content string, opts slackclient.SnippetOptions) (string, error) {
+	if m.uploadSnippet != nil {
+		return m.uploadSnippet(ctx, channelID, filename, content, opts)
+	}
+	// Default: return a fixed file ID
+	return "F01234567", nil
+}
+
+// ListUserGroups implements slackclient.ClientInterface.
+func (m *mockSlackClient) ListUserGroups(ctx context.Context) ([]types.UserGroup, error) {
+	if m.listUserGroups != nil {
+		return m.listUserGroups(ctx)
+	}
+	return nil, nil
+}
+
+// UpdateUserGroupMembers implements slackclient.ClientInterface.
+func (m *mockSlackClient) UpdateUserGroupMembers(ctx context.Context, userGroupID string, userIDs []string) (*types.UserGroup, error) {
+	if m.updateUserGroupMembers != nil {
+		return m.updateUserGroupMembers(ctx, userGroupID, userIDs)
+	}
+	// Default: the update succeeds with the given members
+	return &types.UserGroup{ID: userGroupID, Users: userIDs}, nil
+}
+
+// ReadAnalyticsFile implements slackclient.ClientInterface.
+func (m *mockSlackClient) ReadAnalyticsFile(ctx context.Context, fileType, date string, fn func(row map[string]interface{})) error {
+	if m.readAnalyticsFile != nil {
+		return m.readAnalyticsFile(ctx, fileType, date, fn)
+	}
+	return nil
+}
+
+// GetAuditLogs implements slackclient.ClientInterface.
+func (m *mockSlackClient) GetAuditLogs(ctx context.Context, query slackclient.AuditLogQuery) ([]types.AuditEntry, string, error) {
+	if m.getAuditLogs != nil {
+		return m.getAuditLogs(ctx, query)
+	}
+	return nil, "", nil
+}
+
+// GetChannelAccess implements slackclient.ClientInterface.
+func (m *mockSlackClient) GetChannelAccess(ctx context.Context, channelID string) (*types.ChannelAccess, error) {
+	if m.getChannelAccess != nil {
+		return m.getChannelAccess(ctx, channelID)
+	}
+	// Default: a public channel the bot is a member of
+	return &types.ChannelAccess{
+		Channel:     &types.ChannelInfo{ID: channelID, Name: "general", Type: types.ChannelTypePublic},
+		BotIsMember: true,
+	}, nil
+}
+
 // GetCurrentUser implements slackclient.ClientInterface.
 func (m *mockSlackClient) GetCurrentUser(ctx context.Context) (*types.UserInfo, error) {
 	if m.getCurrentUser != nil {
@@ -82,13 +243,80 @@ func (m *mockSlackClient) GetCurrentUser(ctx context.Context) (*types.UserInfo,
 	}, nil
 }
 
-// ExtractMentions implements slackclient.ClientInterface.
-func (m *mockSlackClient) ExtractMentions(text string) []string {
-	if m.extractMentions != nil {
-		return m.extractMentions(text)
+// GetWorkspaceInfo implements slackclient.ClientInterface.
+func (m *mockSlackClient) GetWorkspaceInfo(ctx context.Context) (*types.WorkspaceInfo, error) {
+	if m.getWorkspaceInfo != nil {
+		return m.getWorkspaceInfo(ctx)
+	}
+	// Default: return a mock workspace
+	return &types.WorkspaceInfo{
+		TeamID: "T01234567",
+		Name:   "Test Workspace",
+		Domain: "workspace",
+		URL:    "https://workspace.slack.com/",
+	}, nil
+}
+
+// ValidateAuth implements slackclient.ClientInterface.
+func (m *mockSlackClient) ValidateAuth(ctx context.Context) error {
+	return nil
+}
+
+// ListWorkspaces implements slackclient.ClientInterface.
+func (m *mockSlackClient) ListWorkspaces(ctx context.Context) ([]types.WorkspaceInfo, bool, error) {
+	if m.listWorkspaces != nil {
+		return m.listWorkspaces(ctx)
+	}
+	return nil, false, types.NewSlackError(types.ErrCodeSlackError, "mock: ListWorkspaces not configured")
+}
+
+// ResolveChannelName implements slackclient.ClientInterface.
+func (m *mockSlackClient) ResolveChannelName(ctx context.Context, name string) (string, error) {
+	if m.resolveChannelName != nil {
+		return m.resolveChannelName(ctx, name)
+	}
+	return "", types.NewSlackError(types.ErrCodeSlackError, "mock: ResolveChannelName not configured")
+}
+
+// ListChannels implements slackclient.ClientInterface.
+func (m *mockSlackClient) ListChannels(ctx context.Context, opts slackclient.ListChannelsOptions) ([]types.ChannelAccess, string, error) {
+	if m.listChannels != nil {
+		return m.listChannels(ctx, opts)
+	}
+	return nil, "", types.NewSlackError(types.ErrCodeSlackError, "mock: ListChannels not configured")
+}
+
+// GetBotInfo implements slackclient.ClientInterface.
+func (m *mockSlackClient) GetBotInfo(ctx context.Context, botID string) (*types.BotInfo, error) {
+	if m.getBotInfo != nil {
+		return m.getBotInfo(ctx, botID)
+	}
+	return nil, types.NewSlackError(types.ErrCodeSlackError, "mock: GetBotInfo not configured")
+}
+
+// GetConversationMembers implements slackclient.ClientInterface.
+func (m *mockSlackClient) GetConversationMembers(ctx context.Context, channelID string) ([]string, error) {
+	if m.getConversationMembers != nil {
+		return m.getConversationMembers(ctx, channelID)
+	}
+	return nil, types.NewSlackError(types.ErrCodeSlackError, "mock: GetConversationMembers not configured")
+}
+
+// GetDMHistory implements slackclient.ClientInterface.
+func (m *mockSlackClient) GetDMHistory(ctx context.Context, userID string, opts slackclient.HistoryOptions) (string, []types.Message, bool, error) {
+	if m.getDMHistory != nil {
+		return m.getDMHistory(ctx, userID, opts)
+	}
+	return "", nil, false, types.NewSlackError(types.ErrCodeChannelNotFound, "mock: GetDMHistory not configured")
+}
+
+// ExtractEntities implements slackclient.ClientInterface.
+func (m *mockSlackClient) ExtractEntities(text string) slackclient.Entities {
+	if m.extractEntities != nil {
+		return m.extractEntities(text)
 	}
-	// Default: return empty slice (no mentions)
-	return []string{}
+	// Default: no entities
+	return slackclient.Entities{}
 }
 
 // SearchMessages implements slackclient.ClientInterface.
@@ -100,6 +328,38 @@ func (m *mockSlackClient) SearchMessages(ctx context.Context, query string, coun
 	return []types.SearchMatch{}, 0, nil
 }
 
+// GetEarliestMessage implements slackclient.ClientInterface.
+func (m *mockSlackClient) GetEarliestMessage(ctx context.Context, channelID string, maxScan int) (*types.Message, bool, error) {
+	if m.getEarliestMessage != nil {
+		return m.getEarliestMessage(ctx, channelID, maxScan)
+	}
+	return nil, false, nil
+}
+
+// ListPins implements slackclient.ClientInterface.
+func (m *mockSlackClient) ListPins(ctx context.Context, channelID string) ([]types.Message, error) {
+	if m.listPins != nil {
+		return m.listPins(ctx, channelID)
+	}
+	return nil, nil
+}
+
+// ListBookmarks implements slackclient.ClientInterface.
+func (m *mockSlackClient) ListBookmarks(ctx context.Context, channelID string) ([]types.Bookmark, error) {
+	if m.listBookmarks != nil {
+		return m.listBookmarks(ctx, channelID)
+	}
+	return nil, nil
+}
+
+// GetCanvasSummary implements slackclient.ClientInterface.
+func (m *mockSlackClient) GetCanvasSummary(ctx context.Context, channelID, canvasID string) (*types.CanvasSummary, error) {
+	if m.getCanvasSummary != nil {
+		return m.getCanvasSummary(ctx, channelID, canvasID)
+	}
+	return &types.CanvasSummary{ID: canvasID}, nil
+}
+
 // Ensure mockSlackClient implements the interface.
 var _ slackclient.ClientInterface = (*mockSlackClient)(nil)
 
@@ -418,6 +678,11 @@ func TestReadMessageHandler_Handle_SlackErrors(t *testing.T) {
 			errorCode:      types.ErrCodeMessageNotFound,
 			wantErrContain: "Message not found",
 		},
+		{
+			name:           "archived channel",
+			errorCode:      types.ErrCodeChannelArchived,
+			wantErrContain: "include_archived=true",
+		},
 		{
 			name:           "permission denied",
 			errorCode:      types.ErrCodePermissionDenied,
@@ -505,14 +770,27 @@ func TestReadMessageHandler_Handle_PartialResult(t *testing.T) {
 		t.Fatalf("expected TextContent, got %T", result.Content[0])
 	}
 
+	// The partial result must remain valid JSON
+	var readResult types.ReadMessageResult
+	if err := json.Unmarshal([]byte(textContent.Text), &readResult); err != nil {
+		t.Fatalf("partial result should be valid JSON: %v\n%s", err, textContent.Text)
+	}
+
 	// Should contain the message data
-	if !strings.Contains(textContent.Text, "Parent message") {
-		t.Error("partial result should contain the message text")
+	if readResult.Message.Text != "Parent message" {
+		t.Errorf("partial result should contain the message text, got %q", readResult.Message.Text)
 	}
 
-	// Should contain a note about the thread failure
-	if !strings.Contains(textContent.Text, "Failed to fetch thread") {
-		t.Error("partial result should note the thread fetch failure")
+	// Should contain a warning about the thread failure
+	if len(readResult.Warnings) != 1 || readResult.Warnings[0].Code != types.WarnCodeThreadFetchFailed {
+		t.Fatalf("expected a single %s warning, got %+v", types.WarnCodeThreadFetchFailed, readResult.Warnings)
+	}
+	if !strings.Contains(readResult.Warnings[0].Message, "rate limited during thread fetch") {
+		t.Errorf("warning should include the thread error, got %q", readResult.Warnings[0].Message)
+	}
+	// The thread failure should also be available as a structured error
+	if readResult.ThreadError == nil || readResult.ThreadError.Code != types.ErrCodeRateLimited {
+		t.Errorf("expected rate_limited thread_error, got %+v", readResult.ThreadError)
 	}
 }
 
@@ -765,6 +1043,99 @@ func TestReadMessageHandler_Handle_ThreadTSFromMessage(t *testing.T) {
 	}
 }
 
+func TestReadMessageHandler_Handle_DeletedMessage(t *testing.T) {
+	notFound := func(ctx context.Context, channelID, timestamp string) (*types.Message, error) {
+		return nil, types.NewSlackError(types.ErrCodeMessageNotFound, "mock: not found")
+	}
+	thread := func(ctx context.Context, channelID, threadTS string) ([]types.Message, error) {
+		return []types.Message{
+			{User: "U12345678", Text: "Parent", Timestamp: "1355517523.000008", ReplyCount: 1},
+			{User: "U87654321", Text: "Reply", Timestamp: "1355517524.000001", ThreadTS: "1355517523.000008"},
+		}, nil
+	}
+
+	tests := []struct {
+		name        string
+		url         string
+		getMessage  func(ctx context.Context, channelID, timestamp string) (*types.Message, error)
+		getThread   func(ctx context.Context, channelID, threadTS string) ([]types.Message, error)
+		wantDeleted bool
+		wantText    string
+		wantThread  int
+		wantError   string
+	}{
+		{
+			name:        "deleted reply in existing thread",
+			url:         "https://workspace.slack.com/archives/C01234567/p1355517525000002?thread_ts=1355517523.000008&cid=C01234567",
+			getMessage:  notFound,
+			getThread:   thread,
+			wantDeleted: true,
+			wantThread:  2,
+		},
+		{
+			name: "tombstoned parent",
+			url:  "https://workspace.slack.com/archives/C01234567/p1355517523000008",
+			getMessage: func(ctx context.Context, channelID, timestamp string) (*types.Message, error) {
+				return &types.Message{Text: "This message was deleted.", Timestamp: timestamp, ReplyCount: 1, Deleted: true}, nil
+			},
+			getThread:   thread,
+			wantDeleted: true,
+			wantText:    "This message was deleted.",
+			wantThread:  2,
+		},
+		{
+			name:       "reply found in thread",
+			url:        "https://workspace.slack.com/archives/C01234567/p1355517524000001?thread_ts=1355517523.000008&cid=C01234567",
+			getMessage: notFound,
+			getThread:  thread,
+			wantText:   "Reply",
+			wantThread: 2,
+		},
+		{
+			name:       "no thread to look in",
+			url:        "https://workspace.slack.com/archives/C01234567/p1355517525000002",
+			getMessage: notFound,
+			wantError:  "Message not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := &mockSlackClient{getMessage: tt.getMessage, getThread: tt.getThread}
+			handler := NewReadMessageHandler(mock)
+			result, err := handler.Handle(context.Background(), createToolRequest(map[string]interface{}{"url": tt.url}))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			text := result.Content[0].(mcp.TextContent).Text
+			if tt.wantError != "" {
+				if !result.IsError || !strings.Contains(text, tt.wantError) {
+					t.Errorf("expected error containing %q, got: %s", tt.wantError, text)
+				}
+				return
+			}
+			if result.IsError {
+				t.Fatalf("expected success, got error: %s", text)
+			}
+
+			var readResult types.ReadMessageResult
+			if err := json.Unmarshal([]byte(text), &readResult); err != nil {
+				t.Fatalf("failed to parse result JSON: %v", err)
+			}
+			if readResult.Deleted != tt.wantDeleted || readResult.Message.Deleted != tt.wantDeleted {
+				t.Errorf("deleted = %v (message %v), want %v", readResult.Deleted, readResult.Message.Deleted, tt.wantDeleted)
+			}
+			if readResult.Message.Text != tt.wantText {
+				t.Errorf("text = %q, want %q", readResult.Message.Text, tt.wantText)
+			}
+			if len(readResult.Thread) != tt.wantThread {
+				t.Errorf("thread has %d messages, want %d", len(readResult.Thread), tt.wantThread)
+			}
+		})
+	}
+}
+
 func TestNewReadMessageHandler(t *testing.T) {
 	mock := &mockSlackClient{}
 	handler := NewReadMessageHandler(mock)
@@ -1150,11 +1521,8 @@ func TestReadMessage_MentionMapping(t *testing.T) {
 					}
 					return nil, nil // User not found
 				},
-				extractMentions: func(text string) []string {
-					if ids, ok := tt.extractedIDs[text]; ok {
-						return ids
-					}
-					return []string{}
+				extractEntities: func(text string) slackclient.Entities {
+					return slackclient.Entities{Users: tt.extractedIDs[text]}
 				},
 			}
 
@@ -1343,3 +1711,470 @@ func TestReadMessage_CurrentUser(t *testing.T) {
 		})
 	}
 }
+
+// TestReadMessageHandler_Handle_DetectLanguage tests that messages are tagged with a language when requested.
+func TestReadMessageHandler_Handle_DetectLanguage(t *testing.T) {
+	mock := &mockSlackClient{
+		getMessage: func(ctx context.Context, channelID, timestamp string) (*types.Message, error) {
+			return &types.Message{
+				User:       "U12345678",
+				Text:       "The deploy is done and this should fix the login issue",
+				Timestamp:  "1355517523.000008",
+				ReplyCount: 1,
+			}, nil
+		},
+		getThread: func(ctx context.Context, channelID, threadTS string) ([]types.Message, error) {
+			return []types.Message{
+				{User: "U12345678", Text: "The deploy is done and this should fix the login issue", Timestamp: "1355517523.000008"},
+				{User: "U87654321", Text: "デプロイが完了しました。確認してください", Timestamp: "1355517524.000001"},
+			}, nil
+		},
+	}
+
+	handler := NewReadMessageHandler(mock)
+
+	tests := []struct {
+		name         string
+		args         map[string]interface{}
+		wantLang     string
+		wantReplyTag string
+	}{
+		{
+			name: "detection disabled by default",
+			args: map[string]interface{}{
+				"url": "https://workspace.slack.com/archives/C01234567/p1355517523000008",
+			},
+		},
+		{
+			name: "detection enabled",
+			args: map[string]interface{}{
+				"url":             "https://workspace.slack.com/archives/C01234567/p1355517523000008",
+				"detect_language": true,
+			},
+			wantLang:     "en",
+			wantReplyTag: "ja",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := handler.Handle(context.Background(), createToolRequest(tt.args))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if result.IsError {
+				t.Fatalf("expected success, got error: %+v", result.Content)
+			}
+
+			textContent := result.Content[0].(mcp.TextContent)
+			var readResult types.ReadMessageResult
+			if err := json.Unmarshal([]byte(textContent.Text), &readResult); err != nil {
+				t.Fatalf("failed to parse result JSON: %v", err)
+			}
+
+			if readResult.Message.Lang != tt.wantLang {
+				t.Errorf("message lang = %q, want %q", readResult.Message.Lang, tt.wantLang)
+			}
+			if len(readResult.Thread) != 2 {
+				t.Fatalf("expected 2 thread messages, got %d", len(readResult.Thread))
+			}
+			if readResult.Thread[1].Lang != tt.wantReplyTag {
+				t.Errorf("reply lang = %q, want %q", readResult.Thread[1].Lang, tt.wantReplyTag)
+			}
+		})
+	}
+}
+
+func TestReadMessageHandler_Handle_ResolutionErrorPolicy(t *testing.T) {
+	mock := &mockSlackClient{
+		getMessage: func(ctx context.Context, channelID, timestamp string) (*types.Message, error) {
+			return &types.Message{
+				User:      "U12345678",
+				Text:      "Hello world",
+				Timestamp: "1355517523.000008",
+			}, nil
+		},
+		getUserInfo: func(ctx context.Context, userID string) (*types.UserInfo, error) {
+			return nil, types.NewSlackError("user_not_found", "user_not_found")
+		},
+	}
+
+	tests := []struct {
+		name         string
+		policy       ResolutionErrorPolicy
+		wantError    bool
+		wantWarnings int
+	}{
+		{
+			name:         "ignore omits warnings",
+			policy:       ResolutionErrorIgnore,
+			wantWarnings: 0,
+		},
+		{
+			name:         "warn adds a warning per unresolved user",
+			policy:       ResolutionErrorWarn,
+			wantWarnings: 1,
+		},
+		{
+			name:      "fail rejects the call",
+			policy:    ResolutionErrorFail,
+			wantError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewReadMessageHandler(mock, WithResolutionErrorPolicy(tt.policy))
+			result, err := handler.Handle(context.Background(), createToolRequest(map[string]interface{}{
+				"url": "https://workspace.slack.com/archives/C01234567/p1355517523000008",
+			}))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			textContent := result.Content[0].(mcp.TextContent)
+			if tt.wantError {
+				if !result.IsError {
+					t.Fatalf("expected error result, got: %s", textContent.Text)
+				}
+				if !strings.Contains(textContent.Text, "U12345678") {
+					t.Errorf("error should name the unresolved user, got: %s", textContent.Text)
+				}
+				return
+			}
+			if result.IsError {
+				t.Fatalf("expected success, got error: %s", textContent.Text)
+			}
+
+			var readResult types.ReadMessageResult
+			if err := json.Unmarshal([]byte(textContent.Text), &readResult); err != nil {
+				t.Fatalf("failed to parse result JSON: %v", err)
+			}
+			if len(readResult.Warnings) != tt.wantWarnings {
+				t.Fatalf("got %d warnings, want %d: %+v", len(readResult.Warnings), tt.wantWarnings, readResult.Warnings)
+			}
+			for _, w := range readResult.Warnings {
+				if w.Code != types.WarnCodeUserResolutionFailed {
+					t.Errorf("warning code = %q, want %q", w.Code, types.WarnCodeUserResolutionFailed)
+				}
+			}
+		})
+	}
+}
+
+func TestReadMessageHandler_Handle_ChannelInfo(t *testing.T) {
+	newMock := func(channelInfo func(ctx context.Context, channelID string) (*types.ChannelInfo, error)) *mockSlackClient {
+		return &mockSlackClient{
+			getMessage: func(ctx context.Context, channelID, timestamp string) (*types.Message, error) {
+				return &types.Message{User: "U12345678", Text: "Hello", Timestamp: "1355517523.000008"}, nil
+			},
+			getUserInfo: func(ctx context.Context, userID string) (*types.UserInfo, error) {
+				return &types.UserInfo{ID: userID, Name: "jsmith"}, nil
+			},
+			getChannelInfo: channelInfo,
+		}
+	}
+	request := createToolRequest(map[string]interface{}{
+		"url": "https://workspace.slack.com/archives/D01234567/p1355517523000008",
+	})
+
+	t.Run("channel details are included", func(t *testing.T) {
+		handler := NewReadMessageHandler(newMock(func(ctx context.Context, channelID string) (*types.ChannelInfo, error) {
+			return &types.ChannelInfo{ID: channelID, IsPrivate: true, IsDM: true}, nil
+		}))
+
+		result, err := handler.Handle(context.Background(), request)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		var readResult types.ReadMessageResult
+		if err := json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &readResult); err != nil {
+			t.Fatalf("failed to parse result JSON: %v", err)
+		}
+		if !readResult.IsDM || !readResult.IsPrivate || readResult.ChannelName != "" {
+			t.Errorf("unexpected channel details: name=%q private=%v dm=%v",
+				readResult.ChannelName, readResult.IsPrivate, readResult.IsDM)
+		}
+	})
+
+	t.Run("channel lookup failure is reported under warn policy", func(t *testing.T) {
+		handler := NewReadMessageHandler(newMock(func(ctx context.Context, channelID string) (*types.ChannelInfo, error) {
+			return nil, types.NewSlackError(types.ErrCodeInvalidToken, "missing_scope")
+		}), WithResolutionErrorPolicy(ResolutionErrorWarn))
+
+		result, err := handler.Handle(context.Background(), request)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if result.IsError {
+			t.Fatalf("channel lookup failure should not fail the call: %+v", result.Content)
+		}
+
+		var readResult types.ReadMessageResult
+		if err := json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &readResult); err != nil {
+			t.Fatalf("failed to parse result JSON: %v", err)
+		}
+		if len(readResult.Warnings) != 1 || readResult.Warnings[0].Code != types.WarnCodeChannelResolutionFailed {
+			t.Errorf("expected a single %s warning, got %+v", types.WarnCodeChannelResolutionFailed, readResult.Warnings)
+		}
+	})
+}
+
+func TestReadMessageHandler_Handle_Workspace(t *testing.T) {
+	mock := &mockSlackClient{
+		getMessage: func(ctx context.Context, channelID, timestamp string) (*types.Message, error) {
+			return &types.Message{User: "U12345678", Text: "Hello", Timestamp: "1355517523.000008"}, nil
+		},
+	}
+
+	handler := NewReadMessageHandler(mock)
+	result, err := handler.Handle(context.Background(), createToolRequest(map[string]interface{}{
+		"url": "https://workspace.slack.com/archives/C01234567/p1355517523000008",
+	}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var readResult types.ReadMessageResult
+	if err := json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &readResult); err != nil {
+		t.Fatalf("failed to parse result JSON: %v", err)
+	}
+	if readResult.Workspace == nil || readResult.Workspace.Domain != "workspace" || readResult.Workspace.TeamID != "T01234567" {
+		t.Errorf("expected workspace info in result, got %+v", readResult.Workspace)
+	}
+}
+
+func TestReadMessageHandler_Handle_UserDMURL(t *testing.T) {
+	// A link by user names a person, not a conversation: no DM is opened or read
+	mock := &mockSlackClient{
+		openDMChannel: func(ctx context.Context, userID string) (string, error) {
+			t.Error("expected no DM to be opened")
+			return "D99999999", nil
+		},
+		getMessage: func(ctx context.Context, channelID, timestamp string) (*types.Message, error) {
+			t.Errorf("expected no message read, got one from %s", channelID)
+			return &types.Message{User: "U01234567", Text: "hi", Timestamp: timestamp}, nil
+		},
+	}
+	handler := NewReadMessageHandler(mock)
+
+	result, err := handler.Handle(context.Background(), createToolRequest(map[string]interface{}{
+		"url": "https://workspace.slack.com/archives/U01234567/p1355517523000008",
+	}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	text := result.Content[0].(mcp.TextContent).Text
+	if !result.IsError || !strings.Contains(text, "U01234567 is a user, not a conversation") {
+		t.Errorf("expected the user link to be refused, got: %s", text)
+	}
+}
+
+func TestReadMessageHandler_Handle_MissingScope(t *testing.T) {
+	mock := &mockSlackClient{
+		getMessage: func(ctx context.Context, channelID, timestamp string) (*types.Message, error) {
+			return nil, types.NewSlackError(types.ErrCodeMissingScope,
+				"Missing scope channels:history. Add it under OAuth & Permissions in the Slack app settings and reinstall the app.")
+		},
+	}
+	handler := NewReadMessageHandler(mock)
+
+	result, err := handler.Handle(context.Background(), createToolRequest(map[string]interface{}{
+		"url": "https://workspace.slack.com/archives/C01234567/p1355517523000008",
+	}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !result.IsError {
+		t.Fatal("expected error result")
+	}
+	text := result.Content[0].(mcp.TextContent).Text
+	if !strings.Contains(text, "channels:history") {
+		t.Errorf("error should name the missing scope, got: %s", text)
+	}
+}
+
+func TestReadMessageHandler_Handle_MaxTokensEstimate(t *testing.T) {
+	parentTS := "1355517523.000008"
+	filler := strings.Repeat("lorem ipsum ", 100)
+	thread := []types.Message{{User: "U12345678", Text: "Parent", Timestamp: parentTS, ReplyCount: 8}}
+	for i := 1; i <= 8; i++ {
+		reply := types.Message{User: "U12345678", Text: filler, Timestamp: fmt.Sprintf("1355517524.00000%d", i), ThreadTS: parentTS}
+		if i == 4 {
+			reply.ReactionCount = 5
+		}
+		thread = append(thread, reply)
+	}
+	mock := &mockSlackClient{
+		getMessage: func(ctx context.Context, channelID, timestamp string) (*types.Message, error) {
+			return &thread[0], nil
+		},
+		getThread: func(ctx context.Context, channelID, threadTS string) ([]types.Message, error) {
+			return append([]types.Message(nil), thread...), nil
+		},
+	}
+	handler := NewReadMessageHandler(mock)
+	url := "https://workspace.slack.com/archives/C01234567/p1355517523000008"
+
+	result, err := handler.Handle(context.Background(), createToolRequest(map[string]interface{}{
+		"url":                 url,
+		"max_tokens_estimate": float64(1600),
+	}))
+	if err != nil || result.IsError {
+		t.Fatalf("unexpected error: %v %v", err, result.Content)
+	}
+	var parsed types.ReadMessageResult
+	if err := json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &parsed); err != nil {
+		t.Fatalf("failed to parse result JSON: %v", err)
+	}
+
+	var kept []string
+	for _, message := range parsed.Thread {
+		kept = append(kept, message.Timestamp)
+	}
+	// Parent, first reply, most-reacted reply, and the latest replies that fit
+	want := []string{parentTS, "1355517524.000001", "1355517524.000004", "1355517524.000007", "1355517524.000008"}
+	if strings.Join(kept, ",") != strings.Join(want, ",") {
+		t.Errorf("kept thread = %v, want %v", kept, want)
+	}
+	if len(parsed.ElidedReplies) != 4 || parsed.ElidedReplies[0] != "1355517524.000002" {
+		t.Errorf("elided_replies = %v, want replies 2, 3, 5, and 6", parsed.ElidedReplies)
+	}
+	if len(parsed.Warnings) != 1 || parsed.Warnings[0].Code != types.WarnCodeThreadTrimmed {
+		t.Errorf("warnings = %+v, want one thread_trimmed", parsed.Warnings)
+	}
+
+	// A budget the thread fits in leaves it whole
+	result, _ = handler.Handle(context.Background(), createToolRequest(map[string]interface{}{
+		"url":                 url,
+		"max_tokens_estimate": float64(100000),
+	}))
+	parsed = types.ReadMessageResult{}
+	if err := json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &parsed); err != nil {
+		t.Fatalf("failed to parse result JSON: %v", err)
+	}
+	if len(parsed.Thread) != 9 || parsed.ElidedReplies != nil {
+		t.Errorf("thread has %d messages, elided %v; want the whole thread", len(parsed.Thread), parsed.ElidedReplies)
+	}
+
+	result, _ = handler.Handle(context.Background(), createToolRequest(map[string]interface{}{
+		"url":                 url,
+		"max_tokens_estimate": float64(0),
+	}))
+	if !result.IsError {
+		t.Error("expected an error for a non-positive max_tokens_estimate")
+	}
+}
+
+func TestReadMessageHandler_Handle_Unchanged(t *testing.T) {
+	parent := &types.Message{
+		User:        "U12345678",
+		Text:        "Deploy thread",
+		Timestamp:   "1355517523.000008",
+		ReplyCount:  3,
+		LatestReply: "1355517600.000120",
+	}
+
+	tests := []struct {
+		name          string
+		args          map[string]interface{}
+		wantUnchanged bool
+	}{
+		{name: "same reply count", args: map[string]interface{}{"known_reply_count": float64(3)}, wantUnchanged: true},
+		{name: "more replies", args: map[string]interface{}{"known_reply_count": float64(2)}},
+		{name: "same latest reply", args: map[string]interface{}{"if_changed_since": "1355517600.000120"}, wantUnchanged: true},
+		{name: "latest reply without trailing zeros", args: map[string]interface{}{"if_changed_since": "1355517600.00012"}, wantUnchanged: true},
+		{name: "older latest reply seen", args: map[string]interface{}{"if_changed_since": "1355517600.000119"}},
+		{
+			name:          "both conditions must hold",
+			args:          map[string]interface{}{"known_reply_count": float64(3), "if_changed_since": "1355517599"},
+			wantUnchanged: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			threadFetched := false
+			mock := &mockSlackClient{
+				getMessage: func(ctx context.Context, channelID, timestamp string) (*types.Message, error) {
+					return parent, nil
+				},
+				getThread: func(ctx context.Context, channelID, threadTS string) ([]types.Message, error) {
+					threadFetched = true
+					return []types.Message{*parent}, nil
+				},
+			}
+
+			tt.args["url"] = "https://test.slack.com/archives/C01234567/p1355517523000008"
+			result, err := NewReadMessageHandler(mock).Handle(context.Background(), createToolRequest(tt.args))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if result.IsError {
+				t.Fatalf("expected success, got error: %+v", result.Content)
+			}
+
+			var unchanged types.ReadMessageUnchangedResult
+			if err := json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &unchanged); err != nil {
+				t.Fatalf("failed to parse result JSON: %v", err)
+			}
+			if unchanged.Unchanged != tt.wantUnchanged {
+				t.Errorf("unchanged = %v, want %v", unchanged.Unchanged, tt.wantUnchanged)
+			}
+			if threadFetched == tt.wantUnchanged {
+				t.Errorf("thread fetched = %v, want %v", threadFetched, !tt.wantUnchanged)
+			}
+			if tt.wantUnchanged && (unchanged.ReplyCount != 3 || unchanged.ThreadTS != "1355517523.000008") {
+				t.Errorf("unexpected result: %+v", unchanged)
+			}
+		})
+	}
+}
+
+func TestReadMessageHandler_Handle_UnchangedBypassesMessageCache(t *testing.T) {
+	// The thread grows from 3 to 4 replies after the parent was cached
+	var replyCount atomic.Int32
+	replyCount.Store(3)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/conversations.history":
+			_, _ = fmt.Fprintf(w, `{"ok":true,"messages":[{"type":"message","user":"U12345678","text":"Deploy thread",`+
+				`"ts":"1355517523.000008","thread_ts":"1355517523.000008","reply_count":%d,"latest_reply":"1355517600.00012%d"}]}`,
+				replyCount.Load(), replyCount.Load())
+		case "/conversations.replies":
+			_, _ = w.Write([]byte(`{"ok":true,"messages":[{"type":"message","user":"U12345678","text":"Deploy thread",` +
+				`"ts":"1355517523.000008","thread_ts":"1355517523.000008","reply_count":4}]}`))
+		default:
+			_, _ = w.Write([]byte(`{"ok":false,"error":"not_found"}`))
+		}
+	}))
+	defer srv.Close()
+	client := slackclient.NewClient("xoxb-test", "", slackclient.WithAPIURL(srv.URL+"/"),
+		slackclient.WithMessageCacheTTL(time.Minute))
+
+	if _, err := client.GetMessage(context.Background(), "C01234567", "1355517523.000008"); err != nil {
+		t.Fatalf("GetMessage failed: %v", err)
+	}
+	replyCount.Store(4)
+
+	result, err := NewReadMessageHandler(client).Handle(context.Background(), createToolRequest(map[string]interface{}{
+		"url":               "https://test.slack.com/archives/C01234567/p1355517523000008",
+		"known_reply_count": float64(3),
+	}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("expected success, got error: %+v", result.Content)
+	}
+
+	var unchanged types.ReadMessageUnchangedResult
+	if err := json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &unchanged); err != nil {
+		t.Fatalf("failed to parse result JSON: %v", err)
+	}
+	if unchanged.Unchanged {
+		t.Error("expected the grown thread to be read in full, not reported unchanged from the cached parent")
+	}
+}
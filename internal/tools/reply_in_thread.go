@@ -0,0 +1,242 @@
+// Package tools provides MCP tool handler implementations for the Slack MCP server.
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	slackclient "github.com/Bitovi/slack-mcp-server/internal/slack"
+	"github.com/Bitovi/slack-mcp-server/internal/urlparser"
+	"github.com/Bitovi/slack-mcp-server/pkg/types"
+)
+
+// ReplyInThreadHandler handles the reply_in_thread MCP tool requests.
+// It posts a reply in the thread of a message identified by its Slack URL,
+// the same URL format read_message accepts.
+// It is a write tool, registered only when write tools are enabled.
+type ReplyInThreadHandler struct {
+	// slackClient is the Slack API client for posting messages.
+	slackClient slackclient.ClientInterface
+	// config holds optional handler behavior.
+	config handlerConfig
+}
+
+// NewReplyInThreadHandler creates a new ReplyInThreadHandler with the given Slack client and options.
+func NewReplyInThreadHandler(client slackclient.ClientInterface, opts ...HandlerOption) *ReplyInThreadHandler {
+	return &ReplyInThreadHandler{
+		slackClient: client,
+		config:      newHandlerConfig(opts),
+	}
+}
+
+// Handle processes a reply_in_thread tool call. A URL of a thread reply posts in
+// the thread that reply belongs to; a URL of any other message starts or continues
+// the thread under that message.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeouts
+//   - request: The MCP tool call request containing url, text, and optional
+//     reply_broadcast and on_long_text
+//
+// Returns an MCP tool result with the posted reply's timestamp and permalink,
+// or an error result if the arguments are invalid or Slack rejects the reply.
+func (h *ReplyInThreadHandler) Handle(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	// Extract the URL argument (required)
+	urlArg, ok := request.Params.Arguments["url"]
+	if !ok {
+		return mcp.NewToolResultError("missing required argument 'url'"), nil
+	}
+
+	url, ok := urlArg.(string)
+	if !ok {
+		return mcp.NewToolResultError("argument 'url' must be a string"), nil
+	}
+
+	if url == "" {
+		return mcp.NewToolResultError("missing required argument 'url'"), nil
+	}
+
+	// Extract the text argument (required)
+	textArg, ok := request.Params.Arguments["text"]
+	if !ok {
+		return mcp.NewToolResultError("missing required argument 'text'"), nil
+	}
+
+	text, ok := textArg.(string)
+	if !ok {
+		return mcp.NewToolResultError("argument 'text' must be a string"), nil
+	}
+
+	if text == "" {
+		return mcp.NewToolResultError("argument 'text' cannot be empty"), nil
+	}
+
+	// Extract reply_broadcast parameter (optional, default false)
+	var opts slackclient.PostMessageOptions
+	if broadcastArg, exists := request.Params.Arguments["reply_broadcast"]; exists {
+		v, ok := broadcastArg.(bool)
+		if !ok {
+			return mcp.NewToolResultError("argument 'reply_broadcast' must be a boolean"), nil
+		}
+		opts.ReplyBroadcast = v
+	}
+
+	// Text too long for one message is split into further replies, unless
+	// on_long_text asks for a snippet or an error
+	longTextMode, err := parseLongTextMode(request.Params.Arguments)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	chunks := []string{text}
+	asSnippet := false
+	if length := utf8.RuneCountInString(text); length > maxMessageLength {
+		switch longTextMode {
+		case longTextError:
+			return mcp.NewToolResultError(fmt.Sprintf("reply is %d characters; Slack posts at most %d "+
+				"per message (use on_long_text '%s' to post it as several replies)",
+				length, maxMessageLength, longTextSplit)), nil
+		case longTextSnippet:
+			if err := checkSnippetLength(text); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			asSnippet = true
+		default:
+			chunks = splitMessage(text, maxMessageLength)
+		}
+	}
+
+	// Parse the Slack URL to find the channel and the thread to reply in
+	parsedURL, err := urlparser.Parse(url)
+	if err != nil {
+		return h.handleError(err), nil
+	}
+
+	// DM deep links that reference a user reply in the IM channel with that user
+	if parsedURL.UserID != "" {
+		parsedURL.ChannelID, err = h.slackClient.OpenDMChannel(ctx, parsedURL.UserID)
+		if err != nil {
+			return h.handleError(err), nil
+		}
+	}
+
+	opts.ThreadTS = parsedURL.Timestamp
+	if parsedURL.IsThread {
+		opts.ThreadTS = parsedURL.ThreadTS
+	}
+
+	// The snippet is shared in the thread; it cannot be broadcast to the channel
+	if asSnippet {
+		result, errResult := uploadLongText(ctx, h.slackClient, parsedURL.ChannelID, opts.ThreadTS, text, h.handleError)
+		if errResult != nil {
+			return errResult, nil
+		}
+		return h.successResult(result)
+	}
+
+	messageTS, err := h.slackClient.PostMessage(ctx, parsedURL.ChannelID, chunks[0], opts)
+	if err != nil {
+		return h.handleError(err), nil
+	}
+
+	result := &types.PostMessageResult{
+		ChannelID: parsedURL.ChannelID,
+		MessageTS: messageTS,
+		ThreadTS:  opts.ThreadTS,
+		Text:      text,
+	}
+
+	// Post the rest of long text as further replies, without broadcasting them
+	continuation := slackclient.PostMessageOptions{ThreadTS: opts.ThreadTS}
+	for i, chunk := range chunks[1:] {
+		ts, err := h.slackClient.PostMessage(ctx, parsedURL.ChannelID, chunk, continuation)
+		if err != nil {
+			// The first parts are already posted, so report the rest rather than failing
+			result.Warnings = append(result.Warnings, types.Warning{
+				Code:    types.WarnCodePostIncomplete,
+				Message: fmt.Sprintf("posted %d of %d parts of the reply: %s", i+1, len(chunks), err.Error()),
+			})
+			break
+		}
+		result.ContinuationTS = append(result.ContinuationTS, ts)
+	}
+
+	result.Workspace = workspaceFor(ctx, h.slackClient)
+	// Link to the posted reply so it can be reviewed
+	if result.Workspace != nil {
+		if permalink, err := urlparser.Build(result.Workspace.URL, parsedURL.ChannelID, messageTS, opts.ThreadTS); err == nil {
+			result.Permalink = permalink
+		}
+	}
+
+	return h.successResult(result)
+}
+
+// handleError converts errors to appropriate MCP error results.
+func (h *ReplyInThreadHandler) handleError(err error) *mcp.CallToolResult {
+	// Check for known error types and provide appropriate messages
+	if slackclient.IsRateLimited(err) {
+		return mcp.NewToolResultError(
+			"Rate limit exceeded. Please wait and try again.")
+	}
+
+	if slackclient.IsInvalidToken(err) {
+		return mcp.NewToolResultError(
+			"Authentication failed. Please check that SLACK_BOT_TOKEN is valid and not expired.")
+	}
+
+	if slackclient.IsChannelNotFound(err) {
+		return mcp.NewToolResultError(
+			"Channel not found. The channel may have been deleted, or the ID in the URL is incorrect.")
+	}
+
+	if slackclient.IsNotInChannel(err) {
+		return mcp.NewToolResultError(
+			"The bot is not a member of this channel. Please invite the bot to the channel first.")
+	}
+
+	// Posting requires chat:write, which read-only installs of the app do not have
+	if slackclient.IsMissingScope(err) {
+		return mcp.NewToolResultError(fmt.Sprintf(
+			"Posting messages requires the chat:write bot scope. %s", err.Error()))
+	}
+
+	if slackclient.IsChannelArchived(err) {
+		return mcp.NewToolResultError(
+			"The channel is archived. Messages cannot be posted to archived channels.")
+	}
+
+	if slackclient.IsPermissionDenied(err) {
+		return mcp.NewToolResultError(
+			"Permission denied. The bot lacks permission to post in this channel.")
+	}
+
+	// Check for URL parsing errors
+	if slackclient.GetErrorCode(err) == types.ErrCodeInvalidURL {
+		return mcp.NewToolResultError(fmt.Sprintf(
+			"Invalid Slack URL format. Expected: https://workspace.slack.com/archives/{channel_id}/p{timestamp}\n\nDetails: %s",
+			err.Error()))
+	}
+
+	// Generic error handling
+	return mcp.NewToolResultError(fmt.Sprintf("Failed to reply in thread: %s", err.Error()))
+}
+
+// successResult creates a successful MCP tool result with the given data.
+func (h *ReplyInThreadHandler) successResult(result *types.PostMessageResult) (*mcp.CallToolResult, error) {
+	resultJSON, err := json.Marshal(result)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to encode result: %s", err.Error())), nil
+	}
+
+	return mcp.NewToolResultText(string(resultJSON)), nil
+}
+
+// HandleFunc returns a function that can be used directly as an MCP tool handler.
+// This is a convenience method for registering the handler with the MCP server.
+func (h *ReplyInThreadHandler) HandleFunc() func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return h.Handle
+}
@@ -0,0 +1,163 @@
+// Package tools provides unit tests for the MCP tool handlers.
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	slackclient "github.com/Bitovi/slack-mcp-server/internal/slack"
+	"github.com/Bitovi/slack-mcp-server/pkg/types"
+)
+
+func TestReplyInThreadHandler_Handle(t *testing.T) {
+	tests := []struct {
+		name          string
+		args          map[string]interface{}
+		postErr       error
+		wantOpts      slackclient.PostMessageOptions
+		wantPermalink string
+		wantError     string
+	}{
+		{
+			name: "message URL starts a thread",
+			args: map[string]interface{}{
+				"url":  "https://workspace.slack.com/archives/C01234567/p1355517523000008",
+				"text": "On it",
+			},
+			wantOpts:      slackclient.PostMessageOptions{ThreadTS: "1355517523.000008"},
+			wantPermalink: "https://workspace.slack.com/archives/C01234567/p1355517524000001?thread_ts=1355517523.000008&cid=C01234567",
+		},
+		{
+			name: "reply URL posts in its thread",
+			args: map[string]interface{}{
+				"url":             "https://workspace.slack.com/archives/C01234567/p1355517530000002?thread_ts=1355517523.000008&cid=C01234567",
+				"text":            "On it",
+				"reply_broadcast": true,
+			},
+			wantOpts:      slackclient.PostMessageOptions{ThreadTS: "1355517523.000008", ReplyBroadcast: true},
+			wantPermalink: "https://workspace.slack.com/archives/C01234567/p1355517524000001?thread_ts=1355517523.000008&cid=C01234567",
+		},
+		{
+			name:      "missing url",
+			args:      map[string]interface{}{"text": "On it"},
+			wantError: "missing required argument 'url'",
+		},
+		{
+			name:      "invalid url",
+			args:      map[string]interface{}{"url": "https://example.com/not-slack", "text": "On it"},
+			wantError: "Invalid Slack URL format",
+		},
+		{
+			name: "empty text",
+			args: map[string]interface{}{
+				"url":  "https://workspace.slack.com/archives/C01234567/p1355517523000008",
+				"text": "",
+			},
+			wantError: "argument 'text' cannot be empty",
+		},
+		{
+			name: "missing chat:write",
+			args: map[string]interface{}{
+				"url":  "https://workspace.slack.com/archives/C01234567/p1355517523000008",
+				"text": "On it",
+			},
+			postErr: types.NewSlackError(types.ErrCodeMissingScope,
+				"Missing scope chat:write. Add it under OAuth & Permissions in the Slack app settings and reinstall the app."),
+			wantError: "Posting messages requires the chat:write bot scope.",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var postedOpts slackclient.PostMessageOptions
+			posted := false
+			mock := &mockSlackClient{
+				postMessage: func(ctx context.Context, channelID, text string, opts slackclient.PostMessageOptions) (string, error) {
+					if tt.postErr != nil {
+						return "", tt.postErr
+					}
+					if channelID != "C01234567" {
+						t.Errorf("channel = %q, want C01234567", channelID)
+					}
+					posted, postedOpts = true, opts
+					return "1355517524.000001", nil
+				},
+			}
+
+			handler := NewReplyInThreadHandler(mock)
+			result, err := handler.Handle(context.Background(), createToolRequest(tt.args))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			text := result.Content[0].(mcp.TextContent).Text
+			if tt.wantError != "" {
+				if !result.IsError || !strings.Contains(text, tt.wantError) {
+					t.Errorf("expected error containing %q, got: %s", tt.wantError, text)
+				}
+				if posted {
+					t.Error("expected no reply to be posted")
+				}
+				return
+			}
+			if result.IsError {
+				t.Fatalf("expected success, got error: %s", text)
+			}
+			if !reflect.DeepEqual(postedOpts, tt.wantOpts) {
+				t.Errorf("options = %+v, want %+v", postedOpts, tt.wantOpts)
+			}
+
+			var replyResult types.PostMessageResult
+			if err := json.Unmarshal([]byte(text), &replyResult); err != nil {
+				t.Fatalf("failed to parse result JSON: %v", err)
+			}
+			if replyResult.MessageTS != "1355517524.000001" || replyResult.Permalink != tt.wantPermalink {
+				t.Errorf("message_ts = %q, permalink = %q, want %q", replyResult.MessageTS, replyResult.Permalink, tt.wantPermalink)
+			}
+		})
+	}
+}
+
+func TestReplyInThreadHandler_Handle_LongTextSnippet(t *testing.T) {
+	var uploadedChannel, uploadedThread string
+	mock := &mockSlackClient{
+		postMessage: func(ctx context.Context, channelID, text string, opts slackclient.PostMessageOptions) (string, error) {
+			t.Error("expected no reply to be posted")
+			return "", nil
+		},
+		uploadSnippet: func(ctx context.Context, channelID, filename, content string, opts slackclient.SnippetOptions) (string, error) {
+			uploadedChannel, uploadedThread = channelID, opts.ThreadTS
+			return "F01234567", nil
+		},
+	}
+
+	handler := NewReplyInThreadHandler(mock)
+	result, err := handler.Handle(context.Background(), createToolRequest(map[string]interface{}{
+		"url":          "https://workspace.slack.com/archives/C01234567/p1355517523000008",
+		"text":         strings.Repeat("a", maxMessageLength+1),
+		"on_long_text": "snippet",
+	}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	text := result.Content[0].(mcp.TextContent).Text
+	if result.IsError {
+		t.Fatalf("expected success, got error: %s", text)
+	}
+	if uploadedChannel != "C01234567" || uploadedThread != "1355517523.000008" {
+		t.Errorf("uploaded to %s in thread %q, want C01234567 in 1355517523.000008", uploadedChannel, uploadedThread)
+	}
+
+	var replyResult types.PostMessageResult
+	if err := json.Unmarshal([]byte(text), &replyResult); err != nil {
+		t.Fatalf("failed to parse result JSON: %v", err)
+	}
+	if replyResult.FileID != "F01234567" || replyResult.ThreadTS != "1355517523.000008" {
+		t.Errorf("unexpected result: %+v", replyResult)
+	}
+}
@@ -0,0 +1,82 @@
+// Package tools provides MCP tool handler implementations for the Slack MCP server.
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	slackclient "github.com/Bitovi/slack-mcp-server/internal/slack"
+	"github.com/Bitovi/slack-mcp-server/pkg/types"
+)
+
+// retentionGapWarning explains a history window that comes back empty (or without
+// its older part) because the workspace's message retention limit removed the
+// older messages. Slack does not report this, so it is inferred: the window was
+// read to its start, both the window and the channel reach back past the retention
+// cutoff, and no message older than the cutoff was returned.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeouts
+//   - client: The Slack client, used to look up when the channel was created
+//   - window: The assumed retention window; zero disables the check
+//   - channelID: The channel that was read
+//   - oldest, latest: The requested window as Unix timestamps, empty for unbounded
+//   - messages: The messages returned for the window
+//   - hasMore: Whether the window has more messages than were returned
+//
+// Returns nil if no gap is suspected.
+func retentionGapWarning(ctx context.Context, client slackclient.ClientInterface, window time.Duration,
+	channelID, oldest, latest string, messages []types.Message, hasMore bool) *types.Warning {
+	// The start of the window was not reached, so nothing is known about it
+	if window <= 0 || hasMore {
+		return nil
+	}
+
+	cutoff := time.Now().Add(-window)
+	if start, ok := parseSlackTime(oldest); ok && !start.Before(cutoff) {
+		return nil
+	}
+
+	// Any message older than the cutoff shows older history is still available
+	for i := range messages {
+		if ts, ok := parseSlackTime(messages[i].Timestamp); ok && ts.Before(cutoff) {
+			return nil
+		}
+	}
+
+	// The channel must have existed before the cutoff, and during the window
+	channel, err := client.GetChannelInfo(ctx, channelID)
+	if err != nil || channel == nil || channel.Created == 0 {
+		return nil
+	}
+	created := time.Unix(channel.Created, 0)
+	if !created.Before(cutoff) {
+		return nil
+	}
+	if end, ok := parseSlackTime(latest); ok && !created.Before(end) {
+		return nil
+	}
+
+	return &types.Warning{
+		Code: types.WarnCodeRetentionGap,
+		Message: fmt.Sprintf("no messages older than %s were returned, although the requested window and the channel "+
+			"(created %s) extend further back; older messages may have been removed by the workspace's message "+
+			"retention limit (Slack's free plan keeps 90 days)",
+			cutoff.UTC().Format("2006-01-02"), created.UTC().Format("2006-01-02")),
+	}
+}
+
+// parseSlackTime parses a Slack timestamp (e.g., "1355517523.000008") or Unix time.
+// Returns false if s is empty or not a timestamp.
+func parseSlackTime(s string) (time.Time, bool) {
+	if s == "" {
+		return time.Time{}, false
+	}
+	sec, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return time.Unix(int64(sec), 0), true
+}
@@ -0,0 +1,99 @@
+// Package tools provides unit tests for the MCP tool handlers.
+package tools
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/Bitovi/slack-mcp-server/pkg/types"
+)
+
+func TestRetentionGapWarning(t *testing.T) {
+	now := time.Now()
+	daysAgo := func(days int) string {
+		return fmt.Sprintf("%d.000100", now.Add(-time.Duration(days)*24*time.Hour).Unix())
+	}
+
+	tests := []struct {
+		name        string
+		oldest      string
+		latest      string
+		messages    []types.Message
+		hasMore     bool
+		createdDays int
+		window      time.Duration
+		wantWarning bool
+	}{
+		{
+			name:        "empty window before cutoff in old channel",
+			oldest:      daysAgo(200),
+			latest:      daysAgo(150),
+			createdDays: 400,
+			window:      FreePlanRetentionWindow,
+			wantWarning: true,
+		},
+		{
+			name:        "unbounded window with only recent messages",
+			messages:    []types.Message{{Timestamp: daysAgo(10)}},
+			createdDays: 400,
+			window:      FreePlanRetentionWindow,
+			wantWarning: true,
+		},
+		{
+			name:        "older messages returned",
+			messages:    []types.Message{{Timestamp: daysAgo(10)}, {Timestamp: daysAgo(120)}},
+			createdDays: 400,
+			window:      FreePlanRetentionWindow,
+		},
+		{
+			name:        "window within retention",
+			oldest:      daysAgo(30),
+			createdDays: 400,
+			window:      FreePlanRetentionWindow,
+		},
+		{
+			name:        "channel newer than cutoff",
+			createdDays: 30,
+			window:      FreePlanRetentionWindow,
+		},
+		{
+			name:        "channel created after window",
+			oldest:      daysAgo(400),
+			latest:      daysAgo(300),
+			createdDays: 200,
+			window:      FreePlanRetentionWindow,
+		},
+		{
+			name:        "window start not reached",
+			hasMore:     true,
+			createdDays: 400,
+			window:      FreePlanRetentionWindow,
+		},
+		{
+			name:        "disabled",
+			createdDays: 400,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := &mockSlackClient{
+				getChannelInfo: func(ctx context.Context, channelID string) (*types.ChannelInfo, error) {
+					created := now.Add(-time.Duration(tt.createdDays) * 24 * time.Hour).Unix()
+					return &types.ChannelInfo{ID: channelID, Created: created}, nil
+				},
+			}
+
+			warning := retentionGapWarning(context.Background(), mock, tt.window,
+				"C01234567", tt.oldest, tt.latest, tt.messages, tt.hasMore)
+			if (warning != nil) != tt.wantWarning {
+				t.Fatalf("warning = %+v, want warning %v", warning, tt.wantWarning)
+			}
+			if warning != nil && warning.Code != types.WarnCodeRetentionGap {
+				t.Errorf("code = %q, want %q", warning.Code, types.WarnCodeRetentionGap)
+			}
+		})
+	}
+}
@@ -5,24 +5,42 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"sort"
+	"strings"
 
 	"github.com/mark3labs/mcp-go/mcp"
 
+	"github.com/Bitovi/slack-mcp-server/internal/langdetect"
+	"github.com/Bitovi/slack-mcp-server/internal/references"
 	slackclient "github.com/Bitovi/slack-mcp-server/internal/slack"
+	"github.com/Bitovi/slack-mcp-server/internal/urlparser"
 	"github.com/Bitovi/slack-mcp-server/pkg/types"
 )
 
+const (
+	// maxContextMessages is the maximum number of context messages fetched per match.
+	maxContextMessages = 10
+	// maxContextMatches is the maximum number of matches that receive context messages.
+	// Each match costs one conversations.history call, so this bounds the extra API usage.
+	maxContextMatches = 10
+	// maxThreadExpansions is the maximum number of distinct thread parents fetched per search.
+	maxThreadExpansions = 20
+)
+
 // SearchMessagesHandler handles the search_messages MCP tool requests.
 // It searches for messages across the Slack workspace and resolves user information.
 type SearchMessagesHandler struct {
 	// slackClient is the Slack API client for searching messages.
 	slackClient slackclient.ClientInterface
+	// config holds optional handler behavior such as the resolution error policy.
+	config handlerConfig
 }
 
-// NewSearchMessagesHandler creates a new SearchMessagesHandler with the given Slack client.
-func NewSearchMessagesHandler(client slackclient.ClientInterface) *SearchMessagesHandler {
+// NewSearchMessagesHandler creates a new SearchMessagesHandler with the given Slack client and options.
+func NewSearchMessagesHandler(client slackclient.ClientInterface, opts ...HandlerOption) *SearchMessagesHandler {
 	return &SearchMessagesHandler{
 		slackClient: client,
+		config:      newHandlerConfig(opts),
 	}
 }
 
@@ -52,6 +70,11 @@ func (h *SearchMessagesHandler) Handle(ctx context.Context, request mcp.CallTool
 		return mcp.NewToolResultError("argument 'query' cannot be empty"), nil
 	}
 
+	// Catch common query syntax mistakes before spending a rate-limited API call
+	if err := validateSearchQuery(query); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid search query: %s", err.Error())), nil
+	}
+
 	// Extract count (default 20, max 100)
 	count := 20
 	if countArg, exists := request.Params.Arguments["count"]; exists {
@@ -66,10 +89,15 @@ func (h *SearchMessagesHandler) Handle(ctx context.Context, request mcp.CallTool
 	}
 
 	// Validate count range
+	var warnings []types.Warning
 	if count < 1 {
 		count = 1
 	}
 	if count > 100 {
+		warnings = append(warnings, types.Warning{
+			Code:    types.WarnCodeResultsTruncated,
+			Message: fmt.Sprintf("count %d exceeds the maximum of 100; at most 100 matches are returned", count),
+		})
 		count = 100
 	}
 
@@ -85,33 +113,206 @@ func (h *SearchMessagesHandler) Handle(ctx context.Context, request mcp.CallTool
 		// Invalid sort values are silently ignored, defaulting to "score"
 	}
 
+	// Extract include_context (optional, default 0, max 10)
+	includeContext := 0
+	if contextArg, exists := request.Params.Arguments["include_context"]; exists {
+		switch v := contextArg.(type) {
+		case float64:
+			includeContext = int(v)
+		case int:
+			includeContext = v
+		default:
+			return mcp.NewToolResultError("argument 'include_context' must be a number"), nil
+		}
+	}
+
+	// Extract expand_threads (optional, default false)
+	expandThreads := false
+	if expandArg, exists := request.Params.Arguments["expand_threads"]; exists {
+		v, ok := expandArg.(bool)
+		if !ok {
+			return mcp.NewToolResultError("argument 'expand_threads' must be a boolean"), nil
+		}
+		expandThreads = v
+	}
+
+	// Extract count_only (optional, default false)
+	countOnly := false
+	if countOnlyArg, exists := request.Params.Arguments["count_only"]; exists {
+		v, ok := countOnlyArg.(bool)
+		if !ok {
+			return mcp.NewToolResultError("argument 'count_only' must be a boolean"), nil
+		}
+		countOnly = v
+	}
+
+	// Extract detect_language (optional, default false)
+	detectLanguage := false
+	if detectArg, exists := request.Params.Arguments["detect_language"]; exists {
+		v, ok := detectArg.(bool)
+		if !ok {
+			return mcp.NewToolResultError("argument 'detect_language' must be a boolean"), nil
+		}
+		detectLanguage = v
+	}
+
+	// Extract extract_references parameter (optional, default false)
+	extractReferences := false
+	if extractArg, exists := request.Params.Arguments["extract_references"]; exists {
+		v, ok := extractArg.(bool)
+		if !ok {
+			return mcp.NewToolResultError("argument 'extract_references' must be a boolean"), nil
+		}
+		extractReferences = v
+	}
+
+	// Extract include_raw parameter (optional, default false)
+	includeRaw := false
+	if rawArg, exists := request.Params.Arguments["include_raw"]; exists {
+		v, ok := rawArg.(bool)
+		if !ok {
+			return mcp.NewToolResultError("argument 'include_raw' must be a boolean"), nil
+		}
+		includeRaw = v
+	}
+
+	// Extract fields parameter (optional, default all fields)
+	fields, err := parseFields(request.Params.Arguments, searchMatchFields)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	// Validate include_context range
+	if includeContext < 0 {
+		includeContext = 0
+	}
+	if includeContext > maxContextMessages {
+		warnings = append(warnings, types.Warning{
+			Code: types.WarnCodeResultsTruncated,
+			Message: fmt.Sprintf("include_context %d exceeds the maximum of %d; at most %d context messages are returned per match",
+				includeContext, maxContextMessages, maxContextMessages),
+		})
+		includeContext = maxContextMessages
+	}
+
+	// In count_only mode, request a single match and report only the total
+	if countOnly {
+		_, total, err := h.slackClient.SearchMessages(ctx, query, 1, sort)
+		if err != nil {
+			return h.handleError(err), nil
+		}
+
+		return h.countResult(ctx, &types.MessageCountResult{
+			Query: query,
+			Count: total,
+		})
+	}
+
 	// Call SearchMessages to search for messages
 	matches, total, err := h.slackClient.SearchMessages(ctx, query, count, sort)
 	if err != nil {
 		return h.handleError(err), nil
 	}
 
-	// Resolve user info for each match
+	// Track user resolution failures so they can be reported according to the configured policy
+	resolution := newResolutionTracker()
+
+	// Resolve user info for each match, looking up the distinct authors in parallel
+	authors := make([]string, 0, len(matches))
+	for i := range matches {
+		authors = append(authors, matches[i].User)
+	}
+	users := lookupUsers(ctx, h.slackClient, authors)
 	for i := range matches {
-		h.resolveUserForMatch(ctx, &matches[i])
+		h.resolveUserForMatch(&matches[i], users, resolution)
+	}
+
+	// Tag matches with their detected language if requested
+	if detectLanguage {
+		for i := range matches {
+			matches[i].Lang = langdetect.Detect(matches[i].Text)
+		}
+	}
+
+	// Extract issue and ticket references if requested
+	if extractReferences {
+		for i := range matches {
+			matches[i].References = references.Extract(matches[i].Text, h.config.referenceTrackers)
+		}
+	}
+
+	// Attach thread parents to matches that are thread replies if requested
+	if expandThreads {
+		warnings = append(warnings, h.expandThreads(ctx, matches, resolution)...)
+	}
+
+	// Attach surrounding messages to each match if requested
+	if includeContext > 0 {
+		warnings = append(warnings, h.attachContext(ctx, matches, includeContext, resolution)...)
+	}
+
+	// Drop the Slack API payloads unless requested
+	if !includeRaw {
+		for i := range matches {
+			matches[i].Raw = nil
+			if matches[i].ThreadParent != nil {
+				matches[i].ThreadParent.Raw = nil
+			}
+		}
 	}
 
 	// Build the result
 	result := &types.SearchMessagesResult{
-		Query:   query,
-		Total:   total,
-		Matches: matches,
+		Query:    query,
+		Total:    total,
+		Matches:  matches,
+		Warnings: warnings,
 	}
 
+	// Resolve the users, channels, and user groups the matches refer to
+	h.buildMappings(ctx, result, resolution)
+
 	// Fetch the authenticated user's identity (graceful degradation on failure)
 	currentUser, err := h.slackClient.GetCurrentUser(ctx)
 	if err == nil && currentUser != nil {
 		result.CurrentUser = currentUser
 	}
-	// Note: If GetCurrentUser fails, we continue without current_user rather than failing
+	// Note: If GetCurrentUser fails, we continue without current_user unless the policy says otherwise
+	resolution.recordCurrentUser(err)
+
+	result.Workspace = workspaceFor(ctx, h.slackClient)
+
+	// Flag or reject degraded results according to the resolution error policy
+	resolutionWarnings, err := resolution.apply(h.config.onResolutionError)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to resolve users: %s", err.Error())), nil
+	}
+	result.Warnings = append(result.Warnings, resolutionWarnings...)
 
 	// Return the successful result as JSON content
-	return h.successResult(result)
+	return h.successResult(result, fields)
+}
+
+// buildMappings resolves the users, channels, and user groups that the matches,
+// their thread parents, and their context messages refer to into the result's
+// user_mapping, channel_mapping, and usergroup_mapping. Entities that cannot be
+// resolved are omitted and recorded on resolution.
+func (h *SearchMessagesHandler) buildMappings(ctx context.Context, result *types.SearchMessagesResult, resolution *resolutionTracker) {
+	refs := newEntityRefs(h.slackClient)
+	for _, match := range result.Matches {
+		refs.addText(match.Text)
+		if match.ThreadParent != nil {
+			refs.addMessage(match.ThreadParent)
+		}
+		for _, msg := range match.Context {
+			refs.addText(msg.Text)
+		}
+	}
+
+	mappings := refs.resolve(ctx, resolution)
+	result.UserMapping = mappings.users
+	result.ChannelMapping = mappings.channels
+	result.UserGroupMapping = mappings.userGroups
 }
 
 // handleError converts an error into an MCP tool error result.
@@ -136,6 +337,11 @@ func (h *SearchMessagesHandler) handleError(err error) *mcp.CallToolResult {
 			"Authentication failed. Please check that SLACK_USER_TOKEN is valid and not expired.")
 	}
 
+	// Check for missing scopes (the message names the scope to add when known)
+	if slackclient.IsMissingScope(err) {
+		return mcp.NewToolResultError(err.Error())
+	}
+
 	// Check for permission denied
 	if slackclient.IsPermissionDenied(err) {
 		return mcp.NewToolResultError(
@@ -147,7 +353,20 @@ func (h *SearchMessagesHandler) handleError(err error) *mcp.CallToolResult {
 }
 
 // successResult creates a successful MCP tool result with the given data.
-func (h *SearchMessagesHandler) successResult(result *types.SearchMessagesResult) (*mcp.CallToolResult, error) {
+// If fields is non-empty, the match objects are trimmed to those fields.
+func (h *SearchMessagesHandler) successResult(result *types.SearchMessagesResult, fields []string) (*mcp.CallToolResult, error) {
+	resultJSON, err := marshalProjected(result, fields, "matches")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to encode result: %s", err.Error())), nil
+	}
+
+	return mcp.NewToolResultText(string(resultJSON)), nil
+}
+
+// countResult creates a successful MCP tool result for count_only mode.
+func (h *SearchMessagesHandler) countResult(ctx context.Context, result *types.MessageCountResult) (*mcp.CallToolResult, error) {
+	result.Workspace = workspaceFor(ctx, h.slackClient)
+
 	resultJSON, err := json.Marshal(result)
 	if err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to encode result: %s", err.Error())), nil
@@ -156,33 +375,37 @@ func (h *SearchMessagesHandler) successResult(result *types.SearchMessagesResult
 	return mcp.NewToolResultText(string(resultJSON)), nil
 }
 
-// resolveUserForMatch populates user name fields on a search match by fetching user info.
+// resolveUserForMatch populates user name fields on a search match from its
+// author's lookup in users (see lookupUsers).
 //
-// This method fetches user information for the message author and populates
-// the UserName, DisplayName, and RealName fields on the match. If the user
-// lookup fails, the match is left unchanged (graceful degradation).
+// This method populates the UserName, DisplayName, and RealName fields on the
+// match from the author's user information. If the user lookup failed, the match
+// is left unchanged (graceful degradation) and the failure is recorded on the
+// resolution tracker.
 //
 // Note: The Slack search API already provides UserName in some cases, but we
 // resolve the full user info for consistency with other tools and to get
 // DisplayName and RealName.
 //
 // Parameters:
-//   - ctx: Context for cancellation and timeouts
 //   - match: Pointer to the search match to populate with user info
+//   - users: The lookups of the matches' authors, by user ID
+//   - resolution: Tracker that collects user resolution failures
 //
 // This method does not return an error. If user resolution fails, the match
 // will simply not have additional user name fields populated.
-func (h *SearchMessagesHandler) resolveUserForMatch(ctx context.Context, match *types.SearchMatch) {
+func (h *SearchMessagesHandler) resolveUserForMatch(match *types.SearchMatch, users map[string]userLookup, resolution *resolutionTracker) {
 	// Skip if match has no user ID (e.g., system messages)
 	if match.User == "" {
 		return
 	}
 
-	// Fetch user info from Slack (or cache)
-	userInfo, err := h.slackClient.GetUserInfo(ctx, match.User)
+	// Take the user info looked up from Slack (or cache)
+	userInfo, err := users[match.User].info, users[match.User].err
 	if err != nil {
-		// Graceful degradation: log the error but don't fail
+		// Graceful degradation: record the error but don't fail
 		// The match will be returned without additional user name fields
+		resolution.recordUser(match.User, err)
 		return
 	}
 
@@ -197,6 +420,218 @@ func (h *SearchMessagesHandler) resolveUserForMatch(ctx context.Context, match *
 	match.RealName = userInfo.RealName
 }
 
+// attachContext fetches the messages posted immediately around each match and attaches
+// them to the match as context, in chronological order. count is split between the
+// messages before the match and those after it, with the extra one before for odd counts.
+//
+// Context is fetched for at most maxContextMatches matches, up to two
+// conversations.history calls per match. If a fetch fails (e.g., rate limited or the bot is not in the channel),
+// that match is left without context and its ContextError is set. A rate limit error stops further context fetches
+// so the remaining budget is not spent on calls that will also fail.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeouts
+//   - matches: The search matches to attach context to
+//   - count: Number of context messages to fetch per match
+//   - resolution: Tracker that collects user resolution failures
+//
+// Returns warnings if context was not fetched for every match because of the
+// maxContextMatches cap or rate limiting.
+func (h *SearchMessagesHandler) attachContext(ctx context.Context, matches []types.SearchMatch, count int, resolution *resolutionTracker) []types.Warning {
+	for i := range matches {
+		if i >= maxContextMatches {
+			return []types.Warning{{
+				Code:    types.WarnCodeResultsTruncated,
+				Message: fmt.Sprintf("context was only fetched for the first %d matches", maxContextMatches),
+			}}
+		}
+
+		match := &matches[i]
+		if match.ChannelID == "" || match.Timestamp == "" {
+			continue
+		}
+
+		messages, err := h.fetchContext(ctx, match.ChannelID, match.Timestamp, (count+1)/2, count/2)
+		if err != nil {
+			match.ContextError = partialError(err)
+			if slackclient.IsRateLimited(err) {
+				return []types.Warning{{
+					Code:    types.WarnCodeRateLimited,
+					Message: fmt.Sprintf("context fetching stopped at match %d of %d because Slack rate limited the request", i+1, len(matches)),
+				}}
+			}
+			continue
+		}
+
+		users := lookupUsers(ctx, h.slackClient, messageAuthors(messages))
+		contextMessages := make([]types.ContextMessage, 0, len(messages))
+		for _, msg := range messages {
+			contextMsg := types.ContextMessage{
+				User:      msg.User,
+				Text:      msg.Text,
+				Timestamp: msg.Timestamp,
+				Permalink: contextPermalink(match.Permalink, msg.Timestamp),
+			}
+			if msg.User != "" {
+				userInfo, err := users[msg.User].info, users[msg.User].err
+				resolution.recordUser(msg.User, err)
+				if err == nil && userInfo != nil {
+					contextMsg.UserName = userInfo.Name
+					contextMsg.DisplayName = userInfo.DisplayName
+				}
+			}
+			contextMessages = append(contextMessages, contextMsg)
+		}
+
+		if len(contextMessages) > 0 {
+			match.Context = contextMessages
+		}
+	}
+
+	return nil
+}
+
+// fetchContext fetches up to before messages preceding timestamp in a channel and up to
+// after messages following it, and returns them in chronological order.
+func (h *SearchMessagesHandler) fetchContext(ctx context.Context, channelID, timestamp string, before, after int) ([]types.Message, error) {
+	// latest is exclusive, so this returns the messages preceding the match
+	messages, _, err := h.slackClient.GetChannelHistory(ctx, channelID, slackclient.HistoryOptions{
+		Limit:  before,
+		Latest: timestamp,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if after > 0 {
+		// oldest is exclusive too; given only oldest, Slack returns the messages right after it
+		following, _, err := h.slackClient.GetChannelHistory(ctx, channelID, slackclient.HistoryOptions{
+			Limit:  after,
+			Oldest: timestamp,
+		})
+		if err != nil {
+			return nil, err
+		}
+		messages = append(messages, following...)
+	}
+
+	// Order both sides together, whichever order Slack returned each in
+	sort.SliceStable(messages, func(i, j int) bool {
+		return slackTimestampAfter(messages[j].Timestamp, messages[i].Timestamp)
+	})
+	return messages, nil
+}
+
+// expandThreads attaches the thread parent message to each match that is a thread reply.
+//
+// Search results do not carry thread_ts directly, so it is read from the thread_ts query
+// parameter of the match permalink. Each distinct thread parent is fetched once; the
+// parent's ReplyCount tells the agent how many sibling replies exist. If a parent cannot
+// be fetched, the match keeps its ThreadTS, has no ThreadParent, and its ThreadError
+// describes the failure. A rate limit error
+// stops further parent fetches.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeouts
+//   - matches: The search matches to expand
+//   - resolution: Tracker that collects user resolution failures
+//
+// Returns warnings if some thread parents were skipped because of the
+// maxThreadExpansions cap or rate limiting.
+func (h *SearchMessagesHandler) expandThreads(ctx context.Context, matches []types.SearchMatch, resolution *resolutionTracker) []types.Warning {
+	parents := make(map[string]*types.Message)
+	parentErrors := make(map[string]*types.SlackError)
+	rateLimited := false
+	capped := false
+
+	for i := range matches {
+		match := &matches[i]
+		if match.Permalink == "" {
+			continue
+		}
+
+		parsedURL, err := urlparser.Parse(match.Permalink)
+		if err != nil || !parsedURL.IsThread || parsedURL.ThreadTS == match.Timestamp {
+			// Not a thread reply (top-level messages and thread parents need no expansion)
+			continue
+		}
+		match.ThreadTS = parsedURL.ThreadTS
+
+		key := match.ChannelID + ":" + parsedURL.ThreadTS
+		parent, seen := parents[key]
+		if !seen {
+			if rateLimited {
+				continue
+			}
+			if len(parents) >= maxThreadExpansions {
+				capped = true
+				continue
+			}
+
+			parent, err = h.slackClient.GetMessage(ctx, match.ChannelID, parsedURL.ThreadTS)
+			if err != nil {
+				rateLimited = slackclient.IsRateLimited(err)
+				parentErrors[key] = partialError(err)
+				parent = nil
+			} else {
+				h.resolveUserForParent(ctx, parent, resolution)
+			}
+			parents[key] = parent
+		}
+
+		match.ThreadParent = parent
+		match.ThreadError = parentErrors[key]
+	}
+
+	var warnings []types.Warning
+	if capped {
+		warnings = append(warnings, types.Warning{
+			Code:    types.WarnCodeResultsTruncated,
+			Message: fmt.Sprintf("thread parents were only fetched for the first %d threads", maxThreadExpansions),
+		})
+	}
+	if rateLimited {
+		warnings = append(warnings, types.Warning{
+			Code:    types.WarnCodeRateLimited,
+			Message: "thread expansion stopped early because Slack rate limited the request",
+		})
+	}
+	return warnings
+}
+
+// resolveUserForParent populates user name fields on a thread parent message.
+// If the user lookup fails, the message is left unchanged (graceful degradation)
+// and the failure is recorded on the resolution tracker.
+func (h *SearchMessagesHandler) resolveUserForParent(ctx context.Context, msg *types.Message, resolution *resolutionTracker) {
+	if msg.User == "" {
+		return
+	}
+
+	userInfo, err := h.slackClient.GetUserInfo(ctx, msg.User)
+	resolution.recordUser(msg.User, err)
+	if err != nil || userInfo == nil {
+		return
+	}
+
+	msg.UserName = userInfo.Name
+	msg.DisplayName = userInfo.DisplayName
+	msg.RealName = userInfo.RealName
+}
+
+// contextPermalink derives the permalink of a message in the same channel as a search match
+// by replacing the timestamp segment of the match's permalink. This avoids a
+// chat.getPermalink call per context message.
+//
+// Returns an empty string if the match permalink is not in the expected format.
+func contextPermalink(matchPermalink, timestamp string) string {
+	slash := strings.LastIndex(matchPermalink, "/p")
+	if slash == -1 || timestamp == "" {
+		return ""
+	}
+
+	return matchPermalink[:slash] + "/p" + strings.Replace(timestamp, ".", "", 1)
+}
+
 // HandleFunc returns a function that can be used directly as an MCP tool handler.
 // This is a convenience method for registering the handler with the MCP server.
 func (h *SearchMessagesHandler) HandleFunc() func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
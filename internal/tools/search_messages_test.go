@@ -9,6 +9,7 @@ import (
 
 	"github.com/mark3labs/mcp-go/mcp"
 
+	slackclient "github.com/Bitovi/slack-mcp-server/internal/slack"
 	"github.com/Bitovi/slack-mcp-server/pkg/types"
 )
 
@@ -643,6 +644,15 @@ func TestSearchMessagesHandler_Handle_CountExceedsMaximum(t *testing.T) {
 	if capturedCount != 100 {
 		t.Errorf("count exceeding max should be capped at 100, got: %d", capturedCount)
 	}
+
+	// The cap should be reported as a truncation warning
+	var searchResult types.SearchMessagesResult
+	if err := json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &searchResult); err != nil {
+		t.Fatalf("failed to parse result JSON: %v", err)
+	}
+	if len(searchResult.Warnings) != 1 || searchResult.Warnings[0].Code != types.WarnCodeResultsTruncated {
+		t.Errorf("expected a single %s warning, got %+v", types.WarnCodeResultsTruncated, searchResult.Warnings)
+	}
 }
 
 func TestSearchMessagesHandler_Handle_DefaultCount(t *testing.T) {
@@ -1030,3 +1040,295 @@ func TestSearchMessagesHandler_Handle_CountValidation(t *testing.T) {
 		})
 	}
 }
+
+// TestSearchMessagesHandler_Handle_IncludeContext tests that the messages before and after each match are attached to it.
+func TestSearchMessagesHandler_Handle_IncludeContext(t *testing.T) {
+	var requests []slackclient.HistoryOptions
+
+	mock := &mockSlackClient{
+		searchMessages: func(ctx context.Context, query string, count int, sort string) ([]types.SearchMatch, int, error) {
+			return []types.SearchMatch{
+				{
+					ChannelID: "C01234567",
+					User:      "U12345678",
+					Text:      "the deploy failed",
+					Timestamp: "1355517523.000008",
+					Permalink: "https://workspace.slack.com/archives/C01234567/p1355517523000008",
+				},
+			}, 1, nil
+		},
+		getChannelHistory: func(ctx context.Context, channelID string, opts slackclient.HistoryOptions) ([]types.Message, bool, error) {
+			requests = append(requests, opts)
+			if opts.Oldest != "" {
+				return []types.Message{
+					{User: "U87654321", Text: "rolling back", Timestamp: "1355517524.000003"},
+				}, true, nil
+			}
+			// Newest first, as returned by conversations.history
+			return []types.Message{
+				{User: "U87654321", Text: "starting deploy now", Timestamp: "1355517522.000002"},
+				{User: "U12345678", Text: "is main green?", Timestamp: "1355517521.000001"},
+			}, true, nil
+		},
+		getUserInfo: func(ctx context.Context, userID string) (*types.UserInfo, error) {
+			return &types.UserInfo{ID: userID, Name: "user_" + userID, DisplayName: "User " + userID}, nil
+		},
+	}
+
+	handler := NewSearchMessagesHandler(mock)
+	request := createSearchMessagesRequest(map[string]interface{}{
+		"query":           "deploy",
+		"include_context": float64(3),
+	})
+
+	result, err := handler.Handle(context.Background(), request)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("unexpected error result: %v", result.Content)
+	}
+
+	// Two messages before the match, exclusive of it, and one after
+	if len(requests) != 2 {
+		t.Fatalf("expected 2 history calls, got %d", len(requests))
+	}
+	if before := requests[0]; before.Limit != 2 || before.Latest != "1355517523.000008" || before.Oldest != "" || before.Inclusive {
+		t.Errorf("unexpected history request for the messages before the match: %+v", before)
+	}
+	if after := requests[1]; after.Limit != 1 || after.Oldest != "1355517523.000008" || after.Latest != "" || after.Inclusive {
+		t.Errorf("unexpected history request for the messages after the match: %+v", after)
+	}
+
+	textContent := result.Content[0].(mcp.TextContent)
+	var searchResult types.SearchMessagesResult
+	if err := json.Unmarshal([]byte(textContent.Text), &searchResult); err != nil {
+		t.Fatalf("failed to parse result JSON: %v", err)
+	}
+
+	// Context should be in chronological order, on both sides of the match
+	contextMessages := searchResult.Matches[0].Context
+	var got []string
+	for _, msg := range contextMessages {
+		got = append(got, msg.Timestamp)
+	}
+	want := []string{"1355517521.000001", "1355517522.000002", "1355517524.000003"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Fatalf("context timestamps = %v, want %v", got, want)
+	}
+	if contextMessages[0].Permalink != "https://workspace.slack.com/archives/C01234567/p1355517521000001" {
+		t.Errorf("unexpected context permalink: %s", contextMessages[0].Permalink)
+	}
+	if contextMessages[2].UserName != "user_U87654321" || contextMessages[2].Text != "rolling back" {
+		t.Errorf("expected the message after the match with a resolved user name, got %+v", contextMessages[2])
+	}
+}
+
+// TestSearchMessagesHandler_Handle_IncludeContextRateLimited tests that context fetching stops on rate limits.
+func TestSearchMessagesHandler_Handle_IncludeContextRateLimited(t *testing.T) {
+	var historyCalls int
+
+	mock := &mockSlackClient{
+		searchMessages: func(ctx context.Context, query string, count int, sort string) ([]types.SearchMatch, int, error) {
+			return []types.SearchMatch{
+				{ChannelID: "C01234567", Text: "one", Timestamp: "1355517523.000001"},
+				{ChannelID: "C01234567", Text: "two", Timestamp: "1355517523.000002"},
+				{ChannelID: "C01234567", Text: "three", Timestamp: "1355517523.000003"},
+			}, 3, nil
+		},
+		getChannelHistory: func(ctx context.Context, channelID string, opts slackclient.HistoryOptions) ([]types.Message, bool, error) {
+			historyCalls++
+			return nil, false, types.NewSlackError(types.ErrCodeRateLimited, "rate limited")
+		},
+	}
+
+	handler := NewSearchMessagesHandler(mock)
+	request := createSearchMessagesRequest(map[string]interface{}{
+		"query":           "deploy",
+		"include_context": float64(3),
+	})
+
+	result, err := handler.Handle(context.Background(), request)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.IsError {
+		t.Fatal("context failures should not fail the search")
+	}
+
+	if historyCalls != 1 {
+		t.Errorf("expected context fetching to stop after the first rate limit, got %d calls", historyCalls)
+	}
+
+	textContent := result.Content[0].(mcp.TextContent)
+	var searchResult types.SearchMessagesResult
+	if err := json.Unmarshal([]byte(textContent.Text), &searchResult); err != nil {
+		t.Fatalf("failed to parse result JSON: %v", err)
+	}
+
+	// The failed match carries the error; matches after the rate limit were never attempted
+	if searchResult.Matches[0].ContextError == nil || searchResult.Matches[0].ContextError.Code != types.ErrCodeRateLimited {
+		t.Errorf("expected rate_limited context_error on first match, got %+v", searchResult.Matches[0].ContextError)
+	}
+	if searchResult.Matches[1].ContextError != nil {
+		t.Errorf("expected no context_error on skipped match, got %+v", searchResult.Matches[1].ContextError)
+	}
+	if len(searchResult.Warnings) != 1 || searchResult.Warnings[0].Code != types.WarnCodeRateLimited {
+		t.Errorf("expected a single %s warning, got %+v", types.WarnCodeRateLimited, searchResult.Warnings)
+	}
+}
+
+// TestSearchMessagesHandler_Handle_ExpandThreads tests that thread parents are attached to reply matches.
+func TestSearchMessagesHandler_Handle_ExpandThreads(t *testing.T) {
+	var getMessageCalls int
+
+	mock := &mockSlackClient{
+		searchMessages: func(ctx context.Context, query string, count int, sort string) ([]types.SearchMatch, int, error) {
+			return []types.SearchMatch{
+				{
+					ChannelID: "C01234567",
+					User:      "U12345678",
+					Text:      "fixed in the latest build",
+					Timestamp: "1355517530.000002",
+					Permalink: "https://workspace.slack.com/archives/C01234567/p1355517530000002?thread_ts=1355517523.000008&cid=C01234567",
+				},
+				{
+					ChannelID: "C01234567",
+					User:      "U87654321",
+					Text:      "confirmed fixed",
+					Timestamp: "1355517540.000003",
+					Permalink: "https://workspace.slack.com/archives/C01234567/p1355517540000003?thread_ts=1355517523.000008&cid=C01234567",
+				},
+				{
+					ChannelID: "C01234567",
+					User:      "U12345678",
+					Text:      "a top-level message",
+					Timestamp: "1355517550.000004",
+					Permalink: "https://workspace.slack.com/archives/C01234567/p1355517550000004",
+				},
+			}, 3, nil
+		},
+		getMessage: func(ctx context.Context, channelID, timestamp string) (*types.Message, error) {
+			getMessageCalls++
+			return &types.Message{
+				User:       "U87654321",
+				Text:       "build is broken",
+				Timestamp:  timestamp,
+				ThreadTS:   timestamp,
+				ReplyCount: 5,
+			}, nil
+		},
+	}
+
+	handler := NewSearchMessagesHandler(mock)
+	request := createSearchMessagesRequest(map[string]interface{}{
+		"query":          "fixed",
+		"expand_threads": true,
+	})
+
+	result, err := handler.Handle(context.Background(), request)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("unexpected error result: %v", result.Content)
+	}
+
+	// Both replies share a parent, so it should be fetched once
+	if getMessageCalls != 1 {
+		t.Errorf("expected 1 GetMessage call, got %d", getMessageCalls)
+	}
+
+	textContent := result.Content[0].(mcp.TextContent)
+	var searchResult types.SearchMessagesResult
+	if err := json.Unmarshal([]byte(textContent.Text), &searchResult); err != nil {
+		t.Fatalf("failed to parse result JSON: %v", err)
+	}
+
+	for i := 0; i < 2; i++ {
+		match := searchResult.Matches[i]
+		if match.ThreadTS != "1355517523.000008" {
+			t.Errorf("match %d: expected thread_ts 1355517523.000008, got %q", i, match.ThreadTS)
+		}
+		if match.ThreadParent == nil {
+			t.Fatalf("match %d: expected thread parent", i)
+		}
+		if match.ThreadParent.ReplyCount != 5 {
+			t.Errorf("match %d: expected parent reply_count 5, got %d", i, match.ThreadParent.ReplyCount)
+		}
+	}
+
+	if searchResult.Matches[2].ThreadParent != nil || searchResult.Matches[2].ThreadTS != "" {
+		t.Error("top-level match should not be expanded")
+	}
+}
+
+func TestSearchMessagesHandler_Handle_InvalidExpandThreadsType(t *testing.T) {
+	handler := NewSearchMessagesHandler(&mockSlackClient{})
+	request := createSearchMessagesRequest(map[string]interface{}{
+		"query":          "test",
+		"expand_threads": "yes",
+	})
+
+	result, err := handler.Handle(context.Background(), request)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !result.IsError {
+		t.Error("expected error result for non-boolean expand_threads")
+	}
+}
+
+// TestSearchMessagesHandler_Handle_CountOnly tests that count_only returns only the search total.
+func TestSearchMessagesHandler_Handle_CountOnly(t *testing.T) {
+	var capturedCount int
+	userLookups := 0
+
+	mock := &mockSlackClient{
+		searchMessages: func(ctx context.Context, query string, count int, sort string) ([]types.SearchMatch, int, error) {
+			capturedCount = count
+			return []types.SearchMatch{
+				{ChannelID: "C01234567", User: "U12345678", Text: "refund please", Timestamp: "1355517523.000008"},
+			}, 137, nil
+		},
+		getUserInfo: func(ctx context.Context, userID string) (*types.UserInfo, error) {
+			userLookups++
+			return nil, nil
+		},
+	}
+
+	handler := NewSearchMessagesHandler(mock)
+	request := createSearchMessagesRequest(map[string]interface{}{
+		"query":      "refund in:#support on:yesterday",
+		"count_only": true,
+	})
+
+	result, err := handler.Handle(context.Background(), request)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("unexpected error result: %v", result.Content)
+	}
+
+	if capturedCount != 1 {
+		t.Errorf("expected a single-result search, got count %d", capturedCount)
+	}
+	if userLookups != 0 {
+		t.Errorf("expected no user lookups in count_only mode, got %d", userLookups)
+	}
+
+	textContent := result.Content[0].(mcp.TextContent)
+	var countResult types.MessageCountResult
+	if err := json.Unmarshal([]byte(textContent.Text), &countResult); err != nil {
+		t.Fatalf("failed to parse result JSON: %v", err)
+	}
+
+	if countResult.Count != 137 {
+		t.Errorf("expected count 137, got %d", countResult.Count)
+	}
+	if countResult.Query != "refund in:#support on:yesterday" {
+		t.Errorf("unexpected query: %q", countResult.Query)
+	}
+}
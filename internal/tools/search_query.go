@@ -0,0 +1,209 @@
+// Package tools provides MCP tool handler implementations for the Slack MCP server.
+package tools
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// knownSearchModifiers lists the Slack search modifiers accepted by search.messages.
+var knownSearchModifiers = map[string]bool{
+	"in":     true,
+	"from":   true,
+	"to":     true,
+	"before": true,
+	"after":  true,
+	"on":     true,
+	"during": true,
+	"has":    true,
+	"is":     true,
+	"with":   true,
+}
+
+// modifierSuggestions maps commonly guessed modifier names to the Slack modifier
+// that provides the intended behavior.
+var modifierSuggestions = map[string]string{
+	"channel": "in",
+	"chan":    "in",
+	"user":    "from",
+	"author":  "from",
+	"by":      "from",
+	"since":   "after",
+	"until":   "before",
+	"date":    "on",
+}
+
+// modifierPattern matches a search modifier token such as "in:#general" or "before:2024-01-15".
+var modifierPattern = regexp.MustCompile(`^-?([a-zA-Z_]+):(.*)$`)
+
+// slashDatePattern matches dates written as MM/DD/YYYY, which Slack does not accept.
+var slashDatePattern = regexp.MustCompile(`^\d{1,2}/\d{1,2}/\d{4}$`)
+
+// monthNames lists the month values accepted by the during: modifier.
+var monthNames = map[string]bool{
+	"january": true, "february": true, "march": true, "april": true,
+	"may": true, "june": true, "july": true, "august": true,
+	"september": true, "october": true, "november": true, "december": true,
+}
+
+// validateSearchQuery checks a search query for common syntax mistakes before it is
+// sent to Slack, so agents get an actionable error instead of empty or unexpected results.
+//
+// The following problems are detected:
+//   - Unbalanced double quotes
+//   - Modifiers with no value (e.g., "in:")
+//   - Invalid dates in before:, after:, on:, and during: modifiers
+//   - Commonly guessed modifiers (e.g., "channel:general" instead of "in:#general")
+//
+// Other words ending in a colon are left alone, as search terms.
+//
+// Returns nil if the query looks valid, or an error describing the problem and a
+// suggested correction.
+func validateSearchQuery(query string) error {
+	if strings.Count(query, `"`)%2 != 0 {
+		return fmt.Errorf("unbalanced double quotes in query. Suggestion: %s", query+`"`)
+	}
+
+	for _, token := range splitQueryTokens(query) {
+		matches := modifierPattern.FindStringSubmatch(token)
+		if matches == nil {
+			continue
+		}
+
+		name := strings.ToLower(matches[1])
+		value := matches[2]
+
+		// URLs (e.g., https://example.com) are search terms, not modifiers
+		if strings.HasPrefix(value, "//") {
+			continue
+		}
+
+		// Other words followed by a colon (e.g., "error: timeout", "TODO:fix") are
+		// search terms; only commonly guessed modifier names are corrected
+		if !knownSearchModifiers[name] {
+			if replacement, ok := modifierSuggestions[name]; ok && value != "" {
+				return fmt.Errorf("unknown search modifier %q. Suggestion: use %q instead",
+					name+":", replacement+":"+suggestModifierValue(replacement, value))
+			}
+			continue
+		}
+
+		if value == "" {
+			return fmt.Errorf("search modifier %q is missing a value (e.g., in:#general, from:@alice)", name+":")
+		}
+
+		switch name {
+		case "before", "after", "on":
+			if err := validateSearchDate(name, value); err != nil {
+				return err
+			}
+		case "during":
+			if err := validateSearchPeriod(value); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// splitQueryTokens splits a query on whitespace while keeping quoted phrases together.
+// Quoted phrases are omitted from the result so their contents are never treated as modifiers.
+func splitQueryTokens(query string) []string {
+	var tokens []string
+	var current strings.Builder
+	inQuotes := false
+
+	for _, r := range query {
+		switch {
+		case r == '"':
+			inQuotes = !inQuotes
+			current.WriteRune(r)
+		case (r == ' ' || r == '\t' || r == '\n') && !inQuotes:
+			if current.Len() > 0 {
+				tokens = append(tokens, current.String())
+				current.Reset()
+			}
+		default:
+			current.WriteRune(r)
+		}
+	}
+	if current.Len() > 0 {
+		tokens = append(tokens, current.String())
+	}
+
+	// Drop quoted phrases; they are literal search terms
+	filtered := tokens[:0]
+	for _, token := range tokens {
+		if !strings.HasPrefix(token, `"`) {
+			filtered = append(filtered, token)
+		}
+	}
+	return filtered
+}
+
+// validateSearchDate validates the value of a before:, after:, or on: modifier.
+// Slack accepts YYYY-MM-DD dates as well as "today" and "yesterday".
+func validateSearchDate(name, value string) error {
+	lower := strings.ToLower(value)
+	if lower == "today" || lower == "yesterday" {
+		return nil
+	}
+
+	if _, err := time.Parse("2006-01-02", value); err == nil {
+		return nil
+	}
+
+	// Offer a corrected date for the common MM/DD/YYYY mistake
+	if slashDatePattern.MatchString(value) {
+		if parsed, err := time.Parse("1/2/2006", value); err == nil {
+			return fmt.Errorf("invalid date %q in %s: modifier. Suggestion: %s:%s",
+				value, name, name, parsed.Format("2006-01-02"))
+		}
+	}
+
+	return fmt.Errorf("invalid date %q in %s: modifier. Dates must use YYYY-MM-DD format "+
+		"(e.g., %s:2024-01-15) or be 'today' or 'yesterday'", value, name, name)
+}
+
+// validateSearchPeriod validates the value of a during: modifier.
+// Slack accepts month names, years, YYYY-MM, YYYY-MM-DD, and relative periods.
+func validateSearchPeriod(value string) error {
+	lower := strings.ToLower(value)
+	switch lower {
+	case "today", "yesterday", "week", "month", "year":
+		return nil
+	}
+	if monthNames[lower] {
+		return nil
+	}
+	for _, layout := range []string{"2006", "2006-01", "2006-01-02"} {
+		if _, err := time.Parse(layout, value); err == nil {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("invalid period %q in during: modifier. Use a month name (during:january), "+
+		"a year (during:2024), a month (during:2024-01), or a date (during:2024-01-15)", value)
+}
+
+// suggestModifierValue adjusts a modifier value to the form expected by the suggested modifier,
+// adding the # prefix for channels and the @ prefix for users when missing.
+func suggestModifierValue(modifier, value string) string {
+	if value == "" {
+		return value
+	}
+	switch modifier {
+	case "in":
+		if !strings.HasPrefix(value, "#") && !strings.HasPrefix(value, "<") {
+			return "#" + value
+		}
+	case "from":
+		if !strings.HasPrefix(value, "@") && !strings.HasPrefix(value, "<") {
+			return "@" + value
+		}
+	}
+	return value
+}
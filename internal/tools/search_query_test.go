@@ -0,0 +1,178 @@
+// Package tools provides unit tests for the MCP tool handlers.
+package tools
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/Bitovi/slack-mcp-server/pkg/types"
+)
+
+func TestValidateSearchQuery(t *testing.T) {
+	tests := []struct {
+		name           string
+		query          string
+		wantErr        bool
+		wantErrContain string
+	}{
+		{
+			name:  "plain text",
+			query: "deployment failed",
+		},
+		{
+			name:  "supported modifiers",
+			query: "outage in:#incidents from:@alice has:link is:thread",
+		},
+		{
+			name:  "valid dates",
+			query: "release before:2024-02-01 after:2024-01-01 on:today",
+		},
+		{
+			name:  "valid during periods",
+			query: "standup during:january during:2024 during:2024-01 during:week",
+		},
+		{
+			name:  "negated modifier",
+			query: "bug -in:#random",
+		},
+		{
+			name:  "quoted phrase containing colon",
+			query: `"error: timeout" in:#alerts`,
+		},
+		{
+			name:  "url in query",
+			query: "https://example.com/docs",
+		},
+		{
+			name:           "unbalanced quotes",
+			query:          `"deployment failed`,
+			wantErr:        true,
+			wantErrContain: "unbalanced double quotes",
+		},
+		{
+			name:           "slash date suggests ISO format",
+			query:          "release before:01/15/2024",
+			wantErr:        true,
+			wantErrContain: "before:2024-01-15",
+		},
+		{
+			name:           "invalid date",
+			query:          "release after:last-week",
+			wantErr:        true,
+			wantErrContain: "YYYY-MM-DD",
+		},
+		{
+			name:           "invalid during period",
+			query:          "release during:someday",
+			wantErr:        true,
+			wantErrContain: "invalid period",
+		},
+		{
+			name:           "channel alias suggests in",
+			query:          "outage channel:incidents",
+			wantErr:        true,
+			wantErrContain: `"in:#incidents"`,
+		},
+		{
+			name:           "user alias suggests from",
+			query:          "outage user:alice",
+			wantErr:        true,
+			wantErrContain: `"from:@alice"`,
+		},
+		{
+			name:  "word ending in a colon",
+			query: "error: timeout",
+		},
+		{
+			name:  "words with colons are search terms",
+			query: "TODO: fix re: outage note:deploy error:404",
+		},
+		{
+			name:  "guessed modifier name without a value",
+			query: "fixed by: alice",
+		},
+		{
+			name:           "modifier missing value",
+			query:          "outage in:",
+			wantErr:        true,
+			wantErrContain: "missing a value",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateSearchQuery(tt.query)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("validateSearchQuery(%q) error = %v, wantErr %v", tt.query, err, tt.wantErr)
+			}
+			if err != nil && !strings.Contains(err.Error(), tt.wantErrContain) {
+				t.Errorf("error should contain %q, got: %s", tt.wantErrContain, err.Error())
+			}
+		})
+	}
+}
+
+func TestSearchMessagesHandler_Handle_InvalidQuerySyntax(t *testing.T) {
+	searchCalled := false
+	mock := &mockSlackClient{
+		searchMessages: func(ctx context.Context, query string, count int, sort string) ([]types.SearchMatch, int, error) {
+			searchCalled = true
+			return []types.SearchMatch{}, 0, nil
+		},
+	}
+
+	handler := NewSearchMessagesHandler(mock)
+	request := createSearchMessagesRequest(map[string]interface{}{
+		"query": "outage before:01/15/2024",
+	})
+
+	result, err := handler.Handle(context.Background(), request)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !result.IsError {
+		t.Error("expected error result")
+	}
+
+	if searchCalled {
+		t.Error("SearchMessages should not be called for an invalid query")
+	}
+
+	textContent, ok := result.Content[0].(mcp.TextContent)
+	if !ok {
+		t.Fatalf("expected TextContent, got %T", result.Content[0])
+	}
+	if !strings.Contains(textContent.Text, "Invalid search query") {
+		t.Errorf("error message should contain 'Invalid search query', got: %s", textContent.Text)
+	}
+}
+
+func TestSearchMessagesHandler_Handle_WordsWithColons(t *testing.T) {
+	for _, query := range []string{"error: timeout", "TODO: fix", "re: outage", "note:deploy"} {
+		var searched string
+		mock := &mockSlackClient{
+			searchMessages: func(ctx context.Context, query string, count int, sort string) ([]types.SearchMatch, int, error) {
+				searched = query
+				return []types.SearchMatch{}, 0, nil
+			},
+		}
+
+		handler := NewSearchMessagesHandler(mock)
+		result, err := handler.Handle(context.Background(), createSearchMessagesRequest(map[string]interface{}{
+			"query": query,
+		}))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if result.IsError {
+			t.Errorf("%q: expected the query to be searched, got error: %+v", query, result.Content)
+		}
+		if searched != query {
+			t.Errorf("%q: searched for %q", query, searched)
+		}
+	}
+}
@@ -0,0 +1,161 @@
+// Package tools provides MCP tool handler implementations for the Slack MCP server.
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	slackclient "github.com/Bitovi/slack-mcp-server/internal/slack"
+	"github.com/Bitovi/slack-mcp-server/pkg/types"
+)
+
+// maxMessageLength is the longest text, in characters, that Slack posts in a
+// single message; chat.postMessage truncates longer text.
+const maxMessageLength = 40000
+
+// Long text handling modes accepted in the on_long_text argument.
+const (
+	// longTextSplit posts text over maxMessageLength as a message followed by
+	// threaded continuation messages.
+	longTextSplit = "split"
+	// longTextError rejects text over maxMessageLength.
+	longTextError = "error"
+	// longTextSnippet uploads text over maxMessageLength as a snippet file instead
+	// of posting it as a message.
+	longTextSnippet = "snippet"
+)
+
+// longTextFilename is the filename of the snippet that long text is uploaded as.
+const longTextFilename = "message.txt"
+
+// codeFence opens and closes mrkdwn code blocks.
+const codeFence = "```"
+
+// parseLongTextMode parses the optional on_long_text argument.
+// Returns longTextSplit if the argument is absent.
+func parseLongTextMode(args map[string]interface{}) (string, error) {
+	modeArg, exists := args["on_long_text"]
+	if !exists {
+		return longTextSplit, nil
+	}
+
+	mode, ok := modeArg.(string)
+	if !ok {
+		return "", fmt.Errorf("argument 'on_long_text' must be a string")
+	}
+
+	switch mode {
+	case longTextSplit, longTextError, longTextSnippet:
+		return mode, nil
+	default:
+		return "", fmt.Errorf("argument 'on_long_text' must be '%s', '%s', or '%s', got %q",
+			longTextSplit, longTextError, longTextSnippet, mode)
+	}
+}
+
+// checkSnippetLength returns an error if text is too large to upload as a snippet
+// with on_long_text 'snippet'.
+func checkSnippetLength(text string) error {
+	if len(text) > maxSnippetLength {
+		return fmt.Errorf("text is %d bytes; snippets are limited to %d", len(text), maxSnippetLength)
+	}
+	return nil
+}
+
+// uploadLongText uploads text as a snippet file shared in channelID (in the thread
+// threadTS, if set) in place of a message, for on_long_text 'snippet'.
+//
+// Returns the result reporting the snippet, which has no message timestamp, or an
+// error result. A missing scope is reported as files:write rather than through
+// handleError, since the posting tools' own message would blame chat:write.
+func uploadLongText(ctx context.Context, client slackclient.ClientInterface, channelID, threadTS, text string,
+	handleError func(error) *mcp.CallToolResult) (*types.PostMessageResult, *mcp.CallToolResult) {
+	fileID, err := client.UploadSnippet(ctx, channelID, longTextFilename, text,
+		slackclient.SnippetOptions{ThreadTS: threadTS})
+	if err != nil {
+		if slackclient.IsMissingScope(err) {
+			return nil, mcp.NewToolResultError(fmt.Sprintf(
+				"Uploading long text as a snippet requires the files:write bot scope. %s", err.Error()))
+		}
+		return nil, handleError(err)
+	}
+
+	return &types.PostMessageResult{
+		ChannelID: channelID,
+		FileID:    fileID,
+		ThreadTS:  threadTS,
+		Text:      text,
+		Workspace: workspaceFor(ctx, client),
+	}, nil
+}
+
+// splitMessage splits text into chunks of at most limit characters, breaking at
+// paragraph, line, or word boundaries where possible. A code block that spans a
+// break is closed at the end of one chunk and reopened at the start of the next,
+// so each chunk renders on its own.
+//
+// Returns text unchanged as the only chunk if it fits within limit.
+func splitMessage(text string, limit int) []string {
+	runes := []rune(text)
+	if len(runes) <= limit {
+		return []string{text}
+	}
+
+	var chunks []string
+	inFence := false
+	for len(runes) > 0 {
+		prefix := ""
+		if inFence {
+			prefix = codeFence + "\n"
+		}
+
+		// The rest fits, including the reopened code block
+		if len(prefix)+len(runes) <= limit {
+			chunks = append(chunks, prefix+string(runes))
+			break
+		}
+
+		budget := limit - len(prefix)
+		cut, skip := splitPoint(runes, budget)
+		open := inFence != (strings.Count(string(runes[:cut]), codeFence)%2 == 1)
+		if open {
+			// Leave room to close the code block at the end of the chunk
+			cut, skip = splitPoint(runes, budget-len("\n"+codeFence))
+			open = inFence != (strings.Count(string(runes[:cut]), codeFence)%2 == 1)
+		}
+
+		chunk := prefix + strings.TrimRight(string(runes[:cut]), "\n")
+		if open {
+			chunk += "\n" + codeFence
+		}
+		chunks = append(chunks, chunk)
+
+		inFence = open
+		runes = runes[cut+skip:]
+		for len(runes) > 0 && runes[0] == '\n' {
+			runes = runes[1:]
+		}
+	}
+	return chunks
+}
+
+// splitPoint finds where to break runes so that the first part is at most budget
+// characters, preferring the last paragraph break, then line break, then space in
+// the second half of the budget. Returns the break index and the length of the
+// separator to drop there.
+func splitPoint(runes []rune, budget int) (int, int) {
+	window := string(runes[:budget])
+	for _, sep := range []string{"\n\n", "\n", " "} {
+		if i := strings.LastIndex(window, sep); i >= 0 {
+			// Convert the byte offset to a rune offset
+			cut := len([]rune(window[:i]))
+			if cut > budget/2 {
+				return cut, len(sep)
+			}
+		}
+	}
+	return budget, 0
+}
@@ -0,0 +1,65 @@
+// Package tools provides unit tests for the MCP tool handlers.
+package tools
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestSplitMessage(t *testing.T) {
+	tests := []struct {
+		name  string
+		text  string
+		limit int
+		want  []string
+	}{
+		{
+			name:  "fits",
+			text:  "short message",
+			limit: 20,
+			want:  []string{"short message"},
+		},
+		{
+			name:  "paragraph break",
+			text:  "first paragraph\n\nsecond paragraph",
+			limit: 20,
+			want:  []string{"first paragraph", "second paragraph"},
+		},
+		{
+			name:  "word break",
+			text:  "one two three four five six",
+			limit: 14,
+			want:  []string{"one two three", "four five six"},
+		},
+		{
+			name:  "hard break",
+			text:  strings.Repeat("x", 25),
+			limit: 10,
+			want:  []string{"xxxxxxxxxx", "xxxxxxxxxx", "xxxxx"},
+		},
+		{
+			name:  "code block reopened",
+			text:  "```\nline one\nline two\nline three\n```",
+			limit: 30,
+			want:  []string{"```\nline one\nline two\n```", "```\nline three\n```"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := splitMessage(tt.text, tt.limit)
+			if len(got) != len(tt.want) {
+				t.Fatalf("splitMessage() = %q, want %q", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("chunk %d = %q, want %q", i, got[i], tt.want[i])
+				}
+				if n := utf8.RuneCountInString(got[i]); n > tt.limit {
+					t.Errorf("chunk %d is %d characters, over the limit of %d", i, n, tt.limit)
+				}
+			}
+		})
+	}
+}
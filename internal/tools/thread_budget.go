@@ -0,0 +1,91 @@
+// Package tools provides thread trimming to fit results into a token budget.
+package tools
+
+import (
+	"encoding/json"
+	"sort"
+
+	"github.com/Bitovi/slack-mcp-server/pkg/types"
+)
+
+// charsPerToken is the approximate number of characters of JSON per model token,
+// used to estimate result sizes against a max_tokens_estimate budget.
+const charsPerToken = 4
+
+// estimateTokens returns the approximate number of tokens v takes when marshaled as JSON.
+func estimateTokens(v interface{}) int {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return 0
+	}
+	return (len(data) + charsPerToken - 1) / charsPerToken
+}
+
+// trimThread reduces a thread, parent first, to roughly fit budget tokens. The
+// parent, the first reply, the latest reply, and the message with timestamp keepTS
+// are always kept, even if they alone exceed the budget. The remaining budget goes
+// to the most-reacted replies first and then to the latest replies. Kept messages
+// stay in thread order.
+//
+// Returns the kept messages and the timestamps of the elided ones, which are nil if
+// the whole thread fits.
+func trimThread(thread []types.Message, keepTS string, budget int) ([]types.Message, []string) {
+	if len(thread) == 0 || estimateTokens(thread) <= budget {
+		return thread, nil
+	}
+
+	sizes := make([]int, len(thread))
+	for i := range thread {
+		sizes[i] = estimateTokens(thread[i])
+	}
+	keep := make([]bool, len(thread))
+	used := 0
+	take := func(i int) {
+		if !keep[i] {
+			keep[i] = true
+			used += sizes[i]
+		}
+	}
+
+	take(0)
+	if len(thread) > 1 {
+		take(1)
+	}
+	take(len(thread) - 1)
+	for i := range thread {
+		if thread[i].Timestamp == keepTS {
+			take(i)
+		}
+	}
+
+	// Most reactions first; among equally reacted replies, the latest first
+	var rest []int
+	for i := range thread {
+		if !keep[i] {
+			rest = append(rest, i)
+		}
+	}
+	sort.SliceStable(rest, func(a, b int) bool {
+		ra, rb := thread[rest[a]].ReactionCount, thread[rest[b]].ReactionCount
+		if ra != rb {
+			return ra > rb
+		}
+		return rest[a] > rest[b]
+	})
+	for _, i := range rest {
+		if used+sizes[i] <= budget {
+			take(i)
+		}
+	}
+
+	var kept []types.Message
+	var elided []string
+	for i := range thread {
+		if keep[i] {
+			kept = append(kept, thread[i])
+		} else {
+			elided = append(elided, thread[i].Timestamp)
+		}
+	}
+	return kept, elided
+}
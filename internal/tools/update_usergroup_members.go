@@ -0,0 +1,298 @@
+// Package tools provides MCP tool handler implementations for the Slack MCP server.
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/Bitovi/slack-mcp-server/internal/requestid"
+	slackclient "github.com/Bitovi/slack-mcp-server/internal/slack"
+	"github.com/Bitovi/slack-mcp-server/internal/urlparser"
+	"github.com/Bitovi/slack-mcp-server/pkg/types"
+)
+
+// maxUserGroupMembers caps the users that update_usergroup_members accepts in one list.
+const maxUserGroupMembers = 500
+
+// userGroupMentionPattern matches a user group mention as it appears in message
+// text, e.g., "<!subteam^S01234567|@oncall>" or "<!subteam^S01234567>".
+var userGroupMentionPattern = regexp.MustCompile(`^<!subteam\^([A-Z0-9]+)(?:\|[^>]*)?>$`)
+
+// userGroupIDPattern matches a user group ID (e.g., "S01234567").
+var userGroupIDPattern = regexp.MustCompile(`^S[A-Z0-9]{8,}$`)
+
+// UpdateUserGroupMembersHandler handles the update_usergroup_members MCP tool requests.
+// It sets, adds, or removes the members of a user group (e.g., handing "@oncall"
+// to the next person at a shift change), and records each change in the audit log.
+// It is a write tool, registered only when write tools are enabled.
+type UpdateUserGroupMembersHandler struct {
+	// slackClient is the Slack API client for reading and updating user groups.
+	slackClient slackclient.ClientInterface
+	// config holds optional handler behavior.
+	config handlerConfig
+}
+
+// NewUpdateUserGroupMembersHandler creates a new UpdateUserGroupMembersHandler with the given Slack client and options.
+func NewUpdateUserGroupMembersHandler(client slackclient.ClientInterface, opts ...HandlerOption) *UpdateUserGroupMembersHandler {
+	return &UpdateUserGroupMembersHandler{
+		slackClient: client,
+		config:      newHandlerConfig(opts),
+	}
+}
+
+// Handle processes an update_usergroup_members tool call.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeouts
+//   - request: The MCP tool call request containing usergroup and either users
+//     (the complete new membership) or add and/or remove
+//
+// Returns an MCP tool result describing the group's new membership, or an error
+// result if the arguments are invalid, the group is not found, or Slack rejects the update.
+func (h *UpdateUserGroupMembersHandler) Handle(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	// Extract the usergroup argument (required)
+	userGroupArg, ok := request.Params.Arguments["usergroup"]
+	if !ok {
+		return mcp.NewToolResultError("missing required argument 'usergroup'"), nil
+	}
+
+	userGroupRef, ok := userGroupArg.(string)
+	if !ok {
+		return mcp.NewToolResultError("argument 'usergroup' must be a string"), nil
+	}
+
+	userGroupRef = strings.TrimSpace(userGroupRef)
+	if userGroupRef == "" {
+		return mcp.NewToolResultError("argument 'usergroup' cannot be empty"), nil
+	}
+
+	// Extract the users, add, and remove arguments; users replaces the membership,
+	// add and remove change it
+	users, setMembers, err := parseUserList(request.Params.Arguments, "users")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	add, hasAdd, err := parseUserList(request.Params.Arguments, "add")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	remove, hasRemove, err := parseUserList(request.Params.Arguments, "remove")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	switch {
+	case setMembers && (hasAdd || hasRemove):
+		return mcp.NewToolResultError("argument 'users' cannot be combined with 'add' or 'remove'"), nil
+	case !setMembers && !hasAdd && !hasRemove:
+		return mcp.NewToolResultError("one of 'users', 'add', or 'remove' is required"), nil
+	}
+	for _, userID := range add {
+		if containsString(remove, userID) {
+			return mcp.NewToolResultError(fmt.Sprintf("user %s is in both 'add' and 'remove'", userID)), nil
+		}
+	}
+
+	group, err := h.findUserGroup(ctx, userGroupRef)
+	if err != nil {
+		return h.handleError(err), nil
+	}
+	if group == nil {
+		return mcp.NewToolResultError(fmt.Sprintf("User group %q not found. Pass the group's handle (e.g., oncall) "+
+			"or ID (e.g., S01234567).", userGroupRef)), nil
+	}
+	if group.IsDisabled {
+		return mcp.NewToolResultError(fmt.Sprintf("User group @%s is disabled. Enable it in Slack before changing "+
+			"its members.", group.Handle)), nil
+	}
+
+	// Work out the new membership, keeping the current order for unchanged members
+	members := users
+	if !setMembers {
+		members = nil
+		for _, userID := range group.Users {
+			if !containsString(remove, userID) {
+				members = append(members, userID)
+			}
+		}
+		for _, userID := range add {
+			if !containsString(members, userID) {
+				members = append(members, userID)
+			}
+		}
+	}
+	if len(members) == 0 {
+		return mcp.NewToolResultError(fmt.Sprintf("User group @%s must keep at least one member; Slack does not "+
+			"allow empty user groups.", group.Handle)), nil
+	}
+
+	result := &types.UpdateUserGroupMembersResult{
+		UserGroupID: group.ID,
+		Handle:      group.Handle,
+		Members:     members,
+		Added:       subtractStrings(members, group.Users),
+		Removed:     subtractStrings(group.Users, members),
+	}
+
+	if len(result.Added) > 0 || len(result.Removed) > 0 {
+		updated, err := h.slackClient.UpdateUserGroupMembers(ctx, group.ID, members)
+		h.audit(ctx, group, result, err)
+		if err != nil {
+			return h.handleError(err), nil
+		}
+		if len(updated.Users) > 0 {
+			result.Members = updated.Users
+		}
+		result.Changed = true
+	}
+
+	result.Workspace = workspaceFor(ctx, h.slackClient)
+
+	return h.successResult(result)
+}
+
+// findUserGroup looks up a user group by ID, handle (with or without "@"), or
+// mention (<!subteam^S01234567|@oncall>).
+//
+// Returns nil if no user group matches.
+func (h *UpdateUserGroupMembersHandler) findUserGroup(ctx context.Context, ref string) (*types.UserGroup, error) {
+	if matches := userGroupMentionPattern.FindStringSubmatch(ref); matches != nil {
+		ref = matches[1]
+	}
+	isID := userGroupIDPattern.MatchString(ref)
+	handle := strings.ToLower(strings.TrimPrefix(ref, "@"))
+
+	groups, err := h.slackClient.ListUserGroups(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for i := range groups {
+		if (isID && groups[i].ID == ref) || (!isID && strings.ToLower(groups[i].Handle) == handle) {
+			return &groups[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// audit records a membership update in the audit log, whether or not it succeeded.
+func (h *UpdateUserGroupMembersHandler) audit(ctx context.Context, group *types.UserGroup,
+	result *types.UpdateUserGroupMembersResult, err error) {
+	if h.config.auditLog == nil {
+		return
+	}
+
+	status := "ok"
+	if err != nil {
+		status = fmt.Sprintf("failed error=%q", err.Error())
+	}
+	h.config.auditLog.Printf("audit request_id=%s tool=update_usergroup_members usergroup=%s handle=@%s "+
+		"added=%s removed=%s before=%s after=%s status=%s",
+		requestid.FromContext(ctx), group.ID, group.Handle,
+		strings.Join(result.Added, ","), strings.Join(result.Removed, ","),
+		strings.Join(group.Users, ","), strings.Join(result.Members, ","), status)
+}
+
+// parseUserList parses an optional array argument of user IDs or user profile links.
+// Duplicate users are dropped.
+//
+// Returns the user IDs and whether the argument was given.
+func parseUserList(args map[string]interface{}, name string) ([]string, bool, error) {
+	arg, exists := args[name]
+	if !exists {
+		return nil, false, nil
+	}
+
+	items, ok := arg.([]interface{})
+	if !ok {
+		return nil, false, fmt.Errorf("argument '%s' must be an array of user IDs", name)
+	}
+	if len(items) > maxUserGroupMembers {
+		return nil, false, fmt.Errorf("argument '%s' has %d users; at most %d are allowed", name, len(items), maxUserGroupMembers)
+	}
+
+	var userIDs []string
+	for i, item := range items {
+		ref, ok := item.(string)
+		if !ok {
+			return nil, false, fmt.Errorf("argument '%s' item %d must be a string", name, i)
+		}
+		userID, ok := urlparser.ParseUserReference(strings.TrimSpace(ref))
+		if !ok {
+			return nil, false, fmt.Errorf("argument '%s' item %d must be a Slack user ID (e.g., U01234567), got %q", name, i, ref)
+		}
+		if !containsString(userIDs, userID) {
+			userIDs = append(userIDs, userID)
+		}
+	}
+	return userIDs, true, nil
+}
+
+// containsString reports whether list contains s.
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
+
+// subtractStrings returns the sorted items of a that are not in b.
+func subtractStrings(a, b []string) []string {
+	var diff []string
+	for _, item := range a {
+		if !containsString(b, item) {
+			diff = append(diff, item)
+		}
+	}
+	sort.Strings(diff)
+	return diff
+}
+
+// handleError converts errors to appropriate MCP error results.
+func (h *UpdateUserGroupMembersHandler) handleError(err error) *mcp.CallToolResult {
+	// Check for known error types and provide appropriate messages
+	if slackclient.IsRateLimited(err) {
+		return mcp.NewToolResultError(
+			"Rate limit exceeded. Please wait and try again.")
+	}
+
+	if slackclient.IsInvalidToken(err) {
+		return mcp.NewToolResultError(
+			"Authentication failed. Please check that SLACK_BOT_TOKEN is valid and not expired.")
+	}
+
+	if slackclient.IsMissingScope(err) {
+		return mcp.NewToolResultError(err.Error())
+	}
+
+	if slackclient.IsPermissionDenied(err) {
+		return mcp.NewToolResultError(
+			"Permission denied. The workspace may restrict who can change user groups; ask an admin to allow the app.")
+	}
+
+	// Generic error handling
+	return mcp.NewToolResultError(fmt.Sprintf("Failed to update user group members: %s", err.Error()))
+}
+
+// successResult creates a successful MCP tool result with the given data.
+func (h *UpdateUserGroupMembersHandler) successResult(result *types.UpdateUserGroupMembersResult) (*mcp.CallToolResult, error) {
+	resultJSON, err := json.Marshal(result)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to encode result: %s", err.Error())), nil
+	}
+
+	return mcp.NewToolResultText(string(resultJSON)), nil
+}
+
+// HandleFunc returns a function that can be used directly as an MCP tool handler.
+// This is a convenience method for registering the handler with the MCP server.
+func (h *UpdateUserGroupMembersHandler) HandleFunc() func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return h.Handle
+}
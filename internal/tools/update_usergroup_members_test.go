@@ -0,0 +1,193 @@
+// Package tools provides unit tests for the MCP tool handlers.
+package tools
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/Bitovi/slack-mcp-server/pkg/types"
+)
+
+func TestUpdateUserGroupMembersHandler_Handle(t *testing.T) {
+	groups := []types.UserGroup{
+		{ID: "S01234567", Handle: "oncall", Name: "On-call", Users: []string{"U01111111", "U02222222"}},
+		{ID: "S07654321", Handle: "old-team", Name: "Old team", IsDisabled: true, Users: []string{"U01111111"}},
+	}
+
+	tests := []struct {
+		name        string
+		args        map[string]interface{}
+		updateErr   error
+		wantMembers []string
+		wantAdded   []string
+		wantRemoved []string
+		wantChanged bool
+		wantError   string
+	}{
+		{
+			name: "set members by handle",
+			args: map[string]interface{}{
+				"usergroup": "@oncall",
+				"users":     []interface{}{"U03333333"},
+			},
+			wantMembers: []string{"U03333333"},
+			wantAdded:   []string{"U03333333"},
+			wantRemoved: []string{"U01111111", "U02222222"},
+			wantChanged: true,
+		},
+		{
+			name: "add and remove by ID",
+			args: map[string]interface{}{
+				"usergroup": "S01234567",
+				"add":       []interface{}{"https://workspace.slack.com/team/U03333333"},
+				"remove":    []interface{}{"U01111111"},
+			},
+			wantMembers: []string{"U02222222", "U03333333"},
+			wantAdded:   []string{"U03333333"},
+			wantRemoved: []string{"U01111111"},
+			wantChanged: true,
+		},
+		{
+			name: "mention",
+			args: map[string]interface{}{
+				"usergroup": "<!subteam^S01234567|@oncall>",
+				"add":       []interface{}{"U01111111"},
+			},
+			wantMembers: []string{"U01111111", "U02222222"},
+		},
+		{
+			name: "remove last member",
+			args: map[string]interface{}{
+				"usergroup": "oncall",
+				"remove":    []interface{}{"U01111111", "U02222222"},
+			},
+			wantError: "at least one member",
+		},
+		{
+			name: "users with add",
+			args: map[string]interface{}{
+				"usergroup": "oncall",
+				"users":     []interface{}{"U03333333"},
+				"add":       []interface{}{"U01111111"},
+			},
+			wantError: "cannot be combined",
+		},
+		{
+			name: "no change requested",
+			args: map[string]interface{}{
+				"usergroup": "oncall",
+			},
+			wantError: "one of 'users', 'add', or 'remove' is required",
+		},
+		{
+			name: "invalid user",
+			args: map[string]interface{}{
+				"usergroup": "oncall",
+				"add":       []interface{}{"C01234567"},
+			},
+			wantError: "must be a Slack user ID",
+		},
+		{
+			name: "unknown group",
+			args: map[string]interface{}{
+				"usergroup": "nobody",
+				"add":       []interface{}{"U01111111"},
+			},
+			wantError: "not found",
+		},
+		{
+			name: "disabled group",
+			args: map[string]interface{}{
+				"usergroup": "old-team",
+				"add":       []interface{}{"U02222222"},
+			},
+			wantError: "is disabled",
+		},
+		{
+			name: "permission denied",
+			args: map[string]interface{}{
+				"usergroup": "oncall",
+				"add":       []interface{}{"U03333333"},
+			},
+			updateErr: types.NewSlackError(types.ErrCodePermissionDenied, "Access denied."),
+			wantError: "Permission denied",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var updated []string
+			mock := &mockSlackClient{
+				listUserGroups: func(ctx context.Context) ([]types.UserGroup, error) {
+					return groups, nil
+				},
+				updateUserGroupMembers: func(ctx context.Context, userGroupID string, userIDs []string) (*types.UserGroup, error) {
+					updated = userIDs
+					if tt.updateErr != nil {
+						return nil, tt.updateErr
+					}
+					return &types.UserGroup{ID: userGroupID, Users: userIDs}, nil
+				},
+			}
+
+			var auditLog bytes.Buffer
+			handler := NewUpdateUserGroupMembersHandler(mock, WithAuditLog(log.New(&auditLog, "", 0)))
+			result, err := handler.Handle(context.Background(), createToolRequest(tt.args))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			text := result.Content[0].(mcp.TextContent).Text
+			if tt.wantError != "" {
+				if !result.IsError || !strings.Contains(text, tt.wantError) {
+					t.Errorf("expected error containing %q, got: %s", tt.wantError, text)
+				}
+				// Failed updates are audited too
+				if tt.updateErr != nil && !strings.Contains(auditLog.String(), "status=failed") {
+					t.Errorf("expected a failed audit entry, got %q", auditLog.String())
+				}
+				return
+			}
+			if result.IsError {
+				t.Fatalf("expected success, got error: %s", text)
+			}
+
+			var updateResult types.UpdateUserGroupMembersResult
+			if err := json.Unmarshal([]byte(text), &updateResult); err != nil {
+				t.Fatalf("failed to parse result JSON: %v", err)
+			}
+			if !reflect.DeepEqual(updateResult.Members, tt.wantMembers) {
+				t.Errorf("members = %v, want %v", updateResult.Members, tt.wantMembers)
+			}
+			if !reflect.DeepEqual(updateResult.Added, tt.wantAdded) {
+				t.Errorf("added = %v, want %v", updateResult.Added, tt.wantAdded)
+			}
+			if !reflect.DeepEqual(updateResult.Removed, tt.wantRemoved) {
+				t.Errorf("removed = %v, want %v", updateResult.Removed, tt.wantRemoved)
+			}
+			if updateResult.Changed != tt.wantChanged {
+				t.Errorf("changed = %v, want %v", updateResult.Changed, tt.wantChanged)
+			}
+
+			if !tt.wantChanged {
+				if updated != nil || auditLog.Len() > 0 {
+					t.Errorf("expected no update, updated %v with audit log %q", updated, auditLog.String())
+				}
+				return
+			}
+			if !reflect.DeepEqual(updated, tt.wantMembers) {
+				t.Errorf("updated members = %v, want %v", updated, tt.wantMembers)
+			}
+			if entry := auditLog.String(); !strings.Contains(entry, "usergroup=S01234567") || !strings.Contains(entry, "status=ok") {
+				t.Errorf("unexpected audit entry %q", entry)
+			}
+		})
+	}
+}
@@ -0,0 +1,145 @@
+// Package tools provides MCP tool handler implementations for the Slack MCP server.
+package tools
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	slackclient "github.com/Bitovi/slack-mcp-server/internal/slack"
+	"github.com/Bitovi/slack-mcp-server/pkg/types"
+)
+
+// maxConcurrentUserLookups caps the user lookups a tool call runs at once, so a page
+// of messages from many authors is resolved in parallel without flooding Slack.
+const maxConcurrentUserLookups = 8
+
+// userLookupTimeout bounds a shared user lookup, which is not cancelled with the tool
+// call that started it (see userFlightGroup.do).
+const userLookupTimeout = 30 * time.Second
+
+// userLookup is the outcome of looking up one user.
+type userLookup struct {
+	// info is the user's info. Nil if the lookup failed.
+	info *types.UserInfo
+	// err is why the lookup failed. Nil if it succeeded.
+	err error
+}
+
+// lookupUsers looks up userIDs concurrently, at most maxConcurrentUserLookups at a
+// time, and returns the outcomes by user ID. Empty and repeated IDs are skipped.
+// Concurrent lookups of the same user, by this call or another tool call, share one
+// request (see userFlights).
+func lookupUsers(ctx context.Context, client slackclient.ClientInterface, userIDs []string) map[string]userLookup {
+	unique := make([]string, 0, len(userIDs))
+	seen := make(map[string]bool, len(userIDs))
+	for _, userID := range userIDs {
+		if userID != "" && !seen[userID] {
+			seen[userID] = true
+			unique = append(unique, userID)
+		}
+	}
+
+	lookups := make([]userLookup, len(unique))
+	next := make(chan int)
+	var wg sync.WaitGroup
+	for range min(maxConcurrentUserLookups, len(unique)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				info, err := userFlights.do(ctx, client, unique[i])
+				lookups[i] = userLookup{info: info, err: err}
+			}
+		}()
+	}
+	for i := range unique {
+		next <- i
+	}
+	close(next)
+	wg.Wait()
+
+	results := make(map[string]userLookup, len(unique))
+	for i, userID := range unique {
+		results[userID] = lookups[i]
+	}
+	return results
+}
+
+// messageAuthors returns the user IDs of the authors of messages.
+func messageAuthors(messages []types.Message) []string {
+	authors := make([]string, 0, len(messages))
+	for i := range messages {
+		authors = append(authors, messages[i].User)
+	}
+	return authors
+}
+
+// userFlights dedupes the user lookups in flight across tool calls.
+var userFlights userFlightGroup
+
+// userFlightKey identifies a user lookup. Lookups through different clients are
+// kept apart, as they may see different workspaces.
+type userFlightKey struct {
+	client slackclient.ClientInterface
+	userID string
+}
+
+// userFlight is a user lookup in flight. done is closed once info and err are set.
+type userFlight struct {
+	done chan struct{}
+	info *types.UserInfo
+	err  error
+}
+
+// userFlightGroup dedupes concurrent lookups of the same user: while a lookup is in
+// flight, later callers wait for it and share its result instead of calling Slack
+// again. It works like golang.org/x/sync/singleflight, for user lookups only.
+type userFlightGroup struct {
+	mu      sync.Mutex
+	flights map[userFlightKey]*userFlight
+}
+
+// do looks up userID with client.GetUserInfo, or waits for the lookup already in
+// flight. A caller returns its context's error if ctx is done first.
+//
+// The lookup is shared by callers from any tool call or session, so it does not stop
+// when the caller that started it gives up: it runs with that caller's context
+// values (e.g., its call stats) but without its cancellation, for at most
+// userLookupTimeout.
+func (g *userFlightGroup) do(ctx context.Context, client slackclient.ClientInterface, userID string) (*types.UserInfo, error) {
+	key := userFlightKey{client: client, userID: userID}
+
+	g.mu.Lock()
+	if g.flights == nil {
+		g.flights = make(map[userFlightKey]*userFlight)
+	}
+	flight, ok := g.flights[key]
+	if !ok {
+		flight = &userFlight{done: make(chan struct{})}
+		g.flights[key] = flight
+		go g.run(ctx, client, key, flight)
+	}
+	g.mu.Unlock()
+
+	select {
+	case <-flight.done:
+		return flight.info, flight.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// run performs the lookup of a flight started by a caller with ctx, then removes
+// the flight from the group and releases its waiters.
+func (g *userFlightGroup) run(ctx context.Context, client slackclient.ClientInterface, key userFlightKey, flight *userFlight) {
+	lookupCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), userLookupTimeout)
+	defer cancel()
+
+	flight.info, flight.err = client.GetUserInfo(lookupCtx, key.userID)
+
+	g.mu.Lock()
+	delete(g.flights, key)
+	g.mu.Unlock()
+	close(flight.done)
+}
@@ -0,0 +1,169 @@
+// Package tools provides unit tests for the MCP tool handlers.
+package tools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Bitovi/slack-mcp-server/pkg/types"
+)
+
+func TestLookupUsers(t *testing.T) {
+	var inFlight, maxInFlight, calls atomic.Int32
+	mock := &mockSlackClient{
+		getUserInfo: func(ctx context.Context, userID string) (*types.UserInfo, error) {
+			calls.Add(1)
+			n := inFlight.Add(1)
+			defer inFlight.Add(-1)
+			for {
+				peak := maxInFlight.Load()
+				if n <= peak || maxInFlight.CompareAndSwap(peak, n) {
+					break
+				}
+			}
+			time.Sleep(5 * time.Millisecond)
+			if userID == "U_FAIL" {
+				return nil, errors.New("user_not_found")
+			}
+			return &types.UserInfo{ID: userID, Name: "name-" + userID}, nil
+		},
+	}
+
+	var userIDs []string
+	for i := 0; i < 40; i++ {
+		// Every user is listed twice, along with an empty ID
+		userIDs = append(userIDs, fmt.Sprintf("U%02d", i%20), "")
+	}
+	userIDs = append(userIDs, "U_FAIL")
+
+	users := lookupUsers(context.Background(), mock, userIDs)
+	if len(users) != 21 {
+		t.Fatalf("expected 21 distinct lookups, got %d", len(users))
+	}
+	if calls.Load() != 21 {
+		t.Errorf("expected each user to be looked up once, got %d lookups", calls.Load())
+	}
+	if maxInFlight.Load() > maxConcurrentUserLookups {
+		t.Errorf("expected at most %d concurrent lookups, got %d", maxConcurrentUserLookups, maxInFlight.Load())
+	}
+	if maxInFlight.Load() < 2 {
+		t.Error("expected lookups to run concurrently")
+	}
+	if users["U07"].info == nil || users["U07"].info.Name != "name-U07" {
+		t.Errorf("unexpected lookup for U07: %+v", users["U07"])
+	}
+	if users["U_FAIL"].err == nil || users["U_FAIL"].info != nil {
+		t.Errorf("expected the failed lookup to keep its error, got %+v", users["U_FAIL"])
+	}
+}
+
+func TestUserFlightGroup_Do(t *testing.T) {
+	var calls atomic.Int32
+	release := make(chan struct{})
+	mock := &mockSlackClient{
+		getUserInfo: func(ctx context.Context, userID string) (*types.UserInfo, error) {
+			calls.Add(1)
+			<-release
+			return &types.UserInfo{ID: userID}, nil
+		},
+	}
+
+	var group userFlightGroup
+	var wg sync.WaitGroup
+	results := make([]*types.UserInfo, 5)
+	lookup := func(i int) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i], _ = group.do(context.Background(), mock, "U01234567")
+		}()
+	}
+
+	// The first caller starts the lookup; the others join it while it is in flight
+	lookup(0)
+	for calls.Load() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	for i := 1; i < len(results); i++ {
+		lookup(i)
+	}
+	time.Sleep(10 * time.Millisecond)
+
+	// A waiter whose context is done gives up without waiting for the lookup
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := group.do(ctx, mock, "U01234567"); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected a canceled waiter to return its context's error, got %v", err)
+	}
+
+	close(release)
+	wg.Wait()
+	if calls.Load() != 1 {
+		t.Errorf("expected concurrent lookups to share one request, got %d", calls.Load())
+	}
+	for i, info := range results {
+		if info == nil || info.ID != "U01234567" {
+			t.Errorf("caller %d got %+v", i, info)
+		}
+	}
+
+	// Once the lookup is done, the next one calls Slack again (the client caches users)
+	if _, err := group.do(context.Background(), mock, "U01234567"); err != nil || calls.Load() != 2 {
+		t.Errorf("expected a new lookup after the first finished, got %d lookups, err %v", calls.Load(), err)
+	}
+}
+
+func TestUserFlightGroup_Do_LeaderCanceled(t *testing.T) {
+	var calls atomic.Int32
+	release := make(chan struct{})
+	mock := &mockSlackClient{
+		getUserInfo: func(ctx context.Context, userID string) (*types.UserInfo, error) {
+			calls.Add(1)
+			select {
+			case <-release:
+				return &types.UserInfo{ID: userID}, nil
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+		},
+	}
+
+	var group userFlightGroup
+	leaderCtx, cancelLeader := context.WithCancel(context.Background())
+	leaderErr := make(chan error, 1)
+	go func() {
+		_, err := group.do(leaderCtx, mock, "U01234567")
+		leaderErr <- err
+	}()
+	for calls.Load() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+
+	waiter := make(chan *types.UserInfo, 1)
+	go func() {
+		info, err := group.do(context.Background(), mock, "U01234567")
+		if err != nil {
+			t.Errorf("waiter failed: %v", err)
+		}
+		waiter <- info
+	}()
+	time.Sleep(10 * time.Millisecond)
+
+	// The caller that started the lookup gives up; the lookup carries on for the waiter
+	cancelLeader()
+	if err := <-leaderErr; !errors.Is(err, context.Canceled) {
+		t.Errorf("expected the canceled caller to return its context's error, got %v", err)
+	}
+	close(release)
+	if info := <-waiter; info == nil || info.ID != "U01234567" {
+		t.Errorf("waiter got %+v, want the user", info)
+	}
+	if calls.Load() != 1 {
+		t.Errorf("expected the waiter to share the lookup, got %d lookups", calls.Load())
+	}
+}
@@ -0,0 +1,21 @@
+// Package tools provides workspace identification for tool results.
+package tools
+
+import (
+	"context"
+
+	slackclient "github.com/Bitovi/slack-mcp-server/internal/slack"
+	"github.com/Bitovi/slack-mcp-server/pkg/types"
+)
+
+// workspaceFor returns the workspace the client is connected to, for tool results
+// to include so multi-workspace clients can tell which workspace a result came from.
+// The lookup is best effort: it returns nil rather than failing a tool call whose
+// Slack reads already succeeded.
+func workspaceFor(ctx context.Context, client slackclient.ClientInterface) *types.WorkspaceInfo {
+	workspace, err := client.GetWorkspaceInfo(ctx)
+	if err != nil {
+		return nil
+	}
+	return workspace
+}
@@ -0,0 +1,25 @@
+// Package tools provides unit tests for the MCP tool handlers.
+package tools
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Bitovi/slack-mcp-server/pkg/types"
+)
+
+func TestWorkspaceFor(t *testing.T) {
+	mock := &mockSlackClient{}
+	if workspace := workspaceFor(context.Background(), mock); workspace == nil || workspace.TeamID != "T01234567" {
+		t.Errorf("workspace = %+v, want T01234567", workspace)
+	}
+
+	// A failed lookup leaves the workspace out instead of failing the call
+	mock.getWorkspaceInfo = func(ctx context.Context) (*types.WorkspaceInfo, error) {
+		return nil, errors.New("auth.test failed")
+	}
+	if workspace := workspaceFor(context.Background(), mock); workspace != nil {
+		t.Errorf("workspace = %+v, want nil", workspace)
+	}
+}
@@ -12,7 +12,22 @@ import (
 
 // slackURLPattern matches Slack message URLs.
 // Format: https://{workspace}.slack.com/archives/{channel_id}/p{timestamp}
-var slackURLPattern = regexp.MustCompile(`^https://[^/]+\.slack\.com/archives/([A-Z0-9]+)/p(\d+)$`)
+// (or {workspace}.slack-gov.com for GovSlack)
+var slackURLPattern = regexp.MustCompile(`^https://[^/]+\.slack(?:-gov)?\.com/archives/([A-Z0-9]+)/p(\d+)$`)
+
+// slackHostSuffixes are the host suffixes of Slack workspaces:
+// commercial Slack and GovSlack (Slack's FedRAMP environment).
+var slackHostSuffixes = []string{".slack.com", ".slack-gov.com"}
+
+// isSlackHost reports whether host is a Slack workspace host.
+func isSlackHost(host string) bool {
+	for _, suffix := range slackHostSuffixes {
+		if strings.HasSuffix(host, suffix) {
+			return true
+		}
+	}
+	return false
+}
 
 // Parse extracts channel ID and timestamps from a Slack message URL.
 // It handles both regular message URLs and thread URLs with query parameters.
@@ -20,6 +35,8 @@ var slackURLPattern = regexp.MustCompile(`^https://[^/]+\.slack\.com/archives/([
 // URL formats supported:
 //   - Message URL: https://workspace.slack.com/archives/C01234567/p1234567890123456
 //   - Thread URL: https://workspace.slack.com/archives/C01234567/p1234567890123456?thread_ts=1234567890.123456&cid=C01234567
+//   - DM URL by user: https://workspace.slack.com/archives/U01234567/p1234567890123456
+//     (UserID is set instead of ChannelID; resolve it to the IM channel before reading)
 //
 // Returns a ParsedURL struct with extracted components, or an error if the URL is invalid.
 func Parse(slackURL string) (*types.ParsedURL, error) {
@@ -34,8 +51,8 @@ func Parse(slackURL string) (*types.ParsedURL, error) {
 	}
 
 	// Validate it's a Slack URL
-	if !strings.HasSuffix(parsedURL.Host, ".slack.com") {
-		return nil, types.NewSlackError(types.ErrCodeInvalidURL, "URL must be a slack.com URL")
+	if !isSlackHost(parsedURL.Host) {
+		return nil, types.NewSlackError(types.ErrCodeInvalidURL, "URL must be a slack.com URL (or slack-gov.com for GovSlack)")
 	}
 
 	// Build the base URL without query parameters for regex matching
@@ -57,9 +74,20 @@ func Parse(slackURL string) (*types.ParsedURL, error) {
 		return nil, types.NewSlackError(types.ErrCodeInvalidURL, err.Error())
 	}
 
-	result := &types.ParsedURL{
-		ChannelID: channelID,
-		Timestamp: timestamp,
+	result := &types.ParsedURL{Timestamp: timestamp}
+
+	// DM deep links may reference the other user instead of the IM channel
+	if isUserID(channelID) {
+		result.UserID = channelID
+		result.ChannelType = types.ChannelTypeIM
+	} else {
+		// Validate the channel ID and determine the conversation type from its prefix
+		channelType, err := detectChannelType(channelID)
+		if err != nil {
+			return nil, types.NewSlackError(types.ErrCodeInvalidURL, err.Error())
+		}
+		result.ChannelID = channelID
+		result.ChannelType = channelType
 	}
 
 	// Check for thread_ts query parameter (indicates a thread URL)
@@ -73,16 +101,153 @@ func Parse(slackURL string) (*types.ParsedURL, error) {
 	return result, nil
 }
 
+const (
+	// minChannelIDLength is the length of the shortest Slack conversation IDs (e.g., C01234567).
+	minChannelIDLength = 9
+	// maxChannelIDLength bounds conversation IDs; current IDs are 9 or 11 characters,
+	// with headroom for Slack lengthening them further.
+	maxChannelIDLength = 15
+)
+
+// teamURLPattern matches Slack DM deep links that reference a user.
+// Format: https://{workspace}.slack.com/team/{user_id}
+var teamURLPattern = regexp.MustCompile(`^https://[^/]+\.slack(?:-gov)?\.com/team/([A-Z0-9]+)/?$`)
+
+// ParseUserReference extracts a user ID from a DM deep link
+// (https://workspace.slack.com/team/U01234567) or a bare user ID (U01234567).
+//
+// Returns the user ID and true if ref references a user, or false otherwise
+// (e.g., ref is a channel ID). Callers resolve the user ID to the IM channel
+// with that user before reading messages.
+func ParseUserReference(ref string) (string, bool) {
+	if isUserID(ref) {
+		return ref, true
+	}
+
+	matches := teamURLPattern.FindStringSubmatch(ref)
+	if matches == nil || !isUserID(matches[1]) {
+		return "", false
+	}
+	return matches[1], true
+}
+
+// maxChannelNameLength is the longest channel name Slack allows.
+const maxChannelNameLength = 80
+
+// ParseChannelName extracts a channel name from a channel reference such as
+// "#general" or "general", as opposed to a conversation ID (C01234567), user ID,
+// or link.
+//
+// Slack channel names are lowercase, so a reference of only uppercase letters and
+// digits is taken to be an ID unless it starts with "#". Returns the lowercased
+// name without the "#" and true if ref names a channel, or false otherwise.
+// Callers resolve the name to the channel's ID before calling Slack.
+func ParseChannelName(ref string) (string, bool) {
+	name, hasHash := strings.CutPrefix(ref, "#")
+	if !hasHash && channelIDPattern.MatchString(name) {
+		return "", false
+	}
+	if name == "" || len(name) > maxChannelNameLength || strings.ContainsAny(name, " \t\n#/<>|@") {
+		return "", false
+	}
+	return strings.ToLower(name), true
+}
+
+// isUserID reports whether id is a Slack user ID: U for regular users,
+// W for Enterprise Grid users.
+func isUserID(id string) bool {
+	if len(id) < minChannelIDLength || len(id) > maxChannelIDLength || !channelIDPattern.MatchString(id) {
+		return false
+	}
+	return id[0] == 'U' || id[0] == 'W'
+}
+
+// mpimNamePattern matches the names Slack gives group DMs: "mpdm-" followed by the
+// participants' usernames joined with "--", and a numeric suffix
+// (e.g., "mpdm-alice--bob--carol-1").
+var mpimNamePattern = regexp.MustCompile(`^mpdm-(.+)-\d+$`)
+
+// IsMPIMName reports whether name is the name of a group DM (e.g., "mpdm-alice--bob--carol-1").
+// Group DM IDs start with C or G like channels, so the name (or the conversation's
+// is_mpim flag) is the only way to tell them apart.
+func IsMPIMName(name string) bool {
+	return mpimNamePattern.MatchString(name)
+}
+
+// MPIMParticipantNames returns the usernames of a group DM's participants from its
+// name, e.g., ["alice", "bob", "carol"] for "mpdm-alice--bob--carol-1".
+// Returns nil if name is not a group DM name.
+//
+// Names are usernames at the time the group DM was created and are not updated
+// when users rename themselves, so prefer the conversation's members when available.
+func MPIMParticipantNames(name string) []string {
+	matches := mpimNamePattern.FindStringSubmatch(name)
+	if matches == nil {
+		return nil
+	}
+
+	var names []string
+	for _, participant := range strings.Split(matches[1], "--") {
+		if participant != "" {
+			names = append(names, participant)
+		}
+	}
+	return names
+}
+
+// detectChannelType validates a conversation ID from a message URL and returns the
+// conversation type implied by its prefix:
+//   - C: public channel (or a private channel created after G-prefixed IDs were retired)
+//   - G: private channel (legacy; group DMs created before 2020 also use G)
+//
+// Group DMs have no prefix of their own, so they are reported as public or private
+// channels; callers that need to know look the conversation up (see IsMPIMName).
+//   - D: direct message
+//
+// Returns an error for IDs that are too short or too long, or that are not
+// conversation IDs (e.g., T team IDs). User IDs are handled by the caller.
+func detectChannelType(channelID string) (string, error) {
+	if len(channelID) < minChannelIDLength || len(channelID) > maxChannelIDLength {
+		return "", fmt.Errorf("invalid channel ID %q: expected %d to %d characters, got %d",
+			channelID, minChannelIDLength, maxChannelIDLength, len(channelID))
+	}
+
+	switch channelID[0] {
+	case 'C':
+		return types.ChannelTypePublic, nil
+	case 'G':
+		return types.ChannelTypePrivate, nil
+	case 'D':
+		return types.ChannelTypeIM, nil
+	default:
+		return "", fmt.Errorf("invalid channel ID %q: expected a conversation ID starting with C, G, or D", channelID)
+	}
+}
+
+const (
+	// secondsDigits is the number of digits in the seconds part of a URL timestamp.
+	secondsDigits = 10
+	// microsecondsDigits is the number of fractional digits in an API timestamp.
+	microsecondsDigits = 6
+)
+
 // convertTimestamp converts a Slack URL timestamp to API format.
 // URL format: 1355517523000008 (no 'p' prefix here, just the digits)
 // API format: 1355517523.000008 (insert '.' after 10th digit)
 //
-// The URL path contains 'p' + 16 digits, where the first 10 are seconds
-// and the remaining 6 are microseconds.
+// The URL path normally contains 'p' + 16 digits, where the first 10 are seconds
+// and the remaining 6 are microseconds. Two real-world variants are also accepted:
+//   - 10 to 15 digits: legacy links and exports built from timestamps whose trailing
+//     fractional zeros were dropped (e.g., 1355517523.0001 -> p13555175230001).
+//     The fraction is padded back to 6 digits.
+//   - 17 digits: links with a 7-digit fraction padded with a trailing zero.
+//     The extra digit must be 0, otherwise the timestamp is ambiguous and rejected.
 func convertTimestamp(urlTimestamp string) (string, error) {
-	// Timestamp should be exactly 16 digits (10 seconds + 6 microseconds)
-	if len(urlTimestamp) != 16 {
-		return "", fmt.Errorf("invalid timestamp format: expected 16 digits, got %d", len(urlTimestamp))
+	minLength := secondsDigits
+	maxLength := secondsDigits + microsecondsDigits + 1
+	if len(urlTimestamp) < minLength || len(urlTimestamp) > maxLength {
+		return "", fmt.Errorf("invalid timestamp format: expected %d to %d digits (usually 16), got %d",
+			minLength, maxLength, len(urlTimestamp))
 	}
 
 	// Validate all characters are digits
@@ -92,9 +257,26 @@ func convertTimestamp(urlTimestamp string) (string, error) {
 		}
 	}
 
+	if urlTimestamp[0] == '0' {
+		return "", fmt.Errorf("invalid timestamp format: seconds cannot have a leading zero")
+	}
+
+	seconds := urlTimestamp[:secondsDigits]
+	fraction := urlTimestamp[secondsDigits:]
+
+	switch {
+	case len(fraction) > microsecondsDigits:
+		if fraction[microsecondsDigits:] != "0" {
+			return "", fmt.Errorf("invalid timestamp format: 17-digit timestamps must end in 0, got %s", urlTimestamp)
+		}
+		fraction = fraction[:microsecondsDigits]
+	case len(fraction) < microsecondsDigits:
+		fraction += strings.Repeat("0", microsecondsDigits-len(fraction))
+	}
+
 	// Insert '.' after the 10th digit
 	// Example: 1355517523000008 -> 1355517523.000008
-	return urlTimestamp[:10] + "." + urlTimestamp[10:], nil
+	return seconds + "." + fraction, nil
 }
 
 // ConvertTimestamp is an exported wrapper for testing purposes.
@@ -103,6 +285,57 @@ func ConvertTimestamp(urlTimestamp string) (string, error) {
 	return convertTimestamp(urlTimestamp)
 }
 
+// channelIDPattern matches Slack conversation IDs (e.g., C01234567).
+var channelIDPattern = regexp.MustCompile(`^[A-Z0-9]+$`)
+
+// apiTimestampPattern matches Slack API timestamps (e.g., 1355517523.000008).
+var apiTimestampPattern = regexp.MustCompile(`^\d{10}\.\d{6}$`)
+
+// Build constructs a Slack message URL from its components. It is the inverse of Parse.
+//
+// Parameters:
+//   - workspaceURL: The workspace URL (e.g., "https://acme.slack.com/")
+//   - channelID: The channel ID (e.g., "C01234567")
+//   - timestamp: The message timestamp in API format (e.g., "1355517523.000008")
+//   - threadTS: The parent thread timestamp in API format, or empty for top-level messages
+//
+// URL formats produced:
+//   - Message URL: https://acme.slack.com/archives/C01234567/p1355517523000008
+//   - Thread reply URL: https://acme.slack.com/archives/C01234567/p1355517524000001?thread_ts=1355517523.000008&cid=C01234567
+//
+// Returns the URL, or an error if any component is invalid.
+func Build(workspaceURL, channelID, timestamp, threadTS string) (string, error) {
+	base, err := url.Parse(workspaceURL)
+	if err != nil || base.Scheme != "https" || !isSlackHost(base.Host) {
+		return "", types.NewSlackError(types.ErrCodeInvalidURL,
+			fmt.Sprintf("invalid workspace URL %q: expected https://{workspace}.slack.com or https://{workspace}.slack-gov.com", workspaceURL))
+	}
+
+	if !channelIDPattern.MatchString(channelID) {
+		return "", types.NewSlackError(types.ErrCodeInvalidURL,
+			fmt.Sprintf("invalid channel ID %q: expected uppercase letters and digits (e.g., C01234567)", channelID))
+	}
+
+	if !apiTimestampPattern.MatchString(timestamp) {
+		return "", types.NewSlackError(types.ErrCodeInvalidURL,
+			fmt.Sprintf("invalid timestamp %q: expected format 1234567890.123456", timestamp))
+	}
+
+	messageURL := fmt.Sprintf("https://%s/archives/%s/p%s",
+		base.Host, channelID, strings.Replace(timestamp, ".", "", 1))
+
+	// Thread parents are linked without thread_ts, matching Slack's own permalinks
+	if threadTS != "" && threadTS != timestamp {
+		if !apiTimestampPattern.MatchString(threadTS) {
+			return "", types.NewSlackError(types.ErrCodeInvalidURL,
+				fmt.Sprintf("invalid thread timestamp %q: expected format 1234567890.123456", threadTS))
+		}
+		messageURL += fmt.Sprintf("?thread_ts=%s&cid=%s", threadTS, channelID)
+	}
+
+	return messageURL, nil
+}
+
 // IsValidSlackURL checks if a URL appears to be a valid Slack message URL
 // without fully parsing it. This can be used for quick validation.
 func IsValidSlackURL(slackURL string) bool {
@@ -115,7 +348,7 @@ func IsValidSlackURL(slackURL string) bool {
 		return false
 	}
 
-	if !strings.HasSuffix(parsedURL.Host, ".slack.com") {
+	if !isSlackHost(parsedURL.Host) {
 		return false
 	}
 
@@ -2,6 +2,7 @@
 package urlparser
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/Bitovi/slack-mcp-server/pkg/types"
@@ -194,13 +195,13 @@ func TestParse_InvalidURL(t *testing.T) {
 			name:        "Slack URL with short timestamp",
 			url:         "https://workspace.slack.com/archives/C01234567/p135551752",
 			wantErrCode: types.ErrCodeInvalidURL,
-			wantErrMsg:  "invalid timestamp format: expected 16 digits",
+			wantErrMsg:  "invalid timestamp format: expected 10 to 17 digits",
 		},
 		{
 			name:        "Slack URL with long timestamp",
 			url:         "https://workspace.slack.com/archives/C01234567/p135551752300000800",
 			wantErrCode: types.ErrCodeInvalidURL,
-			wantErrMsg:  "invalid timestamp format: expected 16 digits",
+			wantErrMsg:  "invalid timestamp format: expected 10 to 17 digits",
 		},
 		{
 			name:        "malformed URL",
@@ -220,6 +221,24 @@ func TestParse_InvalidURL(t *testing.T) {
 			wantErrCode: types.ErrCodeInvalidURL,
 			wantErrMsg:  "invalid Slack message URL format",
 		},
+		{
+			name:        "unknown channel ID prefix",
+			url:         "https://workspace.slack.com/archives/T01234567/p1355517523000008",
+			wantErrCode: types.ErrCodeInvalidURL,
+			wantErrMsg:  "expected a conversation ID starting with C, G, or D",
+		},
+		{
+			name:        "channel ID too short",
+			url:         "https://workspace.slack.com/archives/C0123/p1355517523000008",
+			wantErrCode: types.ErrCodeInvalidURL,
+			wantErrMsg:  "expected 9 to 15 characters",
+		},
+		{
+			name:        "channel ID too long",
+			url:         "https://workspace.slack.com/archives/C0123456789ABCDEF/p1355517523000008",
+			wantErrCode: types.ErrCodeInvalidURL,
+			wantErrMsg:  "expected 9 to 15 characters",
+		},
 	}
 
 	for _, tt := range tests {
@@ -246,6 +265,178 @@ func TestParse_InvalidURL(t *testing.T) {
 	}
 }
 
+func TestParse_ChannelType(t *testing.T) {
+	tests := []struct {
+		name     string
+		url      string
+		wantType string
+	}{
+		{
+			name:     "public channel",
+			url:      "https://workspace.slack.com/archives/C01234567/p1355517523000008",
+			wantType: types.ChannelTypePublic,
+		},
+		{
+			name:     "11-character channel ID",
+			url:      "https://workspace.slack.com/archives/C0123456789/p1355517523000008",
+			wantType: types.ChannelTypePublic,
+		},
+		{
+			name:     "legacy private channel",
+			url:      "https://workspace.slack.com/archives/G01234567/p1355517523000008",
+			wantType: types.ChannelTypePrivate,
+		},
+		{
+			name:     "direct message",
+			url:      "https://workspace.slack.com/archives/D01234567/p1355517523000008",
+			wantType: types.ChannelTypeIM,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := Parse(tt.url)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if result.ChannelType != tt.wantType {
+				t.Errorf("ChannelType = %q, want %q", result.ChannelType, tt.wantType)
+			}
+		})
+	}
+}
+
+func TestParse_GovSlackURL(t *testing.T) {
+	result, err := Parse("https://agency.slack-gov.com/archives/C01234567/p1355517523000008?thread_ts=1355517520.000001&cid=C01234567")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.ChannelID != "C01234567" || result.Timestamp != "1355517523.000008" || result.ThreadTS != "1355517520.000001" {
+		t.Errorf("unexpected result: %+v", result)
+	}
+
+	if !IsValidSlackURL("https://agency.slack-gov.com/archives/C01234567/p1355517523000008") {
+		t.Error("expected GovSlack URL to be valid")
+	}
+	if _, ok := ParseUserReference("https://agency.slack-gov.com/team/U01234567"); !ok {
+		t.Error("expected GovSlack team URL to reference a user")
+	}
+	if _, err := Parse("https://agency.slack-gov.org/archives/C01234567/p1355517523000008"); err == nil {
+		t.Error("expected error for a non-Slack host")
+	}
+}
+
+func TestParse_UserDMURL(t *testing.T) {
+	result, err := Parse("https://workspace.slack.com/archives/U01234567/p1355517523000008")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.UserID != "U01234567" {
+		t.Errorf("UserID = %q, want %q", result.UserID, "U01234567")
+	}
+	if result.ChannelID != "" {
+		t.Errorf("ChannelID = %q, want empty until resolved", result.ChannelID)
+	}
+	if result.ChannelType != types.ChannelTypeIM {
+		t.Errorf("ChannelType = %q, want %q", result.ChannelType, types.ChannelTypeIM)
+	}
+	if result.Timestamp != "1355517523.000008" {
+		t.Errorf("Timestamp = %q, want %q", result.Timestamp, "1355517523.000008")
+	}
+}
+
+func TestParseUserReference(t *testing.T) {
+	tests := []struct {
+		name   string
+		ref    string
+		want   string
+		wantOK bool
+	}{
+		{name: "team URL", ref: "https://workspace.slack.com/team/U01234567", want: "U01234567", wantOK: true},
+		{name: "team URL with trailing slash", ref: "https://workspace.slack.com/team/U01234567/", want: "U01234567", wantOK: true},
+		{name: "enterprise grid user", ref: "https://acme.enterprise.slack.com/team/W01234567", want: "W01234567", wantOK: true},
+		{name: "bare user ID", ref: "U01234567", want: "U01234567", wantOK: true},
+		{name: "channel ID", ref: "C01234567", wantOK: false},
+		{name: "DM channel ID", ref: "D01234567", wantOK: false},
+		{name: "team URL with channel ID", ref: "https://workspace.slack.com/team/C01234567", wantOK: false},
+		{name: "non-Slack team URL", ref: "https://example.com/team/U01234567", wantOK: false},
+		{name: "short user ID", ref: "U0123", wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := ParseUserReference(tt.ref)
+			if ok != tt.wantOK {
+				t.Fatalf("ok = %v, want %v", ok, tt.wantOK)
+			}
+			if got != tt.want {
+				t.Errorf("user ID = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseChannelName(t *testing.T) {
+	tests := []struct {
+		name   string
+		ref    string
+		want   string
+		wantOK bool
+	}{
+		{name: "hash name", ref: "#general", want: "general", wantOK: true},
+		{name: "bare name", ref: "general", want: "general", wantOK: true},
+		{name: "name with dashes and digits", ref: "eng-oncall_2024", want: "eng-oncall_2024", wantOK: true},
+		{name: "mixed case name", ref: "#Eng-General", want: "eng-general", wantOK: true},
+		{name: "uppercase hash name", ref: "#OPS", want: "ops", wantOK: true},
+		{name: "channel ID", ref: "C01234567", wantOK: false},
+		{name: "DM channel ID", ref: "D01234567", wantOK: false},
+		{name: "user ID", ref: "U01234567", wantOK: false},
+		{name: "team URL", ref: "https://workspace.slack.com/team/U01234567", wantOK: false},
+		{name: "hash only", ref: "#", wantOK: false},
+		{name: "name with spaces", ref: "general chat", wantOK: false},
+		{name: "too long", ref: strings.Repeat("a", 81), wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := ParseChannelName(tt.ref)
+			if ok != tt.wantOK {
+				t.Fatalf("ok = %v, want %v", ok, tt.wantOK)
+			}
+			if got != tt.want {
+				t.Errorf("name = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMPIMParticipantNames(t *testing.T) {
+	tests := []struct {
+		name     string
+		channel  string
+		want     []string
+		wantMPIM bool
+	}{
+		{name: "three participants", channel: "mpdm-alice--bob--carol-1", want: []string{"alice", "bob", "carol"}, wantMPIM: true},
+		{name: "dotted and dashed usernames", channel: "mpdm-jane.doe--john-smith--sam-12", want: []string{"jane.doe", "john-smith", "sam"}, wantMPIM: true},
+		{name: "channel name", channel: "general", wantMPIM: false},
+		{name: "channel named like a prefix", channel: "mpdm-notes", wantMPIM: false},
+		{name: "empty", channel: "", wantMPIM: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsMPIMName(tt.channel); got != tt.wantMPIM {
+				t.Fatalf("IsMPIMName() = %v, want %v", got, tt.wantMPIM)
+			}
+			got := MPIMParticipantNames(tt.channel)
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("MPIMParticipantNames() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestConvertTimestamp(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -277,15 +468,45 @@ func TestConvertTimestamp(t *testing.T) {
 			want:      "1609459200.123456",
 			wantError: false,
 		},
+		{
+			name:      "legacy timestamp with trailing zeros dropped",
+			input:     "13555175230001",
+			want:      "1355517523.000100",
+			wantError: false,
+		},
+		{
+			name:      "legacy timestamp with whole seconds only",
+			input:     "1355517523",
+			want:      "1355517523.000000",
+			wantError: false,
+		},
+		{
+			name:      "17-digit timestamp with zero padding",
+			input:     "13555175230000080",
+			want:      "1355517523.000008",
+			wantError: false,
+		},
+		{
+			name:      "17-digit timestamp without zero padding",
+			input:     "13555175230000089",
+			want:      "",
+			wantError: true,
+		},
 		{
 			name:      "too short timestamp",
-			input:     "135551752300000",
+			input:     "135551752",
 			want:      "",
 			wantError: true,
 		},
 		{
 			name:      "too long timestamp",
-			input:     "13555175230000089",
+			input:     "135551752300000800",
+			want:      "",
+			wantError: true,
+		},
+		{
+			name:      "timestamp with leading zero",
+			input:     "0355517523000008",
 			want:      "",
 			wantError: true,
 		},
@@ -471,3 +692,102 @@ func findSubstring(s, substr string) bool {
 	}
 	return false
 }
+
+func TestBuild(t *testing.T) {
+	tests := []struct {
+		name         string
+		workspaceURL string
+		channelID    string
+		timestamp    string
+		threadTS     string
+		want         string
+		wantErr      bool
+	}{
+		{
+			name:         "top-level message",
+			workspaceURL: "https://workspace.slack.com/",
+			channelID:    "C01234567",
+			timestamp:    "1355517523.000008",
+			want:         "https://workspace.slack.com/archives/C01234567/p1355517523000008",
+		},
+		{
+			name:         "GovSlack workspace",
+			workspaceURL: "https://agency.slack-gov.com/",
+			channelID:    "C01234567",
+			timestamp:    "1355517523.000008",
+			want:         "https://agency.slack-gov.com/archives/C01234567/p1355517523000008",
+		},
+		{
+			name:         "thread reply",
+			workspaceURL: "https://workspace.slack.com",
+			channelID:    "C01234567",
+			timestamp:    "1355517524.000001",
+			threadTS:     "1355517523.000008",
+			want:         "https://workspace.slack.com/archives/C01234567/p1355517524000001?thread_ts=1355517523.000008&cid=C01234567",
+		},
+		{
+			name:         "thread parent links without thread_ts",
+			workspaceURL: "https://workspace.slack.com/",
+			channelID:    "C01234567",
+			timestamp:    "1355517523.000008",
+			threadTS:     "1355517523.000008",
+			want:         "https://workspace.slack.com/archives/C01234567/p1355517523000008",
+		},
+		{
+			name:         "non-slack workspace URL",
+			workspaceURL: "https://example.com/",
+			channelID:    "C01234567",
+			timestamp:    "1355517523.000008",
+			wantErr:      true,
+		},
+		{
+			name:         "invalid channel ID",
+			workspaceURL: "https://workspace.slack.com/",
+			channelID:    "general",
+			timestamp:    "1355517523.000008",
+			wantErr:      true,
+		},
+		{
+			name:         "URL-format timestamp",
+			workspaceURL: "https://workspace.slack.com/",
+			channelID:    "C01234567",
+			timestamp:    "1355517523000008",
+			wantErr:      true,
+		},
+		{
+			name:         "invalid thread timestamp",
+			workspaceURL: "https://workspace.slack.com/",
+			channelID:    "C01234567",
+			timestamp:    "1355517524.000001",
+			threadTS:     "yesterday",
+			wantErr:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Build(tt.workspaceURL, tt.channelID, tt.timestamp, tt.threadTS)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got URL %q", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Build() = %q, want %q", got, tt.want)
+			}
+
+			// The built URL must round-trip through Parse
+			parsed, err := Parse(got)
+			if err != nil {
+				t.Fatalf("Parse(Build()) failed: %v", err)
+			}
+			if parsed.ChannelID != tt.channelID || parsed.Timestamp != tt.timestamp {
+				t.Errorf("round trip mismatch: got %+v", parsed)
+			}
+		})
+	}
+}
@@ -1,6 +1,8 @@
 // Package types provides shared type definitions for the Slack MCP server.
 package types
 
+import "encoding/json"
+
 // UserInfo contains resolved user information from Slack.
 type UserInfo struct {
 	// ID is the Slack user ID (e.g., "U06025G6B28").
@@ -16,6 +18,35 @@ type UserInfo struct {
 	// IsDeleted indicates whether this user account has been deleted.
 	// Only set when true.
 	IsDeleted bool `json:"is_deleted,omitempty"`
+	// IsAdmin indicates whether this user is a workspace admin. Only set when true.
+	IsAdmin bool `json:"is_admin,omitempty"`
+	// IsOwner indicates whether this user is a workspace owner. Only set when true.
+	IsOwner bool `json:"is_owner,omitempty"`
+	// Unresolved indicates the user's profile could not be fetched, so only ID is set.
+	// Used in user mappings, so a mention is still visible when its lookup fails.
+	Unresolved bool `json:"unresolved,omitempty"`
+
+	// The fields below are only set on full profiles (get_user_profile), not in
+	// user mappings.
+
+	// Title is the user's job title.
+	Title string `json:"title,omitempty"`
+	// Email is the user's email address. Requires the users:read.email scope.
+	Email string `json:"email,omitempty"`
+	// Phone is the user's phone number.
+	Phone string `json:"phone,omitempty"`
+	// Timezone is the user's IANA timezone (e.g., "America/Chicago").
+	Timezone string `json:"timezone,omitempty"`
+	// TimezoneLabel is the display name of the user's timezone (e.g., "Central Standard Time").
+	TimezoneLabel string `json:"timezone_label,omitempty"`
+	// TimezoneOffset is the user's UTC offset in seconds.
+	TimezoneOffset int `json:"timezone_offset,omitempty"`
+	// StatusEmoji is the emoji of the user's custom status (e.g., ":palm_tree:").
+	StatusEmoji string `json:"status_emoji,omitempty"`
+	// StatusText is the text of the user's custom status (e.g., "On vacation").
+	StatusText string `json:"status_text,omitempty"`
+	// AvatarURL is the URL of the user's profile photo.
+	AvatarURL string `json:"avatar_url,omitempty"`
 }
 
 // Message represents a Slack message.
@@ -31,6 +62,21 @@ type Message struct {
 	// RealName is the full name of the message author.
 	// Empty if user resolution was not performed or failed.
 	RealName string `json:"real_name,omitempty"`
+	// BotID is the ID of the bot that posted the message (e.g., "B01234567").
+	// Empty for messages posted by people.
+	BotID string `json:"bot_id,omitempty"`
+	// BotName is the name of the bot or app that posted the message, from the message's
+	// bot profile or bots.info. Empty for messages posted by people.
+	BotName string `json:"bot_name,omitempty"`
+	// Username is the name a bot message was posted under, when it overrides the bot's
+	// name (e.g., the workflow name for Workflow Builder posts).
+	Username string `json:"username,omitempty"`
+	// Workflow is the name of the Workflow Builder workflow that posted the message.
+	// Empty for messages not posted by a workflow.
+	Workflow string `json:"workflow,omitempty"`
+	// PostedBy labels automated posts, e.g., "workflow 'New Joiner Intake'" or
+	// "app 'PagerDuty'", so they can be told apart from people's messages.
+	PostedBy string `json:"posted_by,omitempty"`
 	// Text is the message content.
 	Text string `json:"text"`
 	// Timestamp is the message timestamp in Slack API format (e.g., "1234567890.123456").
@@ -40,6 +86,221 @@ type Message struct {
 	ThreadTS string `json:"thread_ts,omitempty"`
 	// ReplyCount is the number of replies in the thread (only set on parent messages).
 	ReplyCount int `json:"reply_count,omitempty"`
+	// LatestReply is the timestamp of the latest reply in the thread (only set on parent messages).
+	LatestReply string `json:"latest_reply,omitempty"`
+	// ReactionCount is the total number of reactions on the message, across all emoji.
+	ReactionCount int `json:"reaction_count,omitempty"`
+	// Reactions lists the emoji reactions on the message, with the users who reacted.
+	// The users are resolved in the result's user_mapping. Empty if there are none.
+	Reactions []Reaction `json:"reactions,omitempty"`
+	// Score is the message's importance score, from reactions, replies, mentions of the
+	// current user, length, and author role. Only set when scoring is requested.
+	Score *int `json:"score,omitempty"`
+	// Deleted indicates the message was deleted. Slack keeps a placeholder ("tombstone")
+	// for deleted thread parents that have replies; other deleted messages are only
+	// detected when read_message finds their thread without them.
+	Deleted bool `json:"deleted,omitempty"`
+	// Lang is the detected ISO 639-1 language code of the message text (e.g., "en", "ja").
+	// Only set when language detection is requested and the language could be determined.
+	Lang string `json:"lang,omitempty"`
+	// Metadata is the structured data an app attached to the message when posting it.
+	// Nil if the message has none.
+	Metadata *MessageMetadata `json:"metadata,omitempty"`
+	// Files lists the files uploaded with the message. Empty if there are none.
+	Files []FileInfo `json:"files,omitempty"`
+	// Attachments lists the message's legacy attachments, such as link previews and
+	// app posts formatted with attachments. Empty if there are none.
+	Attachments []Attachment `json:"attachments,omitempty"`
+	// References are the issue, pull request, and ticket references found in the text.
+	// Only set when reference extraction is requested.
+	References []Reference `json:"references,omitempty"`
+	// Raw is the message object from the Slack API response, before conversion.
+	// Only returned when include_raw is requested.
+	Raw json.RawMessage `json:"raw,omitempty"`
+}
+
+// FileInfo describes a file uploaded with a message. Downloading url_private
+// requires the token's Authorization header and the files:read scope.
+type FileInfo struct {
+	// ID is the Slack file ID (e.g., "F01234567").
+	ID string `json:"id"`
+	// Name is the file name (e.g., "report.pdf").
+	Name string `json:"name,omitempty"`
+	// Title is the file's title, which defaults to its name.
+	Title string `json:"title,omitempty"`
+	// Mimetype is the file's MIME type (e.g., "application/pdf").
+	Mimetype string `json:"mimetype,omitempty"`
+	// Size is the file size in bytes.
+	Size int `json:"size,omitempty"`
+	// URLPrivate is the URL to download the file with the token.
+	URLPrivate string `json:"url_private,omitempty"`
+	// Permalink is the URL of the file's page in Slack.
+	Permalink string `json:"permalink,omitempty"`
+}
+
+// Attachment is a legacy message attachment: a link preview (unfurl) or a block
+// of formatted content an app posted with the message.
+type Attachment struct {
+	// Title is the attachment's title.
+	Title string `json:"title,omitempty"`
+	// TitleLink is the URL the title links to.
+	TitleLink string `json:"title_link,omitempty"`
+	// Pretext is the text shown above the attachment.
+	Pretext string `json:"pretext,omitempty"`
+	// Text is the attachment's body text.
+	Text string `json:"text,omitempty"`
+	// Fallback is the plain-text summary of the attachment.
+	Fallback string `json:"fallback,omitempty"`
+	// AuthorName is the author shown on the attachment.
+	AuthorName string `json:"author_name,omitempty"`
+	// ServiceName is the name of the site a link preview came from (e.g., "GitHub").
+	ServiceName string `json:"service_name,omitempty"`
+	// FromURL is the URL a link preview was made from.
+	FromURL string `json:"from_url,omitempty"`
+	// ImageURL is the URL of the attachment's image.
+	ImageURL string `json:"image_url,omitempty"`
+	// Fields are the attachment's title and value pairs, shown as a table.
+	Fields []AttachmentField `json:"fields,omitempty"`
+}
+
+// AttachmentField is a title and value pair in a legacy message attachment.
+type AttachmentField struct {
+	// Title is the field's label.
+	Title string `json:"title"`
+	// Value is the field's text.
+	Value string `json:"value"`
+}
+
+// Reaction is one emoji reaction on a message.
+type Reaction struct {
+	// Name is the emoji name without colons (e.g., "thumbsup", "+1::skin-tone-2").
+	Name string `json:"name"`
+	// Count is the number of users who reacted with the emoji.
+	Count int `json:"count"`
+	// Users lists the IDs of the users who reacted. Slack may list fewer users than
+	// Count on messages with many reactions.
+	Users []string `json:"users,omitempty"`
+}
+
+// Reference is an issue, pull request, or ticket reference found in message text.
+type Reference struct {
+	// Tracker names the pattern that found the reference (e.g., "jira", "github").
+	Tracker string `json:"tracker"`
+	// ID is the reference as written (e.g., "OPS-123", "acme/api#42").
+	ID string `json:"id"`
+}
+
+// MessageMetadata is app-published message metadata: an event type and a payload
+// whose shape is defined by the app that posted the message.
+type MessageMetadata struct {
+	// EventType names the event the message describes (e.g., "incident_created").
+	EventType string `json:"event_type"`
+	// EventPayload is the event's data, as provided by the posting app.
+	EventPayload map[string]interface{} `json:"event_payload,omitempty"`
+}
+
+// WorkspaceInfo identifies the Slack workspace the server is connected to.
+type WorkspaceInfo struct {
+	// TeamID is the Slack workspace ID (e.g., "T01234567").
+	TeamID string `json:"team_id"`
+	// Name is the workspace name.
+	Name string `json:"name,omitempty"`
+	// Domain is the workspace subdomain (e.g., "acme" for acme.slack.com).
+	Domain string `json:"domain,omitempty"`
+	// URL is the workspace URL (e.g., "https://acme.slack.com/").
+	URL string `json:"url,omitempty"`
+}
+
+// ChannelInfo contains resolved channel information from Slack.
+type ChannelInfo struct {
+	// ID is the Slack channel ID (e.g., "C01234567").
+	ID string `json:"id"`
+	// Name is the channel name without the # prefix. Empty for direct messages.
+	Name string `json:"name,omitempty"`
+	// IsPrivate indicates whether the channel is private.
+	IsPrivate bool `json:"is_private,omitempty"`
+	// IsDM indicates whether the conversation is a direct message or group direct message.
+	IsDM bool `json:"is_dm,omitempty"`
+	// IsArchived indicates whether the channel is archived.
+	IsArchived bool `json:"is_archived,omitempty"`
+	// Created is when the channel was created, as a Unix timestamp in seconds.
+	Created int64 `json:"created,omitempty"`
+	// Creator is the user ID of the channel's creator. Empty for direct messages.
+	Creator string `json:"creator,omitempty"`
+	// User is the ID of the other user in a direct message. Empty for channels.
+	User string `json:"user,omitempty"`
+	// Type is the conversation type (one of the ChannelType* constants).
+	Type string `json:"type"`
+	// Topic is the channel topic. Empty if none is set.
+	Topic string `json:"topic,omitempty"`
+	// Purpose is the channel purpose (description). Empty if none is set.
+	Purpose string `json:"purpose,omitempty"`
+	// CanvasID is the file ID of the channel canvas. Empty if the channel has no canvas.
+	CanvasID string `json:"canvas_id,omitempty"`
+}
+
+// Bookmark is a link bookmarked in a channel's header.
+type Bookmark struct {
+	// Title is the bookmark's title.
+	Title string `json:"title"`
+	// Link is the bookmarked URL.
+	Link string `json:"link,omitempty"`
+	// Emoji is the bookmark's emoji, if any (e.g., ":books:").
+	Emoji string `json:"emoji,omitempty"`
+}
+
+// CanvasSummary is a short preview of a channel canvas.
+type CanvasSummary struct {
+	// ID is the canvas file ID (e.g., "F01234567").
+	ID string `json:"id"`
+	// Title is the canvas title.
+	Title string `json:"title,omitempty"`
+	// Preview is the beginning of the canvas text, as provided by Slack.
+	Preview string `json:"preview,omitempty"`
+	// Permalink is the canvas URL.
+	Permalink string `json:"permalink,omitempty"`
+}
+
+// BotInfo contains resolved bot information from Slack.
+type BotInfo struct {
+	// ID is the Slack bot ID (e.g., "B01234567").
+	ID string `json:"id"`
+	// Name is the bot's name.
+	Name string `json:"name"`
+	// AppID is the ID of the app the bot belongs to (e.g., "A01234567").
+	AppID string `json:"app_id,omitempty"`
+	// UserID is the bot's user ID, if it has one.
+	UserID string `json:"user_id,omitempty"`
+}
+
+// UserGroup represents a Slack user group (e.g., @oncall).
+type UserGroup struct {
+	// ID is the user group ID (e.g., "S01234567").
+	ID string `json:"id"`
+	// Handle is the name used to mention the group, without the "@".
+	Handle string `json:"handle"`
+	// Name is the group's display name.
+	Name string `json:"name"`
+	// IsDisabled indicates the group has been disabled.
+	IsDisabled bool `json:"is_disabled,omitempty"`
+	// Users lists the IDs of the group's members.
+	Users []string `json:"users,omitempty"`
+}
+
+// ChannelAccess describes which of the server's tokens can read a channel.
+type ChannelAccess struct {
+	// Channel is the channel's information. Nil if the bot cannot see the channel:
+	// it does not exist, or is a private channel the bot is not a member of.
+	Channel *ChannelInfo
+	// BotIsMember indicates whether the bot is a member of the channel.
+	BotIsMember bool
+	// UserTokenConfigured indicates whether a user token is configured.
+	UserTokenConfigured bool
+	// UserIsMember indicates whether the user token's user is a member of the channel.
+	// Nil if no user token is configured or the check failed.
+	UserIsMember *bool
+	// UserMembershipError describes why the user token's membership could not be checked.
+	UserMembershipError string
 }
 
 // ParsedURL contains the components extracted from a Slack message URL.
@@ -53,29 +314,99 @@ type ParsedURL struct {
 	ThreadTS string
 	// IsThread indicates whether this URL points to a threaded message.
 	IsThread bool
+	// ChannelType is the conversation type implied by the channel ID prefix
+	// (one of the ChannelType* constants).
+	ChannelType string
+	// UserID is set instead of ChannelID when the URL references a user rather than
+	// a conversation (a DM deep link). The caller resolves it to the IM channel.
+	UserID string
 }
 
+// Conversation types, matching the names used by the Slack API.
+const (
+	// ChannelTypePublic is a public channel. Channel IDs starting with C are public
+	// channels, or private channels created after Slack retired G-prefixed IDs.
+	ChannelTypePublic = "public_channel"
+	// ChannelTypePrivate is a private channel (legacy G-prefixed IDs).
+	ChannelTypePrivate = "private_channel"
+	// ChannelTypeIM is a direct message (D-prefixed IDs).
+	ChannelTypeIM = "im"
+	// ChannelTypeMPIM is a group direct message.
+	ChannelTypeMPIM = "mpim"
+)
+
 // ReadMessageArgs is the input schema for the read_message MCP tool.
 type ReadMessageArgs struct {
 	// URL is the Slack message or thread URL to read.
 	URL string `json:"url" jsonschema:"required,description=Slack message or thread URL to read"`
 }
 
+// ReadMessageUnchangedResult is the output schema for the read_message MCP tool when
+// known_reply_count or if_changed_since shows the thread has not grown since the
+// caller last read it. The messages are not returned.
+type ReadMessageUnchangedResult struct {
+	// Unchanged is always true.
+	Unchanged bool `json:"unchanged"`
+	// ChannelID is the Slack channel of the thread.
+	ChannelID string `json:"channel_id"`
+	// ThreadTS is the timestamp of the thread's parent message.
+	ThreadTS string `json:"thread_ts"`
+	// ReplyCount is the thread's current number of replies.
+	ReplyCount int `json:"reply_count"`
+	// LatestReply is the timestamp of the thread's latest reply. Empty if it has none.
+	LatestReply string `json:"latest_reply,omitempty"`
+}
+
 // ReadMessageResult is the output schema for the read_message MCP tool.
 type ReadMessageResult struct {
 	// Message is the primary message referenced by the URL.
 	Message Message `json:"message"`
+	// Deleted indicates the message referenced by the URL was deleted. Message then
+	// holds only its timestamp (or Slack's placeholder); Thread is still fetched.
+	Deleted bool `json:"deleted,omitempty"`
 	// Thread contains all messages in the thread, including the parent.
 	// Empty if the message is not part of a thread.
 	Thread []Message `json:"thread,omitempty"`
+	// ElidedReplies lists the timestamps of the thread replies left out of Thread to
+	// fit max_tokens_estimate. Empty unless the thread was trimmed.
+	ElidedReplies []string `json:"elided_replies,omitempty"`
+	// ThreadError describes why the thread could not be fetched.
+	// Nil if the thread was fetched or the message is not part of a thread.
+	ThreadError *SlackError `json:"thread_error,omitempty"`
 	// ChannelID is the Slack channel where the message was posted.
 	ChannelID string `json:"channel_id"`
+	// ChannelName is the name of the channel (without # prefix).
+	// Empty for direct messages or if channel resolution failed.
+	ChannelName string `json:"channel_name,omitempty"`
+	// IsPrivate indicates the message was posted in a private channel.
+	IsPrivate bool `json:"is_private,omitempty"`
+	// IsDM indicates the message was posted in a direct message or group direct message.
+	IsDM bool `json:"is_dm,omitempty"`
+	// IsArchived indicates the channel has been archived.
+	IsArchived bool `json:"is_archived,omitempty"`
+	// ChannelType is the conversation type (one of the ChannelType* constants).
+	// Resolved via conversations.info when available, otherwise inferred from the channel ID prefix.
+	ChannelType string `json:"channel_type,omitempty"`
+	// Participants lists the members of a group DM. Empty for other conversation types.
+	Participants []UserInfo `json:"participants,omitempty"`
+	// Workspace identifies the Slack workspace the result came from.
+	// Nil if the workspace lookup failed.
+	Workspace *WorkspaceInfo `json:"workspace,omitempty"`
 	// CurrentUser contains the authenticated bot's user information.
 	// Nil if user lookup was not performed or failed.
 	CurrentUser *UserInfo `json:"current_user,omitempty"`
-	// UserMapping maps user IDs to user info for all users mentioned in message text.
-	// Empty if no mentions were found or user resolution was not performed.
+	// UserMapping maps user IDs to user info for all users mentioned in message text
+	// or reacting to messages. Empty if there are none or user resolution was not performed.
 	UserMapping map[string]UserInfo `json:"user_mapping,omitempty"`
+	// ChannelMapping maps channel IDs to channel info for all channels linked in message text.
+	// Empty if there are none or channel resolution failed.
+	ChannelMapping map[string]ChannelInfo `json:"channel_mapping,omitempty"`
+	// UserGroupMapping maps user group IDs to user group info (without members) for all
+	// user groups mentioned in message text. Empty if there are none or resolution failed.
+	UserGroupMapping map[string]UserGroup `json:"usergroup_mapping,omitempty"`
+	// Warnings describes parts of the result that are degraded (e.g., a failed thread fetch,
+	// unresolved users, or truncation). Empty if the result is complete.
+	Warnings []Warning `json:"warnings,omitempty"`
 }
 
 // ListChannelMessagesResult is the output schema for the list_channel_messages MCP tool.
@@ -84,20 +415,111 @@ type ListChannelMessagesResult struct {
 	Messages []Message `json:"messages"`
 	// ChannelID is the Slack channel where the messages were retrieved from.
 	ChannelID string `json:"channel_id"`
+	// Participants lists the members of a group DM. Empty for other conversation types.
+	Participants []UserInfo `json:"participants,omitempty"`
+	// Workspace identifies the Slack workspace the result came from.
+	// Nil if the workspace lookup failed.
+	Workspace *WorkspaceInfo `json:"workspace,omitempty"`
 	// HasMore indicates whether additional messages exist beyond the requested limit.
 	HasMore bool `json:"has_more"`
 	// CurrentUser contains the authenticated bot's user information.
 	// Nil if user lookup was not performed or failed.
 	CurrentUser *UserInfo `json:"current_user,omitempty"`
-	// UserMapping maps user IDs to user info for all users mentioned in message texts.
-	// Empty if no mentions were found or user resolution was not performed.
+	// UserMapping maps user IDs to user info for all users mentioned in message texts
+	// or reacting to messages. Empty if there are none or user resolution was not performed.
 	UserMapping map[string]UserInfo `json:"user_mapping,omitempty"`
+	// ChannelMapping maps channel IDs to channel info for all channels linked in message text.
+	// Empty if there are none or channel resolution failed.
+	ChannelMapping map[string]ChannelInfo `json:"channel_mapping,omitempty"`
+	// UserGroupMapping maps user group IDs to user group info (without members) for all
+	// user groups mentioned in message text. Empty if there are none or resolution failed.
+	UserGroupMapping map[string]UserGroup `json:"usergroup_mapping,omitempty"`
+	// Warnings describes parts of the result that are degraded (e.g., a failed thread fetch,
+	// unresolved users, or truncation). Empty if the result is complete.
+	Warnings []Warning `json:"warnings,omitempty"`
+}
+
+// ChannelBriefingResult is the output schema for the channel_briefing MCP tool.
+// Pinned messages and active threads are summary lines, as in the summary mode of
+// list_channel_messages, so the briefing stays compact.
+type ChannelBriefingResult struct {
+	// Channel is the channel's info, including its topic and purpose.
+	Channel *ChannelInfo `json:"channel"`
+	// Pins lists the channel's pinned messages, one summary line each.
+	Pins []string `json:"pins,omitempty"`
+	// Bookmarks lists the links bookmarked in the channel header.
+	Bookmarks []Bookmark `json:"bookmarks,omitempty"`
+	// Canvas is a preview of the channel canvas. Nil if the channel has none.
+	Canvas *CanvasSummary `json:"canvas,omitempty"`
+	// ActiveThreads lists the most recent messages with thread replies, newest
+	// first, one summary line each.
+	ActiveThreads []string `json:"active_threads,omitempty"`
+	// Workspace identifies the Slack workspace the channel belongs to.
+	Workspace *WorkspaceInfo `json:"workspace,omitempty"`
+	// Warnings describes parts of the briefing that could not be fetched (e.g., a
+	// missing scope for pins or bookmarks). Empty if the briefing is complete.
+	Warnings []Warning `json:"warnings,omitempty"`
+}
+
+// ChannelOriginResult is the output schema for the get_channel_origin MCP tool.
+type ChannelOriginResult struct {
+	// ChannelID is the channel that was looked up.
+	ChannelID string `json:"channel_id"`
+	// Channel is the channel's info, including its creation timestamp and creator ID.
+	Channel *ChannelInfo `json:"channel"`
+	// CreatedAt is when the channel was created, in RFC 3339 format (UTC).
+	CreatedAt string `json:"created_at,omitempty"`
+	// AgeDays is the number of whole days since the channel was created.
+	AgeDays int `json:"age_days"`
+	// Creator is the resolved user who created the channel.
+	// Nil if the channel has no creator (e.g., a DM) or resolution failed.
+	Creator *UserInfo `json:"creator,omitempty"`
+	// EarliestMessage is the oldest message still available in the channel.
+	// Nil if the channel has no messages.
+	EarliestMessage *Message `json:"earliest_message,omitempty"`
+	// HasOlder indicates the search for the earliest message stopped at its scan
+	// limit, so older messages exist than EarliestMessage.
+	HasOlder bool `json:"has_older,omitempty"`
+	// Workspace identifies the Slack workspace the channel belongs to.
+	Workspace *WorkspaceInfo `json:"workspace,omitempty"`
+	// Warnings describes parts of the result that are degraded (e.g., an unresolved
+	// creator or a scan that stopped early). Empty if the result is complete.
+	Warnings []Warning `json:"warnings,omitempty"`
+}
+
+// ReadDMHistoryResult is the output schema for the read_dm_history MCP tool.
+type ReadDMHistoryResult struct {
+	// UserID is the other user in the direct message conversation.
+	UserID string `json:"user_id"`
+	// User is the other user's information. Nil if user lookup failed.
+	User *UserInfo `json:"user,omitempty"`
+	// ChannelID is the IM channel between the user token's user and UserID.
+	ChannelID string `json:"channel_id"`
+	// Messages contains the retrieved messages in reverse chronological order (newest first).
+	Messages []Message `json:"messages"`
+	// HasMore indicates whether additional messages exist beyond the requested limit.
+	HasMore bool `json:"has_more"`
+	// Workspace identifies the Slack workspace the result came from.
+	// Nil if the workspace lookup failed.
+	Workspace *WorkspaceInfo `json:"workspace,omitempty"`
+	// UserMapping maps user IDs to user info for all users mentioned in message texts
+	// or reacting to messages. Empty if there are none or user resolution failed.
+	UserMapping map[string]UserInfo `json:"user_mapping,omitempty"`
+	// ChannelMapping maps channel IDs to channel info for all channels linked in message text.
+	// Empty if there are none or channel resolution failed.
+	ChannelMapping map[string]ChannelInfo `json:"channel_mapping,omitempty"`
+	// UserGroupMapping maps user group IDs to user group info (without members) for all
+	// user groups mentioned in message text. Empty if there are none or resolution failed.
+	UserGroupMapping map[string]UserGroup `json:"usergroup_mapping,omitempty"`
 }
 
 // SearchMessagesResult is the output schema for the search_messages MCP tool.
 type SearchMessagesResult struct {
 	// Query is the search query that was executed.
 	Query string `json:"query"`
+	// Workspace identifies the Slack workspace the result came from.
+	// Nil if the workspace lookup failed.
+	Workspace *WorkspaceInfo `json:"workspace,omitempty"`
 	// Total is the total number of matching messages found.
 	Total int `json:"total"`
 	// Matches contains the matching messages.
@@ -105,6 +527,435 @@ type SearchMessagesResult struct {
 	// CurrentUser contains the authenticated user's information.
 	// Nil if user lookup was not performed or failed.
 	CurrentUser *UserInfo `json:"current_user,omitempty"`
+	// UserMapping maps user IDs to user info for all users mentioned in the matches,
+	// their thread parents, and context messages, or reacting to the thread parents.
+	// Empty if there are none or user resolution failed.
+	UserMapping map[string]UserInfo `json:"user_mapping,omitempty"`
+	// ChannelMapping maps channel IDs to channel info for all channels linked in message text.
+	// Empty if there are none or channel resolution failed.
+	ChannelMapping map[string]ChannelInfo `json:"channel_mapping,omitempty"`
+	// UserGroupMapping maps user group IDs to user group info (without members) for all
+	// user groups mentioned in message text. Empty if there are none or resolution failed.
+	UserGroupMapping map[string]UserGroup `json:"usergroup_mapping,omitempty"`
+	// Warnings describes parts of the result that are degraded (e.g., a failed thread fetch,
+	// unresolved users, or truncation). Empty if the result is complete.
+	Warnings []Warning `json:"warnings,omitempty"`
+}
+
+// ListChannelMessagesSummaryResult is the output schema for the summary mode of the
+// list_channel_messages MCP tool.
+type ListChannelMessagesSummaryResult struct {
+	// ChannelID is the Slack channel where the messages were retrieved from.
+	ChannelID string `json:"channel_id"`
+	// Workspace identifies the Slack workspace the result came from.
+	// Nil if the workspace lookup failed.
+	Workspace *WorkspaceInfo `json:"workspace,omitempty"`
+	// Messages contains one line per message in reverse chronological order (newest first),
+	// in the form "<time> <author>: <text> [<replies>, <reactions>] (ts <timestamp>)".
+	Messages []string `json:"messages"`
+	// HasMore indicates whether additional messages exist beyond the requested limit.
+	HasMore bool `json:"has_more"`
+	// Warnings describes parts of the result that are degraded. Empty if the result is complete.
+	Warnings []Warning `json:"warnings,omitempty"`
+}
+
+// ListSharedLinksResult is the output schema for the list_shared_links MCP tool.
+type ListSharedLinksResult struct {
+	// ChannelID is the Slack channel that was scanned.
+	ChannelID string `json:"channel_id"`
+	// Workspace identifies the Slack workspace the result came from.
+	// Nil if the workspace lookup failed.
+	Workspace *WorkspaceInfo `json:"workspace,omitempty"`
+	// Links are the distinct URLs shared in the scanned messages, most shared first.
+	Links []SharedLink `json:"links"`
+	// MessagesScanned is the number of messages (including thread replies) that were scanned.
+	MessagesScanned int `json:"messages_scanned"`
+	// HasMore indicates whether the time range has messages beyond the scan limit.
+	HasMore bool `json:"has_more"`
+	// UserMapping maps the user IDs in the links' authors to user info.
+	// Users that could not be resolved are omitted.
+	UserMapping map[string]UserInfo `json:"user_mapping,omitempty"`
+	// Warnings describes parts of the result that are degraded. Empty if the result is complete.
+	Warnings []Warning `json:"warnings,omitempty"`
+}
+
+// SharedLink is a URL shared in a channel, with the messages that shared it summarized.
+type SharedLink struct {
+	// URL is the shared link.
+	URL string `json:"url"`
+	// Count is the number of messages that shared the link.
+	Count int `json:"count"`
+	// Authors are the user (or bot) IDs of the people who shared the link, in order of first share.
+	Authors []string `json:"authors"`
+	// FirstSeen is the timestamp of the earliest scanned message that shared the link.
+	FirstSeen string `json:"first_seen"`
+	// FirstSeenPermalink is the URL of that earliest message.
+	// Empty if the workspace URL is unknown.
+	FirstSeenPermalink string `json:"first_seen_permalink,omitempty"`
+}
+
+// EmojiStatsResult is the output schema for the get_emoji_stats MCP tool.
+type EmojiStatsResult struct {
+	// ChannelID is the channel whose reactions were counted. Empty for workspace-wide stats.
+	ChannelID string `json:"channel_id,omitempty"`
+	// Oldest and Latest are the bounds of the time range (Unix timestamps).
+	// Latest is empty if the range runs to the present.
+	Oldest string `json:"oldest"`
+	Latest string `json:"latest,omitempty"`
+	// Emoji lists the emoji used in reactions, most used first, up to the requested number.
+	Emoji []EmojiUsage `json:"emoji"`
+	// TotalReactions is the number of reactions counted, over every emoji (not only those listed).
+	TotalReactions int `json:"total_reactions"`
+	// DistinctEmoji is the number of different emoji used in reactions.
+	DistinctEmoji int `json:"distinct_emoji"`
+	// MessagesScanned is the number of messages whose reactions were counted.
+	MessagesScanned int `json:"messages_scanned"`
+	// MessagesWithReactions is the number of scanned messages with at least one reaction.
+	MessagesWithReactions int `json:"messages_with_reactions"`
+	// Channels lists the channels that were scanned. Only set for workspace-wide stats.
+	Channels []EmojiStatsChannel `json:"channels,omitempty"`
+	// HasMore indicates whether a scanned channel has messages in the time range beyond
+	// the scan limit, so the counts are incomplete.
+	HasMore bool `json:"has_more"`
+	// Workspace identifies the Slack workspace the result came from.
+	// Nil if the workspace lookup failed.
+	Workspace *WorkspaceInfo `json:"workspace,omitempty"`
+	// Warnings describes parts of the result that are degraded. Empty if the result is complete.
+	Warnings []Warning `json:"warnings,omitempty"`
+}
+
+// EmojiUsage is how often one emoji was used in reactions.
+type EmojiUsage struct {
+	// Name is the emoji name without colons (e.g., "tada"). Skin tone variants are
+	// counted under the base emoji.
+	Name string `json:"name"`
+	// Count is the number of times the emoji was added as a reaction.
+	Count int `json:"count"`
+	// Messages is the number of messages with the emoji as a reaction.
+	Messages int `json:"messages"`
+	// Users is the number of different users who reacted with the emoji. Slack may list
+	// fewer users than reacted on messages with many reactions, so this can undercount.
+	Users int `json:"users"`
+	// Channels maps channel IDs to the number of times the emoji was used there.
+	// Only set for workspace-wide stats.
+	Channels map[string]int `json:"channels,omitempty"`
+}
+
+// EmojiStatsChannel is a channel scanned for workspace-wide emoji stats.
+type EmojiStatsChannel struct {
+	// ID is the Slack channel ID.
+	ID string `json:"id"`
+	// Name is the channel name without the "#".
+	Name string `json:"name"`
+	// MessagesScanned is the number of the channel's messages whose reactions were counted.
+	MessagesScanned int `json:"messages_scanned"`
+	// HasMore indicates the channel has messages in the time range beyond the scan limit.
+	HasMore bool `json:"has_more,omitempty"`
+}
+
+// BuildMessageURLResult is the output schema for the build_message_url MCP tool.
+type BuildMessageURLResult struct {
+	// URL is the Slack message URL.
+	URL string `json:"url"`
+	// ChannelID is the channel the message was posted in.
+	ChannelID string `json:"channel_id"`
+	// Timestamp is the message timestamp in Slack API format.
+	Timestamp string `json:"timestamp"`
+	// ThreadTS is the parent thread timestamp, if the URL links to a thread reply.
+	ThreadTS string `json:"thread_ts,omitempty"`
+}
+
+// ComposeBlocksResult is the output schema for the compose_blocks MCP tool.
+type ComposeBlocksResult struct {
+	// Blocks is the Block Kit JSON array, suitable for chat.postMessage's blocks argument.
+	Blocks json.RawMessage `json:"blocks"`
+	// Text is the message as mrkdwn, for chat.postMessage's text argument
+	// (used for notifications and clients that cannot render blocks).
+	Text string `json:"text"`
+	// BlockCount is the number of blocks (Slack allows at most 50 per message).
+	BlockCount int `json:"block_count"`
+}
+
+// PostMessageResult is the output schema for the MCP tools that post messages (post_from_template).
+type PostMessageResult struct {
+	// ChannelID is the channel the message was posted in.
+	ChannelID string `json:"channel_id"`
+	// MessageTS is the timestamp of the posted message.
+	// Omitted if the text was uploaded as a snippet (see FileID).
+	MessageTS string `json:"message_ts,omitempty"`
+	// FileID is the ID of the snippet file the text was uploaded as, if it was too
+	// long for one message and on_long_text was 'snippet'.
+	FileID string `json:"file_id,omitempty"`
+	// ThreadTS is the thread the message was posted in, if any.
+	ThreadTS string `json:"thread_ts,omitempty"`
+	// Permalink is the URL of the posted message.
+	// Omitted if the workspace URL could not be determined, or for a snippet.
+	Permalink string `json:"permalink,omitempty"`
+	// Template is the name of the template the message was rendered from, if any.
+	Template string `json:"template,omitempty"`
+	// Text is the posted message text.
+	Text string `json:"text"`
+	// ContinuationTS lists the timestamps of the threaded continuation messages,
+	// in order, if the text was too long for one message and was split.
+	ContinuationTS []string `json:"continuation_ts,omitempty"`
+	// Workspace identifies the Slack workspace the message was posted in.
+	Workspace *WorkspaceInfo `json:"workspace,omitempty"`
+	// Warnings describes parts of the post that failed (e.g., a continuation message).
+	Warnings []Warning `json:"warnings,omitempty"`
+}
+
+// AddReactionsBulkResult is the output schema for the add_reactions_bulk MCP tool.
+type AddReactionsBulkResult struct {
+	// Reaction is the emoji name that was added, without colons.
+	Reaction string `json:"reaction"`
+	// Added is the number of messages the reaction was added to.
+	Added int `json:"added"`
+	// AlreadyReacted is the number of messages that already had the reaction from the bot.
+	AlreadyReacted int `json:"already_reacted"`
+	// Failed is the number of messages the reaction could not be added to.
+	Failed int `json:"failed"`
+	// Skipped is the number of messages not attempted because the batch stopped early.
+	Skipped int `json:"skipped"`
+	// Results has one entry per requested message, in request order.
+	Results []ReactionResult `json:"results"`
+	// Workspace identifies the Slack workspace the reactions were added in.
+	Workspace *WorkspaceInfo `json:"workspace,omitempty"`
+	// Warnings explains why the batch stopped early, if it did.
+	Warnings []Warning `json:"warnings,omitempty"`
+}
+
+// ReactionResult is the outcome of adding a reaction to one message.
+type ReactionResult struct {
+	// ChannelID is the channel of the message.
+	ChannelID string `json:"channel_id"`
+	// Timestamp is the message timestamp.
+	Timestamp string `json:"timestamp"`
+	// Status is one of the ReactionStatus* constants.
+	Status string `json:"status"`
+	// Error describes why the reaction could not be added. Only set when Status is failed.
+	Error *SlackError `json:"error,omitempty"`
+}
+
+// Reaction outcomes reported by add_reactions_bulk.
+const (
+	// ReactionStatusAdded indicates the reaction was added.
+	ReactionStatusAdded = "added"
+	// ReactionStatusAlreadyReacted indicates the message already had the reaction from the bot.
+	ReactionStatusAlreadyReacted = "already_reacted"
+	// ReactionStatusFailed indicates the reaction could not be added.
+	ReactionStatusFailed = "failed"
+	// ReactionStatusSkipped indicates the message was not attempted because the batch stopped early.
+	ReactionStatusSkipped = "skipped"
+)
+
+// CheckChannelAccessResult is the output schema for the check_channel_access MCP tool.
+type CheckChannelAccessResult struct {
+	// ChannelID is the channel that was checked.
+	ChannelID string `json:"channel_id"`
+	// Channel is the channel's information. Nil if the bot cannot see the channel.
+	Channel *ChannelInfo `json:"channel,omitempty"`
+	// BotIsMember indicates whether the bot is a member of the channel.
+	BotIsMember bool `json:"bot_is_member"`
+	// UserTokenConfigured indicates whether SLACK_USER_TOKEN is set.
+	UserTokenConfigured bool `json:"user_token_configured"`
+	// UserIsMember indicates whether the user token's user is a member of the channel.
+	// Omitted if no user token is configured or membership could not be checked.
+	UserIsMember *bool `json:"user_is_member,omitempty"`
+	// Tools reports, per read tool, whether it will work for this channel.
+	Tools map[string]ToolAccess `json:"tools"`
+	// Workspace identifies the Slack workspace the channel belongs to.
+	Workspace *WorkspaceInfo `json:"workspace,omitempty"`
+	// Warnings describes checks that could not be completed.
+	Warnings []Warning `json:"warnings,omitempty"`
+}
+
+// ToolAccess reports whether a tool will work for a channel.
+type ToolAccess struct {
+	// Available indicates whether the tool is expected to work.
+	Available bool `json:"available"`
+	// Reason explains why the tool will not work, and what to do about it.
+	Reason string `json:"reason,omitempty"`
+}
+
+// ListWorkspacesResult is the output schema for the list_workspaces MCP tool.
+type ListWorkspacesResult struct {
+	// Current is the workspace the bot token belongs to, which the other tools act on.
+	// Nil if the workspace lookup failed.
+	Current *WorkspaceInfo `json:"current,omitempty"`
+	// Workspaces lists the workspaces the bot token can access. On Enterprise Grid,
+	// an org-wide app can access several; otherwise this is only the current workspace.
+	Workspaces []WorkspaceInfo `json:"workspaces"`
+	// Warnings describes parts of the listing that could not be completed.
+	Warnings []Warning `json:"warnings,omitempty"`
+}
+
+// ListChannelsResult is the output schema for the list_channels MCP tool.
+type ListChannelsResult struct {
+	// Channels lists the matching conversations, in the order Slack lists them.
+	Channels []ListedChannel `json:"channels"`
+	// NextCursor continues the listing with the next page, empty if every
+	// conversation has been listed.
+	NextCursor string `json:"next_cursor,omitempty"`
+	// Workspace identifies the Slack workspace the channels belong to.
+	Workspace *WorkspaceInfo `json:"workspace,omitempty"`
+}
+
+// ListedChannel is a conversation returned by list_channels.
+type ListedChannel struct {
+	ChannelInfo
+	// IsMember indicates whether the bot is a member of the conversation.
+	IsMember bool `json:"is_member"`
+}
+
+// GetUserProfileResult is the output schema for the get_user_profile MCP tool.
+type GetUserProfileResult struct {
+	// User is the user's full profile. Nil if a name lookup matched several users
+	// equally well, in which case Candidates lists them.
+	User *UserInfo `json:"user,omitempty"`
+	// MatchedBy is how the user was found: "id", "email", or "name".
+	MatchedBy string `json:"matched_by"`
+	// Candidates lists the users a name lookup could not choose between, best
+	// matches first. Look one up by ID to get their full profile.
+	Candidates []UserInfo `json:"candidates,omitempty"`
+	// Warnings describes parts of the lookup that could not be completed.
+	Warnings []Warning `json:"warnings,omitempty"`
+	// Workspace identifies the Slack workspace the user belongs to.
+	Workspace *WorkspaceInfo `json:"workspace,omitempty"`
+}
+
+// WorkspaceAnalyticsResult is the output schema for the get_workspace_analytics MCP tool.
+type WorkspaceAnalyticsResult struct {
+	// Type is the analytics file type ("member" or "public_channel").
+	Type string `json:"type"`
+	// Date is the day the analytics cover (YYYY-MM-DD).
+	Date string `json:"date"`
+	// RowCount is the number of rows (members or channels) in the export.
+	RowCount int `json:"row_count"`
+	// Totals sums each numeric field across all rows, and counts the rows in which
+	// each boolean field is true (e.g., "is_active" is the number of active members).
+	Totals map[string]float64 `json:"totals"`
+	// Rows holds the first rows of the export, as provided by Slack.
+	Rows []map[string]interface{} `json:"rows"`
+	// Workspace identifies the Slack workspace the server is connected to.
+	Workspace *WorkspaceInfo `json:"workspace,omitempty"`
+	// Warnings describes limits applied to the result (e.g., rows omitted).
+	Warnings []Warning `json:"warnings,omitempty"`
+}
+
+// AuditObject identifies the actor, entity, or location of an audit log entry.
+type AuditObject struct {
+	// Type is the kind of object (e.g., "user", "channel", "workspace").
+	Type string `json:"type"`
+	// ID is the object's Slack ID.
+	ID string `json:"id,omitempty"`
+	// Name is the object's name (e.g., a channel name without the "#").
+	Name string `json:"name,omitempty"`
+	// Email is the user's email address, for users.
+	Email string `json:"email,omitempty"`
+	// Domain is the workspace or organization domain, for workspaces and organizations.
+	Domain string `json:"domain,omitempty"`
+}
+
+// AuditEntry is a single Audit Logs API entry: who did what to which object, and when.
+type AuditEntry struct {
+	// ID is the entry's unique ID.
+	ID string `json:"id"`
+	// Time is when the action happened, in RFC 3339 format (UTC).
+	Time string `json:"time"`
+	// Action is the audit action (e.g., "channel_archive").
+	Action string `json:"action"`
+	// Actor is who performed the action.
+	Actor AuditObject `json:"actor"`
+	// Entity is the object the action was performed on.
+	Entity AuditObject `json:"entity"`
+	// Location is the workspace or organization the action happened in.
+	Location *AuditObject `json:"location,omitempty"`
+	// IPAddress is the IP address the action came from, if recorded.
+	IPAddress string `json:"ip_address,omitempty"`
+	// UserAgent is the client the action came from, if recorded.
+	UserAgent string `json:"user_agent,omitempty"`
+	// PreviousValue and NewValue describe a changed setting (e.g., a retention policy), if any.
+	PreviousValue interface{} `json:"previous_value,omitempty"`
+	NewValue      interface{} `json:"new_value,omitempty"`
+}
+
+// ReadAuditLogsResult is the output schema for the read_audit_logs MCP tool.
+type ReadAuditLogsResult struct {
+	// Entries lists the matching audit log entries, newest first.
+	Entries []AuditEntry `json:"entries"`
+	// NextCursor continues the read with the next page, empty if there are no more entries.
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+// PostSnippetResult is the output schema for the post_snippet MCP tool.
+type PostSnippetResult struct {
+	// ChannelID is the channel the snippet was shared in.
+	ChannelID string `json:"channel_id"`
+	// FileID is the ID of the uploaded snippet file.
+	FileID string `json:"file_id"`
+	// Filename is the snippet's filename.
+	Filename string `json:"filename"`
+	// Title is the snippet's title, if one was given.
+	Title string `json:"title,omitempty"`
+	// SnippetType is the syntax highlighting type the snippet was uploaded with.
+	// Omitted if Slack detects it.
+	SnippetType string `json:"snippet_type,omitempty"`
+	// ThreadTS is the thread the snippet was shared in, if any.
+	ThreadTS string `json:"thread_ts,omitempty"`
+	// Workspace identifies the Slack workspace the snippet was shared in.
+	Workspace *WorkspaceInfo `json:"workspace,omitempty"`
+}
+
+// UpdateUserGroupMembersResult is the output schema for the update_usergroup_members MCP tool.
+type UpdateUserGroupMembersResult struct {
+	// UserGroupID is the ID of the updated user group.
+	UserGroupID string `json:"usergroup_id"`
+	// Handle is the user group's handle, without the "@".
+	Handle string `json:"handle"`
+	// Members lists the group's members after the update.
+	Members []string `json:"members"`
+	// Added lists the users who were added to the group.
+	Added []string `json:"added,omitempty"`
+	// Removed lists the users who were removed from the group.
+	Removed []string `json:"removed,omitempty"`
+	// Changed indicates that the membership was updated; false if it already matched.
+	Changed bool `json:"changed"`
+	// Workspace identifies the Slack workspace the user group belongs to.
+	Workspace *WorkspaceInfo `json:"workspace,omitempty"`
+}
+
+// PostEphemeralResult is the output schema for the post_ephemeral MCP tool.
+type PostEphemeralResult struct {
+	// ChannelID is the channel the message was shown in.
+	ChannelID string `json:"channel_id"`
+	// UserID is the only user who sees the message.
+	UserID string `json:"user_id"`
+	// MessageTS is the timestamp Slack assigned to the ephemeral message. Ephemeral
+	// messages are not stored, so it cannot be read back, linked to, or edited.
+	MessageTS string `json:"message_ts"`
+	// ThreadTS is the thread the message was shown in, if any.
+	ThreadTS string `json:"thread_ts,omitempty"`
+	// Workspace identifies the Slack workspace the message was posted in.
+	Workspace *WorkspaceInfo `json:"workspace,omitempty"`
+}
+
+// MessageCountResult is the output schema for the count_only mode of the
+// list_channel_messages and search_messages MCP tools.
+type MessageCountResult struct {
+	// ChannelID is the Slack channel the messages were counted in.
+	// Only set for list_channel_messages.
+	ChannelID string `json:"channel_id,omitempty"`
+	// Query is the search query that was executed.
+	// Only set for search_messages.
+	Query string `json:"query,omitempty"`
+	// Workspace identifies the Slack workspace the result came from.
+	// Nil if the workspace lookup failed.
+	Workspace *WorkspaceInfo `json:"workspace,omitempty"`
+	// Count is the number of matching messages.
+	Count int `json:"count"`
+	// HasMore indicates counting stopped at the maximum before reaching the end of the window,
+	// so the actual number of messages is larger than Count.
+	HasMore bool `json:"has_more,omitempty"`
 }
 
 // SearchMatch represents a single message match from search results.
@@ -130,8 +981,114 @@ type SearchMatch struct {
 	Timestamp string `json:"timestamp"`
 	// Permalink is the direct URL to the message.
 	Permalink string `json:"permalink"`
+	// Lang is the detected ISO 639-1 language code of the message text (e.g., "en", "ja").
+	// Only set when language detection is requested and the language could be determined.
+	Lang string `json:"lang,omitempty"`
+	// References are the issue, pull request, and ticket references found in the text.
+	// Only set when reference extraction is requested.
+	References []Reference `json:"references,omitempty"`
+	// Raw is the match object from the Slack API response, before conversion.
+	// Only returned when include_raw is requested.
+	Raw json.RawMessage `json:"raw,omitempty"`
+	// ThreadTS is the parent message timestamp if the match is a thread reply.
+	// Only populated when expand_threads is requested.
+	ThreadTS string `json:"thread_ts,omitempty"`
+	// ThreadParent is the parent message of the thread if the match is a thread reply.
+	// Only populated when expand_threads is requested.
+	ThreadParent *Message `json:"thread_parent,omitempty"`
+	// ThreadError describes why the thread parent could not be fetched.
+	// Only set when expand_threads is requested and the fetch failed.
+	ThreadError *SlackError `json:"thread_error,omitempty"`
+	// Context contains the messages posted immediately before and after the match, in chronological order.
+	// Only populated when include_context is requested.
+	Context []ContextMessage `json:"context,omitempty"`
+	// ContextError describes why context messages could not be fetched.
+	// Only set when include_context is requested and the fetch failed.
+	ContextError *SlackError `json:"context_error,omitempty"`
+}
+
+// ContextMessage is a message surrounding a search match, included to help interpret the match.
+type ContextMessage struct {
+	// User is the Slack user ID of the message author.
+	User string `json:"user"`
+	// UserName is the username (handle) of the message author.
+	// Empty if user resolution was not performed or failed.
+	UserName string `json:"user_name,omitempty"`
+	// DisplayName is the display name of the message author.
+	// Empty if user resolution was not performed or failed.
+	DisplayName string `json:"display_name,omitempty"`
+	// Text is the message content.
+	Text string `json:"text"`
+	// Timestamp is the message timestamp in Slack API format.
+	Timestamp string `json:"timestamp"`
+	// Permalink is the direct URL to the context message.
+	Permalink string `json:"permalink,omitempty"`
+}
+
+// APICallStats summarizes the Slack API usage of a single tool call.
+// It is attached to tool results as _meta.api_calls when debug mode is enabled.
+type APICallStats struct {
+	// Calls is the number of Slack API requests made.
+	Calls int `json:"calls"`
+	// CacheHits is the number of lookups served from the client cache instead of the API.
+	CacheHits int `json:"cache_hits"`
+	// Retries is the number of requests that were retried by the client.
+	Retries int `json:"retries"`
+	// RateLimited is the number of requests rejected by Slack rate limiting.
+	RateLimited int `json:"rate_limited"`
+	// TotalLatencyMS is the combined latency of all Slack API requests in milliseconds.
+	TotalLatencyMS int64 `json:"total_latency_ms"`
+	// ResolutionLatencyMS is the part of TotalLatencyMS spent resolving user and bot
+	// IDs to names (users.info and bots.info).
+	ResolutionLatencyMS int64 `json:"resolution_latency_ms"`
+	// Methods maps Slack API method names to the number of times each was called.
+	Methods map[string]int `json:"methods,omitempty"`
+}
+
+// Warning describes a non-fatal problem encountered while building a tool result.
+type Warning struct {
+	// Code is a machine-readable warning code.
+	Code string `json:"code"`
+	// Message is a human-readable description of the problem.
+	Message string `json:"message"`
 }
 
+// Common warning codes for degraded tool results.
+const (
+	// WarnCodeUserResolutionFailed indicates a user ID could not be resolved to user info.
+	WarnCodeUserResolutionFailed = "user_resolution_failed"
+	// WarnCodeChannelResolutionFailed indicates a channel ID could not be resolved to channel info.
+	WarnCodeChannelResolutionFailed = "channel_resolution_failed"
+	// WarnCodeUserGroupResolutionFailed indicates a user group ID could not be resolved to user group info.
+	WarnCodeUserGroupResolutionFailed = "usergroup_resolution_failed"
+	// WarnCodeThreadFetchFailed indicates thread replies could not be fetched, so the thread is omitted.
+	WarnCodeThreadFetchFailed = "thread_fetch_failed"
+	// WarnCodeResultsTruncated indicates the result was limited by a server-side cap.
+	WarnCodeResultsTruncated = "results_truncated"
+	// WarnCodeRetentionGap indicates older messages in the requested window may have been
+	// removed by the workspace's message retention limit.
+	WarnCodeRetentionGap = "retention_gap"
+	// WarnCodeMembershipCheckFailed indicates the user token's channel membership could not be checked.
+	WarnCodeMembershipCheckFailed = "membership_check_failed"
+	// WarnCodeWorkspaceListFailed indicates the accessible workspaces could not be listed,
+	// so only the current workspace is returned.
+	WarnCodeWorkspaceListFailed = "workspace_list_failed"
+	// WarnCodePostIncomplete indicates only some parts of a split message were posted.
+	WarnCodePostIncomplete = "post_incomplete"
+	// WarnCodeBatchStopped indicates a bulk operation stopped before processing every item.
+	WarnCodeBatchStopped = "batch_stopped"
+	// WarnCodeRateLimited indicates optional enrichment stopped early because Slack rate limited the server.
+	WarnCodeRateLimited = "rate_limited"
+	// WarnCodeThreadTrimmed indicates thread replies were left out to fit a token budget.
+	WarnCodeThreadTrimmed = "thread_trimmed"
+	// WarnCodeBriefingPartFailed indicates part of a channel briefing (pins, bookmarks,
+	// the canvas, or recent threads) could not be fetched and is omitted.
+	WarnCodeBriefingPartFailed = "briefing_part_failed"
+	// WarnCodeChannelScanFailed indicates a channel's history could not be read, so it is
+	// left out of a scan across channels.
+	WarnCodeChannelScanFailed = "channel_scan_failed"
+)
+
 // SlackError represents an error from the Slack API or URL parsing.
 type SlackError struct {
 	// Code is a machine-readable error code.
@@ -155,14 +1112,32 @@ const (
 	ErrCodeChannelNotFound = "channel_not_found"
 	// ErrCodeNotInChannel indicates the bot is not a member of the channel.
 	ErrCodeNotInChannel = "not_in_channel"
+	// ErrCodeUserNotInChannel indicates a target user is not a member of the channel.
+	ErrCodeUserNotInChannel = "user_not_in_channel"
 	// ErrCodeRateLimited indicates the Slack API rate limit was exceeded.
 	ErrCodeRateLimited = "rate_limited"
 	// ErrCodeInvalidToken indicates the Slack bot token is invalid or expired.
 	ErrCodeInvalidToken = "invalid_token"
+	// ErrCodeChannelArchived indicates the channel is archived, so it cannot be posted to
+	// or, if the bot is not a member, read with the bot token.
+	ErrCodeChannelArchived = "channel_archived"
 	// ErrCodePermissionDenied indicates the bot lacks required permissions.
 	ErrCodePermissionDenied = "permission_denied"
+	// ErrCodeMissingScope indicates the Slack app lacks an OAuth scope required by the call.
+	ErrCodeMissingScope = "missing_scope"
+	// ErrCodeAlreadyReacted indicates the bot already added the reaction to the message.
+	ErrCodeAlreadyReacted = "already_reacted"
 	// ErrCodeUserTokenNotConfigured indicates the SLACK_USER_TOKEN is not set.
 	ErrCodeUserTokenNotConfigured = "user_token_not_configured"
+	// ErrCodeAdminTokenNotConfigured indicates the SLACK_ADMIN_TOKEN is not set.
+	ErrCodeAdminTokenNotConfigured = "admin_token_not_configured"
+	// ErrCodeAuditTokenNotConfigured indicates the SLACK_AUDIT_TOKEN is not set.
+	ErrCodeAuditTokenNotConfigured = "audit_token_not_configured"
+	// ErrCodeDMReadNotAllowed indicates a read of a direct message in act-as-user mode
+	// while DM reads are not allowed.
+	ErrCodeDMReadNotAllowed = "dm_read_not_allowed"
+	// ErrCodeSlackError indicates an unclassified error returned by the Slack API.
+	ErrCodeSlackError = "slack_error"
 )
 
 // NewSlackError creates a new SlackError with the given code and message.